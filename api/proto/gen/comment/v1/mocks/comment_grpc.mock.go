@@ -0,0 +1,357 @@
+// Code generated by MockGen. DO NOT EDIT.
+// Source: ./api/proto/gen/comment/v1/comment_grpc.pb.go
+//
+// Generated by this command:
+//
+//	mockgen -source=./api/proto/gen/comment/v1/comment_grpc.pb.go -destination=./api/proto/gen/comment/v1/mocks/comment_grpc.mock.go -package=commentv1mocks
+//
+
+// Package commentv1mocks is a generated GoMock package.
+package commentv1mocks
+
+import (
+	context "context"
+	reflect "reflect"
+
+	commentv1 "github.com/Andras5014/gohub/api/proto/gen/comment/v1"
+	gomock "go.uber.org/mock/gomock"
+	grpc "google.golang.org/grpc"
+)
+
+// MockCommentServiceClient is a mock of CommentServiceClient interface.
+type MockCommentServiceClient struct {
+	ctrl     *gomock.Controller
+	recorder *MockCommentServiceClientMockRecorder
+}
+
+// MockCommentServiceClientMockRecorder is the mock recorder for MockCommentServiceClient.
+type MockCommentServiceClientMockRecorder struct {
+	mock *MockCommentServiceClient
+}
+
+// NewMockCommentServiceClient creates a new mock instance.
+func NewMockCommentServiceClient(ctrl *gomock.Controller) *MockCommentServiceClient {
+	mock := &MockCommentServiceClient{ctrl: ctrl}
+	mock.recorder = &MockCommentServiceClientMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockCommentServiceClient) EXPECT() *MockCommentServiceClientMockRecorder {
+	return m.recorder
+}
+
+// CancelLikeComment mocks base method.
+func (m *MockCommentServiceClient) CancelLikeComment(ctx context.Context, in *commentv1.CancelLikeCommentRequest, opts ...grpc.CallOption) (*commentv1.CancelLikeCommentResponse, error) {
+	m.ctrl.T.Helper()
+	varargs := []any{ctx, in}
+	for _, a := range opts {
+		varargs = append(varargs, a)
+	}
+	ret := m.ctrl.Call(m, "CancelLikeComment", varargs...)
+	ret0, _ := ret[0].(*commentv1.CancelLikeCommentResponse)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// CancelLikeComment indicates an expected call of CancelLikeComment.
+func (mr *MockCommentServiceClientMockRecorder) CancelLikeComment(ctx, in any, opts ...any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	varargs := append([]any{ctx, in}, opts...)
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "CancelLikeComment", reflect.TypeOf((*MockCommentServiceClient)(nil).CancelLikeComment), varargs...)
+}
+
+// CreateComment mocks base method.
+func (m *MockCommentServiceClient) CreateComment(ctx context.Context, in *commentv1.CreateCommentRequest, opts ...grpc.CallOption) (*commentv1.CreateCommentResponse, error) {
+	m.ctrl.T.Helper()
+	varargs := []any{ctx, in}
+	for _, a := range opts {
+		varargs = append(varargs, a)
+	}
+	ret := m.ctrl.Call(m, "CreateComment", varargs...)
+	ret0, _ := ret[0].(*commentv1.CreateCommentResponse)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// CreateComment indicates an expected call of CreateComment.
+func (mr *MockCommentServiceClientMockRecorder) CreateComment(ctx, in any, opts ...any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	varargs := append([]any{ctx, in}, opts...)
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "CreateComment", reflect.TypeOf((*MockCommentServiceClient)(nil).CreateComment), varargs...)
+}
+
+// DeleteComment mocks base method.
+func (m *MockCommentServiceClient) DeleteComment(ctx context.Context, in *commentv1.DeleteCommentRequest, opts ...grpc.CallOption) (*commentv1.DeleteCommentResponse, error) {
+	m.ctrl.T.Helper()
+	varargs := []any{ctx, in}
+	for _, a := range opts {
+		varargs = append(varargs, a)
+	}
+	ret := m.ctrl.Call(m, "DeleteComment", varargs...)
+	ret0, _ := ret[0].(*commentv1.DeleteCommentResponse)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// DeleteComment indicates an expected call of DeleteComment.
+func (mr *MockCommentServiceClientMockRecorder) DeleteComment(ctx, in any, opts ...any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	varargs := append([]any{ctx, in}, opts...)
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "DeleteComment", reflect.TypeOf((*MockCommentServiceClient)(nil).DeleteComment), varargs...)
+}
+
+// GetCommentList mocks base method.
+func (m *MockCommentServiceClient) GetCommentList(ctx context.Context, in *commentv1.GetCommentListRequest, opts ...grpc.CallOption) (*commentv1.GetCommentListResponse, error) {
+	m.ctrl.T.Helper()
+	varargs := []any{ctx, in}
+	for _, a := range opts {
+		varargs = append(varargs, a)
+	}
+	ret := m.ctrl.Call(m, "GetCommentList", varargs...)
+	ret0, _ := ret[0].(*commentv1.GetCommentListResponse)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetCommentList indicates an expected call of GetCommentList.
+func (mr *MockCommentServiceClientMockRecorder) GetCommentList(ctx, in any, opts ...any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	varargs := append([]any{ctx, in}, opts...)
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetCommentList", reflect.TypeOf((*MockCommentServiceClient)(nil).GetCommentList), varargs...)
+}
+
+// GetReplyList mocks base method.
+func (m *MockCommentServiceClient) GetReplyList(ctx context.Context, in *commentv1.GetReplyListRequest, opts ...grpc.CallOption) (*commentv1.GetReplyListResponse, error) {
+	m.ctrl.T.Helper()
+	varargs := []any{ctx, in}
+	for _, a := range opts {
+		varargs = append(varargs, a)
+	}
+	ret := m.ctrl.Call(m, "GetReplyList", varargs...)
+	ret0, _ := ret[0].(*commentv1.GetReplyListResponse)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetReplyList indicates an expected call of GetReplyList.
+func (mr *MockCommentServiceClientMockRecorder) GetReplyList(ctx, in any, opts ...any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	varargs := append([]any{ctx, in}, opts...)
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetReplyList", reflect.TypeOf((*MockCommentServiceClient)(nil).GetReplyList), varargs...)
+}
+
+// LikeComment mocks base method.
+func (m *MockCommentServiceClient) LikeComment(ctx context.Context, in *commentv1.LikeCommentRequest, opts ...grpc.CallOption) (*commentv1.LikeCommentResponse, error) {
+	m.ctrl.T.Helper()
+	varargs := []any{ctx, in}
+	for _, a := range opts {
+		varargs = append(varargs, a)
+	}
+	ret := m.ctrl.Call(m, "LikeComment", varargs...)
+	ret0, _ := ret[0].(*commentv1.LikeCommentResponse)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// LikeComment indicates an expected call of LikeComment.
+func (mr *MockCommentServiceClientMockRecorder) LikeComment(ctx, in any, opts ...any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	varargs := append([]any{ctx, in}, opts...)
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "LikeComment", reflect.TypeOf((*MockCommentServiceClient)(nil).LikeComment), varargs...)
+}
+
+// PinComment mocks base method.
+func (m *MockCommentServiceClient) PinComment(ctx context.Context, in *commentv1.PinCommentRequest, opts ...grpc.CallOption) (*commentv1.PinCommentResponse, error) {
+	m.ctrl.T.Helper()
+	varargs := []any{ctx, in}
+	for _, a := range opts {
+		varargs = append(varargs, a)
+	}
+	ret := m.ctrl.Call(m, "PinComment", varargs...)
+	ret0, _ := ret[0].(*commentv1.PinCommentResponse)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// PinComment indicates an expected call of PinComment.
+func (mr *MockCommentServiceClientMockRecorder) PinComment(ctx, in any, opts ...any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	varargs := append([]any{ctx, in}, opts...)
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "PinComment", reflect.TypeOf((*MockCommentServiceClient)(nil).PinComment), varargs...)
+}
+
+// MockCommentServiceServer is a mock of CommentServiceServer interface.
+type MockCommentServiceServer struct {
+	ctrl     *gomock.Controller
+	recorder *MockCommentServiceServerMockRecorder
+}
+
+// MockCommentServiceServerMockRecorder is the mock recorder for MockCommentServiceServer.
+type MockCommentServiceServerMockRecorder struct {
+	mock *MockCommentServiceServer
+}
+
+// NewMockCommentServiceServer creates a new mock instance.
+func NewMockCommentServiceServer(ctrl *gomock.Controller) *MockCommentServiceServer {
+	mock := &MockCommentServiceServer{ctrl: ctrl}
+	mock.recorder = &MockCommentServiceServerMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockCommentServiceServer) EXPECT() *MockCommentServiceServerMockRecorder {
+	return m.recorder
+}
+
+// CancelLikeComment mocks base method.
+func (m *MockCommentServiceServer) CancelLikeComment(arg0 context.Context, arg1 *commentv1.CancelLikeCommentRequest) (*commentv1.CancelLikeCommentResponse, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "CancelLikeComment", arg0, arg1)
+	ret0, _ := ret[0].(*commentv1.CancelLikeCommentResponse)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// CancelLikeComment indicates an expected call of CancelLikeComment.
+func (mr *MockCommentServiceServerMockRecorder) CancelLikeComment(arg0, arg1 any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "CancelLikeComment", reflect.TypeOf((*MockCommentServiceServer)(nil).CancelLikeComment), arg0, arg1)
+}
+
+// CreateComment mocks base method.
+func (m *MockCommentServiceServer) CreateComment(arg0 context.Context, arg1 *commentv1.CreateCommentRequest) (*commentv1.CreateCommentResponse, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "CreateComment", arg0, arg1)
+	ret0, _ := ret[0].(*commentv1.CreateCommentResponse)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// CreateComment indicates an expected call of CreateComment.
+func (mr *MockCommentServiceServerMockRecorder) CreateComment(arg0, arg1 any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "CreateComment", reflect.TypeOf((*MockCommentServiceServer)(nil).CreateComment), arg0, arg1)
+}
+
+// DeleteComment mocks base method.
+func (m *MockCommentServiceServer) DeleteComment(arg0 context.Context, arg1 *commentv1.DeleteCommentRequest) (*commentv1.DeleteCommentResponse, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "DeleteComment", arg0, arg1)
+	ret0, _ := ret[0].(*commentv1.DeleteCommentResponse)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// DeleteComment indicates an expected call of DeleteComment.
+func (mr *MockCommentServiceServerMockRecorder) DeleteComment(arg0, arg1 any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "DeleteComment", reflect.TypeOf((*MockCommentServiceServer)(nil).DeleteComment), arg0, arg1)
+}
+
+// GetCommentList mocks base method.
+func (m *MockCommentServiceServer) GetCommentList(arg0 context.Context, arg1 *commentv1.GetCommentListRequest) (*commentv1.GetCommentListResponse, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetCommentList", arg0, arg1)
+	ret0, _ := ret[0].(*commentv1.GetCommentListResponse)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetCommentList indicates an expected call of GetCommentList.
+func (mr *MockCommentServiceServerMockRecorder) GetCommentList(arg0, arg1 any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetCommentList", reflect.TypeOf((*MockCommentServiceServer)(nil).GetCommentList), arg0, arg1)
+}
+
+// GetReplyList mocks base method.
+func (m *MockCommentServiceServer) GetReplyList(arg0 context.Context, arg1 *commentv1.GetReplyListRequest) (*commentv1.GetReplyListResponse, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetReplyList", arg0, arg1)
+	ret0, _ := ret[0].(*commentv1.GetReplyListResponse)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetReplyList indicates an expected call of GetReplyList.
+func (mr *MockCommentServiceServerMockRecorder) GetReplyList(arg0, arg1 any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetReplyList", reflect.TypeOf((*MockCommentServiceServer)(nil).GetReplyList), arg0, arg1)
+}
+
+// LikeComment mocks base method.
+func (m *MockCommentServiceServer) LikeComment(arg0 context.Context, arg1 *commentv1.LikeCommentRequest) (*commentv1.LikeCommentResponse, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "LikeComment", arg0, arg1)
+	ret0, _ := ret[0].(*commentv1.LikeCommentResponse)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// LikeComment indicates an expected call of LikeComment.
+func (mr *MockCommentServiceServerMockRecorder) LikeComment(arg0, arg1 any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "LikeComment", reflect.TypeOf((*MockCommentServiceServer)(nil).LikeComment), arg0, arg1)
+}
+
+// PinComment mocks base method.
+func (m *MockCommentServiceServer) PinComment(arg0 context.Context, arg1 *commentv1.PinCommentRequest) (*commentv1.PinCommentResponse, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "PinComment", arg0, arg1)
+	ret0, _ := ret[0].(*commentv1.PinCommentResponse)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// PinComment indicates an expected call of PinComment.
+func (mr *MockCommentServiceServerMockRecorder) PinComment(arg0, arg1 any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "PinComment", reflect.TypeOf((*MockCommentServiceServer)(nil).PinComment), arg0, arg1)
+}
+
+// mustEmbedUnimplementedCommentServiceServer mocks base method.
+func (m *MockCommentServiceServer) mustEmbedUnimplementedCommentServiceServer() {
+	m.ctrl.T.Helper()
+	m.ctrl.Call(m, "mustEmbedUnimplementedCommentServiceServer")
+}
+
+// mustEmbedUnimplementedCommentServiceServer indicates an expected call of mustEmbedUnimplementedCommentServiceServer.
+func (mr *MockCommentServiceServerMockRecorder) mustEmbedUnimplementedCommentServiceServer() *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "mustEmbedUnimplementedCommentServiceServer", reflect.TypeOf((*MockCommentServiceServer)(nil).mustEmbedUnimplementedCommentServiceServer))
+}
+
+// MockUnsafeCommentServiceServer is a mock of UnsafeCommentServiceServer interface.
+type MockUnsafeCommentServiceServer struct {
+	ctrl     *gomock.Controller
+	recorder *MockUnsafeCommentServiceServerMockRecorder
+}
+
+// MockUnsafeCommentServiceServerMockRecorder is the mock recorder for MockUnsafeCommentServiceServer.
+type MockUnsafeCommentServiceServerMockRecorder struct {
+	mock *MockUnsafeCommentServiceServer
+}
+
+// NewMockUnsafeCommentServiceServer creates a new mock instance.
+func NewMockUnsafeCommentServiceServer(ctrl *gomock.Controller) *MockUnsafeCommentServiceServer {
+	mock := &MockUnsafeCommentServiceServer{ctrl: ctrl}
+	mock.recorder = &MockUnsafeCommentServiceServerMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockUnsafeCommentServiceServer) EXPECT() *MockUnsafeCommentServiceServerMockRecorder {
+	return m.recorder
+}
+
+// mustEmbedUnimplementedCommentServiceServer mocks base method.
+func (m *MockUnsafeCommentServiceServer) mustEmbedUnimplementedCommentServiceServer() {
+	m.ctrl.T.Helper()
+	m.ctrl.Call(m, "mustEmbedUnimplementedCommentServiceServer")
+}
+
+// mustEmbedUnimplementedCommentServiceServer indicates an expected call of mustEmbedUnimplementedCommentServiceServer.
+func (mr *MockUnsafeCommentServiceServerMockRecorder) mustEmbedUnimplementedCommentServiceServer() *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "mustEmbedUnimplementedCommentServiceServer", reflect.TypeOf((*MockUnsafeCommentServiceServer)(nil).mustEmbedUnimplementedCommentServiceServer))
+}
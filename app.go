@@ -2,6 +2,7 @@ package main
 
 import (
 	"github.com/Andras5014/gohub/internal/events"
+	"github.com/Andras5014/gohub/internal/job"
 	"github.com/gin-gonic/gin"
 	"github.com/robfig/cron/v3"
 )
@@ -10,4 +11,5 @@ type App struct {
 	Server    *gin.Engine
 	Consumers []events.Consumer
 	Cron      *cron.Cron
+	Scheduler *job.Scheduler
 }
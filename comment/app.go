@@ -0,0 +1,9 @@
+package main
+
+import (
+	"github.com/Andras5014/gohub/pkg/grpcx"
+)
+
+type App struct {
+	Server *grpcx.Server
+}
@@ -0,0 +1,43 @@
+package domain
+
+import "time"
+
+// Comment 评论，和 interactive 一样用 biz + biz_id 标识评论的对象
+type Comment struct {
+	Id    int64
+	Biz   string
+	BizId int64
+	// Uid 评论的人
+	Uid int64
+	// RootId 一级评论为 0，回复都挂在一级评论下面
+	RootId int64
+	// ParentId 直接回复的那条评论，一级评论为 0
+	ParentId int64
+	// ReplyToUid 被回复的人
+	ReplyToUid int64
+	Content    string
+
+	LikeCnt int64
+	// ReplyCnt 只有一级评论有
+	ReplyCnt int64
+	// Pinned 被资源的作者置顶，只有一级评论可以置顶
+	Pinned bool
+	// Liked 当前用户有没有点赞
+	Liked bool
+
+	Ctime time.Time
+	Utime time.Time
+}
+
+func (c Comment) IsRoot() bool {
+	return c.RootId == 0
+}
+
+type CommentSort uint8
+
+const (
+	// CommentSortByTime 最新的在前面
+	CommentSortByTime CommentSort = iota
+	// CommentSortByLike 点赞多的在前面
+	CommentSortByLike
+)
@@ -0,0 +1,51 @@
+package events
+
+import (
+	"context"
+	"encoding/json"
+	"github.com/IBM/sarama"
+	"strconv"
+)
+
+const TopicCommentEvent = "comment_events"
+
+// CommentEvent 评论数变化，interactive 消费之后更新评论数
+type CommentEvent struct {
+	Biz   string `json:"biz"`
+	BizId int64  `json:"biz_id"`
+	// Delta 删除评论的时候是负数，删除一级评论会连同回复一起算
+	Delta int64 `json:"delta"`
+
+	// 下面几个只有新增评论的时候有，通知用
+	CommentId int64 `json:"comment_id,omitempty"`
+	// Uid 评论的人
+	Uid int64 `json:"uid,omitempty"`
+	// ReplyToUid 被回复的人，一级评论为 0
+	ReplyToUid int64 `json:"reply_to_uid,omitempty"`
+}
+
+type Producer interface {
+	ProduceCommentEvent(ctx context.Context, event CommentEvent) error
+}
+
+type KafkaProducer struct {
+	producer sarama.SyncProducer
+}
+
+func NewSaramaSyncProducer(producer sarama.SyncProducer) Producer {
+	return &KafkaProducer{producer: producer}
+}
+
+// ProduceCommentEvent 按 biz_id 分区，同一个资源的变化是有序的
+func (k *KafkaProducer) ProduceCommentEvent(ctx context.Context, event CommentEvent) error {
+	data, err := json.Marshal(event)
+	if err != nil {
+		return err
+	}
+	_, _, err = k.producer.SendMessage(&sarama.ProducerMessage{
+		Topic: TopicCommentEvent,
+		Key:   sarama.StringEncoder(event.Biz + ":" + strconv.FormatInt(event.BizId, 10)),
+		Value: sarama.ByteEncoder(data),
+	})
+	return err
+}
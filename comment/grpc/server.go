@@ -0,0 +1,133 @@
+package grpc
+
+import (
+	"context"
+	"errors"
+	commentv1 "github.com/Andras5014/gohub/api/proto/gen/comment/v1"
+	"github.com/Andras5014/gohub/comment/domain"
+	"github.com/Andras5014/gohub/comment/service"
+	"github.com/ecodeclub/ekit/slice"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+)
+
+type CommentServiceServer struct {
+	svc service.CommentService
+	commentv1.UnimplementedCommentServiceServer
+}
+
+func NewCommentServiceServer(svc service.CommentService) *CommentServiceServer {
+	return &CommentServiceServer{
+		svc: svc,
+	}
+}
+
+func (c *CommentServiceServer) Register(server *grpc.Server) {
+	commentv1.RegisterCommentServiceServer(server, c)
+}
+
+func (c *CommentServiceServer) CreateComment(ctx context.Context, request *commentv1.CreateCommentRequest) (*commentv1.CreateCommentResponse, error) {
+	id, err := c.svc.Create(ctx, c.toDomain(request.GetComment()))
+	if err != nil {
+		return nil, c.toStatus(err)
+	}
+	return &commentv1.CreateCommentResponse{Id: id}, nil
+}
+
+func (c *CommentServiceServer) DeleteComment(ctx context.Context, request *commentv1.DeleteCommentRequest) (*commentv1.DeleteCommentResponse, error) {
+	err := c.svc.Delete(ctx, request.Id, request.Uid)
+	if err != nil {
+		return nil, c.toStatus(err)
+	}
+	return &commentv1.DeleteCommentResponse{}, nil
+}
+
+func (c *CommentServiceServer) PinComment(ctx context.Context, request *commentv1.PinCommentRequest) (*commentv1.PinCommentResponse, error) {
+	err := c.svc.Pin(ctx, request.Biz, request.BizId, request.Id, request.Pinned)
+	if err != nil {
+		return nil, c.toStatus(err)
+	}
+	return &commentv1.PinCommentResponse{}, nil
+}
+
+func (c *CommentServiceServer) LikeComment(ctx context.Context, request *commentv1.LikeCommentRequest) (*commentv1.LikeCommentResponse, error) {
+	err := c.svc.Like(ctx, request.Id, request.Uid)
+	if err != nil {
+		return nil, c.toStatus(err)
+	}
+	return &commentv1.LikeCommentResponse{}, nil
+}
+
+func (c *CommentServiceServer) CancelLikeComment(ctx context.Context, request *commentv1.CancelLikeCommentRequest) (*commentv1.CancelLikeCommentResponse, error) {
+	err := c.svc.CancelLike(ctx, request.Id, request.Uid)
+	if err != nil {
+		return nil, c.toStatus(err)
+	}
+	return &commentv1.CancelLikeCommentResponse{}, nil
+}
+
+func (c *CommentServiceServer) GetCommentList(ctx context.Context, request *commentv1.GetCommentListRequest) (*commentv1.GetCommentListResponse, error) {
+	res, err := c.svc.List(ctx, request.Biz, request.BizId, domain.CommentSort(request.SortBy),
+		request.Uid, int(request.Offset), int(request.Limit))
+	if err != nil {
+		return nil, c.toStatus(err)
+	}
+	return &commentv1.GetCommentListResponse{
+		Comments: c.toDTOs(res),
+	}, nil
+}
+
+func (c *CommentServiceServer) GetReplyList(ctx context.Context, request *commentv1.GetReplyListRequest) (*commentv1.GetReplyListResponse, error) {
+	res, err := c.svc.Replies(ctx, request.RootId, request.Uid, int(request.Offset), int(request.Limit))
+	if err != nil {
+		return nil, c.toStatus(err)
+	}
+	return &commentv1.GetReplyListResponse{
+		Replies: c.toDTOs(res),
+	}, nil
+}
+
+// toStatus 业务错误转成 grpc 的错误码，调用方按错误码区分
+func (c *CommentServiceServer) toStatus(err error) error {
+	switch {
+	case errors.Is(err, service.ErrInvalidComment):
+		return status.Error(codes.InvalidArgument, err.Error())
+	case errors.Is(err, service.ErrCommentNotFound):
+		return status.Error(codes.NotFound, "评论不存在")
+	case errors.Is(err, service.ErrCommentPermissionDenied):
+		return status.Error(codes.PermissionDenied, err.Error())
+	}
+	return err
+}
+
+func (c *CommentServiceServer) toDomain(comment *commentv1.Comment) domain.Comment {
+	return domain.Comment{
+		Id:       comment.GetId(),
+		Biz:      comment.GetBiz(),
+		BizId:    comment.GetBizId(),
+		Uid:      comment.GetUid(),
+		ParentId: comment.GetParentId(),
+		Content:  comment.GetContent(),
+	}
+}
+
+func (c *CommentServiceServer) toDTOs(comments []domain.Comment) []*commentv1.Comment {
+	return slice.Map(comments, func(idx int, src domain.Comment) *commentv1.Comment {
+		return &commentv1.Comment{
+			Id:         src.Id,
+			Biz:        src.Biz,
+			BizId:      src.BizId,
+			Uid:        src.Uid,
+			RootId:     src.RootId,
+			ParentId:   src.ParentId,
+			ReplyToUid: src.ReplyToUid,
+			Content:    src.Content,
+			LikeCnt:    src.LikeCnt,
+			ReplyCnt:   src.ReplyCnt,
+			Pinned:     src.Pinned,
+			Liked:      src.Liked,
+			Ctime:      src.Ctime.UnixMilli(),
+		}
+	})
+}
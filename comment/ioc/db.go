@@ -0,0 +1,20 @@
+package ioc
+
+import (
+	"github.com/Andras5014/gohub/comment/config"
+	"github.com/Andras5014/gohub/comment/repository/dao"
+	"gorm.io/driver/mysql"
+	"gorm.io/gorm"
+)
+
+func InitDB(cfg *config.Config) *gorm.DB {
+	db, err := gorm.Open(mysql.Open(cfg.DB.DSN), &gorm.Config{})
+	if err != nil {
+		panic(err)
+	}
+	err = dao.InitTable(db)
+	if err != nil {
+		panic(err)
+	}
+	return db
+}
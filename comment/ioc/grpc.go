@@ -0,0 +1,17 @@
+package ioc
+
+import (
+	"github.com/Andras5014/gohub/comment/config"
+	grpc2 "github.com/Andras5014/gohub/comment/grpc"
+	"github.com/Andras5014/gohub/pkg/grpcx"
+	"google.golang.org/grpc"
+)
+
+func InitGRPCxServer(config *config.Config, commentServer *grpc2.CommentServiceServer) *grpcx.Server {
+	server := grpc.NewServer()
+	commentServer.Register(server)
+	return &grpcx.Server{
+		Server: server,
+		Addr:   config.Grpc.Addr,
+	}
+}
@@ -0,0 +1,24 @@
+package main
+
+import (
+	"github.com/spf13/viper"
+)
+
+func main() {
+	initViper()
+	app := InitApp()
+	err := app.Server.Serve()
+	if err != nil {
+		panic(err)
+	}
+}
+
+func initViper() {
+	viper.SetConfigName("dev")
+	viper.SetConfigType("yaml")
+	viper.AddConfigPath("./config")
+	err := viper.ReadInConfig()
+	if err != nil {
+		panic(err)
+	}
+}
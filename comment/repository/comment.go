@@ -0,0 +1,130 @@
+package repository
+
+import (
+	"context"
+	"github.com/Andras5014/gohub/comment/domain"
+	"github.com/Andras5014/gohub/comment/repository/dao"
+	"github.com/ecodeclub/ekit/slice"
+	"time"
+)
+
+var ErrCommentNotFound = dao.ErrRecordNotFound
+
+type CommentRepository interface {
+	// Create 回复的一级评论已经删除返回 ErrCommentNotFound
+	Create(ctx context.Context, c domain.Comment) (int64, error)
+	// FindById 已经删除的评论返回 ErrCommentNotFound
+	FindById(ctx context.Context, id int64) (domain.Comment, error)
+	// Delete 返回一共删掉了多少条，包括一级评论下面的回复
+	Delete(ctx context.Context, c domain.Comment) (int64, error)
+	Pin(ctx context.Context, biz string, bizId int64, id int64, pinned bool) error
+	// Like 评论已经删除返回 ErrCommentNotFound
+	Like(ctx context.Context, id int64, uid int64) error
+	CancelLike(ctx context.Context, id int64, uid int64) error
+	// LikedIds uid 点赞过的评论
+	LikedIds(ctx context.Context, uid int64, ids []int64) (map[int64]struct{}, error)
+	FindByBiz(ctx context.Context, biz string, bizId int64, sort domain.CommentSort, offset int, limit int) ([]domain.Comment, error)
+	FindReplies(ctx context.Context, rootId int64, offset int, limit int) ([]domain.Comment, error)
+}
+
+type commentRepository struct {
+	dao dao.CommentDAO
+}
+
+func NewCommentRepository(dao dao.CommentDAO) CommentRepository {
+	return &commentRepository{dao: dao}
+}
+
+func (c *commentRepository) Create(ctx context.Context, comment domain.Comment) (int64, error) {
+	return c.dao.Insert(ctx, c.toEntity(comment))
+}
+
+func (c *commentRepository) FindById(ctx context.Context, id int64) (domain.Comment, error) {
+	comment, err := c.dao.FindById(ctx, id)
+	if err != nil {
+		return domain.Comment{}, err
+	}
+	if comment.Status != dao.CommentStatusNormal {
+		return domain.Comment{}, ErrCommentNotFound
+	}
+	return c.toDomain(comment), nil
+}
+
+func (c *commentRepository) Delete(ctx context.Context, comment domain.Comment) (int64, error) {
+	return c.dao.Delete(ctx, c.toEntity(comment))
+}
+
+func (c *commentRepository) Pin(ctx context.Context, biz string, bizId int64, id int64, pinned bool) error {
+	return c.dao.Pin(ctx, biz, bizId, id, pinned)
+}
+
+func (c *commentRepository) Like(ctx context.Context, id int64, uid int64) error {
+	return c.dao.InsertLike(ctx, id, uid)
+}
+
+func (c *commentRepository) CancelLike(ctx context.Context, id int64, uid int64) error {
+	return c.dao.DeleteLike(ctx, id, uid)
+}
+
+func (c *commentRepository) LikedIds(ctx context.Context, uid int64, ids []int64) (map[int64]struct{}, error) {
+	liked, err := c.dao.LikedIds(ctx, uid, ids)
+	if err != nil {
+		return nil, err
+	}
+	res := make(map[int64]struct{}, len(liked))
+	for _, id := range liked {
+		res[id] = struct{}{}
+	}
+	return res, nil
+}
+
+func (c *commentRepository) FindByBiz(ctx context.Context, biz string, bizId int64, sort domain.CommentSort, offset int, limit int) ([]domain.Comment, error) {
+	res, err := c.dao.FindByBiz(ctx, biz, bizId, sort == domain.CommentSortByLike, offset, limit)
+	if err != nil {
+		return nil, err
+	}
+	return slice.Map(res, func(idx int, src dao.Comment) domain.Comment {
+		return c.toDomain(src)
+	}), nil
+}
+
+func (c *commentRepository) FindReplies(ctx context.Context, rootId int64, offset int, limit int) ([]domain.Comment, error) {
+	res, err := c.dao.FindReplies(ctx, rootId, offset, limit)
+	if err != nil {
+		return nil, err
+	}
+	return slice.Map(res, func(idx int, src dao.Comment) domain.Comment {
+		return c.toDomain(src)
+	}), nil
+}
+
+func (c *commentRepository) toEntity(comment domain.Comment) dao.Comment {
+	return dao.Comment{
+		Id:         comment.Id,
+		Biz:        comment.Biz,
+		BizId:      comment.BizId,
+		Uid:        comment.Uid,
+		RootId:     comment.RootId,
+		ParentId:   comment.ParentId,
+		ReplyToUid: comment.ReplyToUid,
+		Content:    comment.Content,
+	}
+}
+
+func (c *commentRepository) toDomain(comment dao.Comment) domain.Comment {
+	return domain.Comment{
+		Id:         comment.Id,
+		Biz:        comment.Biz,
+		BizId:      comment.BizId,
+		Uid:        comment.Uid,
+		RootId:     comment.RootId,
+		ParentId:   comment.ParentId,
+		ReplyToUid: comment.ReplyToUid,
+		Content:    comment.Content,
+		LikeCnt:    comment.LikeCnt,
+		ReplyCnt:   comment.ReplyCnt,
+		Pinned:     comment.Pinned,
+		Ctime:      time.UnixMilli(comment.CreatedAt),
+		Utime:      time.UnixMilli(comment.UpdatedAt),
+	}
+}
@@ -0,0 +1,231 @@
+package dao
+
+import (
+	"context"
+	"gorm.io/gorm"
+	"gorm.io/gorm/clause"
+	"time"
+)
+
+var ErrRecordNotFound = gorm.ErrRecordNotFound
+
+const (
+	CommentStatusNormal uint8 = iota + 1
+	// CommentStatusDeleted 软删除，保留原始内容方便追溯
+	CommentStatusDeleted
+)
+
+type CommentDAO interface {
+	// Insert 回复会同时增加一级评论的回复数，一级评论已经删除返回 ErrRecordNotFound
+	Insert(ctx context.Context, c Comment) (int64, error)
+	// FindById 包括已经删除的
+	FindById(ctx context.Context, id int64) (Comment, error)
+	// Delete 删除一级评论会把下面的回复一起删掉，返回一共删掉了多少条
+	Delete(ctx context.Context, c Comment) (int64, error)
+	// Pin 一个资源只能有一条置顶，置顶新的会把旧的取消掉
+	Pin(ctx context.Context, biz string, bizId int64, id int64, pinned bool) error
+	// InsertLike 评论已经删除返回 ErrRecordNotFound
+	InsertLike(ctx context.Context, cid int64, uid int64) error
+	DeleteLike(ctx context.Context, cid int64, uid int64) error
+	// LikedIds 在 cids 里面 uid 点赞过的
+	LikedIds(ctx context.Context, uid int64, cids []int64) ([]int64, error)
+	// FindByBiz 一级评论，置顶的排在最前面
+	FindByBiz(ctx context.Context, biz string, bizId int64, byLike bool, offset int, limit int) ([]Comment, error)
+	// FindReplies 按时间正序
+	FindReplies(ctx context.Context, rootId int64, offset int, limit int) ([]Comment, error)
+}
+
+type GormCommentDAO struct {
+	db *gorm.DB
+}
+
+func NewCommentDAO(db *gorm.DB) CommentDAO {
+	return &GormCommentDAO{db: db}
+}
+
+func (g *GormCommentDAO) Insert(ctx context.Context, c Comment) (int64, error) {
+	now := time.Now().UnixMilli()
+	c.Status = CommentStatusNormal
+	c.CreatedAt = now
+	c.UpdatedAt = now
+	err := g.db.WithContext(ctx).Transaction(func(tx *gorm.DB) error {
+		err := tx.Create(&c).Error
+		if err != nil || c.RootId == 0 {
+			return err
+		}
+		// 查完父评论之后一级评论可能被删了，这时候回滚
+		res := tx.Model(&Comment{}).Where("id = ? AND status = ?", c.RootId, CommentStatusNormal).
+			Update("reply_cnt", gorm.Expr("`reply_cnt` + 1"))
+		if res.Error != nil {
+			return res.Error
+		}
+		if res.RowsAffected == 0 {
+			return ErrRecordNotFound
+		}
+		return nil
+	})
+	return c.Id, err
+}
+
+func (g *GormCommentDAO) FindById(ctx context.Context, id int64) (Comment, error) {
+	var c Comment
+	err := g.db.WithContext(ctx).Where("id = ?", id).First(&c).Error
+	return c, err
+}
+
+func (g *GormCommentDAO) Delete(ctx context.Context, c Comment) (int64, error) {
+	now := time.Now().UnixMilli()
+	var cnt int64
+	err := g.db.WithContext(ctx).Transaction(func(tx *gorm.DB) error {
+		res := tx.Model(&Comment{}).
+			Where("id = ? AND status = ?", c.Id, CommentStatusNormal).
+			Updates(map[string]any{
+				"status":     CommentStatusDeleted,
+				"pinned":     false,
+				"updated_at": now,
+			})
+		if res.Error != nil {
+			return res.Error
+		}
+		// 并发删除的时候只算一次
+		if res.RowsAffected == 0 {
+			return ErrRecordNotFound
+		}
+		cnt = 1
+		if c.RootId > 0 {
+			return tx.Model(&Comment{}).Where("id = ? AND reply_cnt > 0", c.RootId).
+				Update("reply_cnt", gorm.Expr("`reply_cnt` - 1")).Error
+		}
+		res = tx.Model(&Comment{}).
+			Where("root_id = ? AND status = ?", c.Id, CommentStatusNormal).
+			Updates(map[string]any{
+				"status":     CommentStatusDeleted,
+				"updated_at": now,
+			})
+		cnt += res.RowsAffected
+		return res.Error
+	})
+	return cnt, err
+}
+
+func (g *GormCommentDAO) Pin(ctx context.Context, biz string, bizId int64, id int64, pinned bool) error {
+	now := time.Now().UnixMilli()
+	return g.db.WithContext(ctx).Transaction(func(tx *gorm.DB) error {
+		if pinned {
+			err := tx.Model(&Comment{}).
+				Where("biz = ? AND biz_id = ? AND root_id = 0 AND pinned = ?", biz, bizId, true).
+				Updates(map[string]any{
+					"pinned":     false,
+					"updated_at": now,
+				}).Error
+			if err != nil {
+				return err
+			}
+		}
+		res := tx.Model(&Comment{}).
+			Where("id = ? AND biz = ? AND biz_id = ? AND root_id = 0 AND status = ?",
+				id, biz, bizId, CommentStatusNormal).
+			Updates(map[string]any{
+				"pinned":     pinned,
+				"updated_at": now,
+			})
+		if res.Error != nil {
+			return res.Error
+		}
+		if res.RowsAffected == 0 {
+			return ErrRecordNotFound
+		}
+		return nil
+	})
+}
+
+func (g *GormCommentDAO) InsertLike(ctx context.Context, cid int64, uid int64) error {
+	return g.db.WithContext(ctx).Transaction(func(tx *gorm.DB) error {
+		res := tx.Clauses(clause.OnConflict{DoNothing: true}).Create(&CommentLike{
+			Cid:       cid,
+			Uid:       uid,
+			CreatedAt: time.Now().UnixMilli(),
+		})
+		// 重复点赞不计数
+		if res.Error != nil || res.RowsAffected == 0 {
+			return res.Error
+		}
+		// 删除的评论不能点赞，点赞记录跟着回滚
+		res = tx.Model(&Comment{}).Where("id = ? AND status = ?", cid, CommentStatusNormal).
+			Update("like_cnt", gorm.Expr("`like_cnt` + 1"))
+		if res.Error != nil {
+			return res.Error
+		}
+		if res.RowsAffected == 0 {
+			return ErrRecordNotFound
+		}
+		return nil
+	})
+}
+
+func (g *GormCommentDAO) DeleteLike(ctx context.Context, cid int64, uid int64) error {
+	return g.db.WithContext(ctx).Transaction(func(tx *gorm.DB) error {
+		res := tx.Where("cid = ? AND uid = ?", cid, uid).Delete(&CommentLike{})
+		if res.Error != nil || res.RowsAffected == 0 {
+			return res.Error
+		}
+		return tx.Model(&Comment{}).Where("id = ? AND like_cnt > 0", cid).
+			Update("like_cnt", gorm.Expr("`like_cnt` - 1")).Error
+	})
+}
+
+func (g *GormCommentDAO) LikedIds(ctx context.Context, uid int64, cids []int64) ([]int64, error) {
+	var ids []int64
+	err := g.db.WithContext(ctx).Model(&CommentLike{}).
+		Where("uid = ? AND cid IN ?", uid, cids).
+		Pluck("cid", &ids).Error
+	return ids, err
+}
+
+func (g *GormCommentDAO) FindByBiz(ctx context.Context, biz string, bizId int64, byLike bool, offset int, limit int) ([]Comment, error) {
+	db := g.db.WithContext(ctx).
+		Where("biz = ? AND biz_id = ? AND root_id = 0 AND status = ?", biz, bizId, CommentStatusNormal).
+		Order("pinned DESC")
+	if byLike {
+		db = db.Order("like_cnt DESC")
+	}
+	var res []Comment
+	err := db.Order("id DESC").Offset(offset).Limit(limit).Find(&res).Error
+	return res, err
+}
+
+func (g *GormCommentDAO) FindReplies(ctx context.Context, rootId int64, offset int, limit int) ([]Comment, error) {
+	var res []Comment
+	err := g.db.WithContext(ctx).
+		Where("root_id = ? AND status = ?", rootId, CommentStatusNormal).
+		Order("id ASC").Offset(offset).Limit(limit).Find(&res).Error
+	return res, err
+}
+
+type Comment struct {
+	Id int64 `gorm:"primaryKey,autoIncrement"`
+	// 查一级评论用
+	Biz   string `gorm:"type:varchar(128);index:idx_biz_root"`
+	BizId int64  `gorm:"index:idx_biz_root"`
+	// 查回复用
+	RootId     int64 `gorm:"index:idx_biz_root;index"`
+	ParentId   int64
+	Uid        int64 `gorm:"index"`
+	ReplyToUid int64
+	Content    string `gorm:"type:varchar(4096)"`
+
+	LikeCnt  int64
+	ReplyCnt int64
+	Pinned   bool
+	Status   uint8
+
+	CreatedAt int64
+	UpdatedAt int64
+}
+
+type CommentLike struct {
+	Id        int64 `gorm:"primaryKey,autoIncrement"`
+	Cid       int64 `gorm:"uniqueIndex:cid_uid"`
+	Uid       int64 `gorm:"uniqueIndex:cid_uid"`
+	CreatedAt int64
+}
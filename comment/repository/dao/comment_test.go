@@ -0,0 +1,128 @@
+package dao
+
+import (
+	"context"
+	sqlmock "github.com/DATA-DOG/go-sqlmock"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"gorm.io/driver/mysql"
+	"gorm.io/gorm"
+	"testing"
+)
+
+func newMockGormDB(t *testing.T) (*gorm.DB, sqlmock.Sqlmock) {
+	mockDB, mock, err := sqlmock.New()
+	require.NoError(t, err)
+	db, err := gorm.Open(mysql.New(mysql.Config{
+		Conn:                      mockDB,
+		SkipInitializeWithVersion: true,
+	}), &gorm.Config{
+		SkipDefaultTransaction: true,
+		DisableAutomaticPing:   true,
+	})
+	require.NoError(t, err)
+	return db, mock
+}
+
+func TestGormCommentDAO_Insert(t *testing.T) {
+	testCases := []struct {
+		name    string
+		mock    func(mock sqlmock.Sqlmock)
+		wantId  int64
+		wantErr error
+	}{
+		{
+			name: "回复",
+			mock: func(mock sqlmock.Sqlmock) {
+				mock.ExpectBegin()
+				mock.ExpectExec("INSERT INTO `comments`").WillReturnResult(sqlmock.NewResult(12, 1))
+				mock.ExpectExec("UPDATE `comments` SET `reply_cnt`=`reply_cnt` \\+ 1,`updated_at`=\\? WHERE id = \\? AND status = \\?").
+					WithArgs(sqlmock.AnyArg(), int64(10), CommentStatusNormal).
+					WillReturnResult(sqlmock.NewResult(0, 1))
+				mock.ExpectCommit()
+			},
+			wantId: 12,
+		},
+		{
+			name: "一级评论已经删除",
+			mock: func(mock sqlmock.Sqlmock) {
+				mock.ExpectBegin()
+				mock.ExpectExec("INSERT INTO `comments`").WillReturnResult(sqlmock.NewResult(12, 1))
+				mock.ExpectExec("UPDATE `comments` SET `reply_cnt`=`reply_cnt` \\+ 1,`updated_at`=\\? WHERE id = \\? AND status = \\?").
+					WithArgs(sqlmock.AnyArg(), int64(10), CommentStatusNormal).
+					WillReturnResult(sqlmock.NewResult(0, 0))
+				mock.ExpectRollback()
+			},
+			wantErr: ErrRecordNotFound,
+		},
+	}
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			db, mock := newMockGormDB(t)
+			tc.mock(mock)
+			id, err := NewCommentDAO(db).Insert(context.Background(), Comment{
+				Biz:      "article",
+				BizId:    1,
+				Uid:      123,
+				RootId:   10,
+				ParentId: 10,
+				Content:  "同意",
+			})
+			assert.Equal(t, tc.wantErr, err)
+			assert.NoError(t, mock.ExpectationsWereMet())
+			if err != nil {
+				return
+			}
+			assert.Equal(t, tc.wantId, id)
+		})
+	}
+}
+
+func TestGormCommentDAO_InsertLike(t *testing.T) {
+	testCases := []struct {
+		name    string
+		mock    func(mock sqlmock.Sqlmock)
+		wantErr error
+	}{
+		{
+			name: "点赞",
+			mock: func(mock sqlmock.Sqlmock) {
+				mock.ExpectBegin()
+				mock.ExpectExec("INSERT INTO `comment_likes`").WillReturnResult(sqlmock.NewResult(1, 1))
+				mock.ExpectExec("UPDATE `comments` SET `like_cnt`=`like_cnt` \\+ 1,`updated_at`=\\? WHERE id = \\? AND status = \\?").
+					WithArgs(sqlmock.AnyArg(), int64(10), CommentStatusNormal).
+					WillReturnResult(sqlmock.NewResult(0, 1))
+				mock.ExpectCommit()
+			},
+		},
+		{
+			name: "重复点赞不计数",
+			mock: func(mock sqlmock.Sqlmock) {
+				mock.ExpectBegin()
+				mock.ExpectExec("INSERT INTO `comment_likes`").WillReturnResult(sqlmock.NewResult(0, 0))
+				mock.ExpectCommit()
+			},
+		},
+		{
+			name: "评论已经删除",
+			mock: func(mock sqlmock.Sqlmock) {
+				mock.ExpectBegin()
+				mock.ExpectExec("INSERT INTO `comment_likes`").WillReturnResult(sqlmock.NewResult(1, 1))
+				mock.ExpectExec("UPDATE `comments` SET `like_cnt`=`like_cnt` \\+ 1,`updated_at`=\\? WHERE id = \\? AND status = \\?").
+					WithArgs(sqlmock.AnyArg(), int64(10), CommentStatusNormal).
+					WillReturnResult(sqlmock.NewResult(0, 0))
+				mock.ExpectRollback()
+			},
+			wantErr: ErrRecordNotFound,
+		},
+	}
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			db, mock := newMockGormDB(t)
+			tc.mock(mock)
+			err := NewCommentDAO(db).InsertLike(context.Background(), 10, 123)
+			assert.Equal(t, tc.wantErr, err)
+			assert.NoError(t, mock.ExpectationsWereMet())
+		})
+	}
+}
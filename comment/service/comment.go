@@ -0,0 +1,159 @@
+package service
+
+import (
+	"context"
+	"errors"
+	"github.com/Andras5014/gohub/comment/domain"
+	"github.com/Andras5014/gohub/comment/events"
+	"github.com/Andras5014/gohub/comment/repository"
+	"github.com/Andras5014/gohub/pkg/logx"
+	"github.com/ecodeclub/ekit/slice"
+	"strings"
+	"unicode/utf8"
+)
+
+var (
+	ErrCommentNotFound         = repository.ErrCommentNotFound
+	ErrInvalidComment          = errors.New("评论不合法")
+	ErrCommentPermissionDenied = errors.New("只能删除自己的评论")
+)
+
+const maxCommentLength = 1000
+
+type CommentService interface {
+	// Create ParentId 不为 0 的时候是回复
+	Create(ctx context.Context, c domain.Comment) (int64, error)
+	Delete(ctx context.Context, id int64, uid int64) error
+	// Pin 调用方负责确认 uid 是资源的作者
+	Pin(ctx context.Context, biz string, bizId int64, id int64, pinned bool) error
+	Like(ctx context.Context, id int64, uid int64) error
+	CancelLike(ctx context.Context, id int64, uid int64) error
+	// List 一级评论，uid 用来标记有没有点赞
+	List(ctx context.Context, biz string, bizId int64, sort domain.CommentSort, uid int64, offset int, limit int) ([]domain.Comment, error)
+	Replies(ctx context.Context, rootId int64, uid int64, offset int, limit int) ([]domain.Comment, error)
+}
+
+type commentService struct {
+	repo     repository.CommentRepository
+	producer events.Producer
+	l        logx.Logger
+}
+
+func NewCommentService(repo repository.CommentRepository, producer events.Producer, l logx.Logger) CommentService {
+	return &commentService{repo: repo, producer: producer, l: l}
+}
+
+func (s *commentService) Create(ctx context.Context, c domain.Comment) (int64, error) {
+	c.Content = strings.TrimSpace(c.Content)
+	if c.Content == "" || utf8.RuneCountInString(c.Content) > maxCommentLength {
+		return 0, ErrInvalidComment
+	}
+	c.RootId = 0
+	c.ReplyToUid = 0
+	if c.ParentId > 0 {
+		parent, err := s.repo.FindById(ctx, c.ParentId)
+		if err != nil {
+			return 0, err
+		}
+		if parent.Biz != c.Biz || parent.BizId != c.BizId {
+			return 0, ErrInvalidComment
+		}
+		// 回复的回复也挂在一级评论下面，只有两层
+		c.RootId = parent.RootId
+		if parent.IsRoot() {
+			c.RootId = parent.Id
+		}
+		c.ReplyToUid = parent.Uid
+	}
+	id, err := s.repo.Create(ctx, c)
+	if err != nil {
+		return 0, err
+	}
+	s.produceChange(ctx, events.CommentEvent{
+		Biz:        c.Biz,
+		BizId:      c.BizId,
+		Delta:      1,
+		CommentId:  id,
+		Uid:        c.Uid,
+		ReplyToUid: c.ReplyToUid,
+	})
+	return id, nil
+}
+
+func (s *commentService) Delete(ctx context.Context, id int64, uid int64) error {
+	c, err := s.repo.FindById(ctx, id)
+	if err != nil {
+		return err
+	}
+	if c.Uid != uid {
+		return ErrCommentPermissionDenied
+	}
+	cnt, err := s.repo.Delete(ctx, c)
+	if err != nil {
+		return err
+	}
+	s.produceChange(ctx, events.CommentEvent{
+		Biz:   c.Biz,
+		BizId: c.BizId,
+		Delta: -cnt,
+	})
+	return nil
+}
+
+func (s *commentService) Pin(ctx context.Context, biz string, bizId int64, id int64, pinned bool) error {
+	return s.repo.Pin(ctx, biz, bizId, id, pinned)
+}
+
+func (s *commentService) Like(ctx context.Context, id int64, uid int64) error {
+	if _, err := s.repo.FindById(ctx, id); err != nil {
+		return err
+	}
+	return s.repo.Like(ctx, id, uid)
+}
+
+func (s *commentService) CancelLike(ctx context.Context, id int64, uid int64) error {
+	return s.repo.CancelLike(ctx, id, uid)
+}
+
+func (s *commentService) List(ctx context.Context, biz string, bizId int64, sort domain.CommentSort, uid int64, offset int, limit int) ([]domain.Comment, error) {
+	res, err := s.repo.FindByBiz(ctx, biz, bizId, sort, offset, limit)
+	if err != nil {
+		return nil, err
+	}
+	return s.markLiked(ctx, uid, res), nil
+}
+
+func (s *commentService) Replies(ctx context.Context, rootId int64, uid int64, offset int, limit int) ([]domain.Comment, error) {
+	res, err := s.repo.FindReplies(ctx, rootId, offset, limit)
+	if err != nil {
+		return nil, err
+	}
+	return s.markLiked(ctx, uid, res), nil
+}
+
+// markLiked 查不到点赞状态不影响看评论
+func (s *commentService) markLiked(ctx context.Context, uid int64, comments []domain.Comment) []domain.Comment {
+	if uid <= 0 || len(comments) == 0 {
+		return comments
+	}
+	liked, err := s.repo.LikedIds(ctx, uid, slice.Map(comments, func(idx int, src domain.Comment) int64 {
+		return src.Id
+	}))
+	if err != nil {
+		s.l.Error("查询评论点赞状态失败", logx.Int64("uid", uid), logx.Error(err))
+		return comments
+	}
+	for i := range comments {
+		_, comments[i].Liked = liked[comments[i].Id]
+	}
+	return comments
+}
+
+// produceChange 评论已经写成功了，发消息失败只记日志
+func (s *commentService) produceChange(ctx context.Context, evt events.CommentEvent) {
+	err := s.producer.ProduceCommentEvent(ctx, evt)
+	if err != nil {
+		s.l.Error("发送评论数变更事件失败",
+			logx.String("biz", evt.Biz), logx.Int64("bizId", evt.BizId), logx.Int64("delta", evt.Delta), logx.Error(err))
+	}
+}
@@ -0,0 +1,245 @@
+package service
+
+import (
+	"context"
+	"errors"
+	"github.com/Andras5014/gohub/comment/domain"
+	"github.com/Andras5014/gohub/comment/events"
+	evtmocks "github.com/Andras5014/gohub/comment/events/mocks"
+	"github.com/Andras5014/gohub/comment/repository"
+	repomocks "github.com/Andras5014/gohub/comment/repository/mocks"
+	"github.com/Andras5014/gohub/pkg/logx"
+	"github.com/stretchr/testify/assert"
+	"go.uber.org/mock/gomock"
+	"go.uber.org/zap"
+	"testing"
+)
+
+func Test_commentService_Create(t *testing.T) {
+	testCases := []struct {
+		name    string
+		mock    func(ctrl *gomock.Controller) (repository.CommentRepository, events.Producer)
+		comment domain.Comment
+
+		wantId  int64
+		wantErr error
+	}{
+		{
+			name: "一级评论",
+			mock: func(ctrl *gomock.Controller) (repository.CommentRepository, events.Producer) {
+				repo := repomocks.NewMockCommentRepository(ctrl)
+				producer := evtmocks.NewMockProducer(ctrl)
+				repo.EXPECT().Create(gomock.Any(), domain.Comment{
+					Biz:     "article",
+					BizId:   1,
+					Uid:     123,
+					Content: "写得好",
+				}).Return(int64(10), nil)
+				producer.EXPECT().ProduceCommentEvent(gomock.Any(), events.CommentEvent{
+					Biz:       "article",
+					BizId:     1,
+					Delta:     1,
+					CommentId: 10,
+					Uid:       123,
+				}).Return(nil)
+				return repo, producer
+			},
+			comment: domain.Comment{Biz: "article", BizId: 1, Uid: 123, Content: " 写得好 "},
+			wantId:  10,
+		},
+		{
+			name: "回复的回复挂在一级评论下面",
+			mock: func(ctrl *gomock.Controller) (repository.CommentRepository, events.Producer) {
+				repo := repomocks.NewMockCommentRepository(ctrl)
+				producer := evtmocks.NewMockProducer(ctrl)
+				repo.EXPECT().FindById(gomock.Any(), int64(11)).Return(domain.Comment{
+					Id:     11,
+					Biz:    "article",
+					BizId:  1,
+					Uid:    456,
+					RootId: 10,
+				}, nil)
+				repo.EXPECT().Create(gomock.Any(), domain.Comment{
+					Biz:        "article",
+					BizId:      1,
+					Uid:        123,
+					RootId:     10,
+					ParentId:   11,
+					ReplyToUid: 456,
+					Content:    "同意",
+				}).Return(int64(12), nil)
+				producer.EXPECT().ProduceCommentEvent(gomock.Any(), gomock.Any()).Return(nil)
+				return repo, producer
+			},
+			comment: domain.Comment{Biz: "article", BizId: 1, Uid: 123, ParentId: 11, Content: "同意"},
+			wantId:  12,
+		},
+		{
+			name: "回复别的资源下面的评论",
+			mock: func(ctrl *gomock.Controller) (repository.CommentRepository, events.Producer) {
+				repo := repomocks.NewMockCommentRepository(ctrl)
+				repo.EXPECT().FindById(gomock.Any(), int64(11)).Return(domain.Comment{
+					Id:    11,
+					Biz:   "article",
+					BizId: 2,
+				}, nil)
+				return repo, evtmocks.NewMockProducer(ctrl)
+			},
+			comment: domain.Comment{Biz: "article", BizId: 1, Uid: 123, ParentId: 11, Content: "同意"},
+			wantErr: ErrInvalidComment,
+		},
+		{
+			name: "回复已经删除的评论",
+			mock: func(ctrl *gomock.Controller) (repository.CommentRepository, events.Producer) {
+				repo := repomocks.NewMockCommentRepository(ctrl)
+				repo.EXPECT().FindById(gomock.Any(), int64(11)).
+					Return(domain.Comment{}, repository.ErrCommentNotFound)
+				return repo, evtmocks.NewMockProducer(ctrl)
+			},
+			comment: domain.Comment{Biz: "article", BizId: 1, Uid: 123, ParentId: 11, Content: "同意"},
+			wantErr: ErrCommentNotFound,
+		},
+		{
+			name: "内容为空",
+			mock: func(ctrl *gomock.Controller) (repository.CommentRepository, events.Producer) {
+				return repomocks.NewMockCommentRepository(ctrl), evtmocks.NewMockProducer(ctrl)
+			},
+			comment: domain.Comment{Biz: "article", BizId: 1, Uid: 123, Content: "  "},
+			wantErr: ErrInvalidComment,
+		},
+		{
+			name: "发送消息失败不影响评论",
+			mock: func(ctrl *gomock.Controller) (repository.CommentRepository, events.Producer) {
+				repo := repomocks.NewMockCommentRepository(ctrl)
+				producer := evtmocks.NewMockProducer(ctrl)
+				repo.EXPECT().Create(gomock.Any(), gomock.Any()).Return(int64(10), nil)
+				producer.EXPECT().ProduceCommentEvent(gomock.Any(), gomock.Any()).
+					Return(errors.New("kafka 错误"))
+				return repo, producer
+			},
+			comment: domain.Comment{Biz: "article", BizId: 1, Uid: 123, Content: "写得好"},
+			wantId:  10,
+		},
+	}
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			ctrl := gomock.NewController(t)
+			defer ctrl.Finish()
+			repo, producer := tc.mock(ctrl)
+			svc := NewCommentService(repo, producer, logx.NewZapLogger(zap.NewNop()))
+			id, err := svc.Create(context.Background(), tc.comment)
+			assert.Equal(t, tc.wantErr, err)
+			assert.Equal(t, tc.wantId, id)
+		})
+	}
+}
+
+func Test_commentService_Delete(t *testing.T) {
+	root := domain.Comment{Id: 10, Biz: "article", BizId: 1, Uid: 123}
+	testCases := []struct {
+		name string
+		mock func(ctrl *gomock.Controller) (repository.CommentRepository, events.Producer)
+		uid  int64
+
+		wantErr error
+	}{
+		{
+			name: "删除一级评论连同回复一起减掉",
+			mock: func(ctrl *gomock.Controller) (repository.CommentRepository, events.Producer) {
+				repo := repomocks.NewMockCommentRepository(ctrl)
+				producer := evtmocks.NewMockProducer(ctrl)
+				repo.EXPECT().FindById(gomock.Any(), int64(10)).Return(root, nil)
+				repo.EXPECT().Delete(gomock.Any(), root).Return(int64(3), nil)
+				producer.EXPECT().ProduceCommentEvent(gomock.Any(), events.CommentEvent{
+					Biz:   "article",
+					BizId: 1,
+					Delta: -3,
+				}).Return(nil)
+				return repo, producer
+			},
+			uid: 123,
+		},
+		{
+			name: "不能删除别人的评论",
+			mock: func(ctrl *gomock.Controller) (repository.CommentRepository, events.Producer) {
+				repo := repomocks.NewMockCommentRepository(ctrl)
+				repo.EXPECT().FindById(gomock.Any(), int64(10)).Return(root, nil)
+				return repo, evtmocks.NewMockProducer(ctrl)
+			},
+			uid:     456,
+			wantErr: ErrCommentPermissionDenied,
+		},
+		{
+			name: "评论不存在",
+			mock: func(ctrl *gomock.Controller) (repository.CommentRepository, events.Producer) {
+				repo := repomocks.NewMockCommentRepository(ctrl)
+				repo.EXPECT().FindById(gomock.Any(), int64(10)).
+					Return(domain.Comment{}, ErrCommentNotFound)
+				return repo, evtmocks.NewMockProducer(ctrl)
+			},
+			uid:     123,
+			wantErr: ErrCommentNotFound,
+		},
+	}
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			ctrl := gomock.NewController(t)
+			defer ctrl.Finish()
+			repo, producer := tc.mock(ctrl)
+			svc := NewCommentService(repo, producer, logx.NewZapLogger(zap.NewNop()))
+			err := svc.Delete(context.Background(), 10, tc.uid)
+			assert.Equal(t, tc.wantErr, err)
+		})
+	}
+}
+
+func Test_commentService_Like(t *testing.T) {
+	testCases := []struct {
+		name    string
+		mock    func(ctrl *gomock.Controller) repository.CommentRepository
+		wantErr error
+	}{
+		{
+			name: "点赞成功",
+			mock: func(ctrl *gomock.Controller) repository.CommentRepository {
+				repo := repomocks.NewMockCommentRepository(ctrl)
+				repo.EXPECT().FindById(gomock.Any(), int64(10)).Return(domain.Comment{Id: 10}, nil)
+				repo.EXPECT().Like(gomock.Any(), int64(10), int64(123)).Return(nil)
+				return repo
+			},
+		},
+		{
+			name: "评论已经删除",
+			mock: func(ctrl *gomock.Controller) repository.CommentRepository {
+				repo := repomocks.NewMockCommentRepository(ctrl)
+				repo.EXPECT().FindById(gomock.Any(), int64(10)).
+					Return(domain.Comment{}, repository.ErrCommentNotFound)
+				return repo
+			},
+			wantErr: ErrCommentNotFound,
+		},
+	}
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			ctrl := gomock.NewController(t)
+			defer ctrl.Finish()
+			svc := NewCommentService(tc.mock(ctrl), evtmocks.NewMockProducer(ctrl), logx.NewZapLogger(zap.NewNop()))
+			err := svc.Like(context.Background(), 10, 123)
+			assert.Equal(t, tc.wantErr, err)
+		})
+	}
+}
+
+func Test_commentService_List(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+	repo := repomocks.NewMockCommentRepository(ctrl)
+	repo.EXPECT().FindByBiz(gomock.Any(), "article", int64(1), domain.CommentSortByLike, 0, 10).
+		Return([]domain.Comment{{Id: 10}, {Id: 11}}, nil)
+	repo.EXPECT().LikedIds(gomock.Any(), int64(123), []int64{10, 11}).
+		Return(map[int64]struct{}{11: {}}, nil)
+	svc := NewCommentService(repo, nil, logx.NewZapLogger(zap.NewNop()))
+	res, err := svc.List(context.Background(), "article", 1, domain.CommentSortByLike, 123, 0, 10)
+	assert.NoError(t, err)
+	assert.Equal(t, []domain.Comment{{Id: 10}, {Id: 11, Liked: true}}, res)
+}
@@ -0,0 +1,38 @@
+//go:build wireinject
+
+package main
+
+import (
+	"github.com/Andras5014/gohub/comment/events"
+	"github.com/Andras5014/gohub/comment/grpc"
+	"github.com/Andras5014/gohub/comment/ioc"
+	"github.com/Andras5014/gohub/comment/repository"
+	"github.com/Andras5014/gohub/comment/repository/dao"
+	"github.com/Andras5014/gohub/comment/service"
+	"github.com/google/wire"
+)
+
+var thirdPartySet = wire.NewSet(
+	ioc.InitKafka,
+	ioc.InitSyncProducer,
+	ioc.InitDB,
+	ioc.InitConfig,
+	ioc.InitLogger,
+)
+var commentSvcSet = wire.NewSet(
+	service.NewCommentService,
+	events.NewSaramaSyncProducer,
+	repository.NewCommentRepository,
+	dao.NewCommentDAO,
+)
+
+func InitApp() *App {
+	wire.Build(
+		commentSvcSet,
+		thirdPartySet,
+		grpc.NewCommentServiceServer,
+		ioc.InitGRPCxServer,
+		wire.Struct(new(App), "*"),
+	)
+	return new(App)
+}
@@ -1,12 +1,21 @@
 package config
 
+import "time"
+
 // 配置信息
 type Config struct {
 	DB    DBConfig    `mapstructure:"db"`
 	Redis RedisConfig `mapstructure:"redis"`
+	Mongo MongoConfig `mapstructure:"mongo"`
 
 	Kafka KafkaConfig `mapstructure:"kafka"`
 	Grpc  GrpcConfig  `mapstructure:"grpc"`
+	OSS   OSSConfig   `mapstructure:"oss"`
+
+	Article    ArticleConfig    `mapstructure:"article"`
+	Ranking    RankingConfig    `mapstructure:"ranking"`
+	Moderation ModerationConfig `mapstructure:"moderation"`
+	Search     SearchConfig     `mapstructure:"search"`
 }
 type DBConfig struct {
 	DSN string `mapstructure:"dsn"`
@@ -14,6 +23,14 @@ type DBConfig struct {
 type RedisConfig struct {
 	Addr string `mapstructure:"addr"`
 }
+
+// MongoConfig 文章存到 mongo 的时候才用到
+type MongoConfig struct {
+	URI      string `mapstructure:"uri"`
+	Database string `mapstructure:"database"`
+	// NodeId 生成文章 id 的雪花算法节点，每个实例不一样
+	NodeId int64 `mapstructure:"node_id"`
+}
 type KafkaConfig struct {
 	Addrs []string `mapstructure:"addrs"`
 }
@@ -24,5 +41,80 @@ type GrpcConfig struct {
 			Threshold int    `mapstructure:"threshold"`
 			Secure    bool   `mapstructure:"secure"`
 		}
+		Comment struct {
+			Addr   string `mapstructure:"addr"`
+			Secure bool   `mapstructure:"secure"`
+		}
 	}
 }
+
+type ArticleConfig struct {
+	// Storage 文章存在哪里，mysql 或者 mongo，默认 mysql
+	// mysql 的时候配置了对象存储，线上库的正文放到对象存储
+	Storage string      `mapstructure:"storage"`
+	Trash   TrashConfig `mapstructure:"trash"`
+}
+
+// TrashConfig 回收站
+type TrashConfig struct {
+	// Retention 放进回收站多久之后彻底删除，比如 720h
+	Retention time.Duration `mapstructure:"retention"`
+	// Cron 清理任务的执行时间，带秒
+	Cron string `mapstructure:"cron"`
+}
+
+// RankingConfig 热榜，Boards 为空的时候使用默认的日榜、周榜和总榜
+type RankingConfig struct {
+	// Boards 第一个是默认的热榜
+	Boards []RankingBoardConfig `mapstructure:"boards"`
+}
+
+type RankingBoardConfig struct {
+	Name string `mapstructure:"name"`
+	// Window 只统计这段时间内更新过的文章，比如 24h，不配置表示不限
+	Window time.Duration `mapstructure:"window"`
+	Size   int           `mapstructure:"size"`
+	// Scorer 打分算法，hacker_news、weighted 或者 decay
+	Scorer        string  `mapstructure:"scorer"`
+	ReadWeight    float64 `mapstructure:"read_weight"`
+	LikeWeight    float64 `mapstructure:"like_weight"`
+	CollectWeight float64 `mapstructure:"collect_weight"`
+	// Gravity hacker_news 的时间衰减系数
+	Gravity float64 `mapstructure:"gravity"`
+	// HalfLife decay 的半衰期，比如 6h
+	HalfLife time.Duration `mapstructure:"half_life"`
+	// Streaming 增量模式，只支持 weighted 和 decay，不能配置 Window
+	Streaming bool `mapstructure:"streaming"`
+}
+
+// ModerationConfig 发表之前的内容审核
+type ModerationConfig struct {
+	// Dict 敏感词词库，一行一个词，文件修改之后自动重新加载
+	Dict string `mapstructure:"dict"`
+	// Mode 命中敏感词之后 reject、mask 或者 review，默认 reject
+	Mode string `mapstructure:"mode"`
+	// ReviewAll 没有命中也要人工审核
+	ReviewAll bool `mapstructure:"review_all"`
+	// Reviewers 可以处理审核队列的管理员
+	Reviewers []int64 `mapstructure:"reviewers"`
+}
+
+// OSSConfig 文章正文的对象存储，Type 为空表示正文仍然存在 MySQL
+type OSSConfig struct {
+	// Type minio 或者 local
+	Type      string `mapstructure:"type"`
+	Endpoint  string `mapstructure:"endpoint"`
+	AccessKey string `mapstructure:"access_key"`
+	SecretKey string `mapstructure:"secret_key"`
+	UseSSL    bool   `mapstructure:"use_ssl"`
+	Bucket    string `mapstructure:"bucket"`
+	// Root local 模式下的存储目录
+	Root string `mapstructure:"root"`
+}
+
+// SearchConfig 内置的搜索索引每个实例一份
+type SearchConfig struct {
+	// InstanceId 实例固定的 id，每个实例一个消费组，重新部署之后还用原来的消费组接着消费
+	// 不要用主机名，k8s 里面可以用 StatefulSet 的 pod 名字
+	InstanceId string `mapstructure:"instance_id"`
+}
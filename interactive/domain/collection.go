@@ -0,0 +1,25 @@
+package domain
+
+import "time"
+
+// Collection 收藏夹，Id 为 0 的是每个人都有的默认收藏夹，不落库
+type Collection struct {
+	Id          int64
+	Uid         int64
+	Name        string
+	Description string
+	// Public 公开的别人也能看到
+	Public  bool
+	ItemCnt int64
+	Ctime   time.Time
+	Utime   time.Time
+}
+
+// CollectionItem 收藏夹里面的一个资源，同一个资源可以放进多个收藏夹
+type CollectionItem struct {
+	Cid   int64
+	Uid   int64
+	Biz   string
+	BizId int64
+	Ctime time.Time
+}
@@ -0,0 +1,55 @@
+package events
+
+import (
+	"context"
+	"github.com/Andras5014/gohub/interactive/repository"
+	"github.com/Andras5014/gohub/pkg/logx"
+	"github.com/Andras5014/gohub/pkg/saramax"
+	"github.com/IBM/sarama"
+	"time"
+)
+
+const CommentEventConsumerGroup = "interactive_comment"
+const TopicCommentEvent = "comment_events"
+
+// CommentEvent 评论服务发过来的评论数变化，和 comment/events 里面的保持一致
+type CommentEvent struct {
+	Biz   string `json:"biz"`
+	BizId int64  `json:"biz_id"`
+	// Delta 删除评论的时候是负数，删除一级评论会连同回复一起算
+	Delta int64 `json:"delta"`
+}
+
+type InteractiveCommentEventConsumer struct {
+	client sarama.Client
+	repo   repository.InteractiveRepository
+	l      logx.Logger
+}
+
+func NewInteractiveCommentEventConsumer(client sarama.Client, repo repository.InteractiveRepository, l logx.Logger) *InteractiveCommentEventConsumer {
+	return &InteractiveCommentEventConsumer{
+		client: client,
+		repo:   repo,
+		l:      l,
+	}
+}
+
+func (i *InteractiveCommentEventConsumer) Start() error {
+	cg, err := sarama.NewConsumerGroupFromClient(CommentEventConsumerGroup, i.client)
+	if err != nil {
+		return err
+	}
+	go func() {
+		err := cg.Consume(context.Background(), []string{TopicCommentEvent}, saramax.NewHandler[CommentEvent](i.l, i.Consume))
+		if err != nil {
+			i.l.Error("消费消息失败", logx.Error(err))
+		}
+	}()
+	return err
+}
+
+func (i *InteractiveCommentEventConsumer) Consume(msg *sarama.ConsumerMessage, evt CommentEvent) error {
+	ctx, cancel := context.WithTimeout(context.Background(), time.Second)
+	defer cancel()
+	return i.repo.IncrCommentCnt(ctx, evt.Biz, evt.BizId, evt.Delta)
+}
@@ -0,0 +1,55 @@
+// Code generated by MockGen. DO NOT EDIT.
+// Source: ./interactive/events/producer.go
+//
+// Generated by this command:
+//
+//	mockgen -source=./interactive/events/producer.go -destination=./interactive/events/mocks/producer.go -package=evtmocks
+//
+
+// Package evtmocks is a generated GoMock package.
+package evtmocks
+
+import (
+	context "context"
+	reflect "reflect"
+
+	events "github.com/Andras5014/gohub/interactive/events"
+	gomock "go.uber.org/mock/gomock"
+)
+
+// MockProducer is a mock of Producer interface.
+type MockProducer struct {
+	ctrl     *gomock.Controller
+	recorder *MockProducerMockRecorder
+}
+
+// MockProducerMockRecorder is the mock recorder for MockProducer.
+type MockProducerMockRecorder struct {
+	mock *MockProducer
+}
+
+// NewMockProducer creates a new mock instance.
+func NewMockProducer(ctrl *gomock.Controller) *MockProducer {
+	mock := &MockProducer{ctrl: ctrl}
+	mock.recorder = &MockProducerMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockProducer) EXPECT() *MockProducerMockRecorder {
+	return m.recorder
+}
+
+// ProduceChangeEvent mocks base method.
+func (m *MockProducer) ProduceChangeEvent(ctx context.Context, event events.ChangeEvent) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "ProduceChangeEvent", ctx, event)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// ProduceChangeEvent indicates an expected call of ProduceChangeEvent.
+func (mr *MockProducerMockRecorder) ProduceChangeEvent(ctx, event any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ProduceChangeEvent", reflect.TypeOf((*MockProducer)(nil).ProduceChangeEvent), ctx, event)
+}
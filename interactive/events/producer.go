@@ -0,0 +1,52 @@
+package events
+
+import (
+	"context"
+	"encoding/json"
+	"github.com/IBM/sarama"
+	"strconv"
+)
+
+const TopicChangeEvent = "interactive_change"
+
+const (
+	ChangeTypeLike    = "like"
+	ChangeTypeCollect = "collect"
+)
+
+// ChangeEvent 点赞、收藏的数量变化，给热榜、通知这类需要实时处理的下游用
+type ChangeEvent struct {
+	Biz   string `json:"biz"`
+	BizId int64  `json:"biz_id"`
+	Type  string `json:"type"`
+	// Delta 取消点赞是 -1
+	Delta int64 `json:"delta"`
+	// Uid 点赞、收藏的人，通知用
+	Uid int64 `json:"uid"`
+}
+
+type Producer interface {
+	ProduceChangeEvent(ctx context.Context, event ChangeEvent) error
+}
+
+type KafkaProducer struct {
+	producer sarama.SyncProducer
+}
+
+func NewSaramaSyncProducer(producer sarama.SyncProducer) Producer {
+	return &KafkaProducer{producer: producer}
+}
+
+// ProduceChangeEvent 按 biz_id 分区，同一个资源的变化是有序的
+func (k *KafkaProducer) ProduceChangeEvent(ctx context.Context, event ChangeEvent) error {
+	data, err := json.Marshal(event)
+	if err != nil {
+		return err
+	}
+	_, _, err = k.producer.SendMessage(&sarama.ProducerMessage{
+		Topic: TopicChangeEvent,
+		Key:   sarama.StringEncoder(event.Biz + ":" + strconv.FormatInt(event.BizId, 10)),
+		Value: sarama.ByteEncoder(data),
+	})
+	return err
+}
@@ -2,10 +2,14 @@ package grpc
 
 import (
 	"context"
+	"errors"
 	interactivev1 "github.com/Andras5014/gohub/api/proto/gen/interactive/v1"
 	"github.com/Andras5014/gohub/interactive/domain"
 	"github.com/Andras5014/gohub/interactive/service"
+	"github.com/ecodeclub/ekit/slice"
 	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
 )
 
 type InteractiveServiceServer struct {
@@ -48,11 +52,75 @@ func (i *InteractiveServiceServer) CancelLike(ctx context.Context, request *inte
 func (i *InteractiveServiceServer) Collect(ctx context.Context, request *interactivev1.CollectRequest) (*interactivev1.CollectResponse, error) {
 	err := i.svc.Collect(ctx, request.Biz, request.BizId, request.Cid, request.Uid)
 	if err != nil {
-		return nil, err
+		return nil, ToStatus(err)
 	}
 	return &interactivev1.CollectResponse{}, nil
 }
 
+func (i *InteractiveServiceServer) Uncollect(ctx context.Context, request *interactivev1.UncollectRequest) (*interactivev1.UncollectResponse, error) {
+	err := i.svc.Uncollect(ctx, request.Biz, request.BizId, request.Cid, request.Uid)
+	if err != nil {
+		return nil, ToStatus(err)
+	}
+	return &interactivev1.UncollectResponse{}, nil
+}
+
+func (i *InteractiveServiceServer) MoveCollectionItem(ctx context.Context, request *interactivev1.MoveCollectionItemRequest) (*interactivev1.MoveCollectionItemResponse, error) {
+	err := i.svc.MoveCollectionItem(ctx, request.Biz, request.BizId, request.FromCid, request.ToCid, request.Uid)
+	if err != nil {
+		return nil, ToStatus(err)
+	}
+	return &interactivev1.MoveCollectionItemResponse{}, nil
+}
+
+func (i *InteractiveServiceServer) CreateCollection(ctx context.Context, request *interactivev1.CreateCollectionRequest) (*interactivev1.CreateCollectionResponse, error) {
+	id, err := i.svc.CreateCollection(ctx, CollectionToDomain(request.GetCollection()))
+	if err != nil {
+		return nil, ToStatus(err)
+	}
+	return &interactivev1.CreateCollectionResponse{Id: id}, nil
+}
+
+func (i *InteractiveServiceServer) UpdateCollection(ctx context.Context, request *interactivev1.UpdateCollectionRequest) (*interactivev1.UpdateCollectionResponse, error) {
+	err := i.svc.UpdateCollection(ctx, CollectionToDomain(request.GetCollection()))
+	if err != nil {
+		return nil, ToStatus(err)
+	}
+	return &interactivev1.UpdateCollectionResponse{}, nil
+}
+
+func (i *InteractiveServiceServer) DeleteCollection(ctx context.Context, request *interactivev1.DeleteCollectionRequest) (*interactivev1.DeleteCollectionResponse, error) {
+	err := i.svc.DeleteCollection(ctx, request.Id, request.Uid)
+	if err != nil {
+		return nil, ToStatus(err)
+	}
+	return &interactivev1.DeleteCollectionResponse{}, nil
+}
+
+func (i *InteractiveServiceServer) ListCollections(ctx context.Context, request *interactivev1.ListCollectionsRequest) (*interactivev1.ListCollectionsResponse, error) {
+	res, err := i.svc.ListCollections(ctx, request.Uid, request.ViewerUid, int(request.Offset), int(request.Limit))
+	if err != nil {
+		return nil, ToStatus(err)
+	}
+	return &interactivev1.ListCollectionsResponse{
+		Collections: slice.Map(res, func(idx int, src domain.Collection) *interactivev1.Collection {
+			return CollectionToDTO(src)
+		}),
+	}, nil
+}
+
+func (i *InteractiveServiceServer) ListCollectionItems(ctx context.Context, request *interactivev1.ListCollectionItemsRequest) (*interactivev1.ListCollectionItemsResponse, error) {
+	res, err := i.svc.ListCollectionItems(ctx, request.Cid, request.ViewerUid, int(request.Offset), int(request.Limit))
+	if err != nil {
+		return nil, ToStatus(err)
+	}
+	return &interactivev1.ListCollectionItemsResponse{
+		Items: slice.Map(res, func(idx int, src domain.CollectionItem) *interactivev1.CollectionItem {
+			return CollectionItemToDTO(src)
+		}),
+	}, nil
+}
+
 func (i *InteractiveServiceServer) Get(ctx context.Context, request *interactivev1.GetRequest) (*interactivev1.GetResponse, error) {
 	intr, err := i.svc.Get(ctx, request.Biz, request.BizId, request.Uid)
 	if err != nil {
@@ -92,5 +160,49 @@ func (i *InteractiveServiceServer) toDTO(intr domain.Interactive) *interactivev1
 		LikeCnt:    intr.LikeCnt,
 		Liked:      intr.Liked,
 		ReadCnt:    intr.ReadCnt,
+		CommentCnt: intr.CommentCnt,
+	}
+}
+
+// ToStatus 本地调用的时候也要返回一样的错误码
+func ToStatus(err error) error {
+	switch {
+	case errors.Is(err, service.ErrInvalidCollection):
+		return status.Error(codes.InvalidArgument, err.Error())
+	case errors.Is(err, service.ErrCollectionNotFound):
+		return status.Error(codes.NotFound, "收藏夹不存在")
+	}
+	return err
+}
+
+func CollectionToDomain(c *interactivev1.Collection) domain.Collection {
+	return domain.Collection{
+		Id:          c.GetId(),
+		Uid:         c.GetUid(),
+		Name:        c.GetName(),
+		Description: c.GetDescription(),
+		Public:      c.GetPublic(),
+	}
+}
+
+func CollectionToDTO(c domain.Collection) *interactivev1.Collection {
+	return &interactivev1.Collection{
+		Id:          c.Id,
+		Uid:         c.Uid,
+		Name:        c.Name,
+		Description: c.Description,
+		Public:      c.Public,
+		ItemCnt:     c.ItemCnt,
+		Ctime:       c.Ctime.UnixMilli(),
+		Utime:       c.Utime.UnixMilli(),
+	}
+}
+
+func CollectionItemToDTO(item domain.CollectionItem) *interactivev1.CollectionItem {
+	return &interactivev1.CollectionItem{
+		Cid:   item.Cid,
+		Biz:   item.Biz,
+		BizId: item.BizId,
+		Ctime: item.Ctime.UnixMilli(),
 	}
 }
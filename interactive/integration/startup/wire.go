@@ -3,6 +3,7 @@
 package startup
 
 import (
+	"github.com/Andras5014/gohub/interactive/events"
 	"github.com/Andras5014/gohub/interactive/grpc"
 	"github.com/Andras5014/gohub/interactive/repository"
 	"github.com/Andras5014/gohub/interactive/repository/cache"
@@ -16,17 +17,22 @@ var thirdPartySet = wire.NewSet(
 	InitDB,
 	InitConfig,
 	InitLogger,
+	InitKafka,
+	InitSyncProducer,
 )
 var interactiveSvcSet = wire.NewSet(
 	service.NewInteractiveService,
+	events.NewSaramaSyncProducer,
 	repository.NewInteractiveRepository,
+	repository.NewCollectionRepository,
 	cache.NewInteractiveCache,
 	dao.NewInteractiveDAO,
+	dao.NewCollectionDAO,
 )
 
 func InitInteractiveSvc() service.InteractiveService {
 	wire.Build(thirdPartySet, interactiveSvcSet)
-	return service.NewInteractiveService(nil)
+	return service.NewInteractiveService(nil, nil, nil, nil)
 }
 
 func InitInteractiveGRPCServer() *grpc.InteractiveServiceServer {
@@ -7,6 +7,7 @@
 package startup
 
 import (
+	"github.com/Andras5014/gohub/interactive/events"
 	"github.com/Andras5014/gohub/interactive/grpc"
 	"github.com/Andras5014/gohub/interactive/repository"
 	"github.com/Andras5014/gohub/interactive/repository/cache"
@@ -25,7 +26,12 @@ func InitInteractiveSvc() service.InteractiveService {
 	cmdable := InitRedis(config)
 	interactiveCache := cache.NewInteractiveCache(cmdable)
 	interactiveRepository := repository.NewInteractiveRepository(interactiveDAO, interactiveCache, logger)
-	interactiveService := service.NewInteractiveService(interactiveRepository)
+	collectionDAO := dao.NewCollectionDAO(db)
+	collectionRepository := repository.NewCollectionRepository(collectionDAO, interactiveCache, logger)
+	client := InitKafka(config)
+	syncProducer := InitSyncProducer(client)
+	producer := events.NewSaramaSyncProducer(syncProducer)
+	interactiveService := service.NewInteractiveService(interactiveRepository, collectionRepository, producer, logger)
 	return interactiveService
 }
 
@@ -37,7 +43,12 @@ func InitInteractiveGRPCServer() *grpc.InteractiveServiceServer {
 	cmdable := InitRedis(config)
 	interactiveCache := cache.NewInteractiveCache(cmdable)
 	interactiveRepository := repository.NewInteractiveRepository(interactiveDAO, interactiveCache, logger)
-	interactiveService := service.NewInteractiveService(interactiveRepository)
+	collectionDAO := dao.NewCollectionDAO(db)
+	collectionRepository := repository.NewCollectionRepository(collectionDAO, interactiveCache, logger)
+	client := InitKafka(config)
+	syncProducer := InitSyncProducer(client)
+	producer := events.NewSaramaSyncProducer(syncProducer)
+	interactiveService := service.NewInteractiveService(interactiveRepository, collectionRepository, producer, logger)
 	interactiveServiceServer := grpc.NewInteractiveServiceServer(interactiveService)
 	return interactiveServiceServer
 }
@@ -49,6 +60,8 @@ var thirdPartySet = wire.NewSet(
 	InitDB,
 	InitConfig,
 	InitLogger,
+	InitKafka,
+	InitSyncProducer,
 )
 
-var interactiveSvcSet = wire.NewSet(service.NewInteractiveService, repository.NewInteractiveRepository, cache.NewInteractiveCache, dao.NewInteractiveDAO)
+var interactiveSvcSet = wire.NewSet(service.NewInteractiveService, events.NewSaramaSyncProducer, repository.NewInteractiveRepository, repository.NewCollectionRepository, cache.NewInteractiveCache, dao.NewInteractiveDAO, dao.NewCollectionDAO)
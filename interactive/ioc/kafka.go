@@ -25,6 +25,7 @@ func InitSyncProducer(client sarama.Client) sarama.SyncProducer {
 	return producer
 }
 
-func InitConsumers(c *events.InteractiveReadEventBatchConsumer) []saramax.Consumer {
-	return []saramax.Consumer{c}
+func InitConsumers(c *events.InteractiveReadEventBatchConsumer,
+	commentConsumer *events.InteractiveCommentEventConsumer) []saramax.Consumer {
+	return []saramax.Consumer{c, commentConsumer}
 }
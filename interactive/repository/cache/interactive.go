@@ -14,6 +14,7 @@ import (
 const fieldReadCnt = "read_cnt"
 const fieldLikeCnt = "like_cnt"
 const fieldCollectCnt = "collect_cnt"
+const fieldCommentCnt = "comment_cnt"
 
 var (
 	//go:embed  lua/incr_cnt.lua
@@ -25,6 +26,8 @@ type InteractiveCache interface {
 	IncrLikeCntIfPresent(ctx context.Context, biz string, bizId int64) error
 	DecrLikeCntIfPresent(ctx context.Context, biz string, bizId int64) error
 	IncrCollectCntIfPresent(ctx context.Context, biz string, bizId int64) error
+	DecrCollectCntIfPresent(ctx context.Context, biz string, bizId int64) error
+	IncrCommentCntIfPresent(ctx context.Context, biz string, bizId int64, delta int64) error
 	Get(ctx context.Context, biz string, bizId int64) (domain.Interactive, error)
 	Set(ctx context.Context, biz string, bizId int64, res domain.Interactive) error
 }
@@ -56,6 +59,16 @@ func (i *InteractiveRedisCache) IncrCollectCntIfPresent(ctx context.Context, biz
 	return i.client.Eval(ctx, luaIncrCnt, []string{key}, fieldCollectCnt, 1).Err()
 }
 
+func (i *InteractiveRedisCache) DecrCollectCntIfPresent(ctx context.Context, biz string, bizId int64) error {
+	key := i.key(biz, bizId)
+	return i.client.Eval(ctx, luaIncrCnt, []string{key}, fieldCollectCnt, -1).Err()
+}
+
+func (i *InteractiveRedisCache) IncrCommentCntIfPresent(ctx context.Context, biz string, bizId int64, delta int64) error {
+	key := i.key(biz, bizId)
+	return i.client.Eval(ctx, luaIncrCnt, []string{key}, fieldCommentCnt, delta).Err()
+}
+
 func (i *InteractiveRedisCache) Get(ctx context.Context, biz string, bizId int64) (domain.Interactive, error) {
 	key := i.key(biz, bizId)
 	res, err := i.client.HGetAll(ctx, key).Result()
@@ -69,6 +82,7 @@ func (i *InteractiveRedisCache) Get(ctx context.Context, biz string, bizId int64
 	intr.CollectCnt, _ = strconv.ParseInt(res[fieldCollectCnt], 10, 64)
 	intr.LikeCnt, _ = strconv.ParseInt(res[fieldLikeCnt], 10, 64)
 	intr.ReadCnt, _ = strconv.ParseInt(res[fieldReadCnt], 10, 64)
+	intr.CommentCnt, _ = strconv.ParseInt(res[fieldCommentCnt], 10, 64)
 	return intr, nil
 }
 
@@ -78,6 +92,7 @@ func (i *InteractiveRedisCache) Set(ctx context.Context, biz string, bizId int64
 		fieldCollectCnt: res.CollectCnt,
 		fieldLikeCnt:    res.LikeCnt,
 		fieldReadCnt:    res.ReadCnt,
+		fieldCommentCnt: res.CommentCnt,
 	}).Err()
 	if err != nil {
 		return err
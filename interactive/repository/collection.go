@@ -0,0 +1,129 @@
+package repository
+
+import (
+	"context"
+	"github.com/Andras5014/gohub/interactive/domain"
+	"github.com/Andras5014/gohub/interactive/repository/cache"
+	"github.com/Andras5014/gohub/interactive/repository/dao"
+	"github.com/Andras5014/gohub/pkg/logx"
+	"github.com/ecodeclub/ekit/slice"
+	"time"
+)
+
+var ErrCollectionNotFound = dao.ErrCollectionNotFound
+
+type CollectionRepository interface {
+	Create(ctx context.Context, c domain.Collection) (int64, error)
+	Update(ctx context.Context, c domain.Collection) error
+	// Delete 返回删掉之后不在任何收藏夹里面的资源，这些资源的收藏数已经减掉了
+	Delete(ctx context.Context, id int64, uid int64) ([]domain.CollectionItem, error)
+	GetById(ctx context.Context, id int64) (domain.Collection, error)
+	List(ctx context.Context, uid int64, onlyPublic bool, offset int, limit int) ([]domain.Collection, error)
+	ListItems(ctx context.Context, uid int64, cid int64, offset int, limit int) ([]domain.CollectionItem, error)
+	MoveItem(ctx context.Context, uid int64, biz string, bizId int64, fromCid int64, toCid int64) error
+}
+
+type collectionRepository struct {
+	dao   dao.CollectionDAO
+	cache cache.InteractiveCache
+	l     logx.Logger
+}
+
+func NewCollectionRepository(dao dao.CollectionDAO, cache cache.InteractiveCache, l logx.Logger) CollectionRepository {
+	return &collectionRepository{
+		dao:   dao,
+		cache: cache,
+		l:     l,
+	}
+}
+
+func (c *collectionRepository) Create(ctx context.Context, collection domain.Collection) (int64, error) {
+	return c.dao.Insert(ctx, c.toEntity(collection))
+}
+
+func (c *collectionRepository) Update(ctx context.Context, collection domain.Collection) error {
+	return c.dao.Update(ctx, c.toEntity(collection))
+}
+
+func (c *collectionRepository) Delete(ctx context.Context, id int64, uid int64) ([]domain.CollectionItem, error) {
+	removed, err := c.dao.Delete(ctx, id, uid)
+	if err != nil {
+		return nil, err
+	}
+	for _, item := range removed {
+		// 数据库已经删掉了，缓存更新失败只会让收藏数多一点，等缓存过期
+		er := c.cache.DecrCollectCntIfPresent(ctx, item.Biz, item.BizId)
+		if er != nil {
+			c.l.Error("更新收藏数缓存失败", logx.String("biz", item.Biz),
+				logx.Int64("bizId", item.BizId), logx.Error(er))
+		}
+	}
+	return slice.Map(removed, func(idx int, src dao.UserCollectionBiz) domain.CollectionItem {
+		return c.itemToDomain(src)
+	}), nil
+}
+
+func (c *collectionRepository) GetById(ctx context.Context, id int64) (domain.Collection, error) {
+	collection, err := c.dao.GetById(ctx, id)
+	if err != nil {
+		return domain.Collection{}, err
+	}
+	return c.toDomain(collection), nil
+}
+
+func (c *collectionRepository) List(ctx context.Context, uid int64, onlyPublic bool, offset int, limit int) ([]domain.Collection, error) {
+	res, err := c.dao.ListByUid(ctx, uid, onlyPublic, offset, limit)
+	if err != nil {
+		return nil, err
+	}
+	return slice.Map(res, func(idx int, src dao.Collection) domain.Collection {
+		return c.toDomain(src)
+	}), nil
+}
+
+func (c *collectionRepository) ListItems(ctx context.Context, uid int64, cid int64, offset int, limit int) ([]domain.CollectionItem, error) {
+	res, err := c.dao.ListItems(ctx, uid, cid, offset, limit)
+	if err != nil {
+		return nil, err
+	}
+	return slice.Map(res, func(idx int, src dao.UserCollectionBiz) domain.CollectionItem {
+		return c.itemToDomain(src)
+	}), nil
+}
+
+func (c *collectionRepository) MoveItem(ctx context.Context, uid int64, biz string, bizId int64, fromCid int64, toCid int64) error {
+	return c.dao.MoveItem(ctx, uid, biz, bizId, fromCid, toCid)
+}
+
+func (c *collectionRepository) toEntity(collection domain.Collection) dao.Collection {
+	return dao.Collection{
+		Id:          collection.Id,
+		Uid:         collection.Uid,
+		Name:        collection.Name,
+		Description: collection.Description,
+		Public:      collection.Public,
+	}
+}
+
+func (c *collectionRepository) toDomain(collection dao.Collection) domain.Collection {
+	return domain.Collection{
+		Id:          collection.Id,
+		Uid:         collection.Uid,
+		Name:        collection.Name,
+		Description: collection.Description,
+		Public:      collection.Public,
+		ItemCnt:     collection.ItemCnt,
+		Ctime:       time.UnixMilli(collection.CreatedAt),
+		Utime:       time.UnixMilli(collection.UpdatedAt),
+	}
+}
+
+func (c *collectionRepository) itemToDomain(item dao.UserCollectionBiz) domain.CollectionItem {
+	return domain.CollectionItem{
+		Cid:   item.Cid,
+		Uid:   item.Uid,
+		Biz:   item.Biz,
+		BizId: item.BizId,
+		Ctime: time.UnixMilli(item.CreatedAt),
+	}
+}
@@ -0,0 +1,195 @@
+package dao
+
+import (
+	"context"
+	"gorm.io/gorm"
+	"gorm.io/gorm/clause"
+	"time"
+)
+
+// ErrCollectionNotFound 收藏夹不存在，不是自己的，或者资源不在这个收藏夹里面
+var ErrCollectionNotFound = gorm.ErrRecordNotFound
+
+// CollectionDAO 收藏夹，收藏和取消收藏在 InteractiveDAO 里面，因为要改收藏数
+type CollectionDAO interface {
+	Insert(ctx context.Context, c Collection) (int64, error)
+	// Update 只能改自己的收藏夹，否则返回 ErrCollectionNotFound
+	Update(ctx context.Context, c Collection) error
+	// Delete 连同里面的内容一起删掉，返回删掉之后不在任何收藏夹里面的资源
+	Delete(ctx context.Context, id int64, uid int64) ([]UserCollectionBiz, error)
+	GetById(ctx context.Context, id int64) (Collection, error)
+	// ListByUid 按更新时间倒序，onlyPublic 为 true 的时候只返回公开的
+	ListByUid(ctx context.Context, uid int64, onlyPublic bool, offset int, limit int) ([]Collection, error)
+	// ListItems 按收藏时间倒序，cid 为 0 的是默认收藏夹，所以要带上 uid
+	ListItems(ctx context.Context, uid int64, cid int64, offset int, limit int) ([]UserCollectionBiz, error)
+	// MoveItem 目标收藏夹里面已经有了的时候只从原来的收藏夹里面删掉
+	MoveItem(ctx context.Context, uid int64, biz string, bizId int64, fromCid int64, toCid int64) error
+}
+
+type GormCollectionDAO struct {
+	db *gorm.DB
+}
+
+func NewCollectionDAO(db *gorm.DB) CollectionDAO {
+	return &GormCollectionDAO{db: db}
+}
+
+func (g *GormCollectionDAO) Insert(ctx context.Context, c Collection) (int64, error) {
+	now := time.Now().UnixMilli()
+	c.ItemCnt = 0
+	c.CreatedAt = now
+	c.UpdatedAt = now
+	err := g.db.WithContext(ctx).Create(&c).Error
+	return c.Id, err
+}
+
+func (g *GormCollectionDAO) Update(ctx context.Context, c Collection) error {
+	res := g.db.WithContext(ctx).Model(&Collection{}).
+		Where("id = ? AND uid = ?", c.Id, c.Uid).
+		Updates(map[string]any{
+			"name":        c.Name,
+			"description": c.Description,
+			"public":      c.Public,
+			"updated_at":  time.Now().UnixMilli(),
+		})
+	if res.Error != nil {
+		return res.Error
+	}
+	if res.RowsAffected == 0 {
+		return ErrCollectionNotFound
+	}
+	return nil
+}
+
+func (g *GormCollectionDAO) Delete(ctx context.Context, id int64, uid int64) ([]UserCollectionBiz, error) {
+	var removed []UserCollectionBiz
+	now := time.Now().UnixMilli()
+	err := g.db.WithContext(ctx).Transaction(func(tx *gorm.DB) error {
+		res := tx.Where("id = ? AND uid = ?", id, uid).Delete(&Collection{})
+		if res.Error != nil {
+			return res.Error
+		}
+		if res.RowsAffected == 0 {
+			return ErrCollectionNotFound
+		}
+		var items []UserCollectionBiz
+		err := tx.Where("uid = ? AND cid = ?", uid, id).Find(&items).Error
+		if err != nil || len(items) == 0 {
+			return err
+		}
+		err = tx.Where("uid = ? AND cid = ?", uid, id).Delete(&UserCollectionBiz{}).Error
+		if err != nil {
+			return err
+		}
+		for _, item := range items {
+			last, er := decrCollectCntIfLast(tx, uid, item.Biz, item.BizId, now)
+			if er != nil {
+				return er
+			}
+			if last {
+				removed = append(removed, item)
+			}
+		}
+		return nil
+	})
+	return removed, err
+}
+
+func (g *GormCollectionDAO) GetById(ctx context.Context, id int64) (Collection, error) {
+	var c Collection
+	err := g.db.WithContext(ctx).Where("id = ?", id).First(&c).Error
+	return c, err
+}
+
+func (g *GormCollectionDAO) ListByUid(ctx context.Context, uid int64, onlyPublic bool, offset int, limit int) ([]Collection, error) {
+	var res []Collection
+	db := g.db.WithContext(ctx).Where("uid = ?", uid)
+	if onlyPublic {
+		db = db.Where("public = ?", true)
+	}
+	err := db.Order("updated_at DESC").Offset(offset).Limit(limit).Find(&res).Error
+	return res, err
+}
+
+func (g *GormCollectionDAO) ListItems(ctx context.Context, uid int64, cid int64, offset int, limit int) ([]UserCollectionBiz, error) {
+	var res []UserCollectionBiz
+	err := g.db.WithContext(ctx).Where("uid = ? AND cid = ?", uid, cid).
+		Order("id DESC").Offset(offset).Limit(limit).Find(&res).Error
+	return res, err
+}
+
+func (g *GormCollectionDAO) MoveItem(ctx context.Context, uid int64, biz string, bizId int64, fromCid int64, toCid int64) error {
+	now := time.Now().UnixMilli()
+	return g.db.WithContext(ctx).Transaction(func(tx *gorm.DB) error {
+		var item UserCollectionBiz
+		err := tx.Clauses(clause.Locking{Strength: "UPDATE"}).
+			Where("uid = ? AND cid = ? AND biz = ? AND biz_id = ?", uid, fromCid, biz, bizId).
+			First(&item).Error
+		if err != nil {
+			return err
+		}
+		var cnt int64
+		err = tx.Model(&UserCollectionBiz{}).
+			Where("uid = ? AND cid = ? AND biz = ? AND biz_id = ?", uid, toCid, biz, bizId).
+			Count(&cnt).Error
+		if err != nil {
+			return err
+		}
+		if cnt > 0 {
+			err = tx.Where("id = ?", item.Id).Delete(&UserCollectionBiz{}).Error
+		} else {
+			err = tx.Model(&UserCollectionBiz{}).Where("id = ?", item.Id).
+				Updates(map[string]any{
+					"cid":        toCid,
+					"updated_at": now,
+				}).Error
+			if err == nil {
+				err = incrCollectionItemCnt(tx, toCid, 1, now)
+			}
+		}
+		if err != nil {
+			return err
+		}
+		return incrCollectionItemCnt(tx, fromCid, -1, now)
+	})
+}
+
+// incrCollectionItemCnt 默认收藏夹没有记录，不用改
+func incrCollectionItemCnt(tx *gorm.DB, cid int64, delta int64, now int64) error {
+	if cid == 0 {
+		return nil
+	}
+	return tx.Model(&Collection{}).Where("id = ?", cid).
+		Updates(map[string]any{
+			"item_cnt":   gorm.Expr("GREATEST(`item_cnt` + ?, 0)", delta),
+			"updated_at": now,
+		}).Error
+}
+
+// decrCollectCntIfLast 删掉一条收藏之后，这个人不在任何收藏夹里面收藏这个资源了才减收藏数
+func decrCollectCntIfLast(tx *gorm.DB, uid int64, biz string, bizId int64, now int64) (bool, error) {
+	var cnt int64
+	err := tx.Model(&UserCollectionBiz{}).Clauses(clause.Locking{Strength: "UPDATE"}).
+		Where("uid = ? AND biz = ? AND biz_id = ?", uid, biz, bizId).Count(&cnt).Error
+	if err != nil || cnt > 0 {
+		return false, err
+	}
+	return true, tx.Model(&Interactive{}).
+		Where("biz = ? AND biz_id = ?", biz, bizId).
+		Updates(map[string]any{
+			"collect_cnt": gorm.Expr("GREATEST(`collect_cnt` - 1, 0)"),
+			"updated_at":  now,
+		}).Error
+}
+
+// Collection 收藏夹，默认收藏夹不在这张表里面
+type Collection struct {
+	Id          int64  `gorm:"primaryKey,autoIncrement"`
+	Uid         int64  `gorm:"index:idx_uid_updated_at"`
+	Name        string `gorm:"type:varchar(256)"`
+	Description string `gorm:"type:varchar(1024)"`
+	Public      bool
+	ItemCnt     int64
+	CreatedAt   int64
+	UpdatedAt   int64 `gorm:"index:idx_uid_updated_at"`
+}
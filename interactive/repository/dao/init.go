@@ -5,5 +5,14 @@ import (
 )
 
 func InitTable(db *gorm.DB) error {
-	return db.AutoMigrate(&UserLikeBiz{}, &UserCollectionBiz{}, &Interactive{})
+	err := db.AutoMigrate(&UserLikeBiz{}, &UserCollectionBiz{}, &Interactive{}, &Collection{})
+	if err != nil {
+		return err
+	}
+	// 以前 uid、biz、biz_id 是唯一索引，一个资源只能放进一个收藏夹
+	m := db.Migrator()
+	if m.HasIndex(&UserCollectionBiz{}, "uid_biz_type_id") {
+		return m.DropIndex(&UserCollectionBiz{}, "uid_biz_type_id")
+	}
+	return nil
 }
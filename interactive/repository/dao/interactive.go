@@ -15,12 +15,17 @@ type InteractiveDAO interface {
 	IncrReadCnt(ctx context.Context, biz string, bizId int64) error
 	InsertLikeInfo(ctx context.Context, biz string, id int64, uid int64) error
 	DeleteLikeInfo(ctx context.Context, biz string, id int64, uid int64) error
-	InsertCollectionBiz(ctx context.Context, cb UserCollectionBiz) error
+	// InsertCollectionBiz 已经在这个收藏夹里面的不重复收藏，返回是不是第一次收藏这个资源
+	InsertCollectionBiz(ctx context.Context, cb UserCollectionBiz) (bool, error)
+	// DeleteCollectionBiz 不在这个收藏夹里面的什么也不做，返回是不是已经不在任何收藏夹里面了
+	DeleteCollectionBiz(ctx context.Context, uid int64, cid int64, biz string, bizId int64) (bool, error)
 	Get(ctx context.Context, biz string, id int64) (Interactive, error)
 	GetLikeInfo(ctx context.Context, biz string, id int64, uid int64) (UserLikeBiz, error)
 	GetCollectInfo(ctx context.Context, biz string, id int64, uid int64) (UserCollectionBiz, error)
 	BatchIncrReadCnt(ctx context.Context, bizs []string, ids []int64) error
 	GetByIds(ctx context.Context, biz string, ids []int64) ([]Interactive, error)
+	// IncrCommentCnt 删除评论的时候 delta 是负数
+	IncrCommentCnt(ctx context.Context, biz string, bizId int64, delta int64) error
 }
 
 type GormInteractiveDAO struct {
@@ -72,14 +77,29 @@ func (g *GormInteractiveDAO) Get(ctx context.Context, biz string, id int64) (Int
 	return intr, err
 }
 
-func (g *GormInteractiveDAO) InsertCollectionBiz(ctx context.Context, cb UserCollectionBiz) error {
+func (g *GormInteractiveDAO) InsertCollectionBiz(ctx context.Context, cb UserCollectionBiz) (bool, error) {
 	now := time.Now().UnixMilli()
-	return g.db.WithContext(ctx).Transaction(func(tx *gorm.DB) error {
-		err := g.db.WithContext(ctx).Create(&cb).Error
+	cb.CreatedAt = now
+	cb.UpdatedAt = now
+	var first bool
+	err := g.db.WithContext(ctx).Transaction(func(tx *gorm.DB) error {
+		res := tx.Clauses(clause.OnConflict{DoNothing: true}).Create(&cb)
+		if res.Error != nil || res.RowsAffected == 0 {
+			return res.Error
+		}
+		err := incrCollectionItemCnt(tx, cb.Cid, 1, now)
 		if err != nil {
 			return err
 		}
-		return tx.WithContext(ctx).Clauses(clause.OnConflict{
+		// 放进别的收藏夹的时候收藏数不变
+		var cnt int64
+		err = tx.Model(&UserCollectionBiz{}).Clauses(clause.Locking{Strength: "UPDATE"}).
+			Where("uid = ? AND biz = ? AND biz_id = ?", cb.Uid, cb.Biz, cb.BizId).Count(&cnt).Error
+		if err != nil || cnt > 1 {
+			return err
+		}
+		first = true
+		return tx.Clauses(clause.OnConflict{
 			DoUpdates: clause.Assignments(map[string]interface{}{
 				"collect_cnt": gorm.Expr("`collect_cnt` + 1"),
 				"updated_at":  now,
@@ -92,6 +112,26 @@ func (g *GormInteractiveDAO) InsertCollectionBiz(ctx context.Context, cb UserCol
 			UpdatedAt:  now,
 		}).Error
 	})
+	return first, err
+}
+
+func (g *GormInteractiveDAO) DeleteCollectionBiz(ctx context.Context, uid int64, cid int64, biz string, bizId int64) (bool, error) {
+	now := time.Now().UnixMilli()
+	var last bool
+	err := g.db.WithContext(ctx).Transaction(func(tx *gorm.DB) error {
+		res := tx.Where("uid = ? AND cid = ? AND biz = ? AND biz_id = ?", uid, cid, biz, bizId).
+			Delete(&UserCollectionBiz{})
+		if res.Error != nil || res.RowsAffected == 0 {
+			return res.Error
+		}
+		err := incrCollectionItemCnt(tx, cid, -1, now)
+		if err != nil {
+			return err
+		}
+		last, err = decrCollectCntIfLast(tx, uid, biz, bizId, now)
+		return err
+	})
+	return last, err
 }
 
 func (g *GormInteractiveDAO) InsertLikeInfo(ctx context.Context, biz string, id int64, uid int64) error {
@@ -165,6 +205,22 @@ func (g *GormInteractiveDAO) IncrReadCnt(ctx context.Context, biz string, bizId
 	}).Error
 }
 
+func (g *GormInteractiveDAO) IncrCommentCnt(ctx context.Context, biz string, bizId int64, delta int64) error {
+	now := time.Now().UnixMilli()
+	return g.db.WithContext(ctx).Clauses(clause.OnConflict{
+		DoUpdates: clause.Assignments(map[string]interface{}{
+			"comment_cnt": gorm.Expr("GREATEST(`comment_cnt` + ?, 0)", delta),
+			"updated_at":  now,
+		}),
+	}).Create(&Interactive{
+		Biz:        biz,
+		BizId:      bizId,
+		CommentCnt: max(delta, 0),
+		CreatedAt:  now,
+		UpdatedAt:  now,
+	}).Error
+}
+
 type Interactive struct {
 	Id    int64  `gorm:"primaryKey,autoIncrement"`
 	BizId int64  `gorm:"uniqueIndex:biz_id_type"`
@@ -173,6 +229,7 @@ type Interactive struct {
 	ReadCnt    int64
 	LikeCnt    int64
 	CollectCnt int64
+	CommentCnt int64
 	CreatedAt  int64
 	UpdatedAt  int64
 }
@@ -189,13 +246,13 @@ type UserLikeBiz struct {
 
 type UserCollectionBiz struct {
 	Id int64 `gorm:"primaryKey,autoIncrement"`
-	// 这边还是保留了了唯一索引
-	Uid   int64  `gorm:"uniqueIndex:uid_biz_type_id"`
-	BizId int64  `gorm:"uniqueIndex:uid_biz_type_id"`
-	Biz   string `gorm:"type:varchar(128);uniqueIndex:uid_biz_type_id"`
-	// 收藏夹的ID
+	// 同一个资源可以放进多个收藏夹，同一个收藏夹里面只能放一次
+	Uid   int64  `gorm:"uniqueIndex:uid_biz_type_id_cid"`
+	BizId int64  `gorm:"uniqueIndex:uid_biz_type_id_cid"`
+	Biz   string `gorm:"type:varchar(128);uniqueIndex:uid_biz_type_id_cid"`
+	// 收藏夹的ID，0 是默认收藏夹
 	// 收藏夹ID本身有索引
-	Cid       int64 `gorm:"index"`
+	Cid       int64 `gorm:"index;uniqueIndex:uid_biz_type_id_cid"`
 	CreatedAt int64
 	UpdatedAt int64
 }
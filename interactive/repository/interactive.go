@@ -14,12 +14,16 @@ type InteractiveRepository interface {
 	IncrReadCnt(ctx context.Context, biz string, id int64) error
 	IncrLike(ctx context.Context, biz string, id int64, uid int64) error
 	DecrLike(ctx context.Context, biz string, id int64, uid int64) error
-	AddCollectionItem(ctx context.Context, biz string, id int64, cid int64, uid int64) error
+	// AddCollectionItem 返回是不是第一次收藏这个资源，放进别的收藏夹不算
+	AddCollectionItem(ctx context.Context, biz string, id int64, cid int64, uid int64) (bool, error)
+	// RemoveCollectionItem 返回是不是已经不在任何收藏夹里面了
+	RemoveCollectionItem(ctx context.Context, biz string, id int64, cid int64, uid int64) (bool, error)
 	Get(ctx context.Context, biz string, id int64) (domain.Interactive, error)
 	Liked(ctx context.Context, biz string, id int64, uid int64) (bool, error)
 	Collected(ctx context.Context, biz string, id int64, uid int64) (bool, error)
 	BatchIncrReadCnt(ctx context.Context, bizs []string, ids []int64) error
 	GetByIds(ctx context.Context, biz string, ids []int64) ([]domain.Interactive, error)
+	IncrCommentCnt(ctx context.Context, biz string, id int64, delta int64) error
 }
 
 type CacheInteractiveRepository struct {
@@ -95,17 +99,25 @@ func (c *CacheInteractiveRepository) Collected(ctx context.Context, biz string,
 	}
 }
 
-func (c *CacheInteractiveRepository) AddCollectionItem(ctx context.Context, biz string, id int64, cid int64, uid int64) error {
-	err := c.dao.InsertCollectionBiz(ctx, dao.UserCollectionBiz{
+func (c *CacheInteractiveRepository) AddCollectionItem(ctx context.Context, biz string, id int64, cid int64, uid int64) (bool, error) {
+	first, err := c.dao.InsertCollectionBiz(ctx, dao.UserCollectionBiz{
 		Cid:   cid,
 		Uid:   uid,
 		Biz:   biz,
 		BizId: id,
 	})
-	if err != nil {
-		return err
+	if err != nil || !first {
+		return false, err
+	}
+	return true, c.cache.IncrCollectCntIfPresent(ctx, biz, id)
+}
+
+func (c *CacheInteractiveRepository) RemoveCollectionItem(ctx context.Context, biz string, id int64, cid int64, uid int64) (bool, error) {
+	last, err := c.dao.DeleteCollectionBiz(ctx, uid, cid, biz, id)
+	if err != nil || !last {
+		return false, err
 	}
-	return c.cache.IncrCollectCntIfPresent(ctx, biz, id)
+	return true, c.cache.DecrCollectCntIfPresent(ctx, biz, id)
 }
 
 func (c *CacheInteractiveRepository) IncrReadCnt(ctx context.Context, biz string, id int64) error {
@@ -147,6 +159,14 @@ func (c *CacheInteractiveRepository) DecrLike(ctx context.Context, biz string, i
 	return c.cache.DecrLikeCntIfPresent(ctx, biz, id)
 }
 
+func (c *CacheInteractiveRepository) IncrCommentCnt(ctx context.Context, biz string, id int64, delta int64) error {
+	err := c.dao.IncrCommentCnt(ctx, biz, id, delta)
+	if err != nil {
+		return err
+	}
+	return c.cache.IncrCommentCntIfPresent(ctx, biz, id, delta)
+}
+
 func (c *CacheInteractiveRepository) toDomain(intrDao dao.Interactive) domain.Interactive {
 	return domain.Interactive{
 		Biz:        intrDao.Biz,
@@ -154,5 +174,6 @@ func (c *CacheInteractiveRepository) toDomain(intrDao dao.Interactive) domain.In
 		ReadCnt:    intrDao.ReadCnt,
 		LikeCnt:    intrDao.LikeCnt,
 		CollectCnt: intrDao.CollectCnt,
+		CommentCnt: intrDao.CommentCnt,
 	}
 }
@@ -0,0 +1,132 @@
+package service
+
+import (
+	"context"
+	"errors"
+	"github.com/Andras5014/gohub/interactive/domain"
+	"github.com/Andras5014/gohub/interactive/events"
+	"github.com/Andras5014/gohub/interactive/repository"
+	"strings"
+	"unicode/utf8"
+)
+
+var (
+	ErrCollectionNotFound = repository.ErrCollectionNotFound
+	ErrInvalidCollection  = errors.New("收藏夹名字或者简介不合法")
+)
+
+const (
+	maxCollectionNameLength        = 64
+	maxCollectionDescriptionLength = 500
+)
+
+func (i *interactiveService) Collect(ctx context.Context, biz string, id int64, cid int64, uid int64) error {
+	err := i.checkOwner(ctx, cid, uid)
+	if err != nil {
+		return err
+	}
+	first, err := i.repo.AddCollectionItem(ctx, biz, id, cid, uid)
+	if err != nil {
+		return err
+	}
+	// 放进别的收藏夹不算新的收藏
+	if first {
+		i.produceChange(ctx, biz, id, uid, events.ChangeTypeCollect, 1)
+	}
+	return nil
+}
+
+func (i *interactiveService) Uncollect(ctx context.Context, biz string, id int64, cid int64, uid int64) error {
+	last, err := i.repo.RemoveCollectionItem(ctx, biz, id, cid, uid)
+	if err != nil {
+		return err
+	}
+	if last {
+		i.produceChange(ctx, biz, id, uid, events.ChangeTypeCollect, -1)
+	}
+	return nil
+}
+
+func (i *interactiveService) MoveCollectionItem(ctx context.Context, biz string, id int64, fromCid int64, toCid int64, uid int64) error {
+	if fromCid == toCid {
+		return nil
+	}
+	// 原来的收藏夹不是自己的话查不到这条收藏，DAO 会返回 ErrCollectionNotFound
+	err := i.checkOwner(ctx, toCid, uid)
+	if err != nil {
+		return err
+	}
+	return i.collectionRepo.MoveItem(ctx, uid, biz, id, fromCid, toCid)
+}
+
+func (i *interactiveService) CreateCollection(ctx context.Context, c domain.Collection) (int64, error) {
+	c, err := normalizeCollection(c)
+	if err != nil {
+		return 0, err
+	}
+	return i.collectionRepo.Create(ctx, c)
+}
+
+func (i *interactiveService) UpdateCollection(ctx context.Context, c domain.Collection) error {
+	c, err := normalizeCollection(c)
+	if err != nil {
+		return err
+	}
+	return i.collectionRepo.Update(ctx, c)
+}
+
+func (i *interactiveService) DeleteCollection(ctx context.Context, id int64, uid int64) error {
+	removed, err := i.collectionRepo.Delete(ctx, id, uid)
+	if err != nil {
+		return err
+	}
+	for _, item := range removed {
+		i.produceChange(ctx, item.Biz, item.BizId, uid, events.ChangeTypeCollect, -1)
+	}
+	return nil
+}
+
+func (i *interactiveService) ListCollections(ctx context.Context, uid int64, viewer int64, offset int, limit int) ([]domain.Collection, error) {
+	return i.collectionRepo.List(ctx, uid, uid != viewer, offset, limit)
+}
+
+func (i *interactiveService) ListCollectionItems(ctx context.Context, cid int64, viewer int64, offset int, limit int) ([]domain.CollectionItem, error) {
+	if cid == 0 {
+		return i.collectionRepo.ListItems(ctx, viewer, 0, offset, limit)
+	}
+	c, err := i.collectionRepo.GetById(ctx, cid)
+	if err != nil {
+		return nil, err
+	}
+	// 别人的私密收藏夹当作不存在
+	if !c.Public && c.Uid != viewer {
+		return nil, ErrCollectionNotFound
+	}
+	return i.collectionRepo.ListItems(ctx, c.Uid, cid, offset, limit)
+}
+
+// checkOwner 默认收藏夹谁都有
+func (i *interactiveService) checkOwner(ctx context.Context, cid int64, uid int64) error {
+	if cid == 0 {
+		return nil
+	}
+	c, err := i.collectionRepo.GetById(ctx, cid)
+	if err != nil {
+		return err
+	}
+	if c.Uid != uid {
+		return ErrCollectionNotFound
+	}
+	return nil
+}
+
+func normalizeCollection(c domain.Collection) (domain.Collection, error) {
+	c.Name = strings.TrimSpace(c.Name)
+	c.Description = strings.TrimSpace(c.Description)
+	if c.Name == "" ||
+		utf8.RuneCountInString(c.Name) > maxCollectionNameLength ||
+		utf8.RuneCountInString(c.Description) > maxCollectionDescriptionLength {
+		return domain.Collection{}, ErrInvalidCollection
+	}
+	return c, nil
+}
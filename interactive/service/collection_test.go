@@ -0,0 +1,156 @@
+package service
+
+import (
+	"context"
+	"errors"
+	"github.com/Andras5014/gohub/interactive/domain"
+	"github.com/Andras5014/gohub/interactive/events"
+	evtmocks "github.com/Andras5014/gohub/interactive/events/mocks"
+	"github.com/Andras5014/gohub/interactive/repository"
+	repomocks "github.com/Andras5014/gohub/interactive/repository/mocks"
+	"github.com/Andras5014/gohub/pkg/logx"
+	"github.com/stretchr/testify/assert"
+	"go.uber.org/mock/gomock"
+	"go.uber.org/zap"
+	"testing"
+)
+
+func Test_interactiveService_Collect(t *testing.T) {
+	testCases := []struct {
+		name string
+		mock func(ctrl *gomock.Controller) (repository.InteractiveRepository, repository.CollectionRepository, events.Producer)
+		cid  int64
+
+		wantErr error
+	}{
+		{
+			name: "第一次收藏",
+			mock: func(ctrl *gomock.Controller) (repository.InteractiveRepository, repository.CollectionRepository, events.Producer) {
+				repo := repomocks.NewMockInteractiveRepository(ctrl)
+				collectionRepo := repomocks.NewMockCollectionRepository(ctrl)
+				producer := evtmocks.NewMockProducer(ctrl)
+				collectionRepo.EXPECT().GetById(gomock.Any(), int64(2)).
+					Return(domain.Collection{Id: 2, Uid: 123}, nil)
+				repo.EXPECT().AddCollectionItem(gomock.Any(), "article", int64(1), int64(2), int64(123)).
+					Return(true, nil)
+				producer.EXPECT().ProduceChangeEvent(gomock.Any(), events.ChangeEvent{
+					Biz: "article", BizId: 1, Type: events.ChangeTypeCollect, Delta: 1, Uid: 123,
+				}).Return(nil)
+				return repo, collectionRepo, producer
+			},
+			cid: 2,
+		},
+		{
+			name: "放进另一个收藏夹不发消息",
+			mock: func(ctrl *gomock.Controller) (repository.InteractiveRepository, repository.CollectionRepository, events.Producer) {
+				repo := repomocks.NewMockInteractiveRepository(ctrl)
+				repo.EXPECT().AddCollectionItem(gomock.Any(), "article", int64(1), int64(0), int64(123)).
+					Return(false, nil)
+				return repo, repomocks.NewMockCollectionRepository(ctrl), evtmocks.NewMockProducer(ctrl)
+			},
+		},
+		{
+			name: "别人的收藏夹",
+			mock: func(ctrl *gomock.Controller) (repository.InteractiveRepository, repository.CollectionRepository, events.Producer) {
+				collectionRepo := repomocks.NewMockCollectionRepository(ctrl)
+				collectionRepo.EXPECT().GetById(gomock.Any(), int64(2)).
+					Return(domain.Collection{Id: 2, Uid: 456, Public: true}, nil)
+				return repomocks.NewMockInteractiveRepository(ctrl), collectionRepo, evtmocks.NewMockProducer(ctrl)
+			},
+			cid:     2,
+			wantErr: ErrCollectionNotFound,
+		},
+		{
+			name: "收藏失败",
+			mock: func(ctrl *gomock.Controller) (repository.InteractiveRepository, repository.CollectionRepository, events.Producer) {
+				repo := repomocks.NewMockInteractiveRepository(ctrl)
+				repo.EXPECT().AddCollectionItem(gomock.Any(), "article", int64(1), int64(0), int64(123)).
+					Return(false, errors.New("mock db error"))
+				return repo, repomocks.NewMockCollectionRepository(ctrl), evtmocks.NewMockProducer(ctrl)
+			},
+			wantErr: errors.New("mock db error"),
+		},
+	}
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			ctrl := gomock.NewController(t)
+			defer ctrl.Finish()
+			repo, collectionRepo, producer := tc.mock(ctrl)
+			svc := NewInteractiveService(repo, collectionRepo, producer, logx.NewZapLogger(zap.NewNop()))
+			err := svc.Collect(context.Background(), "article", 1, tc.cid, 123)
+			assert.Equal(t, tc.wantErr, err)
+		})
+	}
+}
+
+func Test_interactiveService_ListCollectionItems(t *testing.T) {
+	items := []domain.CollectionItem{{Cid: 2, Uid: 456, Biz: "article", BizId: 1}}
+	testCases := []struct {
+		name string
+		mock func(ctrl *gomock.Controller) repository.CollectionRepository
+		cid  int64
+
+		wantRes []domain.CollectionItem
+		wantErr error
+	}{
+		{
+			name: "自己的默认收藏夹",
+			mock: func(ctrl *gomock.Controller) repository.CollectionRepository {
+				collectionRepo := repomocks.NewMockCollectionRepository(ctrl)
+				collectionRepo.EXPECT().ListItems(gomock.Any(), int64(123), int64(0), 0, 10).Return(nil, nil)
+				return collectionRepo
+			},
+		},
+		{
+			name: "别人公开的收藏夹",
+			mock: func(ctrl *gomock.Controller) repository.CollectionRepository {
+				collectionRepo := repomocks.NewMockCollectionRepository(ctrl)
+				collectionRepo.EXPECT().GetById(gomock.Any(), int64(2)).
+					Return(domain.Collection{Id: 2, Uid: 456, Public: true}, nil)
+				collectionRepo.EXPECT().ListItems(gomock.Any(), int64(456), int64(2), 0, 10).Return(items, nil)
+				return collectionRepo
+			},
+			cid:     2,
+			wantRes: items,
+		},
+		{
+			name: "别人私密的收藏夹",
+			mock: func(ctrl *gomock.Controller) repository.CollectionRepository {
+				collectionRepo := repomocks.NewMockCollectionRepository(ctrl)
+				collectionRepo.EXPECT().GetById(gomock.Any(), int64(2)).
+					Return(domain.Collection{Id: 2, Uid: 456}, nil)
+				return collectionRepo
+			},
+			cid:     2,
+			wantErr: ErrCollectionNotFound,
+		},
+	}
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			ctrl := gomock.NewController(t)
+			defer ctrl.Finish()
+			svc := NewInteractiveService(repomocks.NewMockInteractiveRepository(ctrl), tc.mock(ctrl),
+				evtmocks.NewMockProducer(ctrl), logx.NewZapLogger(zap.NewNop()))
+			res, err := svc.ListCollectionItems(context.Background(), tc.cid, 123, 0, 10)
+			assert.Equal(t, tc.wantErr, err)
+			assert.Equal(t, tc.wantRes, res)
+		})
+	}
+}
+
+func Test_interactiveService_DeleteCollection(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+	collectionRepo := repomocks.NewMockCollectionRepository(ctrl)
+	producer := evtmocks.NewMockProducer(ctrl)
+	// 只有已经不在任何收藏夹里面的才减收藏数
+	collectionRepo.EXPECT().Delete(gomock.Any(), int64(2), int64(123)).
+		Return([]domain.CollectionItem{{Cid: 2, Uid: 123, Biz: "article", BizId: 1}}, nil)
+	producer.EXPECT().ProduceChangeEvent(gomock.Any(), events.ChangeEvent{
+		Biz: "article", BizId: 1, Type: events.ChangeTypeCollect, Delta: -1, Uid: 123,
+	}).Return(nil)
+	svc := NewInteractiveService(repomocks.NewMockInteractiveRepository(ctrl), collectionRepo,
+		producer, logx.NewZapLogger(zap.NewNop()))
+	err := svc.DeleteCollection(context.Background(), 2, 123)
+	assert.NoError(t, err)
+}
@@ -3,7 +3,9 @@ package service
 import (
 	"context"
 	"github.com/Andras5014/gohub/interactive/domain"
+	"github.com/Andras5014/gohub/interactive/events"
 	"github.com/Andras5014/gohub/interactive/repository"
+	"github.com/Andras5014/gohub/pkg/logx"
 	"github.com/ecodeclub/ekit/slice"
 	"golang.org/x/sync/errgroup"
 )
@@ -13,17 +15,33 @@ type InteractiveService interface {
 	IncrReadCnt(ctx context.Context, biz string, id int64) error
 	Like(ctx context.Context, biz string, id int64, uid int64) error
 	CancelLike(ctx context.Context, biz string, id int64, uid int64) error
+	// Collect cid 为 0 的是默认收藏夹，别的只能放进自己的收藏夹
 	Collect(ctx context.Context, biz string, id int64, cid int64, uid int64) error
+	// Uncollect 只从这个收藏夹里面拿掉，不在任何收藏夹里面了才算取消收藏
+	Uncollect(ctx context.Context, biz string, id int64, cid int64, uid int64) error
+	MoveCollectionItem(ctx context.Context, biz string, id int64, fromCid int64, toCid int64, uid int64) error
+	CreateCollection(ctx context.Context, c domain.Collection) (int64, error)
+	UpdateCollection(ctx context.Context, c domain.Collection) error
+	// DeleteCollection 里面的内容一起删掉
+	DeleteCollection(ctx context.Context, id int64, uid int64) error
+	// ListCollections 看别人的只能看到公开的
+	ListCollections(ctx context.Context, uid int64, viewer int64, offset int, limit int) ([]domain.Collection, error)
+	// ListCollectionItems cid 为 0 的是 viewer 自己的默认收藏夹
+	ListCollectionItems(ctx context.Context, cid int64, viewer int64, offset int, limit int) ([]domain.CollectionItem, error)
 	Get(ctx context.Context, biz string, id int64, uid int64) (domain.Interactive, error)
 	GetByIds(ctx context.Context, biz string, ids []int64) (map[int64]domain.Interactive, error)
 }
 
 type interactiveService struct {
-	repo repository.InteractiveRepository
+	repo           repository.InteractiveRepository
+	collectionRepo repository.CollectionRepository
+	producer       events.Producer
+	l              logx.Logger
 }
 
-func NewInteractiveService(repo repository.InteractiveRepository) InteractiveService {
-	return &interactiveService{repo: repo}
+func NewInteractiveService(repo repository.InteractiveRepository, collectionRepo repository.CollectionRepository,
+	producer events.Producer, l logx.Logger) InteractiveService {
+	return &interactiveService{repo: repo, collectionRepo: collectionRepo, producer: producer, l: l}
 }
 func (i *interactiveService) GetByIds(ctx context.Context, biz string, ids []int64) (map[int64]domain.Interactive, error) {
 	intrs, err := i.repo.GetByIds(ctx, biz, ids)
@@ -61,17 +79,38 @@ func (i *interactiveService) Get(ctx context.Context, biz string, id int64, uid
 
 }
 
-func (i *interactiveService) Collect(ctx context.Context, biz string, id int64, cid int64, uid int64) error {
-	return i.repo.AddCollectionItem(ctx, biz, id, cid, uid)
-}
-
 func (i *interactiveService) IncrReadCnt(ctx context.Context, biz string, id int64) error {
 	return i.repo.IncrReadCnt(ctx, biz, id)
 }
 func (i *interactiveService) Like(ctx context.Context, biz string, id int64, uid int64) error {
-	return i.repo.IncrLike(ctx, biz, id, uid)
+	err := i.repo.IncrLike(ctx, biz, id, uid)
+	if err != nil {
+		return err
+	}
+	i.produceChange(ctx, biz, id, uid, events.ChangeTypeLike, 1)
+	return nil
 }
 
 func (i *interactiveService) CancelLike(ctx context.Context, biz string, id int64, uid int64) error {
-	return i.repo.DecrLike(ctx, biz, id, uid)
+	err := i.repo.DecrLike(ctx, biz, id, uid)
+	if err != nil {
+		return err
+	}
+	i.produceChange(ctx, biz, id, uid, events.ChangeTypeLike, -1)
+	return nil
+}
+
+// produceChange 计数已经改成功了，发消息失败只记日志，下游会定期全量校准
+func (i *interactiveService) produceChange(ctx context.Context, biz string, id int64, uid int64, typ string, delta int64) {
+	err := i.producer.ProduceChangeEvent(ctx, events.ChangeEvent{
+		Biz:   biz,
+		BizId: id,
+		Type:  typ,
+		Delta: delta,
+		Uid:   uid,
+	})
+	if err != nil {
+		i.l.Error("发送互动变更事件失败",
+			logx.String("biz", biz), logx.Int64("bizId", id), logx.String("type", typ), logx.Error(err))
+	}
 }
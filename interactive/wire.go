@@ -15,6 +15,7 @@ import (
 
 var thirdPartySet = wire.NewSet(
 	ioc.InitKafka,
+	ioc.InitSyncProducer,
 	ioc.InitRedis,
 	ioc.InitDB,
 	ioc.InitConfig,
@@ -22,9 +23,12 @@ var thirdPartySet = wire.NewSet(
 )
 var interactiveSvcSet = wire.NewSet(
 	service.NewInteractiveService,
+	events.NewSaramaSyncProducer,
 	repository.NewInteractiveRepository,
+	repository.NewCollectionRepository,
 	cache.NewInteractiveCache,
 	dao.NewInteractiveDAO,
+	dao.NewCollectionDAO,
 )
 
 func InitApp() *App {
@@ -35,6 +39,7 @@ func InitApp() *App {
 		ioc.InitGRPCxServer,
 		ioc.InitConsumers,
 		events.NewInteractiveReadEventBatchConsumer,
+		events.NewInteractiveCommentEventConsumer,
 		wire.Struct(new(App), "*"),
 	)
 	return new(App)
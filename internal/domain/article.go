@@ -1,17 +1,62 @@
 package domain
 
-import "time"
+import (
+	"github.com/Andras5014/gohub/pkg/markdownx"
+	"time"
+)
 
 type Article struct {
-	Id        int64
-	Title     string
-	Content   string
-	Author    Author
-	Status    ArticleStatus
+	Id      int64
+	Title   string
+	Content string
+	Author  Author
+	Status  ArticleStatus
+	Tags    []string
+	// Version 乐观锁版本号，编辑和发表的时候要带上读到的版本
+	Version int64
+	// EditorId 这次保存的人，协作者编辑的时候不是作者，只记在历史版本上
+	EditorId  int64
 	CreatedAt time.Time
 	UpdatedAt time.Time
+
+	// PublishAt 定时发表的时间，零值表示没有定时
+	PublishAt time.Time
+	// UnpublishAt 定时撤回的时间，零值表示没有定时
+	UnpublishAt time.Time
+	// ScheduleRetries 定时任务连续失败的次数
+	ScheduleRetries int
+	// DeletedAt 放进回收站的时间，零值表示没有删除
+	DeletedAt time.Time
+	// ReviewReason 审核没有通过的原因
+	ReviewReason string
+
+	// Rendered 发表时对正文处理的结果，只有线上库有
+	Rendered RenderedContent
+}
+
+// RenderedContent 正文渲染之后的结果，和线上库一起保存，读的时候不用再渲染
+type RenderedContent struct {
+	// HTML 已经过滤过，可以直接输出
+	HTML string
+	Toc  []TocItem
+	// WordCount 中文按字、英文按单词计算
+	WordCount int64
+	// ReadingTime 预计阅读时间，单位分钟
+	ReadingTime int64
+	// Abstract 纯文本摘要
+	Abstract string
 }
 
+type TocItem struct {
+	Level int
+	// Anchor 对应标题的 id
+	Anchor string
+	Text   string
+}
+
+// AbstractLength 摘要的长度，按字符算
+const AbstractLength = 100
+
 type ArticleStatus uint8
 
 const (
@@ -19,14 +64,18 @@ const (
 	ArticleStatusUnPublished
 	ArticleStatusPublished
 	ArticleStatusPrivate
+	// ArticleStatusPendingReview 等待人工审核，线上库还是之前的内容
+	ArticleStatusPendingReview
+	// ArticleStatusRejected 审核没有通过，作者修改之后可以重新发表
+	ArticleStatusRejected
 )
 
+// Abstract 优先用发表时生成的摘要，草稿和老数据现场去掉 Markdown 语法再截取
 func (a Article) Abstract() string {
-	cs := []rune(a.Content)
-	if len(cs) < 100 {
-		return string(cs)
+	if a.Rendered.Abstract != "" {
+		return a.Rendered.Abstract
 	}
-	return string(cs[:100])
+	return markdownx.Summary(markdownx.Render(a.Content).Text, AbstractLength)
 }
 func (s ArticleStatus) ToUint8() uint8 {
 	return uint8(s)
@@ -37,10 +86,24 @@ func (s ArticleStatus) NonPublished() bool {
 }
 
 func (s ArticleStatus) String() string {
-	return [...]string{"unknown", "unpublished", "published", "private"}[s.ToUint8()]
+	return [...]string{"unknown", "unpublished", "published", "private", "pending_review", "rejected"}[s.ToUint8()]
 }
 
 type Author struct {
 	Id   int64
 	Name string
 }
+
+// AuthorProfile 作者主页上公开的信息
+type AuthorProfile struct {
+	Id       int64
+	NickName string
+	AboutMe  string
+	// ArticleCnt 已发表的文章数
+	ArticleCnt int64
+	// LikeCnt 已发表的文章一共收到的点赞
+	LikeCnt int64
+	// FollowerCnt 粉丝数，FolloweeCnt 关注数
+	FollowerCnt int64
+	FolloweeCnt int64
+}
@@ -0,0 +1,85 @@
+package domain
+
+import "time"
+
+// ArticleCollaborator 文章的协作者，被邀请之后要本人接受才生效
+type ArticleCollaborator struct {
+	ArticleId int64
+	Uid       int64
+	Role      ArticleRole
+	Status    CollaboratorStatus
+	// InviterId 发出邀请的作者
+	InviterId int64
+	CreatedAt time.Time
+	UpdatedAt time.Time
+}
+
+// ArticleRole 对某篇文章的角色，后面的角色包含前面所有的权限
+type ArticleRole uint8
+
+const (
+	ArticleRoleUnknown ArticleRole = iota
+	// ArticleRoleViewer 可以看草稿和历史版本
+	ArticleRoleViewer
+	// ArticleRoleEditor 可以保存草稿
+	ArticleRoleEditor
+	// ArticleRolePublisher 可以发表、撤回和定时，发表会带上正文，所以也能编辑
+	ArticleRolePublisher
+	// ArticleRoleOwner 作者本人，删除文章和管理协作者只有作者可以做
+	ArticleRoleOwner
+)
+
+var articleRoleNames = [...]string{"unknown", "viewer", "editor", "publisher", "owner"}
+
+func (r ArticleRole) ToUint8() uint8 {
+	return uint8(r)
+}
+
+func (r ArticleRole) String() string {
+	if int(r) >= len(articleRoleNames) {
+		return articleRoleNames[0]
+	}
+	return articleRoleNames[r]
+}
+
+// Allows 是否拥有 need 要求的权限
+func (r ArticleRole) Allows(need ArticleRole) bool {
+	return r != ArticleRoleUnknown && r >= need
+}
+
+// Invitable 可以邀请别人担任的角色
+func (r ArticleRole) Invitable() bool {
+	return r >= ArticleRoleViewer && r <= ArticleRolePublisher
+}
+
+// ParseArticleRole 不认识的名字返回 ArticleRoleUnknown
+func ParseArticleRole(name string) ArticleRole {
+	for i, n := range articleRoleNames {
+		if n == name {
+			return ArticleRole(i)
+		}
+	}
+	return ArticleRoleUnknown
+}
+
+type CollaboratorStatus uint8
+
+const (
+	CollaboratorStatusUnknown CollaboratorStatus = iota
+	// CollaboratorStatusPending 已经邀请，还没有接受
+	CollaboratorStatusPending
+	CollaboratorStatusAccepted
+)
+
+func (s CollaboratorStatus) ToUint8() uint8 {
+	return uint8(s)
+}
+
+var collaboratorStatusNames = [...]string{"unknown", "pending", "accepted"}
+
+func (s CollaboratorStatus) String() string {
+	if int(s) >= len(collaboratorStatusNames) {
+		return collaboratorStatusNames[0]
+	}
+	return collaboratorStatusNames[s]
+}
@@ -0,0 +1,50 @@
+package domain
+
+import (
+	"encoding/base64"
+	"errors"
+	"fmt"
+	"time"
+)
+
+var ErrInvalidCursor = errors.New("游标不合法")
+
+// ArticleCursor 按 (UpdatedAt, Id) 倒序翻页的位置，取严格排在它后面的数据
+// 零值表示从第一页开始
+type ArticleCursor struct {
+	UpdatedAt time.Time
+	Id        int64
+}
+
+func (c ArticleCursor) IsZero() bool {
+	return c.UpdatedAt.IsZero() && c.Id == 0
+}
+
+// Encode 对前端不透明，前端原样传回来就可以
+func (c ArticleCursor) Encode() string {
+	if c.IsZero() {
+		return ""
+	}
+	raw := fmt.Sprintf("%d_%d", c.UpdatedAt.UnixMilli(), c.Id)
+	return base64.RawURLEncoding.EncodeToString([]byte(raw))
+}
+
+func ParseArticleCursor(s string) (ArticleCursor, error) {
+	if s == "" {
+		return ArticleCursor{}, nil
+	}
+	raw, err := base64.RawURLEncoding.DecodeString(s)
+	if err != nil {
+		return ArticleCursor{}, ErrInvalidCursor
+	}
+	var ms, id int64
+	if _, err = fmt.Sscanf(string(raw), "%d_%d", &ms, &id); err != nil || ms <= 0 || id < 0 {
+		return ArticleCursor{}, ErrInvalidCursor
+	}
+	return ArticleCursor{UpdatedAt: time.UnixMilli(ms), Id: id}, nil
+}
+
+// Cursor 以这篇文章为界的游标，用来取下一页
+func (a Article) Cursor() ArticleCursor {
+	return ArticleCursor{UpdatedAt: a.UpdatedAt, Id: a.Id}
+}
@@ -0,0 +1,23 @@
+package domain
+
+import (
+	"github.com/stretchr/testify/assert"
+	"testing"
+	"time"
+)
+
+func TestArticleCursor(t *testing.T) {
+	c := ArticleCursor{UpdatedAt: time.UnixMilli(1700000000000), Id: 12}
+	got, err := ParseArticleCursor(c.Encode())
+	assert.NoError(t, err)
+	assert.True(t, c.UpdatedAt.Equal(got.UpdatedAt))
+	assert.Equal(t, c.Id, got.Id)
+
+	got, err = ParseArticleCursor("")
+	assert.NoError(t, err)
+	assert.True(t, got.IsZero())
+	assert.Equal(t, "", ArticleCursor{}.Encode())
+
+	_, err = ParseArticleCursor("not-a-cursor")
+	assert.Equal(t, ErrInvalidCursor, err)
+}
@@ -0,0 +1,18 @@
+package domain
+
+import "time"
+
+// FeedItem 推到粉丝收件箱里面的一篇文章
+type FeedItem struct {
+	// Uid 收件箱的主人
+	Uid       int64
+	ArticleId int64
+	AuthorId  int64
+	// Ctime 文章发表的时间，收件箱按它倒序
+	Ctime time.Time
+}
+
+// Cursor 和文章用同一种游标，推拉合并之后按同一个顺序翻页
+func (f FeedItem) Cursor() ArticleCursor {
+	return ArticleCursor{UpdatedAt: f.Ctime, Id: f.ArticleId}
+}
@@ -0,0 +1,20 @@
+package domain
+
+import "time"
+
+// FollowRelation Follower 关注了 Followee
+type FollowRelation struct {
+	Follower int64
+	Followee int64
+	// Ctime 关注的时间，取消之后再关注会更新
+	Ctime time.Time
+}
+
+// FollowStatics 关注数和粉丝数
+type FollowStatics struct {
+	Uid int64
+	// Followers 粉丝数
+	Followers int64
+	// Followees 关注了多少人
+	Followees int64
+}
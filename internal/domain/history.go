@@ -1,7 +1,11 @@
 package domain
 
+import "time"
+
+// HistoryRecord 同一个人同一个资源只留一条，Ctime 是最近一次阅读的时间
 type HistoryRecord struct {
 	BizId int64
 	Biz   string
 	Uid   int64
+	Ctime time.Time
 }
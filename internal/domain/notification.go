@@ -0,0 +1,74 @@
+package domain
+
+import "time"
+
+type NotificationType uint8
+
+const (
+	NotificationTypeUnknown NotificationType = iota
+	NotificationTypeLike
+	NotificationTypeCollect
+	NotificationTypeComment
+	// NotificationTypeReply 评论被回复
+	NotificationTypeReply
+)
+
+func (t NotificationType) ToUint8() uint8 {
+	return uint8(t)
+}
+
+func (t NotificationType) Valid() bool {
+	return t > NotificationTypeUnknown && t <= NotificationTypeReply
+}
+
+func (t NotificationType) String() string {
+	switch t {
+	case NotificationTypeLike:
+		return "like"
+	case NotificationTypeCollect:
+		return "collect"
+	case NotificationTypeComment:
+		return "comment"
+	case NotificationTypeReply:
+		return "reply"
+	default:
+		return "unknown"
+	}
+}
+
+// ParseNotificationType 不认识的返回 NotificationTypeUnknown
+func ParseNotificationType(s string) NotificationType {
+	switch s {
+	case "like":
+		return NotificationTypeLike
+	case "collect":
+		return NotificationTypeCollect
+	case "comment":
+		return NotificationTypeComment
+	case "reply":
+		return NotificationTypeReply
+	default:
+		return NotificationTypeUnknown
+	}
+}
+
+// Notification 同一个人同一个资源上面同一种类型的通知聚合成一条，
+// 比如 "12 个人赞了你的文章"，已读之后再来新的从头开始计数
+type Notification struct {
+	Id int64
+	// Uid 收通知的人
+	Uid   int64
+	Type  NotificationType
+	Biz   string
+	BizId int64
+	// ActorId 最近一个触发通知的人
+	ActorId int64
+	// ActorCnt 上次已读之后有多少个不同的人触发
+	ActorCnt int64
+	// RefId 评论和回复是评论的 id
+	RefId int64
+	Read  bool
+
+	Ctime time.Time
+	Utime time.Time
+}
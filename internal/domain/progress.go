@@ -0,0 +1,26 @@
+package domain
+
+import "time"
+
+// ReadingFinishedPercent 读到这个百分比就算读完了，文章末尾一般是评论和推荐
+const ReadingFinishedPercent = 95
+
+// ReadingProgress 一个人在一篇文章上面的阅读进度
+type ReadingProgress struct {
+	Uid       int64
+	ArticleId int64
+	// Paragraph 读到第几段，从 0 开始，用来恢复位置
+	Paragraph int
+	// Percent 滚动的百分比，0 到 100
+	Percent int
+	Utime   time.Time
+}
+
+func (p ReadingProgress) Finished() bool {
+	return p.Percent >= ReadingFinishedPercent
+}
+
+// Unfinished 读了一部分还没读完，只打开没往下翻的不算
+func (p ReadingProgress) Unfinished() bool {
+	return p.Percent > 0 && !p.Finished()
+}
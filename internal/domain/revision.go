@@ -0,0 +1,25 @@
+package domain
+
+import (
+	"github.com/Andras5014/gohub/pkg/diffx"
+	"time"
+)
+
+// ArticleRevision 文章的历史版本，每次保存/发表都会追加一条，不可修改
+type ArticleRevision struct {
+	Id        int64
+	ArticleId int64
+	// AuthorId 保存这个版本的人，协作者编辑的时候是协作者
+	AuthorId  int64
+	Title     string
+	Content   string
+	Status    ArticleStatus
+	CreatedAt time.Time
+}
+
+type RevisionDiff struct {
+	From    ArticleRevision
+	To      ArticleRevision
+	Title   []diffx.Line
+	Content []diffx.Line
+}
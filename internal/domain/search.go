@@ -0,0 +1,15 @@
+package domain
+
+type ArticleSearchResult struct {
+	// Total 命中的总数
+	Total int
+	Hits  []ArticleSearchHit
+}
+
+type ArticleSearchHit struct {
+	// Article 只有 Id、Title、Author.Id、UpdatedAt
+	Article Article
+	// Title/Content 高亮之后的片段
+	Title   string
+	Content string
+}
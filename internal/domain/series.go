@@ -0,0 +1,24 @@
+package domain
+
+import "time"
+
+// Series 系列，作者把自己的多篇文章按顺序组织起来
+type Series struct {
+	Id          int64
+	AuthorId    int64
+	Title       string
+	Description string
+	// Articles 按顺序排列，只有详情会带上
+	Articles  []Article
+	CreatedAt time.Time
+	UpdatedAt time.Time
+}
+
+// SeriesNav 文章在系列里面的上一篇和下一篇，只算已经发表的
+type SeriesNav struct {
+	// Series 只有 Id 和 Title，Id 为 0 表示文章不在任何系列里面
+	Series Series
+	// Prev 和 Next 只有 Id 和 Title，Id 为 0 表示没有
+	Prev Article
+	Next Article
+}
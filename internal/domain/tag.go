@@ -0,0 +1,7 @@
+package domain
+
+// Tag 标签，ArticleCnt 是标签下已发表的文章数
+type Tag struct {
+	Name       string
+	ArticleCnt int64
+}
@@ -8,7 +8,11 @@ const (
 )
 
 const (
-	ArticleInvalidInput        = 402001
+	ArticleInvalidInput = 402001
+	// ArticleVersionConflict 文章已经被别人修改过了
+	ArticleVersionConflict = 402002
+	// ArticleSensitiveContent 内容包含敏感词，拒绝发表
+	ArticleSensitiveContent    = 402003
 	ArticleInternalServerError = 502001
 )
 
@@ -10,12 +10,21 @@ import (
 	"time"
 )
 
+// HistoryRecordConsumer 根据阅读事件记录阅读历史
 type HistoryRecordConsumer struct {
 	repo   repository.HistoryRecordRepository
 	client sarama.Client
 	l      logx.Logger
 }
 
+func NewHistoryRecordConsumer(client sarama.Client, repo repository.HistoryRecordRepository, l logx.Logger) *HistoryRecordConsumer {
+	return &HistoryRecordConsumer{
+		repo:   repo,
+		client: client,
+		l:      l,
+	}
+}
+
 func (i *HistoryRecordConsumer) Start() error {
 	cg, err := sarama.NewConsumerGroupFromClient("history_record", i.client)
 	if err != nil {
@@ -34,11 +43,21 @@ func (i *HistoryRecordConsumer) Start() error {
 
 func (i *HistoryRecordConsumer) Consume(msg *sarama.ConsumerMessage,
 	event ReadEvent) error {
+	// 没有登录的阅读不记历史
+	if event.UserId <= 0 {
+		return nil
+	}
+	// 用消息写进 kafka 的时间，消费慢了也不会把阅读时间往后推
+	ctime := msg.Timestamp
+	if ctime.IsZero() {
+		ctime = time.Now()
+	}
 	ctx, cancel := context.WithTimeout(context.Background(), time.Second)
 	defer cancel()
 	return i.repo.AddRecord(ctx, domain.HistoryRecord{
 		BizId: event.ArticleId,
 		Biz:   "article",
 		Uid:   event.UserId,
+		Ctime: ctime,
 	})
 }
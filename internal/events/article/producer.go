@@ -4,12 +4,18 @@ import (
 	"context"
 	"encoding/json"
 	"github.com/IBM/sarama"
+	"strconv"
 )
 
-const TopicReadEvent = "article_read"
+const (
+	TopicReadEvent    = "article_read"
+	TopicPublishEvent = "article_publish"
+)
 
 type Producer interface {
 	ProduceReadEvent(ctx context.Context, event ReadEvent) error
+	// ProducePublishEvent 发表和撤回都用这个，按文章 id 分区保证顺序
+	ProducePublishEvent(ctx context.Context, event PublishEvent) error
 }
 type KafkaProducer struct {
 	topic    string
@@ -36,7 +42,33 @@ func (k *KafkaProducer) ProduceReadEvent(ctx context.Context, event ReadEvent) e
 	return err
 }
 
+func (k *KafkaProducer) ProducePublishEvent(ctx context.Context, event PublishEvent) error {
+	data, err := json.Marshal(event)
+	if err != nil {
+		return err
+	}
+	_, _, err = k.producer.SendMessage(&sarama.ProducerMessage{
+		Topic: TopicPublishEvent,
+		Key:   sarama.StringEncoder(strconv.FormatInt(event.ArticleId, 10)),
+		Value: sarama.ByteEncoder(data),
+	})
+	return err
+}
+
 type ReadEvent struct {
 	UserId    int64 `json:"user_id"`
 	ArticleId int64 `json:"article_id"`
 }
+
+// PublishEvent 文章发表或者撤回，撤回的时候只有 ArticleId、AuthorId 和 Status
+type PublishEvent struct {
+	ArticleId int64    `json:"article_id"`
+	AuthorId  int64    `json:"author_id"`
+	Title     string   `json:"title"`
+	Content   string   `json:"content"`
+	Tags      []string `json:"tags"`
+	Status    uint8    `json:"status"`
+	UpdatedAt int64    `json:"updated_at"`
+	// Deleted 放进了回收站，下游按下线处理
+	Deleted bool `json:"deleted,omitempty"`
+}
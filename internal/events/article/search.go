@@ -0,0 +1,62 @@
+package article
+
+import (
+	"context"
+	"github.com/Andras5014/gohub/internal/domain"
+	"github.com/Andras5014/gohub/internal/repository"
+	"github.com/Andras5014/gohub/pkg/logx"
+	"github.com/Andras5014/gohub/pkg/saramax"
+	"github.com/IBM/sarama"
+	"time"
+)
+
+// SearchIndexConsumer 根据发表和撤回事件维护搜索索引
+type SearchIndexConsumer struct {
+	repo   repository.SearchRepository
+	client sarama.Client
+	l      logx.Logger
+	// instanceId 实例固定的 id，决定用哪个消费组
+	instanceId string
+}
+
+func NewSearchIndexConsumer(client sarama.Client, repo repository.SearchRepository, l logx.Logger, instanceId string) *SearchIndexConsumer {
+	return &SearchIndexConsumer{
+		repo:       repo,
+		client:     client,
+		l:          l,
+		instanceId: instanceId,
+	}
+}
+
+func (s *SearchIndexConsumer) Start() error {
+	// 内置索引每个实例一份，所以每个实例单独一个消费组，都要收到全部的消息
+	// 消费组跟着固定的实例 id 走，重启之后从提交过的位置接着消费，不会到处留下废弃的消费组
+	cg, err := sarama.NewConsumerGroupFromClient("search_index_"+s.instanceId, s.client)
+	if err != nil {
+		return err
+	}
+	go func() {
+		er := cg.Consume(context.Background(),
+			[]string{TopicPublishEvent},
+			saramax.NewHandler[PublishEvent](s.l, s.Consume))
+		if er != nil {
+			s.l.Error("退出消费", logx.Error(er))
+		}
+	}()
+	return err
+}
+
+func (s *SearchIndexConsumer) Consume(msg *sarama.ConsumerMessage, event PublishEvent) error {
+	ctx, cancel := context.WithTimeout(context.Background(), time.Second)
+	defer cancel()
+	if event.Deleted || event.Status != domain.ArticleStatusPublished.ToUint8() {
+		return s.repo.DeleteArticle(ctx, event.ArticleId)
+	}
+	return s.repo.InputArticle(ctx, domain.Article{
+		Id:        event.ArticleId,
+		Title:     event.Title,
+		Content:   event.Content,
+		Author:    domain.Author{Id: event.AuthorId},
+		UpdatedAt: time.UnixMilli(event.UpdatedAt),
+	})
+}
@@ -0,0 +1,76 @@
+package feed
+
+import (
+	"context"
+	"github.com/Andras5014/gohub/internal/domain"
+	"github.com/Andras5014/gohub/internal/events/article"
+	"github.com/Andras5014/gohub/internal/events/follow"
+	"github.com/Andras5014/gohub/internal/service"
+	"github.com/Andras5014/gohub/pkg/logx"
+	"github.com/Andras5014/gohub/pkg/saramax"
+	"github.com/IBM/sarama"
+	"time"
+)
+
+// FeedConsumer 根据发表事件推关注流，根据关注事件补或者删收件箱
+type FeedConsumer struct {
+	svc    service.FeedService
+	client sarama.Client
+	l      logx.Logger
+}
+
+func NewFeedConsumer(client sarama.Client, svc service.FeedService, l logx.Logger) *FeedConsumer {
+	return &FeedConsumer{
+		svc:    svc,
+		client: client,
+		l:      l,
+	}
+}
+
+func (f *FeedConsumer) Start() error {
+	publishCg, err := sarama.NewConsumerGroupFromClient("feed_publish", f.client)
+	if err != nil {
+		return err
+	}
+	followCg, err := sarama.NewConsumerGroupFromClient("feed_follow", f.client)
+	if err != nil {
+		return err
+	}
+	go func() {
+		er := publishCg.Consume(context.Background(),
+			[]string{article.TopicPublishEvent},
+			saramax.NewHandler[article.PublishEvent](f.l, f.ConsumePublish))
+		if er != nil {
+			f.l.Error("退出消费", logx.Error(er))
+		}
+	}()
+	go func() {
+		er := followCg.Consume(context.Background(),
+			[]string{follow.TopicFollowEvent},
+			saramax.NewHandler[follow.FollowEvent](f.l, f.ConsumeFollow))
+		if er != nil {
+			f.l.Error("退出消费", logx.Error(er))
+		}
+	}()
+	return nil
+}
+
+func (f *FeedConsumer) ConsumePublish(msg *sarama.ConsumerMessage, event article.PublishEvent) error {
+	// 粉丝多的时候要分好几批写，给的时间长一点
+	ctx, cancel := context.WithTimeout(context.Background(), time.Minute)
+	defer cancel()
+	if event.Deleted || event.Status != domain.ArticleStatusPublished.ToUint8() {
+		return f.svc.Remove(ctx, event.ArticleId)
+	}
+	return f.svc.Push(ctx, event.ArticleId)
+}
+
+func (f *FeedConsumer) ConsumeFollow(msg *sarama.ConsumerMessage, event follow.FollowEvent) error {
+	// 取消关注可能要给所有粉丝补文章，和发表一样给长一点的时间
+	ctx, cancel := context.WithTimeout(context.Background(), time.Minute)
+	defer cancel()
+	if event.Delta > 0 {
+		return f.svc.Backfill(ctx, event.Follower, event.Followee)
+	}
+	return f.svc.DropAuthor(ctx, event.Follower, event.Followee)
+}
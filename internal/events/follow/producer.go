@@ -0,0 +1,44 @@
+package follow
+
+import (
+	"context"
+	"encoding/json"
+	"github.com/IBM/sarama"
+	"strconv"
+)
+
+const TopicFollowEvent = "follow_events"
+
+// FollowEvent 关注和取消关注，只有关系真的变了才会发
+type FollowEvent struct {
+	Follower int64 `json:"follower"`
+	Followee int64 `json:"followee"`
+	// Delta 关注是 1，取消关注是 -1
+	Delta int64 `json:"delta"`
+}
+
+type Producer interface {
+	ProduceFollowEvent(ctx context.Context, event FollowEvent) error
+}
+
+type KafkaProducer struct {
+	producer sarama.SyncProducer
+}
+
+func NewSaramaSyncProducer(producer sarama.SyncProducer) Producer {
+	return &KafkaProducer{producer: producer}
+}
+
+// ProduceFollowEvent 按 followee 分区，同一个人的粉丝变化是有序的
+func (k *KafkaProducer) ProduceFollowEvent(ctx context.Context, event FollowEvent) error {
+	data, err := json.Marshal(event)
+	if err != nil {
+		return err
+	}
+	_, _, err = k.producer.SendMessage(&sarama.ProducerMessage{
+		Topic: TopicFollowEvent,
+		Key:   sarama.StringEncoder(strconv.FormatInt(event.Followee, 10)),
+		Value: sarama.ByteEncoder(data),
+	})
+	return err
+}
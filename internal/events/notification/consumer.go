@@ -0,0 +1,101 @@
+package notification
+
+import (
+	"context"
+	commentEvents "github.com/Andras5014/gohub/comment/events"
+	intrEvents "github.com/Andras5014/gohub/interactive/events"
+	"github.com/Andras5014/gohub/internal/domain"
+	"github.com/Andras5014/gohub/internal/service"
+	"github.com/Andras5014/gohub/pkg/logx"
+	"github.com/Andras5014/gohub/pkg/saramax"
+	"github.com/IBM/sarama"
+	"time"
+)
+
+// NotificationConsumer 把点赞、收藏、评论事件转成站内通知
+type NotificationConsumer struct {
+	svc    service.NotificationService
+	client sarama.Client
+	l      logx.Logger
+}
+
+func NewNotificationConsumer(client sarama.Client, svc service.NotificationService, l logx.Logger) *NotificationConsumer {
+	return &NotificationConsumer{
+		svc:    svc,
+		client: client,
+		l:      l,
+	}
+}
+
+func (n *NotificationConsumer) Start() error {
+	changeCg, err := sarama.NewConsumerGroupFromClient("notification_change", n.client)
+	if err != nil {
+		return err
+	}
+	commentCg, err := sarama.NewConsumerGroupFromClient("notification_comment", n.client)
+	if err != nil {
+		return err
+	}
+	go func() {
+		er := changeCg.Consume(context.Background(),
+			[]string{intrEvents.TopicChangeEvent},
+			saramax.NewHandler[intrEvents.ChangeEvent](n.l, n.ConsumeChange))
+		if er != nil {
+			n.l.Error("退出消费", logx.Error(er))
+		}
+	}()
+	go func() {
+		er := commentCg.Consume(context.Background(),
+			[]string{commentEvents.TopicCommentEvent},
+			saramax.NewHandler[commentEvents.CommentEvent](n.l, n.ConsumeComment))
+		if er != nil {
+			n.l.Error("退出消费", logx.Error(er))
+		}
+	}()
+	return nil
+}
+
+// ConsumeChange 取消点赞、取消收藏不通知，也不撤回已经发出去的通知
+func (n *NotificationConsumer) ConsumeChange(msg *sarama.ConsumerMessage, event intrEvents.ChangeEvent) error {
+	if event.Delta <= 0 || event.Uid <= 0 {
+		return nil
+	}
+	var typ domain.NotificationType
+	switch event.Type {
+	case intrEvents.ChangeTypeLike:
+		typ = domain.NotificationTypeLike
+	case intrEvents.ChangeTypeCollect:
+		typ = domain.NotificationTypeCollect
+	default:
+		return nil
+	}
+	ctx, cancel := context.WithTimeout(context.Background(), time.Second)
+	defer cancel()
+	return n.svc.Notify(ctx, domain.Notification{
+		Type:    typ,
+		Biz:     event.Biz,
+		BizId:   event.BizId,
+		ActorId: event.Uid,
+	})
+}
+
+// ConsumeComment 回复通知被回复的人，一级评论通知作者
+func (n *NotificationConsumer) ConsumeComment(msg *sarama.ConsumerMessage, event commentEvents.CommentEvent) error {
+	if event.Delta <= 0 || event.Uid <= 0 {
+		return nil
+	}
+	notification := domain.Notification{
+		Type:    domain.NotificationTypeComment,
+		Biz:     event.Biz,
+		BizId:   event.BizId,
+		ActorId: event.Uid,
+		RefId:   event.CommentId,
+	}
+	if event.ReplyToUid > 0 {
+		notification.Uid = event.ReplyToUid
+		notification.Type = domain.NotificationTypeReply
+	}
+	ctx, cancel := context.WithTimeout(context.Background(), time.Second)
+	defer cancel()
+	return n.svc.Notify(ctx, notification)
+}
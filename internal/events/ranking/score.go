@@ -0,0 +1,81 @@
+package ranking
+
+import (
+	"context"
+	intrEvents "github.com/Andras5014/gohub/interactive/events"
+	"github.com/Andras5014/gohub/internal/events/article"
+	"github.com/Andras5014/gohub/internal/service"
+	"github.com/Andras5014/gohub/pkg/logx"
+	"github.com/Andras5014/gohub/pkg/saramax"
+	"github.com/IBM/sarama"
+	"time"
+)
+
+// RankingScoreConsumer 根据阅读、点赞、收藏事件累加增量热榜的分数
+type RankingScoreConsumer struct {
+	svc    service.RankingService
+	client sarama.Client
+	l      logx.Logger
+}
+
+func NewRankingScoreConsumer(client sarama.Client, svc service.RankingService, l logx.Logger) *RankingScoreConsumer {
+	return &RankingScoreConsumer{
+		svc:    svc,
+		client: client,
+		l:      l,
+	}
+}
+
+func (r *RankingScoreConsumer) Start() error {
+	readCg, err := sarama.NewConsumerGroupFromClient("ranking_score_read", r.client)
+	if err != nil {
+		return err
+	}
+	changeCg, err := sarama.NewConsumerGroupFromClient("ranking_score_change", r.client)
+	if err != nil {
+		return err
+	}
+	go func() {
+		// 阅读事件量大，批量消费合并之后再写 redis
+		er := readCg.Consume(context.Background(),
+			[]string{article.TopicReadEvent},
+			saramax.NewBatchHandler[article.ReadEvent](r.l, r.BatchConsumeRead))
+		if er != nil {
+			r.l.Error("退出消费", logx.Error(er))
+		}
+	}()
+	go func() {
+		er := changeCg.Consume(context.Background(),
+			[]string{intrEvents.TopicChangeEvent},
+			saramax.NewHandler[intrEvents.ChangeEvent](r.l, r.ConsumeChange))
+		if er != nil {
+			r.l.Error("退出消费", logx.Error(er))
+		}
+	}()
+	return nil
+}
+
+func (r *RankingScoreConsumer) BatchConsumeRead(msgs []*sarama.ConsumerMessage, events []article.ReadEvent) error {
+	cnts := make(map[int64]int64, len(events))
+	for _, event := range events {
+		cnts[event.ArticleId]++
+	}
+	ctx, cancel := context.WithTimeout(context.Background(), time.Second)
+	defer cancel()
+	for id, cnt := range cnts {
+		if err := r.svc.IncrScore(ctx, id, service.RankingEventRead, cnt); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+func (r *RankingScoreConsumer) ConsumeChange(msg *sarama.ConsumerMessage, event intrEvents.ChangeEvent) error {
+	if event.Biz != "article" {
+		return nil
+	}
+	ctx, cancel := context.WithTimeout(context.Background(), time.Second)
+	defer cancel()
+	// 事件的类型和 service 里面的 RankingEventLike、RankingEventCollect 是一样的
+	return r.svc.IncrScore(ctx, event.BizId, event.Type, event.Delta)
+}
@@ -72,6 +72,7 @@ func (s *ArticleTestSuite) TestEdit() {
 					Content:  "测试内容",
 					AuthorId: 123,
 					Status:   domain.ArticleStatusUnPublished.ToUint8(),
+					Version:  1,
 				}, art)
 			},
 			art: Article{
@@ -114,6 +115,7 @@ func (s *ArticleTestSuite) TestEdit() {
 					Content:  "新的内容",
 					AuthorId: 123,
 					Status:   domain.ArticleStatusUnPublished.ToUint8(),
+					Version:  2,
 				}, art)
 
 			},
@@ -121,6 +123,7 @@ func (s *ArticleTestSuite) TestEdit() {
 				Id:      2,
 				Title:   "新的标题",
 				Content: "新的内容",
+				Version: 1,
 			},
 			wantCode: http.StatusOK,
 			wantRes: Result[int64]{
@@ -157,6 +160,7 @@ func (s *ArticleTestSuite) TestEdit() {
 					Title:    "测试标题",
 					Content:  "测试内容",
 					AuthorId: 789,
+					Version:  1,
 				}, art)
 
 			},
@@ -164,6 +168,7 @@ func (s *ArticleTestSuite) TestEdit() {
 				Id:      3,
 				Title:   "新的标题",
 				Content: "新的内容",
+				Version: 1,
 			},
 			wantCode: http.StatusOK,
 			wantRes: Result[int64]{
@@ -206,6 +211,13 @@ type Article struct {
 	Id      int64  `json:"id"`
 	Title   string `json:"title"`
 	Content string `json:"content"`
+	Version int64  `json:"version"`
+}
+
+// PublishResult 发表接口在 data 里面返回 id，状态单独放在 status 里面
+type PublishResult struct {
+	Result[int64]
+	Status uint8 `json:"status"`
 }
 
 type Result[T any] struct {
@@ -56,6 +56,9 @@ func (s *ArticleMongoHandlerTestSuite) TearDownTest() {
 	_, err = s.mdb.Collection("published_articles").
 		DeleteMany(ctx, bson.D{})
 	assert.NoError(s.T(), err)
+	_, err = s.mdb.Collection("article_revisions").
+		DeleteMany(ctx, bson.D{})
+	assert.NoError(s.T(), err)
 }
 
 func (s *ArticleMongoHandlerTestSuite) TestCleanMongo() {
@@ -94,7 +97,7 @@ func (s *ArticleMongoHandlerTestSuite) TestArticleHandler_Edit() {
 				defer cancel()
 				// 验证一下数据
 				var art article.Article
-				err := s.col.FindOne(ctx, bson.D{bson.E{"author_id", 123}}).Decode(&art)
+				err := s.col.FindOne(ctx, bson.D{bson.E{Key: "author_id", Value: 123}}).Decode(&art)
 				assert.NoError(t, err)
 				assert.True(t, art.CreatedAt > 0)
 				assert.True(t, art.UpdatedAt > 0)
@@ -109,6 +112,7 @@ func (s *ArticleMongoHandlerTestSuite) TestArticleHandler_Edit() {
 					Content:  "随便试试",
 					AuthorId: 123,
 					Status:   domain.ArticleStatusUnPublished.ToUint8(),
+					Version:  1,
 				}, art)
 			},
 			req: Article{
@@ -136,6 +140,7 @@ func (s *ArticleMongoHandlerTestSuite) TestArticleHandler_Edit() {
 					UpdatedAt: 234,
 					AuthorId:  123,
 					Status:    domain.ArticleStatusPublished.ToUint8(),
+					Version:   1,
 				})
 				assert.NoError(t, err)
 			},
@@ -156,12 +161,14 @@ func (s *ArticleMongoHandlerTestSuite) TestArticleHandler_Edit() {
 					// 创建时间没变
 					CreatedAt: 456,
 					Status:    domain.ArticleStatusUnPublished.ToUint8(),
+					Version:   2,
 				}, art)
 			},
 			req: Article{
 				Id:      2,
 				Title:   "新的标题",
 				Content: "新的内容",
+				Version: 1,
 			},
 			wantCode: 200,
 			wantResult: Result[int64]{
@@ -183,6 +190,7 @@ func (s *ArticleMongoHandlerTestSuite) TestArticleHandler_Edit() {
 					// 注意。这个 AuthorID 我们设置为另外一个人的ID
 					AuthorId: 789,
 					Status:   domain.ArticleStatusPublished.ToUint8(),
+					Version:  1,
 				})
 				assert.NoError(t, err)
 			},
@@ -202,12 +210,14 @@ func (s *ArticleMongoHandlerTestSuite) TestArticleHandler_Edit() {
 					UpdatedAt: 234,
 					AuthorId:  789,
 					Status:    domain.ArticleStatusPublished.ToUint8(),
+					Version:   1,
 				}, art)
 			},
 			req: Article{
 				Id:      3,
 				Title:   "新的标题",
 				Content: "新的内容",
+				Version: 1,
 			},
 			wantCode: 200,
 			wantResult: Result[int64]{
@@ -251,6 +261,288 @@ func (s *ArticleMongoHandlerTestSuite) TestArticleHandler_Edit() {
 	}
 }
 
+func (s *ArticleMongoHandlerTestSuite) TestArticleHandler_Publish() {
+	t := s.T()
+	testCases := []struct {
+		name   string
+		before func(t *testing.T)
+		after  func(t *testing.T, id int64)
+		req    Article
+
+		wantCode   int
+		wantResult PublishResult
+	}{
+		{
+			name: "新建并发表",
+			before: func(t *testing.T) {
+			},
+			after: func(t *testing.T, id int64) {
+				ctx, cancel := context.WithTimeout(context.Background(), time.Second*3)
+				defer cancel()
+				var art article.Article
+				err := s.col.FindOne(ctx, bson.D{bson.E{Key: "id", Value: id}}).Decode(&art)
+				assert.NoError(t, err)
+				assert.True(t, art.CreatedAt > 0)
+				assert.True(t, art.UpdatedAt > 0)
+				art.CreatedAt = 0
+				art.UpdatedAt = 0
+				assert.Equal(t, article.Article{
+					Id:       id,
+					Title:    "hello，你好",
+					Content:  "随便试试",
+					AuthorId: 123,
+					Status:   domain.ArticleStatusPublished.ToUint8(),
+					Version:  1,
+				}, art)
+
+				var pubArt article.PublishedArticle
+				err = s.liveCol.FindOne(ctx, bson.D{bson.E{Key: "id", Value: id}}).Decode(&pubArt)
+				assert.NoError(t, err)
+				assert.True(t, pubArt.CreatedAt > 0)
+				assert.True(t, pubArt.UpdatedAt > 0)
+				pubArt.CreatedAt = 0
+				pubArt.UpdatedAt = 0
+				assert.Equal(t, article.PublishedArticle{
+					Article: article.Article{
+						Id:       id,
+						Title:    "hello，你好",
+						Content:  "随便试试",
+						AuthorId: 123,
+						Status:   domain.ArticleStatusPublished.ToUint8(),
+						Version:  1,
+					},
+					Rendered: article.Rendered{
+						ContentHTML: "<p>随便试试</p>\n",
+						WordCount:   4,
+						ReadingTime: 1,
+						Abstract:    "随便试试",
+					},
+				}, pubArt)
+			},
+			req: Article{
+				Title:   "hello，你好",
+				Content: "随便试试",
+			},
+			wantCode: 200,
+			wantResult: PublishResult{
+				Result: Result[int64]{Data: 1},
+				Status: domain.ArticleStatusPublished.ToUint8(),
+			},
+		},
+		{
+			name: "更新帖子并重新发表",
+			before: func(t *testing.T) {
+				ctx, cancel := context.WithTimeout(context.Background(), time.Second*3)
+				defer cancel()
+				art := article.Article{
+					Id:        4,
+					Title:     "我的标题",
+					Content:   "我的内容",
+					CreatedAt: 456,
+					UpdatedAt: 234,
+					AuthorId:  123,
+					Status:    domain.ArticleStatusPublished.ToUint8(),
+					Version:   1,
+				}
+				_, err := s.col.InsertOne(ctx, &art)
+				assert.NoError(t, err)
+				_, err = s.liveCol.InsertOne(ctx, article.PublishedArticle{Article: art})
+				assert.NoError(t, err)
+			},
+			after: func(t *testing.T, id int64) {
+				ctx, cancel := context.WithTimeout(context.Background(), time.Second*3)
+				defer cancel()
+				var pubArt article.PublishedArticle
+				err := s.liveCol.FindOne(ctx, bson.D{bson.E{Key: "id", Value: 4}}).Decode(&pubArt)
+				assert.NoError(t, err)
+				assert.True(t, pubArt.UpdatedAt > 234)
+				pubArt.UpdatedAt = 0
+				assert.Equal(t, article.PublishedArticle{
+					Article: article.Article{
+						Id:        4,
+						Title:     "新的标题",
+						Content:   "新的内容",
+						AuthorId:  123,
+						CreatedAt: 456,
+						Status:    domain.ArticleStatusPublished.ToUint8(),
+						Version:   2,
+					},
+					Rendered: article.Rendered{
+						ContentHTML: "<p>新的内容</p>\n",
+						WordCount:   4,
+						ReadingTime: 1,
+						Abstract:    "新的内容",
+					},
+				}, pubArt)
+			},
+			req: Article{
+				Id:      4,
+				Title:   "新的标题",
+				Content: "新的内容",
+				Version: 1,
+			},
+			wantCode: 200,
+			wantResult: PublishResult{
+				Result: Result[int64]{Data: 4},
+				Status: domain.ArticleStatusPublished.ToUint8(),
+			},
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			tc.before(t)
+			data, err := json.Marshal(tc.req)
+			assert.NoError(t, err)
+			req, err := http.NewRequest(http.MethodPost,
+				"/articles/publish", bytes.NewReader(data))
+			assert.NoError(t, err)
+			req.Header.Set("Content-Type",
+				"application/json")
+			recorder := httptest.NewRecorder()
+
+			s.server.ServeHTTP(recorder, req)
+			assert.Equal(t, tc.wantCode, recorder.Code)
+			if recorder.Code != http.StatusOK {
+				return
+			}
+			var result PublishResult
+			err = json.Unmarshal(recorder.Body.Bytes(), &result)
+			assert.NoError(t, err)
+			assert.Equal(t, tc.wantResult.Code, result.Code)
+			assert.Equal(t, tc.wantResult.Status, result.Status)
+			if tc.wantResult.Data > 0 {
+				assert.True(t, result.Data > 0)
+			}
+			tc.after(t, result.Data)
+		})
+	}
+}
+
+func (s *ArticleMongoHandlerTestSuite) TestArticleHandler_Withdraw() {
+	t := s.T()
+	testCases := []struct {
+		name   string
+		before func(t *testing.T)
+		after  func(t *testing.T)
+		req    Article
+
+		wantCode   int
+		wantResult Result[int64]
+	}{
+		{
+			name: "撤回自己的帖子",
+			before: func(t *testing.T) {
+				ctx, cancel := context.WithTimeout(context.Background(), time.Second*3)
+				defer cancel()
+				art := article.Article{
+					Id:        5,
+					Title:     "我的标题",
+					Content:   "我的内容",
+					CreatedAt: 456,
+					UpdatedAt: 234,
+					AuthorId:  123,
+					Status:    domain.ArticleStatusPublished.ToUint8(),
+					Version:   1,
+				}
+				_, err := s.col.InsertOne(ctx, &art)
+				assert.NoError(t, err)
+				_, err = s.liveCol.InsertOne(ctx, article.PublishedArticle{Article: art})
+				assert.NoError(t, err)
+			},
+			after: func(t *testing.T) {
+				ctx, cancel := context.WithTimeout(context.Background(), time.Second*3)
+				defer cancel()
+				var art article.Article
+				err := s.col.FindOne(ctx, bson.D{bson.E{Key: "id", Value: 5}}).Decode(&art)
+				assert.NoError(t, err)
+				assert.True(t, art.UpdatedAt > 234)
+				assert.Equal(t, domain.ArticleStatusPrivate.ToUint8(), art.Status)
+
+				var pubArt article.PublishedArticle
+				err = s.liveCol.FindOne(ctx, bson.D{bson.E{Key: "id", Value: 5}}).Decode(&pubArt)
+				assert.NoError(t, err)
+				assert.True(t, pubArt.UpdatedAt > 234)
+				assert.Equal(t, domain.ArticleStatusPrivate.ToUint8(), pubArt.Status)
+			},
+			req: Article{
+				Id: 5,
+			},
+			wantCode: 200,
+			wantResult: Result[int64]{
+				Msg:  "ok",
+				Data: 5,
+			},
+		},
+		{
+			name: "撤回别人的帖子",
+			before: func(t *testing.T) {
+				ctx, cancel := context.WithTimeout(context.Background(), time.Second*3)
+				defer cancel()
+				art := article.Article{
+					Id:        6,
+					Title:     "我的标题",
+					Content:   "我的内容",
+					CreatedAt: 456,
+					UpdatedAt: 234,
+					AuthorId:  789,
+					Status:    domain.ArticleStatusPublished.ToUint8(),
+					Version:   1,
+				}
+				_, err := s.col.InsertOne(ctx, &art)
+				assert.NoError(t, err)
+				_, err = s.liveCol.InsertOne(ctx, article.PublishedArticle{Article: art})
+				assert.NoError(t, err)
+			},
+			after: func(t *testing.T) {
+				ctx, cancel := context.WithTimeout(context.Background(), time.Second*3)
+				defer cancel()
+				// 两个库都没有变化
+				var art article.Article
+				err := s.col.FindOne(ctx, bson.D{bson.E{Key: "id", Value: 6}}).Decode(&art)
+				assert.NoError(t, err)
+				assert.Equal(t, int64(234), art.UpdatedAt)
+				assert.Equal(t, domain.ArticleStatusPublished.ToUint8(), art.Status)
+
+				var pubArt article.PublishedArticle
+				err = s.liveCol.FindOne(ctx, bson.D{bson.E{Key: "id", Value: 6}}).Decode(&pubArt)
+				assert.NoError(t, err)
+				assert.Equal(t, domain.ArticleStatusPublished.ToUint8(), pubArt.Status)
+			},
+			req: Article{
+				Id: 6,
+			},
+			wantCode: 200,
+			wantResult: Result[int64]{
+				Code: 5,
+				Msg:  "系统错误",
+			},
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			tc.before(t)
+			data, err := json.Marshal(tc.req)
+			assert.NoError(t, err)
+			req, err := http.NewRequest(http.MethodPost,
+				"/articles/withdraw", bytes.NewReader(data))
+			assert.NoError(t, err)
+			req.Header.Set("Content-Type",
+				"application/json")
+			recorder := httptest.NewRecorder()
+
+			s.server.ServeHTTP(recorder, req)
+			assert.Equal(t, tc.wantCode, recorder.Code)
+			var result Result[int64]
+			err = json.Unmarshal(recorder.Body.Bytes(), &result)
+			assert.NoError(t, err)
+			assert.Equal(t, tc.wantResult, result)
+			tc.after(t)
+		})
+	}
+}
+
 func TestArticleMongo(t *testing.T) {
 	suite.Run(t, new(ArticleMongoHandlerTestSuite))
 }
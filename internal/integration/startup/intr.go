@@ -0,0 +1,12 @@
+package startup
+
+import (
+	interactivev1 "github.com/Andras5014/gohub/api/proto/gen/interactive/v1"
+	"github.com/Andras5014/gohub/interactive/service"
+	"github.com/Andras5014/gohub/internal/web/client"
+)
+
+// InitInteractiveClient 集成测试直接走本地实现
+func InitInteractiveClient(svc service.InteractiveService) interactivev1.InteractiveServiceClient {
+	return client.NewInteractiveServiceAdapter(svc)
+}
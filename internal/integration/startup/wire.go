@@ -3,6 +3,13 @@
 package startup
 
 import (
+	"github.com/Andras5014/gohub/interactive/events"
+	repository2 "github.com/Andras5014/gohub/interactive/repository"
+	cache2 "github.com/Andras5014/gohub/interactive/repository/cache"
+	dao2 "github.com/Andras5014/gohub/interactive/repository/dao"
+	service2 "github.com/Andras5014/gohub/interactive/service"
+	articleEvent "github.com/Andras5014/gohub/internal/events/article"
+	followEvent "github.com/Andras5014/gohub/internal/events/follow"
 	"github.com/Andras5014/gohub/internal/repository"
 	"github.com/Andras5014/gohub/internal/repository/article"
 	"github.com/Andras5014/gohub/internal/repository/cache"
@@ -10,7 +17,16 @@ import (
 	article2 "github.com/Andras5014/gohub/internal/repository/dao/article"
 	"github.com/Andras5014/gohub/internal/service"
 	article3 "github.com/Andras5014/gohub/internal/web/handler/article"
+	"github.com/Andras5014/gohub/internal/web/handler/author"
+	"github.com/Andras5014/gohub/internal/web/handler/comment"
+	"github.com/Andras5014/gohub/internal/web/handler/feed"
+	"github.com/Andras5014/gohub/internal/web/handler/follow"
+	"github.com/Andras5014/gohub/internal/web/handler/history"
+	"github.com/Andras5014/gohub/internal/web/handler/notification"
 	"github.com/Andras5014/gohub/internal/web/handler/oauth2"
+	"github.com/Andras5014/gohub/internal/web/handler/progress"
+	"github.com/Andras5014/gohub/internal/web/handler/ranking"
+	"github.com/Andras5014/gohub/internal/web/handler/search"
 	"github.com/Andras5014/gohub/internal/web/handler/user"
 	ijwt "github.com/Andras5014/gohub/internal/web/jwt"
 	"github.com/Andras5014/gohub/ioc"
@@ -33,17 +49,74 @@ var codeSvcProvider = wire.NewSet(
 	service.NewCodeService,
 )
 
-var userSvcProvider = wire.NewSet(
+var userRepoProvider = wire.NewSet(
 	dao.NewUserDAO,
 	cache.NewUserCache,
 	repository.NewUserRepository,
+)
+
+var userSvcProvider = wire.NewSet(
+	userRepoProvider,
 	service.NewUserService,
 )
 
 var articleSvcProvider = wire.NewSet(
 	article2.NewArticleDAO,
+	article2.NewCollaboratorDAO,
 	article.NewArticleRepository,
+	article.NewCollaboratorRepository,
+	ioc.InitModerator,
+	cache.NewRedisArticleCache,
 	service.NewArticleService,
+	article2.NewSeriesDAO,
+	article.NewSeriesRepository,
+	service.NewSeriesService,
+)
+
+var interactiveSvcProvider = wire.NewSet(
+	dao2.NewInteractiveDAO,
+	dao2.NewCollectionDAO,
+	cache2.NewInteractiveCache,
+	repository2.NewInteractiveRepository,
+	repository2.NewCollectionRepository,
+	service2.NewInteractiveService,
+	events.NewSaramaSyncProducer,
+	InitInteractiveClient,
+)
+
+var eventProvider = wire.NewSet(
+	InitKafka,
+	InitSyncProducer,
+	articleEvent.NewSaramaSyncProducer,
+)
+
+var followSvcProvider = wire.NewSet(
+	dao.NewFollowDAO,
+	repository.NewFollowRepository,
+	followEvent.NewSaramaSyncProducer,
+	service.NewFollowService,
+)
+
+var progressSvcProvider = wire.NewSet(
+	dao.NewReadingProgressDAO,
+	cache.NewRedisReadingProgressCache,
+	repository.NewReadingProgressRepository,
+	service.NewReadingProgressService,
+)
+
+var searchSvcProvider = wire.NewSet(
+	ioc.InitSearchIndex,
+	repository.NewSearchRepository,
+	ioc.InitSearchService,
+)
+
+var rankingSvcProvider = wire.NewSet(
+	cache.NewRedisRankingCache,
+	cache.NewRedisRankingScoreCache,
+	cache.NewRankingLocalCache,
+	repository.NewRankingRepository,
+	service.NewRankingService,
+	ioc.InitRankingBoards,
 )
 
 var oauth2SvcProvider = wire.NewSet(
@@ -56,7 +129,13 @@ func InitWebServer() *gin.Engine {
 		userSvcProvider,
 		codeSvcProvider,
 		articleSvcProvider,
+		interactiveSvcProvider,
+		eventProvider,
 		oauth2SvcProvider,
+		searchSvcProvider,
+		rankingSvcProvider,
+		followSvcProvider,
+		progressSvcProvider,
 
 		// handler
 		ioc.InitMiddlewares,
@@ -65,6 +144,27 @@ func InitWebServer() *gin.Engine {
 		user.NewUserHandler,
 		article3.NewArticleHandler,
 		oauth2.NewOAuth2WeChatHandler,
+		search.NewSearchHandler,
+		service.NewAuthorService,
+		author.NewAuthorHandler,
+		ranking.NewRankingHandler,
+		ioc.InitReviewHandler,
+		ioc.InitCommentGrpcClient,
+		comment.NewCommentHandler,
+		dao.NewNotificationDAO,
+		repository.NewNotificationRepository,
+		service.NewNotificationService,
+		notification.NewNotificationHandler,
+		follow.NewFollowHandler,
+		dao.NewFeedDAO,
+		repository.NewFeedRepository,
+		service.NewFeedService,
+		feed.NewFeedHandler,
+		dao.NewHistoryRecordDAO,
+		repository.NewHistoryRecordRepository,
+		service.NewHistoryService,
+		history.NewHistoryHandler,
+		progress.NewReadingProgressHandler,
 
 		ijwt.NewRedisJWTHandler,
 
@@ -76,18 +176,34 @@ func InitWebServer() *gin.Engine {
 func InitArticleHandler() *article3.Handler {
 	wire.Build(
 		thirdPartySet,
+		userRepoProvider,
 		articleSvcProvider,
+		interactiveSvcProvider,
+		eventProvider,
+		followSvcProvider,
+		progressSvcProvider,
 		article3.NewArticleHandler,
 	)
 	return new(article3.Handler)
 }
 func InitArticleHandlerV1(dao article2.ArticleDAO) *article3.Handler {
 	wire.Build(
-
-		InitLogger,
+		thirdPartySet,
+		userRepoProvider,
 
 		article.NewArticleRepository,
+		article2.NewCollaboratorDAO,
+		article.NewCollaboratorRepository,
+		ioc.InitModerator,
+		cache.NewRedisArticleCache,
 		service.NewArticleService,
+		article2.NewSeriesDAO,
+		article.NewSeriesRepository,
+		service.NewSeriesService,
+		interactiveSvcProvider,
+		eventProvider,
+		followSvcProvider,
+		progressSvcProvider,
 		article3.NewArticleHandler,
 	)
 	return new(article3.Handler)
@@ -7,6 +7,13 @@
 package startup
 
 import (
+	"github.com/Andras5014/gohub/interactive/events"
+	repository2 "github.com/Andras5014/gohub/interactive/repository"
+	cache2 "github.com/Andras5014/gohub/interactive/repository/cache"
+	dao2 "github.com/Andras5014/gohub/interactive/repository/dao"
+	service2 "github.com/Andras5014/gohub/interactive/service"
+	article4 "github.com/Andras5014/gohub/internal/events/article"
+	follow2 "github.com/Andras5014/gohub/internal/events/follow"
 	"github.com/Andras5014/gohub/internal/repository"
 	article2 "github.com/Andras5014/gohub/internal/repository/article"
 	"github.com/Andras5014/gohub/internal/repository/cache"
@@ -14,7 +21,16 @@ import (
 	"github.com/Andras5014/gohub/internal/repository/dao/article"
 	"github.com/Andras5014/gohub/internal/service"
 	article3 "github.com/Andras5014/gohub/internal/web/handler/article"
+	"github.com/Andras5014/gohub/internal/web/handler/author"
+	"github.com/Andras5014/gohub/internal/web/handler/comment"
+	"github.com/Andras5014/gohub/internal/web/handler/feed"
+	"github.com/Andras5014/gohub/internal/web/handler/follow"
+	"github.com/Andras5014/gohub/internal/web/handler/history"
+	"github.com/Andras5014/gohub/internal/web/handler/notification"
 	"github.com/Andras5014/gohub/internal/web/handler/oauth2"
+	"github.com/Andras5014/gohub/internal/web/handler/progress"
+	"github.com/Andras5014/gohub/internal/web/handler/ranking"
+	"github.com/Andras5014/gohub/internal/web/handler/search"
 	"github.com/Andras5014/gohub/internal/web/handler/user"
 	"github.com/Andras5014/gohub/internal/web/jwt"
 	"github.com/Andras5014/gohub/ioc"
@@ -44,10 +60,66 @@ func InitWebServer() *gin.Engine {
 	oauth2Service := InitOAuth2WeChatService(logger)
 	oAuth2WeChatHandler := oauth2.NewOAuth2WeChatHandler(oauth2Service, userService, handler)
 	articleDAO := article.NewArticleDAO(db)
-	articleRepository := article2.NewArticleRepository(articleDAO)
-	articleService := service.NewArticleService(articleRepository, logger)
-	articleHandler := article3.NewArticleHandler(articleService, logger)
-	engine := ioc.InitWebServer(v, userHandler, oAuth2WeChatHandler, articleHandler)
+	articleCache := cache.NewRedisArticleCache(cmdable)
+	articleRepository := article2.NewArticleRepository(articleDAO, articleCache, userRepository, logger)
+	client := InitKafka(config)
+	syncProducer := InitSyncProducer(client)
+	producer := article4.NewSaramaSyncProducer(syncProducer)
+	collaboratorDAO := article.NewCollaboratorDAO(db)
+	collaboratorRepository := article2.NewCollaboratorRepository(collaboratorDAO)
+	moderator := ioc.InitModerator(config, logger)
+	articleService := service.NewArticleService(articleRepository, collaboratorRepository, moderator, producer, logger)
+	interactiveDAO := dao2.NewInteractiveDAO(db)
+	interactiveCache := cache2.NewInteractiveCache(cmdable)
+	interactiveRepository := repository2.NewInteractiveRepository(interactiveDAO, interactiveCache, logger)
+	collectionDAO := dao2.NewCollectionDAO(db)
+	collectionRepository := repository2.NewCollectionRepository(collectionDAO, interactiveCache, logger)
+	eventsProducer := events.NewSaramaSyncProducer(syncProducer)
+	interactiveService := service2.NewInteractiveService(interactiveRepository, collectionRepository, eventsProducer, logger)
+	interactiveServiceClient := InitInteractiveClient(interactiveService)
+	seriesDAO := article.NewSeriesDAO(db)
+	seriesRepository := article2.NewSeriesRepository(seriesDAO)
+	seriesService := service.NewSeriesService(seriesRepository, articleRepository)
+	followDAO := dao.NewFollowDAO(db)
+	followRepository := repository.NewFollowRepository(followDAO)
+	followProducer := follow2.NewSaramaSyncProducer(syncProducer)
+	followService := service.NewFollowService(followRepository, userRepository, followProducer, logger)
+	readingProgressDAO := dao.NewReadingProgressDAO(db)
+	readingProgressCache := cache.NewRedisReadingProgressCache(cmdable)
+	readingProgressRepository := repository.NewReadingProgressRepository(readingProgressDAO, readingProgressCache, logger)
+	readingProgressService := service.NewReadingProgressService(readingProgressRepository, articleRepository)
+	articleHandler := article3.NewArticleHandler(articleService, seriesService, followService, readingProgressService, interactiveServiceClient, logger)
+	index := ioc.InitSearchIndex()
+	searchRepository := repository.NewSearchRepository(index)
+	searchService := ioc.InitSearchService(searchRepository, articleRepository, logger)
+	searchHandler := search.NewSearchHandler(searchService, logger)
+	authorService := service.NewAuthorService(articleRepository, userRepository, followRepository, interactiveServiceClient)
+	authorHandler := author.NewAuthorHandler(authorService, followService, logger)
+	rankingCache := cache.NewRedisRankingCache(cmdable)
+	rankingScoreCache := cache.NewRedisRankingScoreCache(cmdable)
+	rankingLocalCache := cache.NewRankingLocalCache()
+	rankingRepository := repository.NewRankingRepository(rankingCache, rankingScoreCache, rankingLocalCache, logger)
+	v2 := ioc.InitRankingBoards(config)
+	rankingService := service.NewRankingService(articleService, interactiveServiceClient, rankingRepository, v2)
+	rankingHandler := ranking.NewRankingHandler(rankingService, logger)
+	reviewHandler := ioc.InitReviewHandler(config, articleService, logger)
+	commentServiceClient := ioc.InitCommentGrpcClient(config)
+	commentHandler := comment.NewCommentHandler(commentServiceClient, articleService, logger)
+	notificationDAO := dao.NewNotificationDAO(db)
+	notificationRepository := repository.NewNotificationRepository(notificationDAO)
+	notificationService := service.NewNotificationService(notificationRepository, articleRepository)
+	notificationHandler := notification.NewNotificationHandler(notificationService, logger)
+	followHandler := follow.NewFollowHandler(followService, logger)
+	feedDAO := dao.NewFeedDAO(db)
+	feedRepository := repository.NewFeedRepository(feedDAO)
+	feedService := service.NewFeedService(feedRepository, followRepository, articleRepository)
+	feedHandler := feed.NewFeedHandler(feedService, logger)
+	historyRecordDAO := dao.NewHistoryRecordDAO(db)
+	historyRecordRepository := repository.NewHistoryRecordRepository(historyRecordDAO)
+	historyService := service.NewHistoryService(historyRecordRepository)
+	historyHandler := history.NewHistoryHandler(historyService, articleService, logger)
+	progressHandler := progress.NewReadingProgressHandler(readingProgressService, articleService, logger)
+	engine := ioc.InitWebServer(v, userHandler, oAuth2WeChatHandler, articleHandler, searchHandler, authorHandler, rankingHandler, reviewHandler, commentHandler, notificationHandler, followHandler, feedHandler, historyHandler, progressHandler)
 	return engine
 }
 
@@ -56,17 +128,79 @@ func InitArticleHandler() *article3.Handler {
 	logger := InitLogger()
 	db := InitDB(config, logger)
 	articleDAO := article.NewArticleDAO(db)
-	articleRepository := article2.NewArticleRepository(articleDAO)
-	articleService := service.NewArticleService(articleRepository, logger)
-	articleHandler := article3.NewArticleHandler(articleService, logger)
+	cmdable := InitRedis(config)
+	articleCache := cache.NewRedisArticleCache(cmdable)
+	userDAO := dao.NewUserDAO(db)
+	userCache := cache.NewUserCache(cmdable)
+	userRepository := repository.NewUserRepository(userDAO, userCache)
+	articleRepository := article2.NewArticleRepository(articleDAO, articleCache, userRepository, logger)
+	client := InitKafka(config)
+	syncProducer := InitSyncProducer(client)
+	producer := article4.NewSaramaSyncProducer(syncProducer)
+	collaboratorDAO := article.NewCollaboratorDAO(db)
+	collaboratorRepository := article2.NewCollaboratorRepository(collaboratorDAO)
+	moderator := ioc.InitModerator(config, logger)
+	articleService := service.NewArticleService(articleRepository, collaboratorRepository, moderator, producer, logger)
+	interactiveDAO := dao2.NewInteractiveDAO(db)
+	interactiveCache := cache2.NewInteractiveCache(cmdable)
+	interactiveRepository := repository2.NewInteractiveRepository(interactiveDAO, interactiveCache, logger)
+	collectionDAO := dao2.NewCollectionDAO(db)
+	collectionRepository := repository2.NewCollectionRepository(collectionDAO, interactiveCache, logger)
+	eventsProducer := events.NewSaramaSyncProducer(syncProducer)
+	interactiveService := service2.NewInteractiveService(interactiveRepository, collectionRepository, eventsProducer, logger)
+	interactiveServiceClient := InitInteractiveClient(interactiveService)
+	seriesDAO := article.NewSeriesDAO(db)
+	seriesRepository := article2.NewSeriesRepository(seriesDAO)
+	seriesService := service.NewSeriesService(seriesRepository, articleRepository)
+	followDAO := dao.NewFollowDAO(db)
+	followRepository := repository.NewFollowRepository(followDAO)
+	followProducer := follow2.NewSaramaSyncProducer(syncProducer)
+	followService := service.NewFollowService(followRepository, userRepository, followProducer, logger)
+	readingProgressDAO := dao.NewReadingProgressDAO(db)
+	readingProgressCache := cache.NewRedisReadingProgressCache(cmdable)
+	readingProgressRepository := repository.NewReadingProgressRepository(readingProgressDAO, readingProgressCache, logger)
+	readingProgressService := service.NewReadingProgressService(readingProgressRepository, articleRepository)
+	articleHandler := article3.NewArticleHandler(articleService, seriesService, followService, readingProgressService, interactiveServiceClient, logger)
 	return articleHandler
 }
 
-func InitArticleHandlerV1(dao2 article.ArticleDAO) *article3.Handler {
-	articleRepository := article2.NewArticleRepository(dao2)
+func InitArticleHandlerV1(dao3 article.ArticleDAO) *article3.Handler {
+	config := InitConfig()
+	cmdable := InitRedis(config)
+	articleCache := cache.NewRedisArticleCache(cmdable)
 	logger := InitLogger()
-	articleService := service.NewArticleService(articleRepository, logger)
-	articleHandler := article3.NewArticleHandler(articleService, logger)
+	db := InitDB(config, logger)
+	userDAO := dao.NewUserDAO(db)
+	userCache := cache.NewUserCache(cmdable)
+	userRepository := repository.NewUserRepository(userDAO, userCache)
+	articleRepository := article2.NewArticleRepository(dao3, articleCache, userRepository, logger)
+	client := InitKafka(config)
+	syncProducer := InitSyncProducer(client)
+	producer := article4.NewSaramaSyncProducer(syncProducer)
+	collaboratorDAO := article.NewCollaboratorDAO(db)
+	collaboratorRepository := article2.NewCollaboratorRepository(collaboratorDAO)
+	moderator := ioc.InitModerator(config, logger)
+	articleService := service.NewArticleService(articleRepository, collaboratorRepository, moderator, producer, logger)
+	interactiveDAO := dao2.NewInteractiveDAO(db)
+	interactiveCache := cache2.NewInteractiveCache(cmdable)
+	interactiveRepository := repository2.NewInteractiveRepository(interactiveDAO, interactiveCache, logger)
+	collectionDAO := dao2.NewCollectionDAO(db)
+	collectionRepository := repository2.NewCollectionRepository(collectionDAO, interactiveCache, logger)
+	eventsProducer := events.NewSaramaSyncProducer(syncProducer)
+	interactiveService := service2.NewInteractiveService(interactiveRepository, collectionRepository, eventsProducer, logger)
+	interactiveServiceClient := InitInteractiveClient(interactiveService)
+	seriesDAO := article.NewSeriesDAO(db)
+	seriesRepository := article2.NewSeriesRepository(seriesDAO)
+	seriesService := service.NewSeriesService(seriesRepository, articleRepository)
+	followDAO := dao.NewFollowDAO(db)
+	followRepository := repository.NewFollowRepository(followDAO)
+	followProducer := follow2.NewSaramaSyncProducer(syncProducer)
+	followService := service.NewFollowService(followRepository, userRepository, followProducer, logger)
+	readingProgressDAO := dao.NewReadingProgressDAO(db)
+	readingProgressCache := cache.NewRedisReadingProgressCache(cmdable)
+	readingProgressRepository := repository.NewReadingProgressRepository(readingProgressDAO, readingProgressCache, logger)
+	readingProgressService := service.NewReadingProgressService(readingProgressRepository, articleRepository)
+	articleHandler := article3.NewArticleHandler(articleService, seriesService, followService, readingProgressService, interactiveServiceClient, logger)
 	return articleHandler
 }
 
@@ -83,9 +217,28 @@ var thirdPartySet = wire.NewSet(
 
 var codeSvcProvider = wire.NewSet(cache.NewCodeCache, repository.NewCodeRepository, service.NewCodeService)
 
-var userSvcProvider = wire.NewSet(dao.NewUserDAO, cache.NewUserCache, repository.NewUserRepository, service.NewUserService)
+var userRepoProvider = wire.NewSet(dao.NewUserDAO, cache.NewUserCache, repository.NewUserRepository)
+
+var userSvcProvider = wire.NewSet(
+	userRepoProvider, service.NewUserService,
+)
+
+var articleSvcProvider = wire.NewSet(article.NewArticleDAO, article.NewCollaboratorDAO, article2.NewArticleRepository, article2.NewCollaboratorRepository, ioc.InitModerator, cache.NewRedisArticleCache, service.NewArticleService, article.NewSeriesDAO, article2.NewSeriesRepository, service.NewSeriesService)
+
+var interactiveSvcProvider = wire.NewSet(dao2.NewInteractiveDAO, dao2.NewCollectionDAO, cache2.NewInteractiveCache, repository2.NewInteractiveRepository, repository2.NewCollectionRepository, service2.NewInteractiveService, events.NewSaramaSyncProducer, InitInteractiveClient)
+
+var eventProvider = wire.NewSet(
+	InitKafka,
+	InitSyncProducer, article4.NewSaramaSyncProducer,
+)
+
+var followSvcProvider = wire.NewSet(dao.NewFollowDAO, repository.NewFollowRepository, follow2.NewSaramaSyncProducer, service.NewFollowService)
+
+var progressSvcProvider = wire.NewSet(dao.NewReadingProgressDAO, cache.NewRedisReadingProgressCache, repository.NewReadingProgressRepository, service.NewReadingProgressService)
+
+var searchSvcProvider = wire.NewSet(ioc.InitSearchIndex, repository.NewSearchRepository, ioc.InitSearchService)
 
-var articleSvcProvider = wire.NewSet(article.NewArticleDAO, article2.NewArticleRepository, service.NewArticleService)
+var rankingSvcProvider = wire.NewSet(cache.NewRedisRankingCache, cache.NewRedisRankingScoreCache, cache.NewRankingLocalCache, repository.NewRankingRepository, service.NewRankingService, ioc.InitRankingBoards)
 
 var oauth2SvcProvider = wire.NewSet(
 	InitOAuth2WeChatService,
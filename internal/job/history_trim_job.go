@@ -0,0 +1,36 @@
+package job
+
+import (
+	"context"
+	"github.com/Andras5014/gohub/internal/service"
+	"github.com/Andras5014/gohub/pkg/logx"
+	"time"
+)
+
+// HistoryTrimJob 删掉每个人超过上限的阅读历史
+// 清理是幂等的，多个实例同时跑也没关系，所以不加分布式锁
+type HistoryTrimJob struct {
+	svc     service.HistoryService
+	timeout time.Duration
+	l       logx.Logger
+}
+
+func NewHistoryTrimJob(svc service.HistoryService, timeout time.Duration, l logx.Logger) *HistoryTrimJob {
+	return &HistoryTrimJob{
+		svc:     svc,
+		timeout: timeout,
+		l:       l,
+	}
+}
+
+func (h *HistoryTrimJob) Name() string {
+	return "history_trim_job"
+}
+
+func (h *HistoryTrimJob) Run(ctx context.Context) error {
+	ctx, cancel := context.WithTimeout(ctx, h.timeout)
+	defer cancel()
+	cnt, err := h.svc.Trim(ctx)
+	h.l.Info("清理阅读历史", logx.Int64("users", int64(cnt)))
+	return err
+}
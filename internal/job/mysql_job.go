@@ -7,6 +7,7 @@ import (
 	"github.com/Andras5014/gohub/internal/service"
 	"github.com/Andras5014/gohub/pkg/logx"
 	"golang.org/x/sync/semaphore"
+	"time"
 )
 
 type Executor interface {
@@ -29,7 +30,7 @@ func (l *LocalFuncExecutor) Name() string {
 }
 
 func (l *LocalFuncExecutor) Exec(ctx context.Context, j domain.Job) error {
-	fn, ok := l.funcs[j.Executor]
+	fn, ok := l.funcs[j.Name]
 	if !ok {
 		return errors.New("没有对应的执行器" + j.Name)
 	}
@@ -71,13 +72,23 @@ func (s *Scheduler) Schedule(ctx context.Context) error {
 		}
 		j, err := s.svc.Preempt(ctx)
 		if err != nil {
-			s.l.Error("抢占任务失败", logx.Error(err))
+			// 大多数时候是没有到期的任务，歇一会再抢
+			s.limiter.Release(1)
+			s.l.Debug("抢占任务失败", logx.Error(err))
+			select {
+			case <-ctx.Done():
+			case <-time.After(time.Second):
+			}
 			continue
 		}
 
 		exec, ok := s.executors[j.Executor]
 		if !ok {
+			s.limiter.Release(1)
 			s.l.Error("没有对应的执行器", logx.String("name", j.Name))
+			if er := j.CancelFunc(); er != nil {
+				s.l.Error("取消任务失败", logx.Error(er))
+			}
 			continue
 		}
 		go func() {
@@ -52,7 +52,7 @@ func (r *RankingJob) Run(ctx context.Context) error {
 
 	ctx, cancel := context.WithTimeout(ctx, r.timeout)
 	defer cancel()
-	return r.svc.TopN(ctx, 100)
+	return r.svc.TopN(ctx)
 }
 
 func (r *RankingJob) Close() error {
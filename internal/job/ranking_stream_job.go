@@ -0,0 +1,34 @@
+package job
+
+import (
+	"context"
+	"github.com/Andras5014/gohub/internal/service"
+	"github.com/Andras5014/gohub/pkg/logx"
+	"time"
+)
+
+// RankingStreamJob 定期衰减增量热榜的分数，并且发布最新的前 N
+// 衰减按照上一次衰减的时间算，多个实例同时跑也不会多衰减，所以不加分布式锁
+type RankingStreamJob struct {
+	svc     service.RankingService
+	timeout time.Duration
+	l       logx.Logger
+}
+
+func NewRankingStreamJob(svc service.RankingService, timeout time.Duration, l logx.Logger) *RankingStreamJob {
+	return &RankingStreamJob{
+		svc:     svc,
+		timeout: timeout,
+		l:       l,
+	}
+}
+
+func (r *RankingStreamJob) Name() string {
+	return "ranking_stream_job"
+}
+
+func (r *RankingStreamJob) Run(ctx context.Context) error {
+	ctx, cancel := context.WithTimeout(ctx, r.timeout)
+	defer cancel()
+	return r.svc.RefreshStream(ctx)
+}
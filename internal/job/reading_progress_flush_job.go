@@ -0,0 +1,38 @@
+package job
+
+import (
+	"context"
+	"github.com/Andras5014/gohub/internal/service"
+	"github.com/Andras5014/gohub/pkg/logx"
+	"time"
+)
+
+// ReadingProgressFlushJob 把缓存里面的阅读进度批量写到数据库
+// 待落库的是用 SPOP 取出来的，多个实例同时跑也不会重复写，所以不加分布式锁
+type ReadingProgressFlushJob struct {
+	svc     service.ReadingProgressService
+	timeout time.Duration
+	l       logx.Logger
+}
+
+func NewReadingProgressFlushJob(svc service.ReadingProgressService, timeout time.Duration, l logx.Logger) *ReadingProgressFlushJob {
+	return &ReadingProgressFlushJob{
+		svc:     svc,
+		timeout: timeout,
+		l:       l,
+	}
+}
+
+func (r *ReadingProgressFlushJob) Name() string {
+	return "reading_progress_flush_job"
+}
+
+func (r *ReadingProgressFlushJob) Run(ctx context.Context) error {
+	ctx, cancel := context.WithTimeout(ctx, r.timeout)
+	defer cancel()
+	cnt, err := r.svc.Flush(ctx)
+	if cnt > 0 {
+		r.l.Debug("阅读进度落库", logx.Int64("cnt", int64(cnt)))
+	}
+	return err
+}
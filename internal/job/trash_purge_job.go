@@ -0,0 +1,38 @@
+package job
+
+import (
+	"context"
+	"github.com/Andras5014/gohub/internal/service"
+	"github.com/Andras5014/gohub/pkg/logx"
+	"time"
+)
+
+// TrashPurgeJob 清理回收站里面超过保留期限的文章
+// 清理是幂等的，多个实例同时跑也没关系，所以不加分布式锁
+type TrashPurgeJob struct {
+	svc       service.ArticleService
+	retention time.Duration
+	timeout   time.Duration
+	l         logx.Logger
+}
+
+func NewTrashPurgeJob(svc service.ArticleService, retention time.Duration, timeout time.Duration, l logx.Logger) *TrashPurgeJob {
+	return &TrashPurgeJob{
+		svc:       svc,
+		retention: retention,
+		timeout:   timeout,
+		l:         l,
+	}
+}
+
+func (t *TrashPurgeJob) Name() string {
+	return "trash_purge_job"
+}
+
+func (t *TrashPurgeJob) Run(ctx context.Context) error {
+	ctx, cancel := context.WithTimeout(ctx, t.timeout)
+	defer cancel()
+	cnt, err := t.svc.PurgeTrash(ctx, time.Now().Add(-t.retention))
+	t.l.Info("清理回收站", logx.Int64("cnt", int64(cnt)))
+	return err
+}
@@ -2,15 +2,23 @@ package article
 
 import (
 	"context"
+	"errors"
 	"github.com/Andras5014/gohub/internal/domain"
 	"github.com/Andras5014/gohub/internal/repository"
 	"github.com/Andras5014/gohub/internal/repository/cache"
 	dao "github.com/Andras5014/gohub/internal/repository/dao/article"
 	"github.com/Andras5014/gohub/pkg/logx"
 	"github.com/ecodeclub/ekit/slice"
+	"golang.org/x/sync/singleflight"
+	"strconv"
 	"time"
 )
 
+var (
+	ErrArticleVersionConflict = dao.ErrVersionConflict
+	ErrArticleNotFound        = dao.ErrArticleNotFound
+)
+
 type Repository interface {
 	Create(ctx context.Context, article domain.Article) (int64, error)
 	Update(ctx context.Context, article domain.Article) error
@@ -20,14 +28,49 @@ type Repository interface {
 	SyncV1(ctx context.Context, article domain.Article) (int64, error)
 	SyncStatus(ctx context.Context, article domain.Article) (int64, error)
 
-	// List 制作库
-	List(ctx context.Context, id int64, offset int, limit int) ([]domain.Article, error)
+	// List 制作库，按 (updated_at, id) 倒序翻页，零值游标表示第一页
+	List(ctx context.Context, id int64, cursor domain.ArticleCursor, limit int) ([]domain.Article, error)
 	GetById(ctx context.Context, id int64) (domain.Article, error)
 
+	// GetPubById 先查缓存，没有的时候同一篇文章只会有一个请求去查数据库
 	GetPubById(ctx context.Context, id int64) (domain.Article, error)
+	// WarmPub 把线上库的文章提前加载到缓存里面
+	WarmPub(ctx context.Context, ids []int64) error
+
+	// ListPub 公开库，翻页方式和 List 一样
+	ListPub(ctx context.Context, cursor domain.ArticleCursor, limit int) ([]domain.Article, error)
+
+	// ListPubByAuthor 某个作者已发表的文章
+	ListPubByAuthor(ctx context.Context, authorId int64, cursor domain.ArticleCursor, limit int) ([]domain.Article, error)
+	// PubIdsByAuthor 按 id 升序分批拿已发表文章的 id，maxId 是上一批最后一个
+	PubIdsByAuthor(ctx context.Context, authorId int64, maxId int64, limit int) ([]int64, error)
+	// ListPubByIds 一次查出 ids 里面已发表的文章，按 ids 的顺序返回，不走缓存，也不带作者信息
+	ListPubByIds(ctx context.Context, ids []int64) ([]domain.Article, error)
+
+	// ListPubByTag 按标签浏览公开库
+	ListPubByTag(ctx context.Context, tag string, cursor domain.ArticleCursor, limit int) ([]domain.Article, error)
+	TagCounts(ctx context.Context, limit int) ([]domain.Tag, error)
+
+	// ListRevisions 历史版本
+	ListRevisions(ctx context.Context, artId int64, offset int, limit int) ([]domain.ArticleRevision, error)
+	GetRevision(ctx context.Context, artId int64, id int64) (domain.ArticleRevision, error)
 
-	// ListPub 公开库
-	ListPub(ctx context.Context, start time.Time, offset int, limit int) ([]domain.Article, error)
+	// UpdateSchedule 更新定时发表/撤回的时间，零值表示取消
+	UpdateSchedule(ctx context.Context, article domain.Article) error
+	// ListDueScheduled 到期需要处理的定时任务
+	ListDueScheduled(ctx context.Context, now time.Time, limit int) ([]domain.Article, error)
+
+	// ListByStatus 制作库里面某个状态的文章，按更新时间正序
+	ListByStatus(ctx context.Context, status domain.ArticleStatus, offset int, limit int) ([]domain.Article, error)
+	// UpdateReview 当前状态是 from 的时候才把状态和审核原因改成 article 上的，不然返回 ErrArticleNotFound
+	UpdateReview(ctx context.Context, article domain.Article, from domain.ArticleStatus) error
+
+	// Delete 放进回收站，制作库和线上库都看不到了
+	Delete(ctx context.Context, id int64, authorId int64) error
+	Restore(ctx context.Context, id int64, authorId int64) error
+	ListTrash(ctx context.Context, authorId int64, offset int, limit int) ([]domain.Article, error)
+	// PurgeDeleted 彻底删除 before 之前放进回收站的文章
+	PurgeDeleted(ctx context.Context, before time.Time, limit int) (int, error)
 }
 type CacheArticleRepository struct {
 	dao dao.ArticleDAO
@@ -39,55 +82,141 @@ type CacheArticleRepository struct {
 	userRepo repository.UserRepository
 
 	cache cache.ArticleCache
-	l     logx.Logger
+	// pubGroup 合并同一篇文章并发的回源请求
+	pubGroup singleflight.Group
+	l        logx.Logger
 }
 
-func NewArticleRepository(dao dao.ArticleDAO, cache cache.ArticleCache, l logx.Logger) Repository {
+func NewArticleRepository(dao dao.ArticleDAO, cache cache.ArticleCache, userRepo repository.UserRepository, l logx.Logger) Repository {
 	return &CacheArticleRepository{
-		dao:   dao,
-		cache: cache,
-		l:     l,
+		dao:      dao,
+		cache:    cache,
+		userRepo: userRepo,
+		l:        l,
 	}
 }
 
-func (c *CacheArticleRepository) ListPub(ctx context.Context, start time.Time, offset int, limit int) ([]domain.Article, error) {
-	arts, err := c.dao.ListPub(ctx, start, offset, limit)
+func (c *CacheArticleRepository) ListPub(ctx context.Context, cursor domain.ArticleCursor, limit int) ([]domain.Article, error) {
+	arts, err := c.dao.ListPub(ctx, cursorToEntity(cursor), limit)
 	if err != nil {
 		return nil, err
 	}
-	return slice.Map[dao.PublishedArticle, domain.Article](arts, func(idx int, src dao.PublishedArticle) domain.Article {
-		return c.toDomain(dao.Article{
-			Id:        src.Id,
-			AuthorId:  src.AuthorId,
-			Content:   src.Content,
-			Title:     src.Title,
-			Status:    src.Status,
-			CreatedAt: src.CreatedAt,
-			UpdatedAt: src.UpdatedAt,
-		})
-	}), nil
+	return c.pubToDomain(arts), nil
 }
-func (c *CacheArticleRepository) GetPubById(ctx context.Context, id int64) (domain.Article, error) {
-	pubArt, err := c.dao.GetPubById(ctx, id)
+
+func (c *CacheArticleRepository) ListPubByAuthor(ctx context.Context, authorId int64, cursor domain.ArticleCursor, limit int) ([]domain.Article, error) {
+	arts, err := c.dao.ListPubByAuthor(ctx, authorId, cursorToEntity(cursor), limit)
 	if err != nil {
-		return domain.Article{}, err
+		return nil, err
 	}
-	user, err := c.userRepo.FindById(ctx, pubArt.AuthorId)
+	return c.pubToDomain(arts), nil
+}
+
+func (c *CacheArticleRepository) PubIdsByAuthor(ctx context.Context, authorId int64, maxId int64, limit int) ([]int64, error) {
+	return c.dao.PubIdsByAuthor(ctx, authorId, maxId, limit)
+}
+
+func (c *CacheArticleRepository) ListPubByIds(ctx context.Context, ids []int64) ([]domain.Article, error) {
+	if len(ids) == 0 {
+		return nil, nil
+	}
+	arts, err := c.dao.ListPubByIds(ctx, ids)
 	if err != nil {
-		return domain.Article{}, err
+		return nil, err
 	}
-	return domain.Article{
-		Id: pubArt.Id,
-		Author: domain.Author{
+	artMap := make(map[int64]dao.PublishedArticle, len(arts))
+	for _, art := range arts {
+		artMap[art.Id] = art
+	}
+	res := make([]domain.Article, 0, len(arts))
+	for _, id := range ids {
+		if art, ok := artMap[id]; ok {
+			res = append(res, c.pubToDomainOne(art))
+		}
+	}
+	return res, nil
+}
+
+func (c *CacheArticleRepository) ListPubByTag(ctx context.Context, tag string, cursor domain.ArticleCursor, limit int) ([]domain.Article, error) {
+	arts, err := c.dao.ListPubByTag(ctx, tag, cursorToEntity(cursor), limit)
+	if err != nil {
+		return nil, err
+	}
+	return c.pubToDomain(arts), nil
+}
+
+func (c *CacheArticleRepository) TagCounts(ctx context.Context, limit int) ([]domain.Tag, error) {
+	res, err := c.dao.TagCounts(ctx, limit)
+	if err != nil {
+		return nil, err
+	}
+	return slice.Map[dao.TagCount, domain.Tag](res, func(idx int, src dao.TagCount) domain.Tag {
+		return domain.Tag{Name: src.Name, ArticleCnt: src.Cnt}
+	}), nil
+}
+
+func (c *CacheArticleRepository) pubToDomain(arts []dao.PublishedArticle) []domain.Article {
+	return slice.Map[dao.PublishedArticle, domain.Article](arts, func(idx int, src dao.PublishedArticle) domain.Article {
+		return c.pubToDomainOne(src)
+	})
+}
+func (c *CacheArticleRepository) GetPubById(ctx context.Context, id int64) (domain.Article, error) {
+	art, err := c.cache.GetPub(ctx, id)
+	switch {
+	case err == nil:
+		return art, nil
+	case errors.Is(err, cache.ErrPubNotFound):
+		return domain.Article{}, ErrArticleNotFound
+	case !errors.Is(err, cache.ErrKeyNotExist):
+		// redis 出问题了，还是去查数据库，singleflight 可以挡住一部分流量
+		c.l.Error("查询文章缓存失败", logx.Int64("id", id), logx.Error(err))
+	}
+	return c.loadPub(ctx, id)
+}
+
+func (c *CacheArticleRepository) WarmPub(ctx context.Context, ids []int64) error {
+	for _, id := range ids {
+		_, err := c.loadPub(ctx, id)
+		if err != nil && !errors.Is(err, ErrArticleNotFound) {
+			return err
+		}
+	}
+	return nil
+}
+
+// loadPub 回源查询并且写回缓存
+func (c *CacheArticleRepository) loadPub(ctx context.Context, id int64) (domain.Article, error) {
+	val, err, _ := c.pubGroup.Do(strconv.FormatInt(id, 10), func() (any, error) {
+		// 结果是大家共享的，不能因为第一个请求被取消了，其它请求跟着失败
+		ctx := context.WithoutCancel(ctx)
+		pubArt, err := c.dao.GetPubById(ctx, id)
+		if errors.Is(err, dao.ErrArticleNotFound) {
+			if er := c.cache.SetPubNotFound(ctx, id); er != nil {
+				c.l.Error("缓存文章不存在失败", logx.Int64("id", id), logx.Error(er))
+			}
+			return domain.Article{}, ErrArticleNotFound
+		}
+		if err != nil {
+			return domain.Article{}, err
+		}
+		user, err := c.userRepo.FindById(ctx, pubArt.AuthorId)
+		if err != nil {
+			return domain.Article{}, err
+		}
+		res := c.pubToDomainOne(pubArt)
+		res.Author = domain.Author{
 			Id:   user.Id,
 			Name: user.NickName,
-		},
-		Content:   pubArt.Content,
-		Title:     pubArt.Title,
-		Status:    domain.ArticleStatus(pubArt.Status),
-		CreatedAt: time.UnixMilli(pubArt.CreatedAt),
-		UpdatedAt: time.UnixMilli(pubArt.UpdatedAt),
-	}, nil
+		}
+		if er := c.cache.SetPub(ctx, res); er != nil {
+			c.l.Error("缓存文章失败", logx.Int64("id", id), logx.Error(er))
+		}
+		return res, nil
+	})
+	if err != nil {
+		return domain.Article{}, err
+	}
+	return val.(domain.Article), nil
 }
 
 func (c *CacheArticleRepository) GetById(ctx context.Context, id int64) (domain.Article, error) {
@@ -108,66 +237,211 @@ func (c *CacheArticleRepository) Create(ctx context.Context, article domain.Arti
 		Content:  article.Content,
 		Title:    article.Title,
 		Status:   article.Status.ToUint8(),
+		Tags:     article.Tags,
 	})
 }
 
+// Update 第一页的缓存里面有版本号，不删掉的话作者拿着旧的版本号再编辑会冲突
 func (c *CacheArticleRepository) Update(ctx context.Context, article domain.Article) error {
-	return c.dao.UpdateById(ctx, dao.Article{
-		Id:       article.Id,
-		AuthorId: article.Author.Id,
-		Content:  article.Content,
-		Title:    article.Title,
-		Status:   article.Status.ToUint8(),
-	})
+	err := c.dao.UpdateById(ctx, c.toEntity(article))
+	if err == nil {
+		c.delFirstPage(ctx, article.Author.Id)
+	}
+	return err
 }
 func (c *CacheArticleRepository) Sync(ctx context.Context, article domain.Article) (int64, error) {
-	return c.dao.Sync(ctx, c.toEntity(article))
+	id, err := c.dao.Sync(ctx, c.toPubEntity(article))
+	if err == nil {
+		c.delPub(ctx, id)
+		c.delFirstPage(ctx, article.Author.Id)
+	}
+	return id, err
 }
 func (c *CacheArticleRepository) SyncV1(ctx context.Context, article domain.Article) (int64, error) {
 	var (
 		id  = article.Id
 		err error
 	)
-	articleEntity := c.toEntity(article)
+	articleEntity := c.toPubEntity(article)
 	if article.Id > 0 {
-		err = c.authorDAO.UpdateById(ctx, articleEntity)
+		err = c.authorDAO.UpdateById(ctx, articleEntity.Article)
 	} else {
-		id, err = c.authorDAO.Insert(ctx, articleEntity)
+		id, err = c.authorDAO.Insert(ctx, articleEntity.Article)
 	}
 	if err != nil {
 		return id, err
 	}
-	return id, c.readerDAO.Upsert(ctx, articleEntity)
+	err = c.readerDAO.Upsert(ctx, articleEntity)
+	if err == nil {
+		c.delPub(ctx, id)
+		c.delFirstPage(ctx, article.Author.Id)
+	}
+	return id, err
 }
 func (c *CacheArticleRepository) SyncStatus(ctx context.Context, article domain.Article) (int64, error) {
-	return c.dao.SyncStatus(ctx, c.toEntity(article))
+	id, err := c.dao.SyncStatus(ctx, c.toEntity(article))
+	if err == nil {
+		c.delPub(ctx, id)
+		c.delFirstPage(ctx, article.Author.Id)
+	}
+	return id, err
 }
 
-func (c *CacheArticleRepository) List(ctx context.Context, id int64, offset int, limit int) ([]domain.Article, error) {
-	// 缓存方案
-	if offset == 0 && limit <= 100 {
-		data, err := c.cache.GeFirstPage(ctx, id)
-		if err == nil {
-			return data, nil
+// delPub 线上库变了就删掉缓存，下次读的时候再加载
+func (c *CacheArticleRepository) delPub(ctx context.Context, id int64) {
+	if err := c.cache.DelPub(ctx, id); err != nil {
+		c.l.Error("删除文章缓存失败", logx.Int64("id", id), logx.Error(err))
+	}
+}
+
+// delFirstPage 制作库变了就删掉作者列表第一页的缓存，版本号和状态都在里面
+func (c *CacheArticleRepository) delFirstPage(ctx context.Context, authorId int64) {
+	if err := c.cache.DelFirstPage(ctx, authorId); err != nil {
+		c.l.Error("删除列表缓存失败", logx.Int64("author_id", authorId), logx.Error(err))
+	}
+}
+
+// firstPageSize 第一页固定缓存这么多条，limit 不超过它的请求都走缓存
+const firstPageSize = 100
+
+func (c *CacheArticleRepository) List(ctx context.Context, id int64, cursor domain.ArticleCursor, limit int) ([]domain.Article, error) {
+	if !cursor.IsZero() || limit > firstPageSize {
+		res, err := c.dao.FindByAuthorId(ctx, id, cursorToEntity(cursor), limit)
+		if err != nil {
+			return nil, err
 		}
+		return c.entitiesToDomain(res), nil
 	}
 
-	res, err := c.dao.FindByAuthorId(ctx, id, offset, limit)
+	// 缓存方案
+	data, err := c.cache.GeFirstPage(ctx, id)
+	if err == nil {
+		return firstN(data, limit), nil
+	}
+	res, err := c.dao.FindByAuthorId(ctx, id, dao.Cursor{}, firstPageSize)
 	if err != nil {
 		return nil, err
 	}
-
-	data := slice.Map[dao.Article, domain.Article](res, func(idx int, src dao.Article) domain.Article {
-		return c.toDomain(src)
-	})
+	data = c.entitiesToDomain(res)
 	go func() {
-		err := c.cache.SetFirstPage(ctx, data)
+		err := c.cache.SetFirstPage(ctx, id, data)
 		if err != nil {
 			c.l.Error("缓存失败", logx.Any("err", err))
 		}
 	}()
-	return data, err
+	return firstN(data, limit), nil
+}
+
+func (c *CacheArticleRepository) entitiesToDomain(arts []dao.Article) []domain.Article {
+	return slice.Map[dao.Article, domain.Article](arts, func(idx int, src dao.Article) domain.Article {
+		return c.toDomain(src)
+	})
 }
+
+func firstN(arts []domain.Article, n int) []domain.Article {
+	if len(arts) > n {
+		return arts[:n]
+	}
+	return arts
+}
+
+func cursorToEntity(cursor domain.ArticleCursor) dao.Cursor {
+	if cursor.UpdatedAt.IsZero() {
+		return dao.Cursor{}
+	}
+	return dao.Cursor{UpdatedAt: cursor.UpdatedAt.UnixMilli(), Id: cursor.Id}
+}
+
+func (c *CacheArticleRepository) ListRevisions(ctx context.Context, artId int64, offset int, limit int) ([]domain.ArticleRevision, error) {
+	revs, err := c.dao.ListRevisions(ctx, artId, offset, limit)
+	if err != nil {
+		return nil, err
+	}
+	return slice.Map[dao.ArticleRevision, domain.ArticleRevision](revs, func(idx int, src dao.ArticleRevision) domain.ArticleRevision {
+		return c.revisionToDomain(src)
+	}), nil
+}
+
+func (c *CacheArticleRepository) GetRevision(ctx context.Context, artId int64, id int64) (domain.ArticleRevision, error) {
+	rev, err := c.dao.GetRevision(ctx, artId, id)
+	if err != nil {
+		return domain.ArticleRevision{}, err
+	}
+	return c.revisionToDomain(rev), nil
+}
+
+func (c *CacheArticleRepository) UpdateSchedule(ctx context.Context, article domain.Article) error {
+	defer func() {
+		c.cache.DelFirstPage(ctx, article.Author.Id)
+	}()
+	return c.dao.UpdateSchedule(ctx, dao.Article{
+		Id:          article.Id,
+		AuthorId:    article.Author.Id,
+		PublishAt:   toMilli(article.PublishAt),
+		UnpublishAt: toMilli(article.UnpublishAt),
+
+		ScheduleRetries: article.ScheduleRetries,
+	})
+}
+
+func (c *CacheArticleRepository) ListDueScheduled(ctx context.Context, now time.Time, limit int) ([]domain.Article, error) {
+	arts, err := c.dao.FindDueScheduled(ctx, now.UnixMilli(), limit)
+	if err != nil {
+		return nil, err
+	}
+	return slice.Map[dao.Article, domain.Article](arts, func(idx int, src dao.Article) domain.Article {
+		return c.toDomain(src)
+	}), nil
+}
+
+func (c *CacheArticleRepository) Delete(ctx context.Context, id int64, authorId int64) error {
+	defer func() {
+		c.cache.DelFirstPage(ctx, authorId)
+		c.cache.DelPub(ctx, id)
+	}()
+	return c.dao.SoftDelete(ctx, id, authorId)
+}
+
+func (c *CacheArticleRepository) Restore(ctx context.Context, id int64, authorId int64) error {
+	defer func() {
+		c.cache.DelFirstPage(ctx, authorId)
+		// 删除期间可能缓存了文章不存在
+		c.cache.DelPub(ctx, id)
+	}()
+	return c.dao.Restore(ctx, id, authorId)
+}
+
+func (c *CacheArticleRepository) ListTrash(ctx context.Context, authorId int64, offset int, limit int) ([]domain.Article, error) {
+	arts, err := c.dao.ListTrash(ctx, authorId, offset, limit)
+	if err != nil {
+		return nil, err
+	}
+	return slice.Map[dao.Article, domain.Article](arts, func(idx int, src dao.Article) domain.Article {
+		return c.toDomain(src)
+	}), nil
+}
+
+func (c *CacheArticleRepository) ListByStatus(ctx context.Context, status domain.ArticleStatus, offset int, limit int) ([]domain.Article, error) {
+	arts, err := c.dao.FindByStatus(ctx, status.ToUint8(), offset, limit)
+	if err != nil {
+		return nil, err
+	}
+	return slice.Map[dao.Article, domain.Article](arts, func(idx int, src dao.Article) domain.Article {
+		return c.toDomain(src)
+	}), nil
+}
+
+func (c *CacheArticleRepository) UpdateReview(ctx context.Context, article domain.Article, from domain.ArticleStatus) error {
+	defer func() {
+		c.cache.DelFirstPage(ctx, article.Author.Id)
+	}()
+	return c.dao.UpdateReview(ctx, article.Id, from.ToUint8(), article.Status.ToUint8(), article.ReviewReason)
+}
+
+func (c *CacheArticleRepository) PurgeDeleted(ctx context.Context, before time.Time, limit int) (int, error) {
+	return c.dao.PurgeDeleted(ctx, before.UnixMilli(), limit)
+}
+
 func (c *CacheArticleRepository) preCache(ctx context.Context, articles []domain.Article) {
 	const contentSizeThreshold = 1024 * 1024
 	if len(articles) > 0 && len(articles[0].Content) <= contentSizeThreshold {
@@ -184,6 +458,25 @@ func (c *CacheArticleRepository) toEntity(article domain.Article) dao.Article {
 		Content:  article.Content,
 		Title:    article.Title,
 		Status:   article.Status.ToUint8(),
+		Tags:     article.Tags,
+		Version:  article.Version,
+		EditorId: article.EditorId,
+
+		ReviewReason: article.ReviewReason,
+	}
+}
+
+// toPubEntity 发表的时候带上渲染结果，只有线上库有
+func (c *CacheArticleRepository) toPubEntity(article domain.Article) dao.PublishedArticle {
+	return dao.PublishedArticle{
+		Article: c.toEntity(article),
+		Rendered: dao.Rendered{
+			ContentHTML: article.Rendered.HTML,
+			Toc:         tocToEntity(article.Rendered.Toc),
+			WordCount:   article.Rendered.WordCount,
+			ReadingTime: article.Rendered.ReadingTime,
+			Abstract:    article.Rendered.Abstract,
+		},
 	}
 }
 func (c *CacheArticleRepository) toDomain(article dao.Article) domain.Article {
@@ -193,7 +486,75 @@ func (c *CacheArticleRepository) toDomain(article dao.Article) domain.Article {
 		Content:   article.Content,
 		Title:     article.Title,
 		Status:    domain.ArticleStatus(article.Status),
+		Tags:      article.Tags,
+		Version:   article.Version,
 		CreatedAt: time.UnixMilli(article.CreatedAt),
 		UpdatedAt: time.UnixMilli(article.UpdatedAt),
+
+		PublishAt:   fromMilli(article.PublishAt),
+		UnpublishAt: fromMilli(article.UnpublishAt),
+		DeletedAt:   fromMilli(article.DeletedAt),
+
+		ScheduleRetries: article.ScheduleRetries,
+
+		ReviewReason: article.ReviewReason,
+	}
+}
+
+func (c *CacheArticleRepository) pubToDomainOne(article dao.PublishedArticle) domain.Article {
+	res := c.toDomain(article.Article)
+	res.Rendered = domain.RenderedContent{
+		HTML:        article.ContentHTML,
+		Toc:         tocToDomain(article.Toc),
+		WordCount:   article.WordCount,
+		ReadingTime: article.ReadingTime,
+		Abstract:    article.Abstract,
+	}
+	return res
+}
+
+// tocToEntity 没有目录的时候保持 nil
+func tocToEntity(toc []domain.TocItem) dao.Toc {
+	if len(toc) == 0 {
+		return nil
+	}
+	return slice.Map[domain.TocItem, dao.TocItem](toc, func(idx int, src domain.TocItem) dao.TocItem {
+		return dao.TocItem{Level: src.Level, Anchor: src.Anchor, Text: src.Text}
+	})
+}
+
+func tocToDomain(toc dao.Toc) []domain.TocItem {
+	if len(toc) == 0 {
+		return nil
+	}
+	return slice.Map[dao.TocItem, domain.TocItem](toc, func(idx int, src dao.TocItem) domain.TocItem {
+		return domain.TocItem{Level: src.Level, Anchor: src.Anchor, Text: src.Text}
+	})
+}
+
+// toMilli 零值时间存成 0
+func toMilli(t time.Time) int64 {
+	if t.IsZero() {
+		return 0
+	}
+	return t.UnixMilli()
+}
+
+func fromMilli(ms int64) time.Time {
+	if ms == 0 {
+		return time.Time{}
+	}
+	return time.UnixMilli(ms)
+}
+
+func (c *CacheArticleRepository) revisionToDomain(rev dao.ArticleRevision) domain.ArticleRevision {
+	return domain.ArticleRevision{
+		Id:        rev.Id,
+		ArticleId: rev.ArticleId,
+		AuthorId:  rev.AuthorId,
+		Title:     rev.Title,
+		Content:   rev.Content,
+		Status:    domain.ArticleStatus(rev.Status),
+		CreatedAt: time.UnixMilli(rev.CreatedAt),
 	}
 }
@@ -0,0 +1,258 @@
+package article
+
+import (
+	"context"
+	"errors"
+	"github.com/Andras5014/gohub/internal/domain"
+	"github.com/Andras5014/gohub/internal/repository"
+	"github.com/Andras5014/gohub/internal/repository/cache"
+	cachemocks "github.com/Andras5014/gohub/internal/repository/cache/mocks"
+	dao "github.com/Andras5014/gohub/internal/repository/dao/article"
+	artdaomocks "github.com/Andras5014/gohub/internal/repository/dao/article/mocks"
+	repomocks "github.com/Andras5014/gohub/internal/repository/mocks"
+	"github.com/Andras5014/gohub/pkg/logx"
+	"github.com/stretchr/testify/assert"
+	"go.uber.org/mock/gomock"
+	"go.uber.org/zap"
+	"sync"
+	"testing"
+	"time"
+)
+
+func TestCacheArticleRepository_GetPubById(t *testing.T) {
+	utime := time.UnixMilli(1700000000000)
+	wantArt := domain.Article{
+		Id:        1,
+		Title:     "标题",
+		Content:   "内容",
+		Author:    domain.Author{Id: 123, Name: "andras"},
+		Status:    domain.ArticleStatusPublished,
+		CreatedAt: utime,
+		UpdatedAt: utime,
+	}
+	testCases := []struct {
+		name string
+		mock func(ctrl *gomock.Controller) (dao.ArticleDAO, cache.ArticleCache, repository.UserRepository)
+
+		wantArt domain.Article
+		wantErr error
+	}{
+		{
+			name: "命中缓存",
+			mock: func(ctrl *gomock.Controller) (dao.ArticleDAO, cache.ArticleCache, repository.UserRepository) {
+				c := cachemocks.NewMockArticleCache(ctrl)
+				c.EXPECT().GetPub(gomock.Any(), int64(1)).Return(wantArt, nil)
+				return artdaomocks.NewMockArticleDAO(ctrl), c, repomocks.NewMockUserRepository(ctrl)
+			},
+			wantArt: wantArt,
+		},
+		{
+			name: "缓存里记着不存在",
+			mock: func(ctrl *gomock.Controller) (dao.ArticleDAO, cache.ArticleCache, repository.UserRepository) {
+				c := cachemocks.NewMockArticleCache(ctrl)
+				c.EXPECT().GetPub(gomock.Any(), int64(1)).Return(domain.Article{}, cache.ErrPubNotFound)
+				return artdaomocks.NewMockArticleDAO(ctrl), c, repomocks.NewMockUserRepository(ctrl)
+			},
+			wantErr: ErrArticleNotFound,
+		},
+		{
+			name: "缓存未命中，回源之后写回缓存",
+			mock: func(ctrl *gomock.Controller) (dao.ArticleDAO, cache.ArticleCache, repository.UserRepository) {
+				c := cachemocks.NewMockArticleCache(ctrl)
+				c.EXPECT().GetPub(gomock.Any(), int64(1)).Return(domain.Article{}, cache.ErrKeyNotExist)
+				c.EXPECT().SetPub(gomock.Any(), wantArt).Return(nil)
+				d := artdaomocks.NewMockArticleDAO(ctrl)
+				d.EXPECT().GetPubById(gomock.Any(), int64(1)).Return(dao.PublishedArticle{Article: dao.Article{
+					Id:        1,
+					Title:     "标题",
+					Content:   "内容",
+					AuthorId:  123,
+					Status:    domain.ArticleStatusPublished.ToUint8(),
+					CreatedAt: utime.UnixMilli(),
+					UpdatedAt: utime.UnixMilli(),
+				}}, nil)
+				u := repomocks.NewMockUserRepository(ctrl)
+				u.EXPECT().FindById(gomock.Any(), int64(123)).
+					Return(domain.User{Id: 123, NickName: "andras"}, nil)
+				return d, c, u
+			},
+			wantArt: wantArt,
+		},
+		{
+			name: "文章不存在，缓存下来",
+			mock: func(ctrl *gomock.Controller) (dao.ArticleDAO, cache.ArticleCache, repository.UserRepository) {
+				c := cachemocks.NewMockArticleCache(ctrl)
+				c.EXPECT().GetPub(gomock.Any(), int64(1)).Return(domain.Article{}, cache.ErrKeyNotExist)
+				c.EXPECT().SetPubNotFound(gomock.Any(), int64(1)).Return(nil)
+				d := artdaomocks.NewMockArticleDAO(ctrl)
+				d.EXPECT().GetPubById(gomock.Any(), int64(1)).
+					Return(dao.PublishedArticle{}, dao.ErrArticleNotFound)
+				return d, c, repomocks.NewMockUserRepository(ctrl)
+			},
+			wantErr: ErrArticleNotFound,
+		},
+		{
+			name: "数据库出错不缓存",
+			mock: func(ctrl *gomock.Controller) (dao.ArticleDAO, cache.ArticleCache, repository.UserRepository) {
+				c := cachemocks.NewMockArticleCache(ctrl)
+				c.EXPECT().GetPub(gomock.Any(), int64(1)).Return(domain.Article{}, cache.ErrKeyNotExist)
+				d := artdaomocks.NewMockArticleDAO(ctrl)
+				d.EXPECT().GetPubById(gomock.Any(), int64(1)).
+					Return(dao.PublishedArticle{}, errors.New("mock db error"))
+				return d, c, repomocks.NewMockUserRepository(ctrl)
+			},
+			wantErr: errors.New("mock db error"),
+		},
+	}
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			ctrl := gomock.NewController(t)
+			defer ctrl.Finish()
+			d, c, u := tc.mock(ctrl)
+			repo := NewArticleRepository(d, c, u, logx.NewZapLogger(zap.NewNop()))
+			art, err := repo.GetPubById(context.Background(), 1)
+			assert.Equal(t, tc.wantErr, err)
+			assert.Equal(t, tc.wantArt, art)
+		})
+	}
+}
+
+func TestCacheArticleRepository_GetPubById_Coalesce(t *testing.T) {
+	const n = 10
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+	c := cachemocks.NewMockArticleCache(ctrl)
+	c.EXPECT().GetPub(gomock.Any(), int64(1)).
+		Return(domain.Article{}, cache.ErrKeyNotExist).Times(n)
+	c.EXPECT().SetPub(gomock.Any(), gomock.Any()).Return(nil)
+	d := artdaomocks.NewMockArticleDAO(ctrl)
+	// 回源慢一点，让其它请求都等在 singleflight 上面，只能有一次回源
+	d.EXPECT().GetPubById(gomock.Any(), int64(1)).
+		DoAndReturn(func(ctx context.Context, id int64) (dao.PublishedArticle, error) {
+			time.Sleep(time.Millisecond * 100)
+			return dao.PublishedArticle{Article: dao.Article{Id: 1, AuthorId: 123}}, nil
+		})
+	u := repomocks.NewMockUserRepository(ctrl)
+	u.EXPECT().FindById(gomock.Any(), int64(123)).Return(domain.User{Id: 123}, nil)
+	repo := NewArticleRepository(d, c, u, logx.NewZapLogger(zap.NewNop()))
+
+	var wg sync.WaitGroup
+	for i := 0; i < n; i++ {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			art, err := repo.GetPubById(context.Background(), 1)
+			assert.NoError(t, err)
+			assert.Equal(t, int64(1), art.Id)
+		}()
+	}
+	wg.Wait()
+}
+
+func TestCacheArticleRepository_Update(t *testing.T) {
+	art := domain.Article{
+		Id:       1,
+		Title:    "标题",
+		Content:  "内容",
+		Author:   domain.Author{Id: 123},
+		Status:   domain.ArticleStatusUnPublished,
+		Version:  2,
+		EditorId: 456,
+	}
+	testCases := []struct {
+		name string
+		mock func(ctrl *gomock.Controller) (dao.ArticleDAO, cache.ArticleCache)
+
+		wantErr error
+	}{
+		{
+			name: "保存之后删掉列表第一页的缓存",
+			mock: func(ctrl *gomock.Controller) (dao.ArticleDAO, cache.ArticleCache) {
+				d := artdaomocks.NewMockArticleDAO(ctrl)
+				// 协作者保存的时候作者不变，历史版本记的是协作者
+				d.EXPECT().UpdateById(gomock.Any(), dao.Article{
+					Id:       1,
+					AuthorId: 123,
+					Title:    "标题",
+					Content:  "内容",
+					Status:   domain.ArticleStatusUnPublished.ToUint8(),
+					Version:  2,
+					EditorId: 456,
+				}).Return(nil)
+				c := cachemocks.NewMockArticleCache(ctrl)
+				c.EXPECT().DelFirstPage(gomock.Any(), int64(123)).Return(nil)
+				return d, c
+			},
+		},
+		{
+			name: "删缓存失败不影响保存",
+			mock: func(ctrl *gomock.Controller) (dao.ArticleDAO, cache.ArticleCache) {
+				d := artdaomocks.NewMockArticleDAO(ctrl)
+				d.EXPECT().UpdateById(gomock.Any(), gomock.Any()).Return(nil)
+				c := cachemocks.NewMockArticleCache(ctrl)
+				c.EXPECT().DelFirstPage(gomock.Any(), int64(123)).Return(errors.New("mock redis error"))
+				return d, c
+			},
+		},
+		{
+			name: "保存失败不删缓存",
+			mock: func(ctrl *gomock.Controller) (dao.ArticleDAO, cache.ArticleCache) {
+				d := artdaomocks.NewMockArticleDAO(ctrl)
+				d.EXPECT().UpdateById(gomock.Any(), gomock.Any()).Return(dao.ErrVersionConflict)
+				return d, cachemocks.NewMockArticleCache(ctrl)
+			},
+			wantErr: dao.ErrVersionConflict,
+		},
+	}
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			ctrl := gomock.NewController(t)
+			defer ctrl.Finish()
+			d, c := tc.mock(ctrl)
+			repo := NewArticleRepository(d, c, repomocks.NewMockUserRepository(ctrl), logx.NewZapLogger(zap.NewNop()))
+			err := repo.Update(context.Background(), art)
+			assert.Equal(t, tc.wantErr, err)
+		})
+	}
+}
+
+// 发表和撤回之后线上库和列表第一页的缓存都要删掉
+func TestCacheArticleRepository_Sync(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+	d := artdaomocks.NewMockArticleDAO(ctrl)
+	d.EXPECT().Sync(gomock.Any(), gomock.Any()).Return(int64(1), nil)
+	d.EXPECT().SyncStatus(gomock.Any(), gomock.Any()).Return(int64(1), nil)
+	c := cachemocks.NewMockArticleCache(ctrl)
+	c.EXPECT().DelPub(gomock.Any(), int64(1)).Return(nil).Times(2)
+	c.EXPECT().DelFirstPage(gomock.Any(), int64(123)).Return(nil).Times(2)
+	repo := NewArticleRepository(d, c, repomocks.NewMockUserRepository(ctrl), logx.NewZapLogger(zap.NewNop()))
+
+	art := domain.Article{Id: 1, Author: domain.Author{Id: 123}, Status: domain.ArticleStatusPublished}
+	_, err := repo.Sync(context.Background(), art)
+	assert.NoError(t, err)
+	art.Status = domain.ArticleStatusPrivate
+	_, err = repo.SyncStatus(context.Background(), art)
+	assert.NoError(t, err)
+}
+
+// 按传进来的顺序返回，没有发表的跳过
+func TestCacheArticleRepository_ListPubByIds(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+	d := artdaomocks.NewMockArticleDAO(ctrl)
+	d.EXPECT().ListPubByIds(gomock.Any(), []int64{3, 1, 2}).
+		Return([]dao.PublishedArticle{
+			{Article: dao.Article{Id: 1, Title: "标题1", Status: domain.ArticleStatusPublished.ToUint8()}},
+			{Article: dao.Article{Id: 3, Title: "标题3", Status: domain.ArticleStatusPublished.ToUint8()}},
+		}, nil)
+	repo := NewArticleRepository(d, cachemocks.NewMockArticleCache(ctrl),
+		repomocks.NewMockUserRepository(ctrl), logx.NewZapLogger(zap.NewNop()))
+	arts, err := repo.ListPubByIds(context.Background(), []int64{3, 1, 2})
+	assert.NoError(t, err)
+	ids := make([]int64, 0, len(arts))
+	for _, art := range arts {
+		ids = append(ids, art.Id)
+	}
+	assert.Equal(t, []int64{3, 1}, ids)
+}
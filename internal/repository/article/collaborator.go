@@ -0,0 +1,98 @@
+package article
+
+import (
+	"context"
+	"errors"
+	"github.com/Andras5014/gohub/internal/domain"
+	dao "github.com/Andras5014/gohub/internal/repository/dao/article"
+	"github.com/ecodeclub/ekit/slice"
+	"time"
+)
+
+var ErrCollaboratorNotFound = dao.ErrCollaboratorNotFound
+
+type CollaboratorRepository interface {
+	// Invite 发出邀请，已经是协作者的只修改角色
+	Invite(ctx context.Context, c domain.ArticleCollaborator) error
+	// Accept 只能接受待处理的邀请
+	Accept(ctx context.Context, artId, uid int64) error
+	// Remove 拒绝邀请或者移除协作者
+	Remove(ctx context.Context, artId, uid int64) error
+	// Role 已经接受邀请的协作者的角色，其它情况都是 ArticleRoleUnknown
+	Role(ctx context.Context, artId, uid int64) (domain.ArticleRole, error)
+	ListByArticle(ctx context.Context, artId int64) ([]domain.ArticleCollaborator, error)
+	ListByUid(ctx context.Context, uid int64, status domain.CollaboratorStatus, offset int, limit int) ([]domain.ArticleCollaborator, error)
+}
+
+type collaboratorRepository struct {
+	dao dao.CollaboratorDAO
+}
+
+func NewCollaboratorRepository(dao dao.CollaboratorDAO) CollaboratorRepository {
+	return &collaboratorRepository{dao: dao}
+}
+
+func (c *collaboratorRepository) Invite(ctx context.Context, collab domain.ArticleCollaborator) error {
+	return c.dao.Upsert(ctx, dao.ArticleCollaborator{
+		ArticleId: collab.ArticleId,
+		Uid:       collab.Uid,
+		Role:      collab.Role.ToUint8(),
+		Status:    domain.CollaboratorStatusPending.ToUint8(),
+		InviterId: collab.InviterId,
+	})
+}
+
+func (c *collaboratorRepository) Accept(ctx context.Context, artId, uid int64) error {
+	return c.dao.UpdateStatus(ctx, artId, uid,
+		domain.CollaboratorStatusPending.ToUint8(), domain.CollaboratorStatusAccepted.ToUint8())
+}
+
+func (c *collaboratorRepository) Remove(ctx context.Context, artId, uid int64) error {
+	return c.dao.Delete(ctx, artId, uid)
+}
+
+func (c *collaboratorRepository) Role(ctx context.Context, artId, uid int64) (domain.ArticleRole, error) {
+	collab, err := c.dao.Get(ctx, artId, uid)
+	if errors.Is(err, dao.ErrCollaboratorNotFound) {
+		return domain.ArticleRoleUnknown, nil
+	}
+	if err != nil {
+		return domain.ArticleRoleUnknown, err
+	}
+	if collab.Status != domain.CollaboratorStatusAccepted.ToUint8() {
+		return domain.ArticleRoleUnknown, nil
+	}
+	return domain.ArticleRole(collab.Role), nil
+}
+
+func (c *collaboratorRepository) ListByArticle(ctx context.Context, artId int64) ([]domain.ArticleCollaborator, error) {
+	res, err := c.dao.ListByArticle(ctx, artId)
+	if err != nil {
+		return nil, err
+	}
+	return c.toDomains(res), nil
+}
+
+func (c *collaboratorRepository) ListByUid(ctx context.Context, uid int64, status domain.CollaboratorStatus,
+	offset int, limit int) ([]domain.ArticleCollaborator, error) {
+	res, err := c.dao.ListByUid(ctx, uid, status.ToUint8(), offset, limit)
+	if err != nil {
+		return nil, err
+	}
+	return c.toDomains(res), nil
+}
+
+func (c *collaboratorRepository) toDomains(collabs []dao.ArticleCollaborator) []domain.ArticleCollaborator {
+	return slice.Map[dao.ArticleCollaborator, domain.ArticleCollaborator](collabs,
+		func(idx int, src dao.ArticleCollaborator) domain.ArticleCollaborator {
+			return domain.ArticleCollaborator{
+				ArticleId: src.ArticleId,
+				Uid:       src.Uid,
+				Role:      domain.ArticleRole(src.Role),
+				Status:    domain.CollaboratorStatus(src.Status),
+				InviterId: src.InviterId,
+				CreatedAt: time.UnixMilli(src.CreatedAt),
+				UpdatedAt: time.UnixMilli(src.UpdatedAt),
+			}
+		})
+}
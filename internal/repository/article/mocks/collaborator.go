@@ -0,0 +1,128 @@
+// Code generated by MockGen. DO NOT EDIT.
+// Source: ./internal/repository/article/collaborator.go
+//
+// Generated by this command:
+//
+//	mockgen -source=./internal/repository/article/collaborator.go -destination=./internal/repository/article/mocks/collaborator.go -package=artrepomocks
+//
+
+// Package artrepomocks is a generated GoMock package.
+package artrepomocks
+
+import (
+	context "context"
+	reflect "reflect"
+
+	domain "github.com/Andras5014/gohub/internal/domain"
+	gomock "go.uber.org/mock/gomock"
+)
+
+// MockCollaboratorRepository is a mock of CollaboratorRepository interface.
+type MockCollaboratorRepository struct {
+	ctrl     *gomock.Controller
+	recorder *MockCollaboratorRepositoryMockRecorder
+}
+
+// MockCollaboratorRepositoryMockRecorder is the mock recorder for MockCollaboratorRepository.
+type MockCollaboratorRepositoryMockRecorder struct {
+	mock *MockCollaboratorRepository
+}
+
+// NewMockCollaboratorRepository creates a new mock instance.
+func NewMockCollaboratorRepository(ctrl *gomock.Controller) *MockCollaboratorRepository {
+	mock := &MockCollaboratorRepository{ctrl: ctrl}
+	mock.recorder = &MockCollaboratorRepositoryMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockCollaboratorRepository) EXPECT() *MockCollaboratorRepositoryMockRecorder {
+	return m.recorder
+}
+
+// Accept mocks base method.
+func (m *MockCollaboratorRepository) Accept(ctx context.Context, artId, uid int64) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Accept", ctx, artId, uid)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// Accept indicates an expected call of Accept.
+func (mr *MockCollaboratorRepositoryMockRecorder) Accept(ctx, artId, uid any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Accept", reflect.TypeOf((*MockCollaboratorRepository)(nil).Accept), ctx, artId, uid)
+}
+
+// Invite mocks base method.
+func (m *MockCollaboratorRepository) Invite(ctx context.Context, c domain.ArticleCollaborator) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Invite", ctx, c)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// Invite indicates an expected call of Invite.
+func (mr *MockCollaboratorRepositoryMockRecorder) Invite(ctx, c any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Invite", reflect.TypeOf((*MockCollaboratorRepository)(nil).Invite), ctx, c)
+}
+
+// ListByArticle mocks base method.
+func (m *MockCollaboratorRepository) ListByArticle(ctx context.Context, artId int64) ([]domain.ArticleCollaborator, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "ListByArticle", ctx, artId)
+	ret0, _ := ret[0].([]domain.ArticleCollaborator)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// ListByArticle indicates an expected call of ListByArticle.
+func (mr *MockCollaboratorRepositoryMockRecorder) ListByArticle(ctx, artId any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ListByArticle", reflect.TypeOf((*MockCollaboratorRepository)(nil).ListByArticle), ctx, artId)
+}
+
+// ListByUid mocks base method.
+func (m *MockCollaboratorRepository) ListByUid(ctx context.Context, uid int64, status domain.CollaboratorStatus, offset, limit int) ([]domain.ArticleCollaborator, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "ListByUid", ctx, uid, status, offset, limit)
+	ret0, _ := ret[0].([]domain.ArticleCollaborator)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// ListByUid indicates an expected call of ListByUid.
+func (mr *MockCollaboratorRepositoryMockRecorder) ListByUid(ctx, uid, status, offset, limit any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ListByUid", reflect.TypeOf((*MockCollaboratorRepository)(nil).ListByUid), ctx, uid, status, offset, limit)
+}
+
+// Remove mocks base method.
+func (m *MockCollaboratorRepository) Remove(ctx context.Context, artId, uid int64) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Remove", ctx, artId, uid)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// Remove indicates an expected call of Remove.
+func (mr *MockCollaboratorRepositoryMockRecorder) Remove(ctx, artId, uid any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Remove", reflect.TypeOf((*MockCollaboratorRepository)(nil).Remove), ctx, artId, uid)
+}
+
+// Role mocks base method.
+func (m *MockCollaboratorRepository) Role(ctx context.Context, artId, uid int64) (domain.ArticleRole, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Role", ctx, artId, uid)
+	ret0, _ := ret[0].(domain.ArticleRole)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// Role indicates an expected call of Role.
+func (mr *MockCollaboratorRepositoryMockRecorder) Role(ctx, artId, uid any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Role", reflect.TypeOf((*MockCollaboratorRepository)(nil).Role), ctx, artId, uid)
+}
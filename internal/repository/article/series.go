@@ -0,0 +1,114 @@
+package article
+
+import (
+	"context"
+	"errors"
+	"github.com/Andras5014/gohub/internal/domain"
+	dao "github.com/Andras5014/gohub/internal/repository/dao/article"
+	"github.com/ecodeclub/ekit/slice"
+	"time"
+)
+
+var (
+	ErrSeriesNotFound      = dao.ErrSeriesNotFound
+	ErrArticleInSeries     = dao.ErrArticleInSeries
+	ErrSeriesOrderMismatch = dao.ErrSeriesOrderMismatch
+)
+
+type SeriesRepository interface {
+	Create(ctx context.Context, s domain.Series) (int64, error)
+	Update(ctx context.Context, s domain.Series) error
+	Delete(ctx context.Context, id int64, authorId int64) error
+	// GetById 不带文章
+	GetById(ctx context.Context, id int64) (domain.Series, error)
+	ListByAuthor(ctx context.Context, authorId int64, offset int, limit int) ([]domain.Series, error)
+
+	AddArticle(ctx context.Context, seriesId int64, artId int64) error
+	RemoveArticle(ctx context.Context, seriesId int64, artId int64) error
+	Reorder(ctx context.Context, seriesId int64, artIds []int64) error
+	ArticleIds(ctx context.Context, seriesId int64) ([]int64, error)
+	// SeriesIdOf 文章所在的系列，不在任何系列里面的时候返回 0
+	SeriesIdOf(ctx context.Context, artId int64) (int64, error)
+}
+
+type seriesRepository struct {
+	dao dao.SeriesDAO
+}
+
+func NewSeriesRepository(dao dao.SeriesDAO) SeriesRepository {
+	return &seriesRepository{dao: dao}
+}
+
+func (s *seriesRepository) Create(ctx context.Context, series domain.Series) (int64, error) {
+	return s.dao.Insert(ctx, s.toEntity(series))
+}
+
+func (s *seriesRepository) Update(ctx context.Context, series domain.Series) error {
+	return s.dao.Update(ctx, s.toEntity(series))
+}
+
+func (s *seriesRepository) Delete(ctx context.Context, id int64, authorId int64) error {
+	return s.dao.Delete(ctx, id, authorId)
+}
+
+func (s *seriesRepository) GetById(ctx context.Context, id int64) (domain.Series, error) {
+	series, err := s.dao.GetById(ctx, id)
+	if err != nil {
+		return domain.Series{}, err
+	}
+	return s.toDomain(series), nil
+}
+
+func (s *seriesRepository) ListByAuthor(ctx context.Context, authorId int64, offset int, limit int) ([]domain.Series, error) {
+	res, err := s.dao.ListByAuthor(ctx, authorId, offset, limit)
+	if err != nil {
+		return nil, err
+	}
+	return slice.Map[dao.Series, domain.Series](res, func(idx int, src dao.Series) domain.Series {
+		return s.toDomain(src)
+	}), nil
+}
+
+func (s *seriesRepository) AddArticle(ctx context.Context, seriesId int64, artId int64) error {
+	return s.dao.AddArticle(ctx, seriesId, artId)
+}
+
+func (s *seriesRepository) RemoveArticle(ctx context.Context, seriesId int64, artId int64) error {
+	return s.dao.RemoveArticle(ctx, seriesId, artId)
+}
+
+func (s *seriesRepository) Reorder(ctx context.Context, seriesId int64, artIds []int64) error {
+	return s.dao.Reorder(ctx, seriesId, artIds)
+}
+
+func (s *seriesRepository) ArticleIds(ctx context.Context, seriesId int64) ([]int64, error) {
+	return s.dao.ArticleIds(ctx, seriesId)
+}
+
+func (s *seriesRepository) SeriesIdOf(ctx context.Context, artId int64) (int64, error) {
+	sa, err := s.dao.FindByArticle(ctx, artId)
+	if errors.Is(err, dao.ErrSeriesNotFound) {
+		return 0, nil
+	}
+	return sa.SeriesId, err
+}
+
+func (s *seriesRepository) toEntity(series domain.Series) dao.Series {
+	return dao.Series{
+		Id:          series.Id,
+		AuthorId:    series.AuthorId,
+		Title:       series.Title,
+		Description: series.Description,
+	}
+}
+
+func (s *seriesRepository) toDomain(series dao.Series) domain.Series {
+	return domain.Series{
+		Id:          series.Id,
+		AuthorId:    series.AuthorId,
+		Title:       series.Title,
+		Description: series.Description,
+		CreatedAt:   time.UnixMilli(series.CreatedAt),
+		UpdatedAt:   time.UnixMilli(series.UpdatedAt),
+	}
+}
@@ -3,25 +3,39 @@ package cache
 import (
 	"context"
 	"encoding/json"
+	"errors"
 	"fmt"
 	"github.com/Andras5014/gohub/internal/domain"
 	"github.com/redis/go-redis/v9"
+	"math/rand"
 	"time"
 )
 
 type ArticleCache interface {
 	GeFirstPage(ctx context.Context, id int64) ([]domain.Article, error)
-	SetFirstPage(ctx context.Context, articles []domain.Article) error
+	SetFirstPage(ctx context.Context, authorId int64, articles []domain.Article) error
 	DelFirstPage(ctx context.Context, id int64) error
 
 	Set(ctx context.Context, article domain.Article) error
 	Get(ctx context.Context, id int64) (domain.Article, error)
 
 	SetPub(ctx context.Context, article domain.Article) error
+	// SetPubNotFound 记下文章不存在，防止不存在的 id 一直打到数据库
+	SetPubNotFound(ctx context.Context, id int64) error
 	DelPub(ctx context.Context, id int64) error
+	// GetPub 缓存里面记的是不存在的时候返回 ErrPubNotFound
 	GetPub(ctx context.Context, id int64) (domain.Article, error)
 }
 
+var ErrPubNotFound = errors.New("缓存里记录文章不存在")
+
+const (
+	pubExpiration         = time.Minute * 30
+	pubNotFoundExpiration = time.Minute
+	// pubNotFoundPlaceholder 正常的数据都是 JSON，不会和它冲突
+	pubNotFoundPlaceholder = "-"
+)
+
 type RedisArticleCache struct {
 	client redis.Cmdable
 }
@@ -40,15 +54,21 @@ func (r *RedisArticleCache) GeFirstPage(ctx context.Context, id int64) ([]domain
 	return articles, json.Unmarshal(data, &articles)
 }
 
-func (r *RedisArticleCache) SetFirstPage(ctx context.Context, articles []domain.Article) error {
-	for i := 0; i < len(articles); i++ {
-		articles[i].Content = articles[i].Abstract()
+func (r *RedisArticleCache) SetFirstPage(ctx context.Context, authorId int64, articles []domain.Article) error {
+	if len(articles) == 0 {
+		return nil
 	}
-	data, err := json.Marshal(articles)
+	// 拷贝一份，调用方还在用原来的数据
+	page := make([]domain.Article, len(articles))
+	for i, art := range articles {
+		art.Content = art.Abstract()
+		page[i] = art
+	}
+	data, err := json.Marshal(page)
 	if err != nil {
 		return err
 	}
-	return r.client.Set(ctx, r.firstPageKey(articles[0].Id), data, time.Minute*10).Err()
+	return r.client.Set(ctx, r.firstPageKey(authorId), data, time.Minute*10).Err()
 }
 
 func (r *RedisArticleCache) DelFirstPage(ctx context.Context, id int64) error {
@@ -77,7 +97,11 @@ func (r *RedisArticleCache) SetPub(ctx context.Context, article domain.Article)
 	if err != nil {
 		return err
 	}
-	return r.client.Set(ctx, r.readerArtKey(article.Id), data, time.Minute*30).Err()
+	return r.client.Set(ctx, r.readerArtKey(article.Id), data, jitter(pubExpiration)).Err()
+}
+
+func (r *RedisArticleCache) SetPubNotFound(ctx context.Context, id int64) error {
+	return r.client.Set(ctx, r.readerArtKey(id), pubNotFoundPlaceholder, jitter(pubNotFoundExpiration)).Err()
 }
 
 func (r *RedisArticleCache) DelPub(ctx context.Context, id int64) error {
@@ -89,11 +113,19 @@ func (r *RedisArticleCache) GetPub(ctx context.Context, id int64) (domain.Articl
 	if err != nil {
 		return domain.Article{}, err
 	}
+	if string(data) == pubNotFoundPlaceholder {
+		return domain.Article{}, ErrPubNotFound
+	}
 	var article domain.Article
 	err = json.Unmarshal(data, &article)
 	return article, err
 }
 
+// jitter 过期时间加上最多 10% 的随机值，避免一批同时写进去的 key 同时过期
+func jitter(expiration time.Duration) time.Duration {
+	return expiration + time.Duration(rand.Int63n(int64(expiration/10)+1))
+}
+
 // 创作端的缓存设置
 func (r *RedisArticleCache) authorArtKey(id int64) string {
 	return fmt.Sprintf("article:author:%d", id)
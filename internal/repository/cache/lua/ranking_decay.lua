@@ -0,0 +1,34 @@
+-- 热榜分数按照半衰期衰减，衰减的系数由上一次衰减到现在的时间决定
+-- 多个实例重复执行也不会多衰减
+local key = KEYS[1]
+local decayedAtKey = KEYS[2]
+local now = tonumber(ARGV[1])
+-- 半衰期，毫秒，0 表示不衰减
+local halfLife = tonumber(ARGV[2])
+-- 最多保留多少个
+local capacity = tonumber(ARGV[3])
+-- 分数低于这个值直接删掉
+local minScore = tonumber(ARGV[4])
+
+if halfLife > 0 then
+    local last = tonumber(redis.call("get", decayedAtKey))
+    if last == nil then
+        -- 第一次执行，只记录时间
+        redis.call("set", decayedAtKey, now)
+    elseif now > last then
+        redis.call("set", decayedAtKey, now)
+        local factor = 0.5 ^ ((now - last) / halfLife)
+        local members = redis.call("zrange", key, 0, -1, "withscores")
+        for i = 1, #members, 2 do
+            local score = tonumber(members[i + 1]) * factor
+            if score < minScore then
+                redis.call("zrem", key, members[i])
+            else
+                redis.call("zadd", key, score, members[i])
+            end
+        end
+    end
+end
+-- 只留分数最高的一批
+redis.call("zremrangebyrank", key, 0, -(capacity + 1))
+return 0
@@ -0,0 +1,23 @@
+-- 进度变了才写，写了返回 1，调用方再把它标记成待落库
+local key = KEYS[1]
+local field = ARGV[1]
+-- 段落:百分比
+local pos = ARGV[2]
+local utime = tonumber(ARGV[3])
+local ttl = tonumber(ARGV[4])
+
+local changed = 1
+local old = redis.call("hget", key, field)
+if old then
+    local oldPos, oldUtime = string.match(old, "^(%d+:%d+):(%d+)$")
+    -- 多个实例的上报可能乱序，旧的不能覆盖新的
+    if oldPos == pos or (oldUtime ~= nil and tonumber(oldUtime) > utime) then
+        changed = 0
+    end
+end
+if changed == 1 then
+    redis.call("hset", key, field, pos .. ":" .. ARGV[3])
+end
+-- 没变也续期，一直在读的不会过期
+redis.call("expire", key, ttl)
+return changed
@@ -0,0 +1,117 @@
+// Code generated by MockGen. DO NOT EDIT.
+// Source: ./internal/repository/cache/ranking_score.go
+//
+// Generated by this command:
+//
+//	mockgen -source=./internal/repository/cache/ranking_score.go -destination=./internal/repository/cache/mocks/ranking_score.go -package=cachemocks
+//
+
+// Package cachemocks is a generated GoMock package.
+package cachemocks
+
+import (
+	context "context"
+	reflect "reflect"
+	time "time"
+
+	gomock "go.uber.org/mock/gomock"
+)
+
+// MockRankingScoreCache is a mock of RankingScoreCache interface.
+type MockRankingScoreCache struct {
+	ctrl     *gomock.Controller
+	recorder *MockRankingScoreCacheMockRecorder
+}
+
+// MockRankingScoreCacheMockRecorder is the mock recorder for MockRankingScoreCache.
+type MockRankingScoreCacheMockRecorder struct {
+	mock *MockRankingScoreCache
+}
+
+// NewMockRankingScoreCache creates a new mock instance.
+func NewMockRankingScoreCache(ctrl *gomock.Controller) *MockRankingScoreCache {
+	mock := &MockRankingScoreCache{ctrl: ctrl}
+	mock.recorder = &MockRankingScoreCacheMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockRankingScoreCache) EXPECT() *MockRankingScoreCacheMockRecorder {
+	return m.recorder
+}
+
+// Decay mocks base method.
+func (m *MockRankingScoreCache) Decay(ctx context.Context, name string, now time.Time, halfLife time.Duration, capacity int) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Decay", ctx, name, now, halfLife, capacity)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// Decay indicates an expected call of Decay.
+func (mr *MockRankingScoreCacheMockRecorder) Decay(ctx, name, now, halfLife, capacity any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Decay", reflect.TypeOf((*MockRankingScoreCache)(nil).Decay), ctx, name, now, halfLife, capacity)
+}
+
+// IncrBy mocks base method.
+func (m *MockRankingScoreCache) IncrBy(ctx context.Context, name string, id int64, delta float64) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "IncrBy", ctx, name, id, delta)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// IncrBy indicates an expected call of IncrBy.
+func (mr *MockRankingScoreCacheMockRecorder) IncrBy(ctx, name, id, delta any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "IncrBy", reflect.TypeOf((*MockRankingScoreCache)(nil).IncrBy), ctx, name, id, delta)
+}
+
+// Remove mocks base method.
+func (m *MockRankingScoreCache) Remove(ctx context.Context, name string, ids ...int64) error {
+	m.ctrl.T.Helper()
+	varargs := []any{ctx, name}
+	for _, a := range ids {
+		varargs = append(varargs, a)
+	}
+	ret := m.ctrl.Call(m, "Remove", varargs...)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// Remove indicates an expected call of Remove.
+func (mr *MockRankingScoreCacheMockRecorder) Remove(ctx, name any, ids ...any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	varargs := append([]any{ctx, name}, ids...)
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Remove", reflect.TypeOf((*MockRankingScoreCache)(nil).Remove), varargs...)
+}
+
+// Replace mocks base method.
+func (m *MockRankingScoreCache) Replace(ctx context.Context, name string, scores map[int64]float64, now time.Time) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Replace", ctx, name, scores, now)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// Replace indicates an expected call of Replace.
+func (mr *MockRankingScoreCacheMockRecorder) Replace(ctx, name, scores, now any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Replace", reflect.TypeOf((*MockRankingScoreCache)(nil).Replace), ctx, name, scores, now)
+}
+
+// TopN mocks base method.
+func (m *MockRankingScoreCache) TopN(ctx context.Context, name string, n int) ([]int64, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "TopN", ctx, name, n)
+	ret0, _ := ret[0].([]int64)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// TopN indicates an expected call of TopN.
+func (mr *MockRankingScoreCacheMockRecorder) TopN(ctx, name, n any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "TopN", reflect.TypeOf((*MockRankingScoreCache)(nil).TopN), ctx, name, n)
+}
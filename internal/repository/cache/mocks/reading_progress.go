@@ -0,0 +1,115 @@
+// Code generated by MockGen. DO NOT EDIT.
+// Source: ./internal/repository/cache/reading_progress.go
+//
+// Generated by this command:
+//
+//	mockgen -source=./internal/repository/cache/reading_progress.go -destination=./internal/repository/cache/mocks/reading_progress.go -package=cachemocks
+//
+
+// Package cachemocks is a generated GoMock package.
+package cachemocks
+
+import (
+	context "context"
+	reflect "reflect"
+
+	domain "github.com/Andras5014/gohub/internal/domain"
+	gomock "go.uber.org/mock/gomock"
+)
+
+// MockReadingProgressCache is a mock of ReadingProgressCache interface.
+type MockReadingProgressCache struct {
+	ctrl     *gomock.Controller
+	recorder *MockReadingProgressCacheMockRecorder
+}
+
+// MockReadingProgressCacheMockRecorder is the mock recorder for MockReadingProgressCache.
+type MockReadingProgressCacheMockRecorder struct {
+	mock *MockReadingProgressCache
+}
+
+// NewMockReadingProgressCache creates a new mock instance.
+func NewMockReadingProgressCache(ctrl *gomock.Controller) *MockReadingProgressCache {
+	mock := &MockReadingProgressCache{ctrl: ctrl}
+	mock.recorder = &MockReadingProgressCacheMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockReadingProgressCache) EXPECT() *MockReadingProgressCacheMockRecorder {
+	return m.recorder
+}
+
+// Get mocks base method.
+func (m *MockReadingProgressCache) Get(ctx context.Context, uid, aid int64) (domain.ReadingProgress, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Get", ctx, uid, aid)
+	ret0, _ := ret[0].(domain.ReadingProgress)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// Get indicates an expected call of Get.
+func (mr *MockReadingProgressCacheMockRecorder) Get(ctx, uid, aid any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Get", reflect.TypeOf((*MockReadingProgressCache)(nil).Get), ctx, uid, aid)
+}
+
+// GetAll mocks base method.
+func (m *MockReadingProgressCache) GetAll(ctx context.Context, uid int64) ([]domain.ReadingProgress, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetAll", ctx, uid)
+	ret0, _ := ret[0].([]domain.ReadingProgress)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetAll indicates an expected call of GetAll.
+func (mr *MockReadingProgressCacheMockRecorder) GetAll(ctx, uid any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetAll", reflect.TypeOf((*MockReadingProgressCache)(nil).GetAll), ctx, uid)
+}
+
+// MarkDirty mocks base method.
+func (m *MockReadingProgressCache) MarkDirty(ctx context.Context, ps []domain.ReadingProgress) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "MarkDirty", ctx, ps)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// MarkDirty indicates an expected call of MarkDirty.
+func (mr *MockReadingProgressCacheMockRecorder) MarkDirty(ctx, ps any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "MarkDirty", reflect.TypeOf((*MockReadingProgressCache)(nil).MarkDirty), ctx, ps)
+}
+
+// PopDirty mocks base method.
+func (m *MockReadingProgressCache) PopDirty(ctx context.Context, n int) ([]domain.ReadingProgress, int, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "PopDirty", ctx, n)
+	ret0, _ := ret[0].([]domain.ReadingProgress)
+	ret1, _ := ret[1].(int)
+	ret2, _ := ret[2].(error)
+	return ret0, ret1, ret2
+}
+
+// PopDirty indicates an expected call of PopDirty.
+func (mr *MockReadingProgressCacheMockRecorder) PopDirty(ctx, n any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "PopDirty", reflect.TypeOf((*MockReadingProgressCache)(nil).PopDirty), ctx, n)
+}
+
+// Set mocks base method.
+func (m *MockReadingProgressCache) Set(ctx context.Context, p domain.ReadingProgress) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Set", ctx, p)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// Set indicates an expected call of Set.
+func (mr *MockReadingProgressCacheMockRecorder) Set(ctx, p any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Set", reflect.TypeOf((*MockReadingProgressCache)(nil).Set), ctx, p)
+}
@@ -3,19 +3,31 @@ package cache
 import (
 	"context"
 	"encoding/json"
+	"fmt"
 	"github.com/Andras5014/gohub/internal/domain"
 	"github.com/redis/go-redis/v9"
+	"strconv"
+	"sync"
 	"time"
 )
 
+// RankingCache 每个热榜按名字分开存
 type RankingCache interface {
-	Set(ctx context.Context, arts []domain.Article) error
-	Get(ctx context.Context) ([]domain.Article, error)
+	// Set 覆盖热榜，同时生成一个新的版本号
+	Set(ctx context.Context, name string, arts []domain.Article) error
+	// Get 热榜和它的版本号
+	Get(ctx context.Context, name string) ([]domain.Article, int64, error)
+	// Version 只查版本号，用来判断本地缓存是不是过期了
+	Version(ctx context.Context, name string) (int64, error)
 }
 
+const (
+	rankingFieldData    = "data"
+	rankingFieldVersion = "version"
+)
+
 type RedisRankingCache struct {
 	client redis.Cmdable
-	key    string
 }
 
 func NewRedisRankingCache(client redis.Cmdable) RankingCache {
@@ -23,22 +35,106 @@ func NewRedisRankingCache(client redis.Cmdable) RankingCache {
 		client: client,
 	}
 }
-func (r *RedisRankingCache) Set(ctx context.Context, arts []domain.Article) error {
-	for i := 0; i < len(arts); i++ {
-		arts[i].Content = ""
-	}
-	data, err := json.Marshal(arts)
+
+// Set 数据和版本号放在同一个 hash 里面，一次事务写进去，读的时候不会对不上
+// 不设置过期时间，任务停了旧的热榜也比没有强
+func (r *RedisRankingCache) Set(ctx context.Context, name string, arts []domain.Article) error {
+	data, err := json.Marshal(rankingItems(arts))
 	if err != nil {
 		return err
 	}
-	return r.client.Set(ctx, r.key, data, time.Minute*10).Err()
+	_, err = r.client.TxPipelined(ctx, func(pipe redis.Pipeliner) error {
+		pipe.HSet(ctx, r.key(name), rankingFieldData, data)
+		pipe.HIncrBy(ctx, r.key(name), rankingFieldVersion, 1)
+		return nil
+	})
+	return err
 }
 
-func (r *RedisRankingCache) Get(ctx context.Context) ([]domain.Article, error) {
-	data, err := r.client.Get(ctx, r.key).Bytes()
+func (r *RedisRankingCache) Get(ctx context.Context, name string) ([]domain.Article, int64, error) {
+	res, err := r.client.HMGet(ctx, r.key(name), rankingFieldData, rankingFieldVersion).Result()
 	if err != nil {
-		return nil, err
+		return nil, 0, err
+	}
+	data, ok := res[0].(string)
+	if !ok {
+		return nil, 0, ErrKeyNotExist
+	}
+	version, err := parseVersion(res[1])
+	if err != nil {
+		return nil, 0, err
 	}
 	var arts []domain.Article
-	return arts, json.Unmarshal(data, &arts)
+	return arts, version, json.Unmarshal([]byte(data), &arts)
+}
+
+func (r *RedisRankingCache) Version(ctx context.Context, name string) (int64, error) {
+	return r.client.HGet(ctx, r.key(name), rankingFieldVersion).Int64()
+}
+
+func (r *RedisRankingCache) key(name string) string {
+	return fmt.Sprintf("ranking:top_n:%s", name)
+}
+
+func parseVersion(val any) (int64, error) {
+	str, ok := val.(string)
+	if !ok {
+		return 0, ErrKeyNotExist
+	}
+	return strconv.ParseInt(str, 10, 64)
+}
+
+// rankingItems 热榜只展示标题和摘要，正文和渲染结果都不要
+func rankingItems(arts []domain.Article) []domain.Article {
+	res := make([]domain.Article, len(arts))
+	for i, art := range arts {
+		art.Content = art.Abstract()
+		art.Rendered = domain.RenderedContent{Abstract: art.Rendered.Abstract}
+		res[i] = art
+	}
+	return res
+}
+
+// RankingLocalCache 进程内的热榜，每个实例各自一份
+type RankingLocalCache struct {
+	mu      sync.RWMutex
+	entries map[string]rankingEntry
+}
+
+type rankingEntry struct {
+	arts    []domain.Article
+	version int64
+	// checkedAt 上一次和 redis 核对版本的时间
+	checkedAt time.Time
+}
+
+func NewRankingLocalCache() *RankingLocalCache {
+	return &RankingLocalCache{entries: make(map[string]rankingEntry)}
+}
+
+// Get ok 为 false 表示本地还没有数据
+func (l *RankingLocalCache) Get(name string) (arts []domain.Article, version int64, checkedAt time.Time, ok bool) {
+	l.mu.RLock()
+	defer l.mu.RUnlock()
+	e, ok := l.entries[name]
+	return e.arts, e.version, e.checkedAt, ok
+}
+
+func (l *RankingLocalCache) Set(name string, arts []domain.Article, version int64, now time.Time) {
+	if arts == nil {
+		arts = []domain.Article{}
+	}
+	l.mu.Lock()
+	defer l.mu.Unlock()
+	l.entries[name] = rankingEntry{arts: arts, version: version, checkedAt: now}
+}
+
+// Touch 版本没变，只更新核对时间
+func (l *RankingLocalCache) Touch(name string, now time.Time) {
+	l.mu.Lock()
+	defer l.mu.Unlock()
+	if e, ok := l.entries[name]; ok {
+		e.checkedAt = now
+		l.entries[name] = e
+	}
 }
@@ -0,0 +1,105 @@
+package cache
+
+import (
+	"context"
+	_ "embed"
+	"fmt"
+	"github.com/redis/go-redis/v9"
+	"strconv"
+	"time"
+)
+
+//go:embed lua/ranking_decay.lua
+var luaRankingDecay string
+
+// rankingMinScore 衰减到这个分数以下的文章不可能再上榜了
+const rankingMinScore = 0.01
+
+// RankingScoreCache 增量模式的热榜，每个热榜一个有序集合，member 是文章 id
+type RankingScoreCache interface {
+	IncrBy(ctx context.Context, name string, id int64, delta float64) error
+	// TopN 分数从高到低
+	TopN(ctx context.Context, name string, n int) ([]int64, error)
+	// Decay 按照上一次衰减到 now 的时间衰减分数，并且只保留 capacity 个
+	Decay(ctx context.Context, name string, now time.Time, halfLife time.Duration, capacity int) error
+	// Replace 全量重建，now 作为下一次衰减的起点
+	Replace(ctx context.Context, name string, scores map[int64]float64, now time.Time) error
+	Remove(ctx context.Context, name string, ids ...int64) error
+}
+
+type RedisRankingScoreCache struct {
+	client redis.Cmdable
+}
+
+func NewRedisRankingScoreCache(client redis.Cmdable) RankingScoreCache {
+	return &RedisRankingScoreCache{
+		client: client,
+	}
+}
+
+func (r *RedisRankingScoreCache) IncrBy(ctx context.Context, name string, id int64, delta float64) error {
+	return r.client.ZIncrBy(ctx, r.key(name), delta, strconv.FormatInt(id, 10)).Err()
+}
+
+func (r *RedisRankingScoreCache) TopN(ctx context.Context, name string, n int) ([]int64, error) {
+	members, err := r.client.ZRevRange(ctx, r.key(name), 0, int64(n-1)).Result()
+	if err != nil {
+		return nil, err
+	}
+	ids := make([]int64, 0, len(members))
+	for _, m := range members {
+		id, err := strconv.ParseInt(m, 10, 64)
+		if err != nil {
+			return nil, err
+		}
+		ids = append(ids, id)
+	}
+	return ids, nil
+}
+
+func (r *RedisRankingScoreCache) Decay(ctx context.Context, name string, now time.Time,
+	halfLife time.Duration, capacity int) error {
+	return r.client.Eval(ctx, luaRankingDecay, []string{r.key(name), r.decayedAtKey(name)},
+		now.UnixMilli(), halfLife.Milliseconds(), capacity, rankingMinScore).Err()
+}
+
+// Replace 先写临时的 key 再改名，读的人不会看到写了一半的数据
+func (r *RedisRankingScoreCache) Replace(ctx context.Context, name string, scores map[int64]float64, now time.Time) error {
+	tmp := r.key(name) + ":tmp"
+	members := make([]redis.Z, 0, len(scores))
+	for id, score := range scores {
+		members = append(members, redis.Z{Score: score, Member: strconv.FormatInt(id, 10)})
+	}
+	_, err := r.client.TxPipelined(ctx, func(pipe redis.Pipeliner) error {
+		pipe.Del(ctx, tmp)
+		if len(members) > 0 {
+			pipe.ZAdd(ctx, tmp, members...)
+			pipe.Rename(ctx, tmp, r.key(name))
+		} else {
+			pipe.Del(ctx, r.key(name))
+		}
+		pipe.Set(ctx, r.decayedAtKey(name), now.UnixMilli(), 0)
+		return nil
+	})
+	return err
+}
+
+func (r *RedisRankingScoreCache) Remove(ctx context.Context, name string, ids ...int64) error {
+	if len(ids) == 0 {
+		return nil
+	}
+	members := make([]any, 0, len(ids))
+	for _, id := range ids {
+		members = append(members, strconv.FormatInt(id, 10))
+	}
+	return r.client.ZRem(ctx, r.key(name), members...).Err()
+}
+
+// key 用 hash tag 保证集群模式下几个 key 落在同一个节点上
+func (r *RedisRankingScoreCache) key(name string) string {
+	return fmt.Sprintf("ranking:score:{%s}", name)
+}
+
+func (r *RedisRankingScoreCache) decayedAtKey(name string) string {
+	return r.key(name) + ":decayed_at"
+}
@@ -0,0 +1,198 @@
+package cache
+
+import (
+	"context"
+	_ "embed"
+	"fmt"
+	"github.com/Andras5014/gohub/internal/domain"
+	"github.com/ecodeclub/ekit/slice"
+	"github.com/redis/go-redis/v9"
+	"strconv"
+	"strings"
+	"time"
+)
+
+//go:embed lua/reading_progress_set.lua
+var luaReadingProgressSet string
+
+// ReadingProgressCache 阅读进度先写缓存，变了的记在待落库的集合里面，定时任务批量写数据库
+// 客户端滚动的时候频繁上报，同一篇文章在两次落库之间改了多少次都只写一次数据库
+type ReadingProgressCache interface {
+	// Set 进度没变或者比缓存里面的旧的时候不写，也不标记
+	Set(ctx context.Context, p domain.ReadingProgress) error
+	// Get 没有的时候返回 ErrKeyNotExist
+	Get(ctx context.Context, uid int64, aid int64) (domain.ReadingProgress, error)
+	// GetAll 一个人最近读过的全部文章，没有顺序
+	GetAll(ctx context.Context, uid int64) ([]domain.ReadingProgress, error)
+	// PopDirty 取出最多 n 条待落库的，取出来就不在集合里面了。
+	// 第二个返回值是从集合里面取了多少条，过期了的会被跳过，所以可能比返回的进度多
+	PopDirty(ctx context.Context, n int) ([]domain.ReadingProgress, int, error)
+	// MarkDirty 落库失败的时候放回去，等下一次
+	MarkDirty(ctx context.Context, ps []domain.ReadingProgress) error
+}
+
+type RedisReadingProgressCache struct {
+	client redis.Cmdable
+	// expiration 最近没读过的只在数据库里面
+	expiration time.Duration
+}
+
+func NewRedisReadingProgressCache(client redis.Cmdable) ReadingProgressCache {
+	return &RedisReadingProgressCache{
+		client:     client,
+		expiration: time.Hour * 24 * 7,
+	}
+}
+
+func (r *RedisReadingProgressCache) Set(ctx context.Context, p domain.ReadingProgress) error {
+	changed, err := r.client.Eval(ctx, luaReadingProgressSet, []string{r.key(p.Uid)},
+		p.ArticleId, fmt.Sprintf("%d:%d", p.Paragraph, p.Percent),
+		p.Utime.UnixMilli(), int64(r.expiration/time.Second)).Int()
+	if err != nil || changed == 0 {
+		return err
+	}
+	// 待落库的集合和进度不在同一个 key 上面，集群模式下不能放在一个脚本里面
+	return r.client.SAdd(ctx, r.dirtyKey(), r.dirtyMember(p.Uid, p.ArticleId)).Err()
+}
+
+func (r *RedisReadingProgressCache) Get(ctx context.Context, uid int64, aid int64) (domain.ReadingProgress, error) {
+	val, err := r.client.HGet(ctx, r.key(uid), strconv.FormatInt(aid, 10)).Result()
+	if err != nil {
+		return domain.ReadingProgress{}, err
+	}
+	return r.parse(uid, aid, val)
+}
+
+func (r *RedisReadingProgressCache) GetAll(ctx context.Context, uid int64) ([]domain.ReadingProgress, error) {
+	vals, err := r.client.HGetAll(ctx, r.key(uid)).Result()
+	if err != nil {
+		return nil, err
+	}
+	res := make([]domain.ReadingProgress, 0, len(vals))
+	for field, val := range vals {
+		aid, err := strconv.ParseInt(field, 10, 64)
+		if err != nil {
+			return nil, err
+		}
+		p, err := r.parse(uid, aid, val)
+		if err != nil {
+			return nil, err
+		}
+		res = append(res, p)
+	}
+	return res, nil
+}
+
+func (r *RedisReadingProgressCache) PopDirty(ctx context.Context, n int) ([]domain.ReadingProgress, int, error) {
+	members, err := r.client.SPopN(ctx, r.dirtyKey(), int64(n)).Result()
+	if err != nil || len(members) == 0 {
+		return nil, 0, err
+	}
+	type entry struct {
+		uid int64
+		aid int64
+		cmd *redis.StringCmd
+	}
+	entries := make([]entry, 0, len(members))
+	_, err = r.client.Pipelined(ctx, func(pipe redis.Pipeliner) error {
+		for _, m := range members {
+			uid, aid, ok := r.parseDirtyMember(m)
+			if !ok {
+				continue
+			}
+			entries = append(entries, entry{
+				uid: uid,
+				aid: aid,
+				cmd: pipe.HGet(ctx, r.key(uid), strconv.FormatInt(aid, 10)),
+			})
+		}
+		return nil
+	})
+	// 过期了的会返回 redis.Nil，下面单独跳过
+	if err != nil && err != redis.Nil {
+		// 已经从集合里面取出来了，放回去等下一次
+		_ = r.client.SAdd(ctx, r.dirtyKey(), slice.Map(members, func(idx int, src string) any {
+			return src
+		})...).Err()
+		return nil, 0, err
+	}
+	res := make([]domain.ReadingProgress, 0, len(entries))
+	for _, e := range entries {
+		val, er := e.cmd.Result()
+		if er != nil {
+			continue
+		}
+		p, er := r.parse(e.uid, e.aid, val)
+		if er != nil {
+			continue
+		}
+		res = append(res, p)
+	}
+	return res, len(members), nil
+}
+
+func (r *RedisReadingProgressCache) MarkDirty(ctx context.Context, ps []domain.ReadingProgress) error {
+	if len(ps) == 0 {
+		return nil
+	}
+	members := make([]any, 0, len(ps))
+	for _, p := range ps {
+		members = append(members, r.dirtyMember(p.Uid, p.ArticleId))
+	}
+	return r.client.SAdd(ctx, r.dirtyKey(), members...).Err()
+}
+
+// parse 缓存里面存的是 段落:百分比:更新时间
+func (r *RedisReadingProgressCache) parse(uid int64, aid int64, val string) (domain.ReadingProgress, error) {
+	segs := strings.Split(val, ":")
+	if len(segs) != 3 {
+		return domain.ReadingProgress{}, fmt.Errorf("阅读进度格式不对 %s", val)
+	}
+	paragraph, err := strconv.Atoi(segs[0])
+	if err != nil {
+		return domain.ReadingProgress{}, err
+	}
+	percent, err := strconv.Atoi(segs[1])
+	if err != nil {
+		return domain.ReadingProgress{}, err
+	}
+	utime, err := strconv.ParseInt(segs[2], 10, 64)
+	if err != nil {
+		return domain.ReadingProgress{}, err
+	}
+	return domain.ReadingProgress{
+		Uid:       uid,
+		ArticleId: aid,
+		Paragraph: paragraph,
+		Percent:   percent,
+		Utime:     time.UnixMilli(utime),
+	}, nil
+}
+
+func (r *RedisReadingProgressCache) key(uid int64) string {
+	return fmt.Sprintf("reading_progress:%d", uid)
+}
+
+func (r *RedisReadingProgressCache) dirtyKey() string {
+	return "reading_progress:dirty"
+}
+
+func (r *RedisReadingProgressCache) dirtyMember(uid int64, aid int64) string {
+	return fmt.Sprintf("%d:%d", uid, aid)
+}
+
+func (r *RedisReadingProgressCache) parseDirtyMember(member string) (int64, int64, bool) {
+	uidStr, aidStr, ok := strings.Cut(member, ":")
+	if !ok {
+		return 0, 0, false
+	}
+	uid, err := strconv.ParseInt(uidStr, 10, 64)
+	if err != nil {
+		return 0, 0, false
+	}
+	aid, err := strconv.ParseInt(aidStr, 10, 64)
+	if err != nil {
+		return 0, 0, false
+	}
+	return uid, aid, true
+}
@@ -2,17 +2,69 @@ package article
 
 import (
 	"context"
-	"time"
+	"errors"
+	"gorm.io/gorm"
 )
 
+var (
+	// ErrVersionConflict 制作库里的版本号已经变了，说明别的地方先改了
+	ErrVersionConflict = errors.New("文章版本冲突")
+	// ErrArticleNotFound 各个实现找不到数据的时候都统一成这个错误
+	ErrArticleNotFound = gorm.ErrRecordNotFound
+)
+
+// Cursor 按 (updated_at, id) 倒序翻页，只取严格排在游标后面的数据
+// UpdatedAt 为 0 表示从头开始
+type Cursor struct {
+	UpdatedAt int64
+	Id        int64
+}
+
 type ArticleDAO interface {
 	Insert(ctx context.Context, article Article) (int64, error)
 	UpdateById(ctx context.Context, article Article) error
-	Sync(ctx context.Context, article Article) (int64, error)
-	SyncV1(ctx context.Context, article Article) (int64, error)
+	Sync(ctx context.Context, article PublishedArticle) (int64, error)
+	SyncV1(ctx context.Context, article PublishedArticle) (int64, error)
 	SyncStatus(ctx context.Context, article Article) (int64, error)
-	FindByAuthorId(Dao context.Context, id int64, offset int, limit int) ([]Article, error)
+	// FindByStatus 制作库里面某个状态的文章，按更新时间正序，审核队列先进先出
+	FindByStatus(ctx context.Context, status uint8, offset int, limit int) ([]Article, error)
+	// UpdateReview 只有当前状态是 from 的时候才更新状态和审核原因，不然返回 ErrArticleNotFound
+	UpdateReview(ctx context.Context, id int64, from uint8, to uint8, reason string) error
+	// FindByAuthorId 作者的文章，按 (updated_at, id) 倒序翻页
+	FindByAuthorId(ctx context.Context, id int64, cursor Cursor, limit int) ([]Article, error)
 	GetById(ctx context.Context, id int64) (Article, error)
+	// GetPubById 带上正文和渲染结果
 	GetPubById(ctx context.Context, id int64) (PublishedArticle, error)
-	ListPub(ctx context.Context, start time.Time, offset int, limit int) ([]PublishedArticle, error)
+	// ListPub 已发表的文章，按 (updated_at, id) 倒序翻页
+	// 线上库的列表只保证有元数据，正文不一定有（S3DAO 的正文不在数据库里面），要正文用 GetPubById
+	ListPub(ctx context.Context, cursor Cursor, limit int) ([]PublishedArticle, error)
+	// ListPubByAuthor 某个作者已发表的文章，翻页方式和 ListPub 一样
+	ListPubByAuthor(ctx context.Context, authorId int64, cursor Cursor, limit int) ([]PublishedArticle, error)
+	// PubIdsByAuthor 某个作者已发表文章的 id，按 id 升序，只返回比 maxId 大的，用来分批遍历
+	PubIdsByAuthor(ctx context.Context, authorId int64, maxId int64, limit int) ([]int64, error)
+	// ListPubByIds ids 里面已发表的文章，不保证顺序，和 ListPub 一样只保证有元数据
+	ListPubByIds(ctx context.Context, ids []int64) ([]PublishedArticle, error)
+
+	// ListRevisions 历史版本，按创建时间倒序
+	ListRevisions(ctx context.Context, artId int64, offset int, limit int) ([]ArticleRevision, error)
+	GetRevision(ctx context.Context, artId int64, id int64) (ArticleRevision, error)
+
+	// ListPubByTag 某个标签下已发表的文章，翻页方式和 ListPub 一样
+	ListPubByTag(ctx context.Context, tag string, cursor Cursor, limit int) ([]PublishedArticle, error)
+	// TagCounts 标签和已发表文章数，按数量倒序
+	TagCounts(ctx context.Context, limit int) ([]TagCount, error)
+
+	// UpdateSchedule 覆盖定时发表/撤回的时间，0 表示取消
+	UpdateSchedule(ctx context.Context, article Article) error
+	// FindDueScheduled 到时间需要发表或者撤回的文章
+	FindDueScheduled(ctx context.Context, now int64, limit int) ([]Article, error)
+
+	// SoftDelete 制作库和线上库一起打上删除标记，不存在或者已经删除的返回 ErrArticleNotFound
+	SoftDelete(ctx context.Context, id int64, authorId int64) error
+	// Restore 从回收站恢复，制作库和线上库一起恢复，不在回收站里面的返回 ErrArticleNotFound
+	Restore(ctx context.Context, id int64, authorId int64) error
+	// ListTrash 回收站里面的文章，按删除时间倒序
+	ListTrash(ctx context.Context, authorId int64, offset int, limit int) ([]Article, error)
+	// PurgeDeleted 彻底删除 before 之前放进回收站的文章，一次最多 limit 篇，返回删除的数量
+	PurgeDeleted(ctx context.Context, before int64, limit int) (int, error)
 }
@@ -0,0 +1,105 @@
+package article
+
+import (
+	"context"
+	"gorm.io/gorm"
+	"gorm.io/gorm/clause"
+	"time"
+)
+
+// ErrCollaboratorNotFound 没有这个协作者或者邀请
+var ErrCollaboratorNotFound = gorm.ErrRecordNotFound
+
+// ArticleCollaborator 一篇文章对一个用户只有一条记录，邀请和协作共用
+type ArticleCollaborator struct {
+	Id        int64 `gorm:"primaryKey,autoIncrement"`
+	ArticleId int64 `gorm:"uniqueIndex:uk_article_uid"`
+	// 按用户查自己的邀请和协作
+	Uid       int64 `gorm:"uniqueIndex:uk_article_uid;index:idx_uid_status"`
+	Role      uint8
+	Status    uint8 `gorm:"index:idx_uid_status"`
+	InviterId int64
+	CreatedAt int64
+	UpdatedAt int64
+}
+
+// CollaboratorDAO 协作者只存在 MySQL 里面，和正文用什么存储没有关系
+type CollaboratorDAO interface {
+	// Upsert 重复邀请的时候只更新角色，已经接受的仍然是接受状态
+	Upsert(ctx context.Context, c ArticleCollaborator) error
+	Get(ctx context.Context, artId, uid int64) (ArticleCollaborator, error)
+	// UpdateStatus 只有当前状态是 from 的时候才会更新，否则返回 ErrCollaboratorNotFound
+	UpdateStatus(ctx context.Context, artId, uid int64, from, to uint8) error
+	Delete(ctx context.Context, artId, uid int64) error
+	ListByArticle(ctx context.Context, artId int64) ([]ArticleCollaborator, error)
+	// ListByUid 按更新时间倒序
+	ListByUid(ctx context.Context, uid int64, status uint8, offset int, limit int) ([]ArticleCollaborator, error)
+}
+
+type GormCollaboratorDAO struct {
+	db *gorm.DB
+}
+
+func NewCollaboratorDAO(db *gorm.DB) CollaboratorDAO {
+	return &GormCollaboratorDAO{db: db}
+}
+
+func (g *GormCollaboratorDAO) Upsert(ctx context.Context, c ArticleCollaborator) error {
+	now := time.Now().UnixMilli()
+	c.CreatedAt = now
+	c.UpdatedAt = now
+	return g.db.WithContext(ctx).Clauses(clause.OnConflict{
+		DoUpdates: clause.Assignments(map[string]any{
+			"role":       c.Role,
+			"inviter_id": c.InviterId,
+			"updated_at": now,
+		}),
+	}).Create(&c).Error
+}
+
+func (g *GormCollaboratorDAO) Get(ctx context.Context, artId, uid int64) (ArticleCollaborator, error) {
+	var c ArticleCollaborator
+	err := g.db.WithContext(ctx).Where("article_id = ? AND uid = ?", artId, uid).First(&c).Error
+	return c, err
+}
+
+func (g *GormCollaboratorDAO) UpdateStatus(ctx context.Context, artId, uid int64, from, to uint8) error {
+	res := g.db.WithContext(ctx).Model(&ArticleCollaborator{}).
+		Where("article_id = ? AND uid = ? AND status = ?", artId, uid, from).
+		Updates(map[string]any{
+			"status":     to,
+			"updated_at": time.Now().UnixMilli(),
+		})
+	if res.Error != nil {
+		return res.Error
+	}
+	if res.RowsAffected == 0 {
+		return ErrCollaboratorNotFound
+	}
+	return nil
+}
+
+func (g *GormCollaboratorDAO) Delete(ctx context.Context, artId, uid int64) error {
+	res := g.db.WithContext(ctx).Where("article_id = ? AND uid = ?", artId, uid).
+		Delete(&ArticleCollaborator{})
+	if res.Error != nil {
+		return res.Error
+	}
+	if res.RowsAffected == 0 {
+		return ErrCollaboratorNotFound
+	}
+	return nil
+}
+
+func (g *GormCollaboratorDAO) ListByArticle(ctx context.Context, artId int64) ([]ArticleCollaborator, error) {
+	var res []ArticleCollaborator
+	err := g.db.WithContext(ctx).Where("article_id = ?", artId).Order("id").Find(&res).Error
+	return res, err
+}
+
+func (g *GormCollaboratorDAO) ListByUid(ctx context.Context, uid int64, status uint8, offset int, limit int) ([]ArticleCollaborator, error) {
+	var res []ArticleCollaborator
+	err := g.db.WithContext(ctx).Where("uid = ? AND status = ?", uid, status).
+		Order("updated_at DESC").Offset(offset).Limit(limit).Find(&res).Error
+	return res, err
+}
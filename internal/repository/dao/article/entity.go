@@ -1,19 +1,184 @@
 package article
 
+import (
+	"database/sql/driver"
+	"encoding/json"
+	"errors"
+)
+
+// ArticleStatusPublished 和 domain.ArticleStatusPublished 一致，查线上库的时候用
+const ArticleStatusPublished = 2
+
 type Article struct {
 	Id      int64  `gorm:"primaryKey,autoIncrement" bson:"id,omitempty"`
 	Title   string `gorm:"not null" bson:"title,omitempty"`
 	Content string `gorm:"type=BLOB" bson:"content,omitempty"`
 
-	AuthorId int64 `gorm:"index" bson:"author_id,omitempty"`
-	Status   uint8 `bson:"status,omitempty"`
+	// 翻页按 (updated_at, id) 倒序，InnoDB 的二级索引末尾自带主键
+	AuthorId int64 `gorm:"index:idx_author_updated_at" bson:"author_id,omitempty"`
+	// 审核队列按 (status, updated_at) 查
+	Status uint8 `gorm:"index:idx_status_updated_at" bson:"status,omitempty"`
+	// Tags 草稿上的标签，发表的时候同步到 published_article_tags
+	Tags Tags `gorm:"type:varchar(512)" bson:"tags,omitempty"`
+	// Version 乐观锁，制作库每修改一次加一
+	Version int64 `gorm:"not null;default:1" bson:"version,omitempty"`
+	// EditorId 这次保存的人，不落库，只写到历史版本上
+	EditorId int64 `gorm:"-" bson:"-"`
 
 	CreatedAt int64 `bson:"created_at,omitempty"`
-	UpdatedAt int64 `bson:"updated_at,omitempty"`
-	DeletedAt int64 `bson:"deletedAt,omitempty"`
+	UpdatedAt int64 `gorm:"index:idx_author_updated_at;index:idx_status_updated_at;index" bson:"updated_at,omitempty"`
+	// DeletedAt 放进回收站的时间，0 表示没有删除
+	DeletedAt int64 `gorm:"index" bson:"deletedAt,omitempty"`
+
+	// PublishAt 定时发表，0 表示没有
+	PublishAt int64 `gorm:"index" bson:"publish_at,omitempty"`
+	// UnpublishAt 定时撤回，0 表示没有
+	UnpublishAt int64 `gorm:"index" bson:"unpublish_at,omitempty"`
+	// ScheduleRetries 定时任务连续失败的次数，每失败一次往后推迟得更久
+	ScheduleRetries int `gorm:"not null;default:0" bson:"schedule_retries,omitempty"`
+	// ReviewReason 审核没有通过的原因，只有制作库会用到
+	ReviewReason string `gorm:"type:varchar(512)" bson:"review_reason,omitempty"`
+}
+
+// PublishedArticle 线上库，比制作库多了发表时渲染的结果
+type PublishedArticle struct {
+	Article  `bson:",inline"`
+	Rendered `bson:",inline"`
+	// ContentKey 内容放在对象存储时的 key
+	ContentKey string `gorm:"type:varchar(256)" bson:"content_key,omitempty"`
+}
+
+// Rendered 发表时渲染的结果，只有线上库有这些列
+type Rendered struct {
+	ContentHTML string `gorm:"type:longtext" bson:"content_html,omitempty"`
+	Toc         Toc    `gorm:"type:text" bson:"toc,omitempty"`
+	WordCount   int64  `bson:"word_count,omitempty"`
+	ReadingTime int64  `bson:"reading_time,omitempty"`
+	Abstract    string `gorm:"type:varchar(512)" bson:"abstract,omitempty"`
 }
-type PublishedArticle Article
 
 type PublishedArticleV1 struct {
 	Article
 }
+
+// ArticleRevision 文章历史版本，只追加不修改
+type ArticleRevision struct {
+	Id        int64 `gorm:"primaryKey,autoIncrement" bson:"id,omitempty"`
+	ArticleId int64 `gorm:"index:idx_article_id_created_at" bson:"article_id,omitempty"`
+	// AuthorId 保存这个版本的人，协作者编辑的时候是协作者
+	AuthorId  int64  `bson:"author_id,omitempty"`
+	Title     string `bson:"title,omitempty"`
+	Content   string `gorm:"type=BLOB" bson:"content,omitempty"`
+	Status    uint8  `bson:"status,omitempty"`
+	CreatedAt int64  `gorm:"index:idx_article_id_created_at" bson:"created_at,omitempty"`
+}
+
+// editor 历史版本记录的是这次保存的人，没有带的时候就是作者
+func (a Article) editor() int64 {
+	if a.EditorId > 0 {
+		return a.EditorId
+	}
+	return a.AuthorId
+}
+
+// Tags MySQL 里面存 json，mongo 里面直接是数组
+type Tags []string
+
+func (t Tags) Value() (driver.Value, error) {
+	if len(t) == 0 {
+		return "[]", nil
+	}
+	data, err := json.Marshal([]string(t))
+	return string(data), err
+}
+
+func (t *Tags) Scan(src any) error {
+	var data []byte
+	switch val := src.(type) {
+	case nil:
+		*t = nil
+		return nil
+	case []byte:
+		data = val
+	case string:
+		data = []byte(val)
+	default:
+		return errors.New("tags 类型不对")
+	}
+	var res []string
+	if len(data) > 0 {
+		if err := json.Unmarshal(data, &res); err != nil {
+			return err
+		}
+	}
+	// 空数组也当成没有标签
+	if len(res) == 0 {
+		res = nil
+	}
+	*t = res
+	return nil
+}
+
+// TocItem 目录，MySQL 里面整个目录存成 json
+type TocItem struct {
+	Level  int    `json:"level" bson:"level"`
+	Anchor string `json:"anchor" bson:"anchor"`
+	Text   string `json:"text" bson:"text"`
+}
+
+type Toc []TocItem
+
+func (t Toc) Value() (driver.Value, error) {
+	if len(t) == 0 {
+		return "[]", nil
+	}
+	data, err := json.Marshal([]TocItem(t))
+	return string(data), err
+}
+
+func (t *Toc) Scan(src any) error {
+	var data []byte
+	switch val := src.(type) {
+	case nil:
+		*t = nil
+		return nil
+	case []byte:
+		data = val
+	case string:
+		data = []byte(val)
+	default:
+		return errors.New("toc 类型不对")
+	}
+	var res []TocItem
+	if len(data) > 0 {
+		if err := json.Unmarshal(data, &res); err != nil {
+			return err
+		}
+	}
+	if len(res) == 0 {
+		res = nil
+	}
+	*t = res
+	return nil
+}
+
+// Tag 标签本身，name 唯一
+type Tag struct {
+	Id        int64  `gorm:"primaryKey,autoIncrement"`
+	Name      string `gorm:"type:varchar(64);uniqueIndex"`
+	CreatedAt int64
+}
+
+// PublishedArticleTag 线上库文章和标签的多对多关系
+type PublishedArticleTag struct {
+	Id        int64 `gorm:"primaryKey,autoIncrement"`
+	TagId     int64 `gorm:"uniqueIndex:uk_tag_article"`
+	ArticleId int64 `gorm:"uniqueIndex:uk_tag_article;index"`
+	CreatedAt int64
+}
+
+// TagCount 标签下面已发表的文章数
+type TagCount struct {
+	Name string
+	Cnt  int64
+}
@@ -3,6 +3,7 @@ package article
 import (
 	"context"
 	"errors"
+	"github.com/ecodeclub/ekit/slice"
 	"gorm.io/gorm"
 	"gorm.io/gorm/clause"
 	"time"
@@ -16,40 +17,143 @@ func NewArticleDAO(db *gorm.DB) ArticleDAO {
 	return &GormArticleDAO{db: db}
 }
 
-func (g *GormArticleDAO) ListPub(ctx context.Context, start time.Time, offset int, limit int) ([]PublishedArticle, error) {
+func (g *GormArticleDAO) ListPub(ctx context.Context, cursor Cursor, limit int) ([]PublishedArticle, error) {
 	var pubArts []PublishedArticle
-	const ArticleStatusPublished = 2
-	err := g.db.WithContext(ctx).Where("updated_at < ? and status = ?", start.UnixMilli(), ArticleStatusPublished).Limit(limit).Offset(offset).Find(&pubArts).Error
+	err := afterCursor(g.db.WithContext(ctx), cursor).
+		Where("status = ? and deleted_at = 0", ArticleStatusPublished).
+		Order("updated_at DESC, id DESC").Limit(limit).Find(&pubArts).Error
 	return pubArts, err
 }
 
-func (g *GormArticleDAO) FindByAuthorId(Dao context.Context, id int64, offset int, limit int) ([]Article, error) {
+func (g *GormArticleDAO) ListPubByAuthor(ctx context.Context, authorId int64, cursor Cursor, limit int) ([]PublishedArticle, error) {
+	var pubArts []PublishedArticle
+	err := afterCursor(g.db.WithContext(ctx), cursor).
+		Where("author_id = ? AND status = ? AND deleted_at = 0", authorId, ArticleStatusPublished).
+		Order("updated_at DESC, id DESC").Limit(limit).Find(&pubArts).Error
+	return pubArts, err
+}
+
+func (g *GormArticleDAO) PubIdsByAuthor(ctx context.Context, authorId int64, maxId int64, limit int) ([]int64, error) {
+	var ids []int64
+	err := g.db.WithContext(ctx).Model(&PublishedArticle{}).
+		Where("author_id = ? AND id > ? AND status = ? AND deleted_at = 0", authorId, maxId, ArticleStatusPublished).
+		Order("id").Limit(limit).Pluck("id", &ids).Error
+	return ids, err
+}
+
+func (g *GormArticleDAO) ListPubByIds(ctx context.Context, ids []int64) ([]PublishedArticle, error) {
+	var pubArts []PublishedArticle
+	err := g.db.WithContext(ctx).
+		Where("id IN ? AND status = ? AND deleted_at = 0", ids, ArticleStatusPublished).
+		Find(&pubArts).Error
+	return pubArts, err
+}
+
+func (g *GormArticleDAO) FindByAuthorId(ctx context.Context, id int64, cursor Cursor, limit int) ([]Article, error) {
 	var arts []Article
 	// orderby 命中索引
-	err := g.db.WithContext(Dao).Where("author_id = ?", id).Limit(limit).Offset(offset).Order("updated_at DESC").Find(&arts).Error
+	err := afterCursor(g.db.WithContext(ctx), cursor).
+		Where("author_id = ? AND deleted_at = 0", id).
+		Order("updated_at DESC, id DESC").Limit(limit).Find(&arts).Error
+	return arts, err
+}
+
+func (g *GormArticleDAO) FindByStatus(ctx context.Context, status uint8, offset int, limit int) ([]Article, error) {
+	var arts []Article
+	err := g.db.WithContext(ctx).Where("status = ? AND deleted_at = 0", status).
+		Order("updated_at, id").Offset(offset).Limit(limit).Find(&arts).Error
 	return arts, err
 }
 
+func (g *GormArticleDAO) UpdateReview(ctx context.Context, id int64, from uint8, to uint8, reason string) error {
+	// 不改 version，作者手上的草稿还能继续编辑
+	res := g.db.WithContext(ctx).Model(&Article{}).
+		Where("id = ? AND status = ? AND deleted_at = 0", id, from).
+		Updates(map[string]any{
+			"status":        to,
+			"review_reason": reason,
+			"updated_at":    time.Now().UnixMilli(),
+		})
+	if res.Error != nil {
+		return res.Error
+	}
+	if res.RowsAffected == 0 {
+		return ErrArticleNotFound
+	}
+	return nil
+}
+
+// afterCursor 不用 offset，深翻页也只扫需要的行，翻页期间有新发表的文章也不会重复或者漏掉
+func afterCursor(db *gorm.DB, cursor Cursor) *gorm.DB {
+	if cursor.UpdatedAt == 0 {
+		return db
+	}
+	return db.Where("(updated_at < ? OR (updated_at = ? AND id < ?))",
+		cursor.UpdatedAt, cursor.UpdatedAt, cursor.Id)
+}
+
 func (g *GormArticleDAO) Insert(ctx context.Context, article Article) (int64, error) {
 	now := time.Now().UnixMilli()
 	article.CreatedAt = now
 	article.UpdatedAt = now
-	return article.Id, g.db.WithContext(ctx).Create(&article).Error
+	article.Version = 1
+	err := g.db.WithContext(ctx).Transaction(func(tx *gorm.DB) error {
+		if err := tx.Create(&article).Error; err != nil {
+			return err
+		}
+		return g.appendRevision(tx, article, now)
+	})
+	return article.Id, err
 }
 
 func (g *GormArticleDAO) UpdateById(ctx context.Context, article Article) error {
 	now := time.Now().UnixMilli()
 	article.UpdatedAt = now
-	res := g.db.WithContext(ctx).Model(&article).Where("id = ? And author_id = ?", article.Id, article.AuthorId).Updates(&article)
-	if res.Error != nil {
-		return res.Error
-	}
-	if res.RowsAffected == 0 {
-		return errors.New("更新失败，可能是非法操作")
+	return g.db.WithContext(ctx).Transaction(func(tx *gorm.DB) error {
+		res := tx.Model(&Article{}).
+			Where("id = ? And author_id = ? AND version = ? AND deleted_at = 0", article.Id, article.AuthorId, article.Version).
+			Updates(map[string]any{
+				"title":         article.Title,
+				"content":       article.Content,
+				"tags":          article.Tags,
+				"status":        article.Status,
+				"review_reason": article.ReviewReason,
+				"updated_at":    now,
+				"version":       gorm.Expr("version + 1"),
+			})
+		if res.Error != nil {
+			return res.Error
+		}
+		if res.RowsAffected == 0 {
+			return g.updateFailed(tx, article)
+		}
+		return g.appendRevision(tx, article, now)
+	})
+}
+
+// updateFailed 区分是版本冲突还是非法操作
+func (g *GormArticleDAO) updateFailed(tx *gorm.DB, article Article) error {
+	var cur Article
+	err := tx.Select("id", "author_id", "version").
+		Where("id = ?", article.Id).First(&cur).Error
+	if err == nil && cur.AuthorId == article.AuthorId && cur.Version != article.Version {
+		return ErrVersionConflict
 	}
-	return nil
+	return errors.New("更新失败，可能是非法操作")
+}
+
+// appendRevision 和制作库的写操作在同一个事务里追加历史版本
+func (g *GormArticleDAO) appendRevision(tx *gorm.DB, article Article, now int64) error {
+	return tx.Create(&ArticleRevision{
+		ArticleId: article.Id,
+		AuthorId:  article.editor(),
+		Title:     article.Title,
+		Content:   article.Content,
+		Status:    article.Status,
+		CreatedAt: now,
+	}).Error
 }
-func (g *GormArticleDAO) Sync(ctx context.Context, article Article) (int64, error) {
+func (g *GormArticleDAO) Sync(ctx context.Context, article PublishedArticle) (int64, error) {
 	var id = article.Id
 	err := g.db.WithContext(ctx).Transaction(func(tx *gorm.DB) error {
 		var (
@@ -57,16 +161,18 @@ func (g *GormArticleDAO) Sync(ctx context.Context, article Article) (int64, erro
 		)
 		dao := NewArticleDAO(tx)
 		if id > 0 {
-			err = dao.UpdateById(ctx, article)
+			err = dao.UpdateById(ctx, article.Article)
+			article.Version++
 		} else {
-			id, err = dao.Insert(ctx, article)
+			id, err = dao.Insert(ctx, article.Article)
+			article.Version = 1
 		}
 		if err != nil {
 			return err
 		}
 		article.Id = id
 		now := time.Now().UnixMilli()
-		pubArt := PublishedArticle(article)
+		pubArt := article
 		pubArt.CreatedAt = now
 		pubArt.UpdatedAt = now
 		err = tx.Clauses(clause.OnConflict{
@@ -76,18 +182,52 @@ func (g *GormArticleDAO) Sync(ctx context.Context, article Article) (int64, erro
 			// sqlite INSERT XXX ON CONFLICT DO UPDATES WHERE
 			Columns: []clause.Column{{Name: "id"}},
 			DoUpdates: clause.Assignments(map[string]interface{}{
-				"title":      pubArt.Title,
-				"content":    pubArt.Content,
-				"updated_at": now,
-				"status":     pubArt.Status,
+				"title":        pubArt.Title,
+				"content":      pubArt.Content,
+				"tags":         pubArt.Tags,
+				"content_html": pubArt.ContentHTML,
+				"toc":          pubArt.Toc,
+				"word_count":   pubArt.WordCount,
+				"reading_time": pubArt.ReadingTime,
+				"abstract":     pubArt.Abstract,
+				"updated_at":   now,
+				"status":       pubArt.Status,
 			}),
 		}).Create(&pubArt).Error
-		return err
+		if err != nil {
+			return err
+		}
+		return g.syncTags(tx, id, article.Tags, now)
 	})
 	return id, err
 }
 
-func (g *GormArticleDAO) SyncV1(ctx context.Context, art Article) (int64, error) {
+// syncTags 发表的时候把草稿上的标签同步到线上库，覆盖之前的
+func (g *GormArticleDAO) syncTags(tx *gorm.DB, artId int64, tags Tags, now int64) error {
+	err := tx.Where("article_id = ?", artId).Delete(&PublishedArticleTag{}).Error
+	if err != nil || len(tags) == 0 {
+		return err
+	}
+	ts := slice.Map[string, Tag](tags, func(idx int, src string) Tag {
+		return Tag{Name: src, CreatedAt: now}
+	})
+	err = tx.Clauses(clause.OnConflict{DoNothing: true}).Create(&ts).Error
+	if err != nil {
+		return err
+	}
+	// 已经存在的标签拿不到 id，统一再查一次
+	var saved []Tag
+	err = tx.Where("name IN ?", []string(tags)).Find(&saved).Error
+	if err != nil {
+		return err
+	}
+	rels := slice.Map[Tag, PublishedArticleTag](saved, func(idx int, src Tag) PublishedArticleTag {
+		return PublishedArticleTag{TagId: src.Id, ArticleId: artId, CreatedAt: now}
+	})
+	return tx.Create(&rels).Error
+}
+
+func (g *GormArticleDAO) SyncV1(ctx context.Context, art PublishedArticle) (int64, error) {
 	tx := g.db.WithContext(ctx).Begin()
 	if tx.Error != nil {
 		return 0, tx.Error
@@ -101,16 +241,16 @@ func (g *GormArticleDAO) SyncV1(ctx context.Context, art Article) (int64, error)
 	)
 	dao := NewArticleDAO(tx)
 	if id > 0 {
-		err = dao.UpdateById(ctx, art)
+		err = dao.UpdateById(ctx, art.Article)
 	} else {
-		id, err = dao.Insert(ctx, art)
+		id, err = dao.Insert(ctx, art.Article)
 	}
 	if err != nil {
 		return 0, err
 	}
 	art.Id = id
 	now := time.Now().UnixMilli()
-	pubArt := PublishedArticle(art)
+	pubArt := art
 	pubArt.CreatedAt = now
 	pubArt.UpdatedAt = now
 	err = tx.Clauses(clause.OnConflict{
@@ -120,30 +260,43 @@ func (g *GormArticleDAO) SyncV1(ctx context.Context, art Article) (int64, error)
 		// sqlite INSERT XXX ON CONFLICT DO UPDATES WHERE
 		Columns: []clause.Column{{Name: "id"}},
 		DoUpdates: clause.Assignments(map[string]interface{}{
-			"title":      pubArt.Title,
-			"content":    pubArt.Content,
-			"updated_at": now,
+			"title":        pubArt.Title,
+			"content":      pubArt.Content,
+			"tags":         pubArt.Tags,
+			"content_html": pubArt.ContentHTML,
+			"toc":          pubArt.Toc,
+			"word_count":   pubArt.WordCount,
+			"reading_time": pubArt.ReadingTime,
+			"abstract":     pubArt.Abstract,
+			"updated_at":   now,
 		}),
 	}).Create(&pubArt).Error
 	if err != nil {
 		return 0, err
 	}
+	if err = g.syncTags(tx, id, art.Tags, now); err != nil {
+		return 0, err
+	}
 	tx.Commit()
 	return id, nil
 }
 
 func (g *GormArticleDAO) SyncStatus(ctx context.Context, article Article) (int64, error) {
 	now := time.Now().UnixMilli()
-	article.UpdatedAt = now
+	// 只改状态，不能顺带把 version 之类的字段写回去
+	fields := map[string]any{
+		"status":     article.Status,
+		"updated_at": now,
+	}
 	err := g.db.WithContext(ctx).Transaction(func(tx *gorm.DB) error {
-		result := tx.Model(&article).Where("id = ? And author_id = ?", article.Id, article.AuthorId).Updates(&article)
+		result := tx.Model(&Article{}).Where("id = ? And author_id = ?", article.Id, article.AuthorId).Updates(fields)
 		if result.Error != nil {
 			return result.Error
 		}
 		if result.RowsAffected == 0 {
 			return errors.New("更新失败，可能是非法操作")
 		}
-		return tx.Model(&PublishedArticle{}).Where("id = ?", article.Id).Updates(&article).Error
+		return tx.Model(&PublishedArticle{}).Where("id = ?", article.Id).Updates(fields).Error
 	})
 	return article.Id, err
 }
@@ -155,6 +308,148 @@ func (g *GormArticleDAO) GetById(ctx context.Context, id int64) (Article, error)
 
 func (g *GormArticleDAO) GetPubById(ctx context.Context, id int64) (PublishedArticle, error) {
 	var pubArt PublishedArticle
-	err := g.db.WithContext(ctx).Where("id = ?", id).First(&pubArt).Error
+	err := g.db.WithContext(ctx).Where("id = ? AND deleted_at = 0", id).First(&pubArt).Error
 	return pubArt, err
 }
+
+func (g *GormArticleDAO) ListRevisions(ctx context.Context, artId int64, offset int, limit int) ([]ArticleRevision, error) {
+	var revs []ArticleRevision
+	err := g.db.WithContext(ctx).Where("article_id = ?", artId).
+		Order("created_at DESC, id DESC").Offset(offset).Limit(limit).Find(&revs).Error
+	return revs, err
+}
+
+func (g *GormArticleDAO) GetRevision(ctx context.Context, artId int64, id int64) (ArticleRevision, error) {
+	var rev ArticleRevision
+	err := g.db.WithContext(ctx).Where("id = ? AND article_id = ?", id, artId).First(&rev).Error
+	return rev, err
+}
+
+func (g *GormArticleDAO) ListPubByTag(ctx context.Context, tag string, cursor Cursor, limit int) ([]PublishedArticle, error) {
+	var pubArts []PublishedArticle
+	db := g.db.WithContext(ctx)
+	// join 了 tags 表，不能直接用 afterCursor，id 会有歧义
+	if cursor.UpdatedAt > 0 {
+		db = db.Where("(published_articles.updated_at < ? OR (published_articles.updated_at = ? AND published_articles.id < ?))",
+			cursor.UpdatedAt, cursor.UpdatedAt, cursor.Id)
+	}
+	err := db.
+		Joins("JOIN published_article_tags pat ON pat.article_id = published_articles.id").
+		Joins("JOIN tags ON tags.id = pat.tag_id").
+		Where("tags.name = ? AND published_articles.status = ? AND published_articles.deleted_at = 0",
+			tag, ArticleStatusPublished).
+		Order("published_articles.updated_at DESC, published_articles.id DESC").
+		Limit(limit).Find(&pubArts).Error
+	return pubArts, err
+}
+
+func (g *GormArticleDAO) TagCounts(ctx context.Context, limit int) ([]TagCount, error) {
+	var res []TagCount
+	err := g.db.WithContext(ctx).Model(&PublishedArticleTag{}).
+		Select("tags.name AS name, COUNT(*) AS cnt").
+		Joins("JOIN tags ON tags.id = published_article_tags.tag_id").
+		Joins("JOIN published_articles ON published_articles.id = published_article_tags.article_id").
+		Where("published_articles.status = ? AND published_articles.deleted_at = 0", ArticleStatusPublished).
+		Group("tags.name").Order("cnt DESC").Limit(limit).
+		Scan(&res).Error
+	return res, err
+}
+
+func (g *GormArticleDAO) UpdateSchedule(ctx context.Context, article Article) error {
+	// 用 map 才能把 0 写进去
+	return g.db.WithContext(ctx).Model(&Article{}).
+		Where("id = ? AND author_id = ?", article.Id, article.AuthorId).
+		Updates(map[string]any{
+			"publish_at":       article.PublishAt,
+			"unpublish_at":     article.UnpublishAt,
+			"schedule_retries": article.ScheduleRetries,
+		}).Error
+}
+
+func (g *GormArticleDAO) FindDueScheduled(ctx context.Context, now int64, limit int) ([]Article, error) {
+	var arts []Article
+	err := g.db.WithContext(ctx).
+		Where("((publish_at > 0 AND publish_at <= ?) OR (unpublish_at > 0 AND unpublish_at <= ?)) AND deleted_at = 0", now, now).
+		Order("id").Limit(limit).Find(&arts).Error
+	return arts, err
+}
+
+func (g *GormArticleDAO) SoftDelete(ctx context.Context, id int64, authorId int64) error {
+	now := time.Now().UnixMilli()
+	return g.db.WithContext(ctx).Transaction(func(tx *gorm.DB) error {
+		res := tx.Model(&Article{}).
+			Where("id = ? AND author_id = ? AND deleted_at = 0", id, authorId).
+			Update("deleted_at", now)
+		if res.Error != nil {
+			return res.Error
+		}
+		if res.RowsAffected == 0 {
+			return ErrArticleNotFound
+		}
+		return tx.Model(&PublishedArticle{}).Where("id = ?", id).
+			Update("deleted_at", now).Error
+	})
+}
+
+func (g *GormArticleDAO) Restore(ctx context.Context, id int64, authorId int64) error {
+	return g.db.WithContext(ctx).Transaction(func(tx *gorm.DB) error {
+		res := tx.Model(&Article{}).
+			Where("id = ? AND author_id = ? AND deleted_at > 0", id, authorId).
+			Update("deleted_at", 0)
+		if res.Error != nil {
+			return res.Error
+		}
+		if res.RowsAffected == 0 {
+			return ErrArticleNotFound
+		}
+		return tx.Model(&PublishedArticle{}).Where("id = ?", id).
+			Update("deleted_at", 0).Error
+	})
+}
+
+func (g *GormArticleDAO) ListTrash(ctx context.Context, authorId int64, offset int, limit int) ([]Article, error) {
+	var arts []Article
+	err := g.db.WithContext(ctx).Where("author_id = ? AND deleted_at > 0", authorId).
+		Order("deleted_at DESC").Offset(offset).Limit(limit).Find(&arts).Error
+	return arts, err
+}
+
+func (g *GormArticleDAO) PurgeDeleted(ctx context.Context, before int64, limit int) (int, error) {
+	ids, err := g.purgeDeleted(ctx, before, limit, nil)
+	return len(ids), err
+}
+
+// purgeDeleted 返回删掉的文章 id，方便组合的实现清理别的存储
+// beforeDelete 在同一个事务里面、删除之前调用，可以查出要一起清理的数据
+func (g *GormArticleDAO) purgeDeleted(ctx context.Context, before int64, limit int,
+	beforeDelete func(tx *gorm.DB, ids []int64) error) ([]int64, error) {
+	var ids []int64
+	err := g.db.WithContext(ctx).Transaction(func(tx *gorm.DB) error {
+		// 锁住这一批，避免和恢复操作并发
+		err := tx.Model(&Article{}).Clauses(clause.Locking{Strength: "UPDATE"}).
+			Where("deleted_at > 0 AND deleted_at < ?", before).
+			Order("deleted_at").Limit(limit).Pluck("id", &ids).Error
+		if err != nil || len(ids) == 0 {
+			return err
+		}
+		if beforeDelete != nil {
+			if err = beforeDelete(tx, ids); err != nil {
+				return err
+			}
+		}
+		if err = tx.Where("id IN ?", ids).Delete(&Article{}).Error; err != nil {
+			return err
+		}
+		if err = tx.Where("id IN ?", ids).Delete(&PublishedArticle{}).Error; err != nil {
+			return err
+		}
+		if err = tx.Where("article_id IN ?", ids).Delete(&PublishedArticleTag{}).Error; err != nil {
+			return err
+		}
+		return tx.Where("article_id IN ?", ids).Delete(&ArticleRevision{}).Error
+	})
+	if err != nil {
+		return nil, err
+	}
+	return ids, nil
+}
@@ -0,0 +1,98 @@
+package article
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+	sqlmock "github.com/DATA-DOG/go-sqlmock"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"testing"
+)
+
+func TestGormArticleDAO_SoftDelete(t *testing.T) {
+	testCases := []struct {
+		name    string
+		mock    func(t *testing.T) *sql.DB
+		wantErr error
+	}{
+		{
+			name: "制作库和线上库一起删除",
+			mock: func(t *testing.T) *sql.DB {
+				db, mock, err := sqlmock.New()
+				require.NoError(t, err)
+				mock.ExpectBegin()
+				mock.ExpectExec("UPDATE `articles` SET `deleted_at`=.*").
+					WillReturnResult(sqlmock.NewResult(0, 1))
+				mock.ExpectExec("UPDATE `published_articles` SET `deleted_at`=.*").
+					WillReturnResult(sqlmock.NewResult(0, 1))
+				mock.ExpectCommit()
+				return db
+			},
+		},
+		{
+			name: "不存在或者已经删除",
+			mock: func(t *testing.T) *sql.DB {
+				db, mock, err := sqlmock.New()
+				require.NoError(t, err)
+				mock.ExpectBegin()
+				mock.ExpectExec("UPDATE `articles` SET `deleted_at`=.*").
+					WillReturnResult(sqlmock.NewResult(0, 0))
+				mock.ExpectRollback()
+				return db
+			},
+			wantErr: ErrArticleNotFound,
+		},
+		{
+			name: "数据库错误",
+			mock: func(t *testing.T) *sql.DB {
+				db, mock, err := sqlmock.New()
+				require.NoError(t, err)
+				mock.ExpectBegin()
+				mock.ExpectExec("UPDATE `articles` SET `deleted_at`=.*").
+					WillReturnError(errors.New("mock db error"))
+				mock.ExpectRollback()
+				return db
+			},
+			wantErr: errors.New("mock db error"),
+		},
+	}
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			d := NewArticleDAO(newMockGormDB(t, tc.mock(t)))
+			err := d.SoftDelete(context.Background(), 1, 123)
+			assert.Equal(t, tc.wantErr, err)
+		})
+	}
+}
+
+func TestGormArticleDAO_Restore(t *testing.T) {
+	db, mock, err := sqlmock.New()
+	require.NoError(t, err)
+	mock.ExpectBegin()
+	mock.ExpectExec("UPDATE `articles` SET `deleted_at`=.*").
+		WillReturnResult(sqlmock.NewResult(0, 0))
+	mock.ExpectRollback()
+	d := NewArticleDAO(newMockGormDB(t, db))
+	// 不在回收站里面
+	err = d.Restore(context.Background(), 1, 123)
+	assert.Equal(t, ErrArticleNotFound, err)
+	assert.NoError(t, mock.ExpectationsWereMet())
+}
+
+// join 了 tags 表，游标条件要带上表名
+func TestGormArticleDAO_ListPubByTag(t *testing.T) {
+	db, mock, err := sqlmock.New()
+	require.NoError(t, err)
+	mock.ExpectQuery("SELECT `published_articles`.`id`.* FROM `published_articles` "+
+		"JOIN published_article_tags pat .* JOIN tags .* "+
+		"WHERE \\(\\(published_articles.updated_at < \\? OR \\(published_articles.updated_at = \\? AND published_articles.id < \\?\\)\\)\\) "+
+		"AND \\(tags.name = \\? .*\\) ORDER BY published_articles.updated_at DESC, published_articles.id DESC LIMIT \\?").
+		WithArgs(int64(100), int64(100), int64(3), "go", 2, 10).
+		WillReturnRows(sqlmock.NewRows([]string{"id", "updated_at"}).AddRow(2, 90))
+	d := NewArticleDAO(newMockGormDB(t, db))
+	arts, err := d.ListPubByTag(context.Background(), "go", Cursor{UpdatedAt: 100, Id: 3}, 10)
+	require.NoError(t, err)
+	assert.Equal(t, []PublishedArticle{{Article: Article{Id: 2, UpdatedAt: 90}}}, arts)
+	assert.NoError(t, mock.ExpectationsWereMet())
+}
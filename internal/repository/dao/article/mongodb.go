@@ -12,6 +12,9 @@ import (
 
 type IDGenerator int64
 
+// notDeleted 没有删除的文档，恢复的时候会去掉 deletedAt 字段，老数据也没有这个字段
+var notDeleted = bson.M{"$in": bson.A{nil, 0}}
+
 type MongoDBDAO struct {
 
 	//// 代表gohub
@@ -22,34 +25,127 @@ type MongoDBDAO struct {
 
 	//线上库
 	liveCol *mongo.Collection
-	node    *snowflake.Node
-	idGen   IDGenerator
+	// 历史版本
+	revCol *mongo.Collection
+	node   *snowflake.Node
+	idGen  IDGenerator
+}
+
+func (m *MongoDBDAO) ListPub(ctx context.Context, cursor Cursor, limit int) ([]PublishedArticle, error) {
+	filter := afterCursorFilter(bson.M{
+		"status":    ArticleStatusPublished,
+		"deletedAt": notDeleted,
+	}, cursor)
+	cursorOpts := options.Find().SetSort(cursorSort).SetLimit(int64(limit))
+	res, err := m.liveCol.Find(ctx, filter, cursorOpts)
+	if err != nil {
+		return nil, err
+	}
+	var pubArts []PublishedArticle
+	err = res.All(ctx, &pubArts)
+	return pubArts, err
+}
+
+func (m *MongoDBDAO) ListPubByAuthor(ctx context.Context, authorId int64, cursor Cursor, limit int) ([]PublishedArticle, error) {
+	filter := afterCursorFilter(bson.M{
+		"author_id": authorId,
+		"status":    ArticleStatusPublished,
+		"deletedAt": notDeleted,
+	}, cursor)
+	res, err := m.liveCol.Find(ctx, filter, options.Find().SetSort(cursorSort).SetLimit(int64(limit)))
+	if err != nil {
+		return nil, err
+	}
+	var pubArts []PublishedArticle
+	err = res.All(ctx, &pubArts)
+	return pubArts, err
 }
 
-func (m *MongoDBDAO) ListPub(ctx context.Context, start time.Time, offset int, limit int) ([]PublishedArticle, error) {
-	//TODO implement me
-	panic("implement me")
+func (m *MongoDBDAO) ListPubByIds(ctx context.Context, ids []int64) ([]PublishedArticle, error) {
+	filter := bson.M{
+		"id":        bson.M{"$in": ids},
+		"status":    ArticleStatusPublished,
+		"deletedAt": notDeleted,
+	}
+	res, err := m.liveCol.Find(ctx, filter)
+	if err != nil {
+		return nil, err
+	}
+	var pubArts []PublishedArticle
+	err = res.All(ctx, &pubArts)
+	return pubArts, err
+}
+
+func (m *MongoDBDAO) PubIdsByAuthor(ctx context.Context, authorId int64, maxId int64, limit int) ([]int64, error) {
+	filter := bson.M{
+		"author_id": authorId,
+		"id":        bson.M{"$gt": maxId},
+		"status":    ArticleStatusPublished,
+		"deletedAt": notDeleted,
+	}
+	opts := options.Find().SetProjection(bson.M{"id": 1}).
+		SetSort(bson.D{bson.E{Key: "id", Value: 1}}).SetLimit(int64(limit))
+	res, err := m.liveCol.Find(ctx, filter, opts)
+	if err != nil {
+		return nil, err
+	}
+	var pubArts []PublishedArticle
+	if err = res.All(ctx, &pubArts); err != nil {
+		return nil, err
+	}
+	ids := make([]int64, 0, len(pubArts))
+	for _, art := range pubArts {
+		ids = append(ids, art.Id)
+	}
+	return ids, nil
+}
+
+// cursorSort 和游标的顺序一致
+var cursorSort = bson.D{bson.E{Key: "updated_at", Value: -1}, bson.E{Key: "id", Value: -1}}
+
+func afterCursorFilter(filter bson.M, cursor Cursor) bson.M {
+	if cursor.UpdatedAt == 0 {
+		return filter
+	}
+	filter["$or"] = bson.A{
+		bson.M{"updated_at": bson.M{"$lt": cursor.UpdatedAt}},
+		bson.M{"updated_at": cursor.UpdatedAt, "id": bson.M{"$lt": cursor.Id}},
+	}
+	return filter
 }
 
 func (m *MongoDBDAO) GetById(ctx context.Context, id int64) (Article, error) {
-	//TODO implement me
-	panic("implement me")
+	var art Article
+	err := m.col.FindOne(ctx, bson.M{"id": id}).Decode(&art)
+	return art, err
 }
 
 func (m *MongoDBDAO) GetPubById(ctx context.Context, id int64) (PublishedArticle, error) {
-	//TODO implement me
-	panic("implement me")
+	var pubArt PublishedArticle
+	err := m.liveCol.FindOne(ctx, bson.M{"id": id, "deletedAt": notDeleted}).Decode(&pubArt)
+	if errors.Is(err, mongo.ErrNoDocuments) {
+		return PublishedArticle{}, ErrArticleNotFound
+	}
+	return pubArt, err
 }
 
-func (m *MongoDBDAO) FindByAuthorId(Dao context.Context, id int64, offset int, limit int) ([]Article, error) {
-	//TODO implement me
-	panic("implement me")
+func (m *MongoDBDAO) FindByAuthorId(ctx context.Context, id int64, cursor Cursor, limit int) ([]Article, error) {
+	filter := afterCursorFilter(bson.M{"author_id": id, "deletedAt": notDeleted}, cursor)
+	opts := options.Find().SetSort(cursorSort).SetLimit(int64(limit))
+	res, err := m.col.Find(ctx, filter, opts)
+	if err != nil {
+		return nil, err
+	}
+	var arts []Article
+	err = res.All(ctx, &arts)
+	return arts, err
 }
 
 func NewMongoDBDAO(db *mongo.Database, node *snowflake.Node) ArticleDAO {
 	return &MongoDBDAO{
 		col:     db.Collection("articles"),
 		liveCol: db.Collection("published_articles"),
+		revCol:  db.Collection("article_revisions"),
 		node:    node,
 	}
 }
@@ -57,6 +153,7 @@ func NewMongoDBDAOV1(db *mongo.Database, idGen IDGenerator) *MongoDBDAO {
 	return &MongoDBDAO{
 		col:     db.Collection("articles"),
 		liveCol: db.Collection("published_articles"),
+		revCol:  db.Collection("article_revisions"),
 		idGen:   idGen,
 	}
 }
@@ -74,6 +171,21 @@ func InitCollections(db *mongo.Database) error {
 			},
 			Options: options.Index(),
 		},
+		{
+			// 分页按照 (updated_at, id) 倒序
+			Keys: bson.D{bson.E{Key: "author_id", Value: 1},
+				bson.E{Key: "updated_at", Value: -1},
+				bson.E{Key: "id", Value: -1},
+			},
+			Options: options.Index(),
+		},
+		{
+			Keys: bson.D{bson.E{Key: "status", Value: 1},
+				bson.E{Key: "updated_at", Value: -1},
+				bson.E{Key: "id", Value: -1},
+			},
+			Options: options.Index(),
+		},
 	}
 	_, err := db.Collection("articles").Indexes().
 		CreateMany(ctx, index)
@@ -81,49 +193,123 @@ func InitCollections(db *mongo.Database) error {
 		return err
 	}
 	_, err = db.Collection("published_articles").Indexes().
-		CreateMany(ctx, index)
+		CreateMany(ctx, append(index, mongo.IndexModel{
+			// 按标签浏览
+			Keys: bson.D{bson.E{Key: "tags", Value: 1},
+				bson.E{Key: "updated_at", Value: -1},
+				bson.E{Key: "id", Value: -1},
+			},
+			Options: options.Index(),
+		}))
+	if err != nil {
+		return err
+	}
+	_, err = db.Collection("article_revisions").Indexes().
+		CreateMany(ctx, []mongo.IndexModel{
+			{
+				Keys:    bson.D{bson.E{Key: "id", Value: 1}},
+				Options: options.Index().SetUnique(true),
+			},
+			{
+				Keys: bson.D{bson.E{Key: "article_id", Value: 1},
+					bson.E{Key: "created_at", Value: -1},
+				},
+				Options: options.Index(),
+			},
+		})
 	return err
 }
 func (m *MongoDBDAO) Insert(ctx context.Context, article Article) (int64, error) {
 	now := time.Now().UnixMilli()
 	article.CreatedAt = now
 	article.UpdatedAt = now
+	article.Version = 1
 	id := m.node.Generate().Int64()
 	article.Id = id
 	_, err := m.col.InsertOne(ctx, article)
-	return id, err
+	if err != nil {
+		return 0, err
+	}
+	return id, m.appendRevision(ctx, article, now)
 }
 
 func (m *MongoDBDAO) UpdateById(ctx context.Context, article Article) error {
 	// 操作制作库
-	filter := bson.M{"id": article.Id, "author_id": article.AuthorId}
-	update := bson.D{bson.E{"$set", bson.M{
-		"title":      article.Title,
-		"content":    article.Content,
-		"updated_at": time.Now().UnixMilli(),
-		"status":     article.Status,
-	}}}
+	now := time.Now().UnixMilli()
+	filter := bson.M{"id": article.Id, "author_id": article.AuthorId, "version": article.Version, "deletedAt": notDeleted}
+	update := bson.D{
+		bson.E{Key: "$set", Value: bson.M{
+			"title":         article.Title,
+			"content":       article.Content,
+			"tags":          article.Tags,
+			"updated_at":    now,
+			"status":        article.Status,
+			"review_reason": article.ReviewReason,
+		}},
+		bson.E{Key: "$inc", Value: bson.M{"version": 1}},
+	}
 	res, err := m.col.UpdateOne(ctx, filter, update)
 	if err != nil {
 		return err
 	}
 
-	// 这边就是校验了 author_id 是不是正确的 ID
+	// 这边就是校验了 author_id 和 version
 	if res.ModifiedCount == 0 {
+		var cur Article
+		er := m.col.FindOne(ctx, bson.M{"id": article.Id}).Decode(&cur)
+		if er == nil && cur.AuthorId == article.AuthorId && cur.Version != article.Version {
+			return ErrVersionConflict
+		}
 		return errors.New("更新数据失败")
 	}
-	return nil
+	return m.appendRevision(ctx, article, now)
 }
 
-func (m *MongoDBDAO) Sync(ctx context.Context, article Article) (int64, error) {
+// appendRevision mongo 这里没有用事务，制作库写成功之后再追加历史版本
+func (m *MongoDBDAO) appendRevision(ctx context.Context, article Article, now int64) error {
+	_, err := m.revCol.InsertOne(ctx, ArticleRevision{
+		Id:        m.node.Generate().Int64(),
+		ArticleId: article.Id,
+		AuthorId:  article.editor(),
+		Title:     article.Title,
+		Content:   article.Content,
+		Status:    article.Status,
+		CreatedAt: now,
+	})
+	return err
+}
+
+func (m *MongoDBDAO) ListRevisions(ctx context.Context, artId int64, offset int, limit int) ([]ArticleRevision, error) {
+	filter := bson.M{"article_id": artId}
+	opts := options.Find().
+		SetSort(bson.D{bson.E{Key: "created_at", Value: -1}, bson.E{Key: "id", Value: -1}}).
+		SetSkip(int64(offset)).SetLimit(int64(limit))
+	cursor, err := m.revCol.Find(ctx, filter, opts)
+	if err != nil {
+		return nil, err
+	}
+	var revs []ArticleRevision
+	err = cursor.All(ctx, &revs)
+	return revs, err
+}
+
+func (m *MongoDBDAO) GetRevision(ctx context.Context, artId int64, id int64) (ArticleRevision, error) {
+	var rev ArticleRevision
+	err := m.revCol.FindOne(ctx, bson.M{"id": id, "article_id": artId}).Decode(&rev)
+	return rev, err
+}
+
+func (m *MongoDBDAO) Sync(ctx context.Context, article PublishedArticle) (int64, error) {
 	var (
 		id  = article.Id
 		err error
 	)
 	if id > 0 {
-		err = m.UpdateById(ctx, article)
+		err = m.UpdateById(ctx, article.Article)
+		article.Version++
 	} else {
-		id, err = m.Insert(ctx, article)
+		id, err = m.Insert(ctx, article.Article)
+		article.Version = 1
 	}
 	if err != nil {
 		return 0, err
@@ -136,10 +322,31 @@ func (m *MongoDBDAO) Sync(ctx context.Context, article Article) (int64, error) {
 	article.UpdatedAt = now
 	updateV1 := bson.M{
 		// 更新，如果不存在，就是插入，
-		"$set": PublishedArticle(article),
+		"$set": article,
 		// 在插入的时候，要插入 ctime
 		"$setOnInsert": bson.M{"created_at": now},
 	}
+	// omitempty 不会覆盖掉原来的值，空的字段要显式删掉
+	unset := bson.M{}
+	if len(article.Tags) == 0 {
+		unset["tags"] = ""
+	}
+	if len(article.Toc) == 0 {
+		unset["toc"] = ""
+	}
+	if article.ContentHTML == "" {
+		unset["content_html"] = ""
+	}
+	if article.Abstract == "" {
+		unset["abstract"] = ""
+	}
+	if article.WordCount == 0 {
+		unset["word_count"] = ""
+		unset["reading_time"] = ""
+	}
+	if len(unset) > 0 {
+		updateV1["$unset"] = unset
+	}
 	filter := bson.M{"id": article.Id}
 	_, err = m.liveCol.UpdateOne(ctx, filter,
 		//bson.D{update, upsert},
@@ -148,12 +355,201 @@ func (m *MongoDBDAO) Sync(ctx context.Context, article Article) (int64, error) {
 	return id, err
 }
 
-func (m *MongoDBDAO) SyncV1(ctx context.Context, article Article) (int64, error) {
-	//TODO implement me
-	panic("implement me")
+// SyncV1 mongo 单机没有事务，和 Sync 是同一个流程
+func (m *MongoDBDAO) SyncV1(ctx context.Context, article PublishedArticle) (int64, error) {
+	return m.Sync(ctx, article)
 }
 
 func (m *MongoDBDAO) SyncStatus(ctx context.Context, article Article) (int64, error) {
-	//TODO implement me
-	panic("implement me")
+	now := time.Now().UnixMilli()
+	// 制作库带上 author_id，校验是不是作者本人
+	filter := bson.M{"id": article.Id, "author_id": article.AuthorId}
+	update := bson.M{"$set": bson.M{
+		"status":     article.Status,
+		"updated_at": now,
+	}}
+	res, err := m.col.UpdateOne(ctx, filter, update)
+	if err != nil {
+		return 0, err
+	}
+	if res.MatchedCount == 0 {
+		return 0, errors.New("更新失败，可能是非法操作")
+	}
+	_, err = m.liveCol.UpdateOne(ctx, bson.M{"id": article.Id}, update)
+	return article.Id, err
+}
+
+func (m *MongoDBDAO) FindByStatus(ctx context.Context, status uint8, offset int, limit int) ([]Article, error) {
+	filter := bson.M{"status": status, "deletedAt": notDeleted}
+	opts := options.Find().
+		SetSort(bson.D{bson.E{Key: "updated_at", Value: 1}, bson.E{Key: "id", Value: 1}}).
+		SetSkip(int64(offset)).SetLimit(int64(limit))
+	cursor, err := m.col.Find(ctx, filter, opts)
+	if err != nil {
+		return nil, err
+	}
+	var arts []Article
+	err = cursor.All(ctx, &arts)
+	return arts, err
+}
+
+func (m *MongoDBDAO) UpdateReview(ctx context.Context, id int64, from uint8, to uint8, reason string) error {
+	filter := bson.M{"id": id, "status": from, "deletedAt": notDeleted}
+	res, err := m.col.UpdateOne(ctx, filter, bson.M{"$set": bson.M{
+		"status":        to,
+		"review_reason": reason,
+		"updated_at":    time.Now().UnixMilli(),
+	}})
+	if err != nil {
+		return err
+	}
+	if res.MatchedCount == 0 {
+		return ErrArticleNotFound
+	}
+	return nil
+}
+
+func (m *MongoDBDAO) UpdateSchedule(ctx context.Context, article Article) error {
+	filter := bson.M{"id": article.Id, "author_id": article.AuthorId}
+	_, err := m.col.UpdateOne(ctx, filter, bson.M{"$set": bson.M{
+		"publish_at":       article.PublishAt,
+		"unpublish_at":     article.UnpublishAt,
+		"schedule_retries": article.ScheduleRetries,
+	}})
+	return err
+}
+
+func (m *MongoDBDAO) FindDueScheduled(ctx context.Context, now int64, limit int) ([]Article, error) {
+	filter := bson.M{
+		"$or": bson.A{
+			bson.M{"publish_at": bson.M{"$gt": 0, "$lte": now}},
+			bson.M{"unpublish_at": bson.M{"$gt": 0, "$lte": now}},
+		},
+		"deletedAt": notDeleted,
+	}
+	opts := options.Find().
+		SetSort(bson.D{bson.E{Key: "id", Value: 1}}).
+		SetLimit(int64(limit))
+	cursor, err := m.col.Find(ctx, filter, opts)
+	if err != nil {
+		return nil, err
+	}
+	var arts []Article
+	err = cursor.All(ctx, &arts)
+	return arts, err
+}
+
+func (m *MongoDBDAO) ListPubByTag(ctx context.Context, tag string, cursor Cursor, limit int) ([]PublishedArticle, error) {
+	filter := afterCursorFilter(bson.M{
+		"tags":      tag,
+		"status":    ArticleStatusPublished,
+		"deletedAt": notDeleted,
+	}, cursor)
+	res, err := m.liveCol.Find(ctx, filter, options.Find().SetSort(cursorSort).SetLimit(int64(limit)))
+	if err != nil {
+		return nil, err
+	}
+	var pubArts []PublishedArticle
+	err = res.All(ctx, &pubArts)
+	return pubArts, err
+}
+
+func (m *MongoDBDAO) TagCounts(ctx context.Context, limit int) ([]TagCount, error) {
+	pipeline := mongo.Pipeline{
+		{{Key: "$match", Value: bson.M{"status": ArticleStatusPublished, "deletedAt": notDeleted}}},
+		{{Key: "$unwind", Value: "$tags"}},
+		{{Key: "$group", Value: bson.M{"_id": "$tags", "cnt": bson.M{"$sum": 1}}}},
+		{{Key: "$sort", Value: bson.D{bson.E{Key: "cnt", Value: -1}}}},
+		{{Key: "$limit", Value: int64(limit)}},
+	}
+	cursor, err := m.liveCol.Aggregate(ctx, pipeline)
+	if err != nil {
+		return nil, err
+	}
+	var rows []struct {
+		Name string `bson:"_id"`
+		Cnt  int64  `bson:"cnt"`
+	}
+	if err = cursor.All(ctx, &rows); err != nil {
+		return nil, err
+	}
+	res := make([]TagCount, 0, len(rows))
+	for _, row := range rows {
+		res = append(res, TagCount{Name: row.Name, Cnt: row.Cnt})
+	}
+	return res, nil
+}
+
+func (m *MongoDBDAO) SoftDelete(ctx context.Context, id int64, authorId int64) error {
+	now := time.Now().UnixMilli()
+	update := bson.M{"$set": bson.M{"deletedAt": now}}
+	res, err := m.col.UpdateOne(ctx,
+		bson.M{"id": id, "author_id": authorId, "deletedAt": notDeleted}, update)
+	if err != nil {
+		return err
+	}
+	if res.MatchedCount == 0 {
+		return ErrArticleNotFound
+	}
+	_, err = m.liveCol.UpdateOne(ctx, bson.M{"id": id}, update)
+	return err
+}
+
+func (m *MongoDBDAO) Restore(ctx context.Context, id int64, authorId int64) error {
+	update := bson.M{"$unset": bson.M{"deletedAt": ""}}
+	res, err := m.col.UpdateOne(ctx,
+		bson.M{"id": id, "author_id": authorId, "deletedAt": bson.M{"$gt": 0}}, update)
+	if err != nil {
+		return err
+	}
+	if res.MatchedCount == 0 {
+		return ErrArticleNotFound
+	}
+	_, err = m.liveCol.UpdateOne(ctx, bson.M{"id": id}, update)
+	return err
+}
+
+func (m *MongoDBDAO) ListTrash(ctx context.Context, authorId int64, offset int, limit int) ([]Article, error) {
+	filter := bson.M{"author_id": authorId, "deletedAt": bson.M{"$gt": 0}}
+	opts := options.Find().
+		SetSort(bson.D{bson.E{Key: "deletedAt", Value: -1}}).
+		SetSkip(int64(offset)).SetLimit(int64(limit))
+	cursor, err := m.col.Find(ctx, filter, opts)
+	if err != nil {
+		return nil, err
+	}
+	var arts []Article
+	err = cursor.All(ctx, &arts)
+	return arts, err
+}
+
+// PurgeDeleted mongo 单机没有事务，先删线上库和历史版本，最后删制作库
+// 中途失败的话制作库的记录还在，下一轮会重新清理
+func (m *MongoDBDAO) PurgeDeleted(ctx context.Context, before int64, limit int) (int, error) {
+	filter := bson.M{"deletedAt": bson.M{"$gt": 0, "$lt": before}}
+	opts := options.Find().
+		SetSort(bson.D{bson.E{Key: "deletedAt", Value: 1}}).
+		SetLimit(int64(limit)).
+		SetProjection(bson.M{"id": 1})
+	cursor, err := m.col.Find(ctx, filter, opts)
+	if err != nil {
+		return 0, err
+	}
+	var arts []Article
+	if err = cursor.All(ctx, &arts); err != nil || len(arts) == 0 {
+		return 0, err
+	}
+	ids := make(bson.A, 0, len(arts))
+	for _, art := range arts {
+		ids = append(ids, art.Id)
+	}
+	if _, err = m.liveCol.DeleteMany(ctx, bson.M{"id": bson.M{"$in": ids}}); err != nil {
+		return 0, err
+	}
+	if _, err = m.revCol.DeleteMany(ctx, bson.M{"article_id": bson.M{"$in": ids}}); err != nil {
+		return 0, err
+	}
+	// 带上删除条件，期间被恢复的文章不会被删掉
+	_, err = m.col.DeleteMany(ctx, bson.M{"id": bson.M{"$in": ids}, "deletedAt": bson.M{"$gt": 0}})
+	return len(ids), err
 }
@@ -3,5 +3,5 @@ package article
 import "context"
 
 type ReaderDAO interface {
-	Upsert(ctx context.Context, article Article) error
+	Upsert(ctx context.Context, article PublishedArticle) error
 }
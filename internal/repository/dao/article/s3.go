@@ -1,6 +1,202 @@
 package article
 
+import (
+	"context"
+	"errors"
+	"fmt"
+	"github.com/Andras5014/gohub/pkg/ossx"
+	"gorm.io/gorm"
+	"gorm.io/gorm/clause"
+	"time"
+)
+
+// S3DAO 线上库只保存元数据和对象的 key，正文放到对象存储
+// 制作库还是完整保存在 MySQL 里面
 type S3DAO struct {
-	//oss *s3.S3
+	oss ossx.Store
 	GormArticleDAO
 }
+
+func NewArticleS3DAO(db *gorm.DB, oss ossx.Store) ArticleDAO {
+	return &S3DAO{
+		oss:            oss,
+		GormArticleDAO: GormArticleDAO{db: db},
+	}
+}
+
+// Sync 正文先上传到一个新的 key，事务里面把线上库切换过去，提交之后再删掉原来的对象
+// 不管哪一步失败，线上库指向的都是一份完整的、已经发表的内容
+func (o *S3DAO) Sync(ctx context.Context, article PublishedArticle) (int64, error) {
+	key := o.contentKey(article.AuthorId, time.Now().UnixNano())
+	// 渲染好的 HTML 和正文放在一起
+	if err := o.oss.Put(ctx, o.htmlKey(key), []byte(article.ContentHTML)); err != nil {
+		return 0, err
+	}
+	if err := o.oss.Put(ctx, key, []byte(article.Content)); err != nil {
+		o.deleteObjects(ctx, key)
+		return 0, err
+	}
+	var (
+		id     = article.Id
+		oldKey string
+	)
+	err := o.db.WithContext(ctx).Transaction(func(tx *gorm.DB) error {
+		var err error
+		dao := NewArticleDAO(tx)
+		if id > 0 {
+			err = dao.UpdateById(ctx, article.Article)
+		} else {
+			id, err = dao.Insert(ctx, article.Article)
+		}
+		if err != nil {
+			return err
+		}
+		article.Id = id
+		var oldKeys []string
+		err = tx.Model(&PublishedArticle{}).Where("id = ?", id).Pluck("content_key", &oldKeys).Error
+		if err != nil {
+			return err
+		}
+		if len(oldKeys) > 0 {
+			oldKey = oldKeys[0]
+		}
+		now := time.Now().UnixMilli()
+		pubArt := PublishedArticle{
+			Article: Article{
+				Id:        id,
+				Title:     article.Title,
+				AuthorId:  article.AuthorId,
+				Status:    article.Status,
+				Tags:      article.Tags,
+				CreatedAt: now,
+				UpdatedAt: now,
+			},
+			// HTML 和正文一起放在对象存储
+			Rendered: Rendered{
+				Toc:         article.Toc,
+				WordCount:   article.WordCount,
+				ReadingTime: article.ReadingTime,
+				Abstract:    article.Abstract,
+			},
+			ContentKey: key,
+		}
+		err = tx.Clauses(clause.OnConflict{
+			Columns: []clause.Column{{Name: "id"}},
+			DoUpdates: clause.Assignments(map[string]interface{}{
+				"title":        pubArt.Title,
+				"content":      "",
+				"content_html": "",
+				"content_key":  pubArt.ContentKey,
+				"tags":         pubArt.Tags,
+				"toc":          pubArt.Toc,
+				"word_count":   pubArt.WordCount,
+				"reading_time": pubArt.ReadingTime,
+				"abstract":     pubArt.Abstract,
+				"updated_at":   now,
+				"status":       pubArt.Status,
+			}),
+		}).Create(&pubArt).Error
+		if err != nil {
+			return err
+		}
+		return o.syncTags(tx, id, article.Tags, now)
+	})
+	if err != nil {
+		// 线上库没有切换过去，新上传的对象没人引用
+		o.deleteObjects(ctx, key)
+		return id, err
+	}
+	if oldKey != "" {
+		o.deleteObjects(ctx, oldKey)
+	}
+	return id, nil
+}
+
+func (o *S3DAO) SyncV1(ctx context.Context, article PublishedArticle) (int64, error) {
+	return o.Sync(ctx, article)
+}
+
+// SyncStatus 只改状态，避免把正文写回线上库
+func (o *S3DAO) SyncStatus(ctx context.Context, article Article) (int64, error) {
+	now := time.Now().UnixMilli()
+	err := o.db.WithContext(ctx).Transaction(func(tx *gorm.DB) error {
+		res := tx.Model(&Article{}).Where("id = ? And author_id = ?", article.Id, article.AuthorId).
+			Updates(map[string]any{
+				"status":     article.Status,
+				"updated_at": now,
+			})
+		if res.Error != nil {
+			return res.Error
+		}
+		if res.RowsAffected == 0 {
+			return errors.New("更新失败，可能是非法操作")
+		}
+		return tx.Model(&PublishedArticle{}).Where("id = ?", article.Id).
+			Updates(map[string]any{
+				"status":     article.Status,
+				"updated_at": now,
+			}).Error
+	})
+	return article.Id, err
+}
+
+// GetPubById 列表只有元数据，只有看详情的时候才去对象存储拿正文
+func (o *S3DAO) GetPubById(ctx context.Context, id int64) (PublishedArticle, error) {
+	pubArt, err := o.GormArticleDAO.GetPubById(ctx, id)
+	if err != nil {
+		return PublishedArticle{}, err
+	}
+	// 兼容迁移之前正文还在 MySQL 里面的数据
+	if pubArt.ContentKey == "" {
+		return pubArt, nil
+	}
+	data, err := o.oss.Get(ctx, pubArt.ContentKey)
+	if err != nil {
+		return PublishedArticle{}, err
+	}
+	pubArt.Content = string(data)
+	html, err := o.oss.Get(ctx, o.htmlKey(pubArt.ContentKey))
+	if err != nil {
+		// 渲染结果丢了不影响阅读，上层会用正文重新渲染
+		return pubArt, nil
+	}
+	pubArt.ContentHTML = string(html)
+	return pubArt, nil
+}
+
+// PurgeDeleted 数据库提交之后再删对象，删对象失败只会留下没人引用的对象
+func (o *S3DAO) PurgeDeleted(ctx context.Context, before int64, limit int) (int, error) {
+	var keys []string
+	ids, err := o.purgeDeleted(ctx, before, limit, func(tx *gorm.DB, ids []int64) error {
+		return tx.Model(&PublishedArticle{}).Where("id IN ? AND content_key <> ''", ids).
+			Pluck("content_key", &keys).Error
+	})
+	if err != nil {
+		return 0, err
+	}
+	for _, key := range keys {
+		if er := o.oss.Delete(ctx, key); er != nil {
+			return len(ids), er
+		}
+		if er := o.oss.Delete(ctx, o.htmlKey(key)); er != nil {
+			return len(ids), er
+		}
+	}
+	return len(ids), nil
+}
+
+// deleteObjects 已经没人引用的对象，删除失败也不影响读，不返回错误
+func (o *S3DAO) deleteObjects(ctx context.Context, key string) {
+	_ = o.oss.Delete(ctx, key)
+	_ = o.oss.Delete(ctx, o.htmlKey(key))
+}
+
+// contentKey 每次发表都是一个新的 key，新文章在拿到 id 之前就可以先上传
+// 老数据的 key 是 article/{id}，这里换了一个前缀，本地存储的文件和目录不会冲突
+func (o *S3DAO) contentKey(authorId int64, nano int64) string {
+	return fmt.Sprintf("articles/%d/%d", authorId, nano)
+}
+
+func (o *S3DAO) htmlKey(contentKey string) string {
+	return contentKey + ".html"
+}
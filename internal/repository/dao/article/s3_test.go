@@ -0,0 +1,215 @@
+package article
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+	"github.com/Andras5014/gohub/pkg/ossx"
+	sqlmock "github.com/DATA-DOG/go-sqlmock"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"gorm.io/driver/mysql"
+	"gorm.io/gorm"
+	"path/filepath"
+	"testing"
+)
+
+func TestS3DAO_GetPubById(t *testing.T) {
+	testCases := []struct {
+		name string
+		mock func(t *testing.T) *sql.DB
+		// objects 对象存储里面已有的对象
+		objects map[string]string
+
+		wantArt PublishedArticle
+		wantErr error
+	}{
+		{
+			name: "正文和渲染结果都从对象存储拿",
+			mock: func(t *testing.T) *sql.DB {
+				db, mock, err := sqlmock.New()
+				require.NoError(t, err)
+				mock.ExpectQuery("SELECT \\* FROM `published_articles` WHERE id = .*").
+					WillReturnRows(sqlmock.NewRows([]string{"id", "title", "content_key"}).
+						AddRow(1, "标题", "article/1"))
+				return db
+			},
+			objects: map[string]string{
+				"article/1":      "正文",
+				"article/1.html": "<p>正文</p>",
+			},
+			wantArt: PublishedArticle{
+				Article:    Article{Id: 1, Title: "标题", Content: "正文"},
+				ContentKey: "article/1",
+				Rendered:   Rendered{ContentHTML: "<p>正文</p>"},
+			},
+		},
+		{
+			name: "渲染结果丢了",
+			mock: func(t *testing.T) *sql.DB {
+				db, mock, err := sqlmock.New()
+				require.NoError(t, err)
+				mock.ExpectQuery("SELECT \\* FROM `published_articles` WHERE id = .*").
+					WillReturnRows(sqlmock.NewRows([]string{"id", "title", "content_key"}).
+						AddRow(1, "标题", "article/1"))
+				return db
+			},
+			objects: map[string]string{
+				"article/1": "正文",
+			},
+			wantArt: PublishedArticle{Article: Article{Id: 1, Title: "标题", Content: "正文"}, ContentKey: "article/1"},
+		},
+		{
+			name: "迁移之前的数据正文还在数据库里面",
+			mock: func(t *testing.T) *sql.DB {
+				db, mock, err := sqlmock.New()
+				require.NoError(t, err)
+				mock.ExpectQuery("SELECT \\* FROM `published_articles` WHERE id = .*").
+					WillReturnRows(sqlmock.NewRows([]string{"id", "title", "content"}).
+						AddRow(1, "标题", "老的正文"))
+				return db
+			},
+			wantArt: PublishedArticle{Article: Article{Id: 1, Title: "标题", Content: "老的正文"}},
+		},
+		{
+			name: "正文对象不存在",
+			mock: func(t *testing.T) *sql.DB {
+				db, mock, err := sqlmock.New()
+				require.NoError(t, err)
+				mock.ExpectQuery("SELECT \\* FROM `published_articles` WHERE id = .*").
+					WillReturnRows(sqlmock.NewRows([]string{"id", "title", "content_key"}).
+						AddRow(1, "标题", "article/1"))
+				return db
+			},
+			wantErr: ossx.ErrObjectNotFound,
+		},
+	}
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			store := ossx.NewLocalStore(t.TempDir())
+			for key, val := range tc.objects {
+				require.NoError(t, store.Put(context.Background(), key, []byte(val)))
+			}
+			d := NewArticleS3DAO(newMockGormDB(t, tc.mock(t)), store)
+			art, err := d.GetPubById(context.Background(), 1)
+			assert.Equal(t, tc.wantErr, err)
+			assert.Equal(t, tc.wantArt, art)
+		})
+	}
+}
+
+// 列表只查数据库，对象存储里面什么都没有也不影响
+func TestS3DAO_ListPub(t *testing.T) {
+	db, mock, err := sqlmock.New()
+	require.NoError(t, err)
+	mock.ExpectQuery("SELECT \\* FROM `published_articles` WHERE .*").
+		WillReturnRows(sqlmock.NewRows([]string{"id", "title", "content_key", "abstract"}).
+			AddRow(2, "标题2", "article/2", "摘要2").
+			AddRow(1, "标题1", "article/1", "摘要1"))
+	d := NewArticleS3DAO(newMockGormDB(t, db), ossx.NewLocalStore(t.TempDir()))
+	arts, err := d.ListPub(context.Background(), Cursor{}, 10)
+	require.NoError(t, err)
+	assert.Equal(t, []PublishedArticle{
+		{
+			Article:    Article{Id: 2, Title: "标题2"},
+			ContentKey: "article/2",
+			Rendered:   Rendered{Abstract: "摘要2"},
+		},
+		{
+			Article:    Article{Id: 1, Title: "标题1"},
+			ContentKey: "article/1",
+			Rendered:   Rendered{Abstract: "摘要1"},
+		},
+	}, arts)
+	assert.NoError(t, mock.ExpectationsWereMet())
+}
+
+func TestS3DAO_Sync(t *testing.T) {
+	testCases := []struct {
+		name string
+		mock func(t *testing.T) *sql.DB
+
+		// wantOld 原来的对象还在不在
+		wantOld bool
+		// wantNew 新上传的对象还在不在
+		wantNew bool
+		wantErr error
+	}{
+		{
+			name: "切换到新的对象，删掉原来的",
+			mock: func(t *testing.T) *sql.DB {
+				db, mock, err := sqlmock.New()
+				require.NoError(t, err)
+				mock.ExpectBegin()
+				mock.ExpectExec("SAVEPOINT .*").WillReturnResult(sqlmock.NewResult(0, 0))
+				mock.ExpectExec("UPDATE `articles` SET .*").WillReturnResult(sqlmock.NewResult(0, 1))
+				mock.ExpectExec("INSERT INTO `article_revisions` .*").WillReturnResult(sqlmock.NewResult(1, 1))
+				mock.ExpectQuery("SELECT `content_key` FROM `published_articles` WHERE id = \\?").
+					WithArgs(int64(1)).
+					WillReturnRows(sqlmock.NewRows([]string{"content_key"}).AddRow("article/1"))
+				mock.ExpectExec("INSERT INTO `published_articles` .* ON DUPLICATE KEY UPDATE .*").
+					WillReturnResult(sqlmock.NewResult(1, 2))
+				mock.ExpectExec("DELETE FROM `published_article_tags` WHERE article_id = \\?").
+					WillReturnResult(sqlmock.NewResult(0, 0))
+				mock.ExpectCommit()
+				return db
+			},
+			wantNew: true,
+		},
+		{
+			name: "事务失败，线上库还是原来的对象",
+			mock: func(t *testing.T) *sql.DB {
+				db, mock, err := sqlmock.New()
+				require.NoError(t, err)
+				mock.ExpectBegin()
+				mock.ExpectExec("SAVEPOINT .*").WillReturnResult(sqlmock.NewResult(0, 0))
+				mock.ExpectExec("UPDATE `articles` SET .*").WillReturnError(errors.New("mock db error"))
+				mock.ExpectExec("ROLLBACK TO SAVEPOINT .*").WillReturnResult(sqlmock.NewResult(0, 0))
+				mock.ExpectRollback()
+				return db
+			},
+			wantOld: true,
+			wantErr: errors.New("mock db error"),
+		},
+	}
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			root := t.TempDir()
+			store := ossx.NewLocalStore(root)
+			ctx := context.Background()
+			require.NoError(t, store.Put(ctx, "article/1", []byte("老的正文")))
+			require.NoError(t, store.Put(ctx, "article/1.html", []byte("<p>老的正文</p>")))
+			d := NewArticleS3DAO(newMockGormDB(t, tc.mock(t)), store)
+			_, err := d.Sync(ctx, PublishedArticle{
+				Article:  Article{Id: 1, AuthorId: 123, Title: "标题", Content: "新的正文", Version: 2},
+				Rendered: Rendered{ContentHTML: "<p>新的正文</p>"},
+			})
+			assert.Equal(t, tc.wantErr, err)
+
+			_, err = store.Get(ctx, "article/1")
+			assert.Equal(t, tc.wantOld, err == nil)
+			_, err = store.Get(ctx, "article/1.html")
+			assert.Equal(t, tc.wantOld, err == nil)
+			objects, err := filepath.Glob(filepath.Join(root, "articles", "123", "*"))
+			require.NoError(t, err)
+			if tc.wantNew {
+				// 正文和 HTML
+				assert.Len(t, objects, 2)
+			} else {
+				assert.Empty(t, objects)
+			}
+		})
+	}
+}
+
+func newMockGormDB(t *testing.T, db *sql.DB) *gorm.DB {
+	gormDB, err := gorm.Open(mysql.New(mysql.Config{
+		Conn:                      db,
+		SkipInitializeWithVersion: true,
+	}), &gorm.Config{
+		SkipDefaultTransaction: true,
+		DisableAutomaticPing:   true,
+	})
+	require.NoError(t, err)
+	return gormDB
+}
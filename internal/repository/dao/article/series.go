@@ -0,0 +1,228 @@
+package article
+
+import (
+	"context"
+	"errors"
+	"gorm.io/gorm"
+	"gorm.io/gorm/clause"
+	"time"
+)
+
+var (
+	// ErrSeriesNotFound 系列不存在，或者文章不在这个系列里面
+	ErrSeriesNotFound = gorm.ErrRecordNotFound
+	// ErrArticleInSeries 一篇文章只能属于一个系列
+	ErrArticleInSeries = errors.New("文章已经在别的系列里面")
+	// ErrSeriesOrderMismatch 排序带上来的文章和系列里面的对不上
+	ErrSeriesOrderMismatch = errors.New("系列文章不一致")
+)
+
+type Series struct {
+	Id          int64  `gorm:"primaryKey,autoIncrement"`
+	AuthorId    int64  `gorm:"index"`
+	Title       string `gorm:"type:varchar(256)"`
+	Description string `gorm:"type:varchar(1024)"`
+	CreatedAt   int64
+	UpdatedAt   int64
+}
+
+// SeriesArticle 系列里面的文章，Position 从 1 开始
+type SeriesArticle struct {
+	Id        int64 `gorm:"primaryKey,autoIncrement"`
+	SeriesId  int64 `gorm:"index:idx_series_position"`
+	ArticleId int64 `gorm:"uniqueIndex"`
+	Position  int   `gorm:"index:idx_series_position"`
+	CreatedAt int64
+}
+
+// SeriesDAO 作者的系列，以及系列里面文章的先后顺序
+type SeriesDAO interface {
+	Insert(ctx context.Context, s Series) (int64, error)
+	// Update 只能改自己的系列，否则返回 ErrSeriesNotFound
+	Update(ctx context.Context, s Series) error
+	// Delete 连同系列里面的文章关系一起删掉，文章本身不动
+	Delete(ctx context.Context, id int64, authorId int64) error
+	GetById(ctx context.Context, id int64) (Series, error)
+	// ListByAuthor 按更新时间倒序
+	ListByAuthor(ctx context.Context, authorId int64, offset int, limit int) ([]Series, error)
+
+	// AddArticle 加到系列的最后面
+	AddArticle(ctx context.Context, seriesId int64, artId int64) error
+	RemoveArticle(ctx context.Context, seriesId int64, artId int64) error
+	// Reorder artIds 必须正好是系列里面的全部文章
+	Reorder(ctx context.Context, seriesId int64, artIds []int64) error
+	// ArticleIds 按顺序排列
+	ArticleIds(ctx context.Context, seriesId int64) ([]int64, error)
+	// FindByArticle 文章所在的系列
+	FindByArticle(ctx context.Context, artId int64) (SeriesArticle, error)
+}
+
+type GormSeriesDAO struct {
+	db *gorm.DB
+}
+
+func NewSeriesDAO(db *gorm.DB) SeriesDAO {
+	return &GormSeriesDAO{db: db}
+}
+
+func (g *GormSeriesDAO) Insert(ctx context.Context, s Series) (int64, error) {
+	now := time.Now().UnixMilli()
+	s.CreatedAt = now
+	s.UpdatedAt = now
+	err := g.db.WithContext(ctx).Create(&s).Error
+	return s.Id, err
+}
+
+func (g *GormSeriesDAO) Update(ctx context.Context, s Series) error {
+	res := g.db.WithContext(ctx).Model(&Series{}).
+		Where("id = ? AND author_id = ?", s.Id, s.AuthorId).
+		Updates(map[string]any{
+			"title":       s.Title,
+			"description": s.Description,
+			"updated_at":  time.Now().UnixMilli(),
+		})
+	if res.Error != nil {
+		return res.Error
+	}
+	if res.RowsAffected == 0 {
+		return ErrSeriesNotFound
+	}
+	return nil
+}
+
+func (g *GormSeriesDAO) Delete(ctx context.Context, id int64, authorId int64) error {
+	return g.db.WithContext(ctx).Transaction(func(tx *gorm.DB) error {
+		res := tx.Where("id = ? AND author_id = ?", id, authorId).Delete(&Series{})
+		if res.Error != nil {
+			return res.Error
+		}
+		if res.RowsAffected == 0 {
+			return ErrSeriesNotFound
+		}
+		return tx.Where("series_id = ?", id).Delete(&SeriesArticle{}).Error
+	})
+}
+
+func (g *GormSeriesDAO) GetById(ctx context.Context, id int64) (Series, error) {
+	var s Series
+	err := g.db.WithContext(ctx).Where("id = ?", id).First(&s).Error
+	return s, err
+}
+
+func (g *GormSeriesDAO) ListByAuthor(ctx context.Context, authorId int64, offset int, limit int) ([]Series, error) {
+	var res []Series
+	err := g.db.WithContext(ctx).Where("author_id = ?", authorId).
+		Order("updated_at DESC").Offset(offset).Limit(limit).Find(&res).Error
+	return res, err
+}
+
+func (g *GormSeriesDAO) AddArticle(ctx context.Context, seriesId int64, artId int64) error {
+	now := time.Now().UnixMilli()
+	return g.db.WithContext(ctx).Transaction(func(tx *gorm.DB) error {
+		// 锁住系列，同一个系列并发加文章的时候位置不会重复
+		var s Series
+		err := tx.Clauses(clause.Locking{Strength: "UPDATE"}).
+			Where("id = ?", seriesId).First(&s).Error
+		if err != nil {
+			return err
+		}
+		var cnt int64
+		err = tx.Model(&SeriesArticle{}).Where("article_id = ?", artId).Count(&cnt).Error
+		if err != nil {
+			return err
+		}
+		if cnt > 0 {
+			return ErrArticleInSeries
+		}
+		var last int
+		err = tx.Model(&SeriesArticle{}).Where("series_id = ?", seriesId).
+			Select("COALESCE(MAX(position), 0)").Scan(&last).Error
+		if err != nil {
+			return err
+		}
+		err = tx.Create(&SeriesArticle{
+			SeriesId:  seriesId,
+			ArticleId: artId,
+			Position:  last + 1,
+			CreatedAt: now,
+		}).Error
+		if err != nil {
+			return err
+		}
+		return tx.Model(&Series{}).Where("id = ?", seriesId).Update("updated_at", now).Error
+	})
+}
+
+func (g *GormSeriesDAO) RemoveArticle(ctx context.Context, seriesId int64, artId int64) error {
+	// 删掉之后位置不连续也没关系，只用来排序
+	res := g.db.WithContext(ctx).Where("series_id = ? AND article_id = ?", seriesId, artId).
+		Delete(&SeriesArticle{})
+	if res.Error != nil {
+		return res.Error
+	}
+	if res.RowsAffected == 0 {
+		return ErrSeriesNotFound
+	}
+	return nil
+}
+
+func (g *GormSeriesDAO) Reorder(ctx context.Context, seriesId int64, artIds []int64) error {
+	return g.db.WithContext(ctx).Transaction(func(tx *gorm.DB) error {
+		var s Series
+		err := tx.Clauses(clause.Locking{Strength: "UPDATE"}).
+			Where("id = ?", seriesId).First(&s).Error
+		if err != nil {
+			return err
+		}
+		var cur []int64
+		err = tx.Model(&SeriesArticle{}).Where("series_id = ?", seriesId).
+			Pluck("article_id", &cur).Error
+		if err != nil {
+			return err
+		}
+		if !sameIdSet(cur, artIds) {
+			return ErrSeriesOrderMismatch
+		}
+		for i, artId := range artIds {
+			err = tx.Model(&SeriesArticle{}).
+				Where("series_id = ? AND article_id = ?", seriesId, artId).
+				Update("position", i+1).Error
+			if err != nil {
+				return err
+			}
+		}
+		return tx.Model(&Series{}).Where("id = ?", seriesId).
+			Update("updated_at", time.Now().UnixMilli()).Error
+	})
+}
+
+func (g *GormSeriesDAO) ArticleIds(ctx context.Context, seriesId int64) ([]int64, error) {
+	var ids []int64
+	err := g.db.WithContext(ctx).Model(&SeriesArticle{}).Where("series_id = ?", seriesId).
+		Order("position, id").Pluck("article_id", &ids).Error
+	return ids, err
+}
+
+func (g *GormSeriesDAO) FindByArticle(ctx context.Context, artId int64) (SeriesArticle, error) {
+	var sa SeriesArticle
+	err := g.db.WithContext(ctx).Where("article_id = ?", artId).First(&sa).Error
+	return sa, err
+}
+
+// sameIdSet 不允许重复
+func sameIdSet(a, b []int64) bool {
+	if len(a) != len(b) {
+		return false
+	}
+	set := make(map[int64]struct{}, len(a))
+	for _, id := range a {
+		set[id] = struct{}{}
+	}
+	for _, id := range b {
+		if _, ok := set[id]; !ok {
+			return false
+		}
+		delete(set, id)
+	}
+	return true
+}
@@ -0,0 +1,62 @@
+package dao
+
+import (
+	"context"
+	"gorm.io/gorm"
+	"gorm.io/gorm/clause"
+)
+
+type FeedDAO interface {
+	// Insert 同一篇文章重新发表的时候更新时间
+	Insert(ctx context.Context, items []FeedItem) error
+	// List 按 (ctime, article_id) 倒序翻页，ctime 为 0 表示第一页
+	List(ctx context.Context, uid int64, ctime int64, articleId int64, limit int) ([]FeedItem, error)
+	// DeleteByArticle 文章撤回或者删除的时候从所有人的收件箱里面删掉
+	DeleteByArticle(ctx context.Context, articleId int64) error
+	// DeleteByAuthor 取消关注的时候删掉这个作者推过来的
+	DeleteByAuthor(ctx context.Context, uid int64, authorId int64) error
+}
+
+type GormFeedDAO struct {
+	db *gorm.DB
+}
+
+func NewFeedDAO(db *gorm.DB) FeedDAO {
+	return &GormFeedDAO{db: db}
+}
+
+func (g *GormFeedDAO) Insert(ctx context.Context, items []FeedItem) error {
+	if len(items) == 0 {
+		return nil
+	}
+	return g.db.WithContext(ctx).Clauses(clause.OnConflict{
+		DoUpdates: clause.AssignmentColumns([]string{"ctime"}),
+	}).Create(&items).Error
+}
+
+func (g *GormFeedDAO) List(ctx context.Context, uid int64, ctime int64, articleId int64, limit int) ([]FeedItem, error) {
+	db := g.db.WithContext(ctx).Where("uid = ?", uid)
+	if ctime > 0 {
+		db = db.Where("(ctime < ? OR (ctime = ? AND article_id < ?))", ctime, ctime, articleId)
+	}
+	var res []FeedItem
+	err := db.Order("ctime DESC, article_id DESC").Limit(limit).Find(&res).Error
+	return res, err
+}
+
+func (g *GormFeedDAO) DeleteByArticle(ctx context.Context, articleId int64) error {
+	return g.db.WithContext(ctx).Where("article_id = ?", articleId).Delete(&FeedItem{}).Error
+}
+
+func (g *GormFeedDAO) DeleteByAuthor(ctx context.Context, uid int64, authorId int64) error {
+	return g.db.WithContext(ctx).Where("uid = ? AND author_id = ?", uid, authorId).Delete(&FeedItem{}).Error
+}
+
+// FeedItem 推模式的收件箱，只存 id，文章内容读的时候再查
+type FeedItem struct {
+	Id        int64 `gorm:"primaryKey,autoIncrement"`
+	Uid       int64 `gorm:"uniqueIndex:uid_article_id;index:idx_uid_ctime"`
+	ArticleId int64 `gorm:"uniqueIndex:uid_article_id;index"`
+	AuthorId  int64
+	Ctime     int64 `gorm:"index:idx_uid_ctime"`
+}
@@ -0,0 +1,180 @@
+package dao
+
+import (
+	"context"
+	"gorm.io/gorm"
+	"gorm.io/gorm/clause"
+	"time"
+)
+
+var ErrFollowStaticsNotFound = gorm.ErrRecordNotFound
+
+const (
+	FollowRelationStatusUnknown uint8 = iota
+	FollowRelationStatusActive
+	FollowRelationStatusInactive
+)
+
+type FollowDAO interface {
+	// Follow 已经关注了返回 false，只有状态变了才改关注数和粉丝数
+	Follow(ctx context.Context, follower int64, followee int64) (bool, error)
+	// Unfollow 没有关注返回 false
+	Unfollow(ctx context.Context, follower int64, followee int64) (bool, error)
+	// FollowerList followee 的粉丝，按关注时间倒序
+	FollowerList(ctx context.Context, followee int64, offset int, limit int) ([]FollowRelation, error)
+	// FolloweeList follower 关注的人，按关注时间倒序
+	FolloweeList(ctx context.Context, follower int64, offset int, limit int) ([]FollowRelation, error)
+	Statics(ctx context.Context, uid int64) (FollowStatics, error)
+	// FollowedIds followees 里面 follower 关注了的
+	FollowedIds(ctx context.Context, follower int64, followees []int64) ([]int64, error)
+	// PopularFollowees follower 关注的人里面粉丝数不少于 minFollowers 的
+	PopularFollowees(ctx context.Context, follower int64, minFollowers int64) ([]int64, error)
+}
+
+type GormFollowDAO struct {
+	db *gorm.DB
+}
+
+func NewFollowDAO(db *gorm.DB) FollowDAO {
+	return &GormFollowDAO{db: db}
+}
+
+func (g *GormFollowDAO) Follow(ctx context.Context, follower int64, followee int64) (bool, error) {
+	changed := false
+	now := time.Now().UnixMilli()
+	err := g.db.WithContext(ctx).Transaction(func(tx *gorm.DB) error {
+		// 不先查再写，SELECT FOR UPDATE 查不到的时候会加间隙锁，并发关注容易死锁
+		// MySQL 按顺序赋值，updated_at 要在 status 前面，不然判断的是新的 status
+		res := tx.Clauses(clause.OnConflict{
+			DoUpdates: []clause.Assignment{
+				{Column: clause.Column{Name: "updated_at"},
+					Value: gorm.Expr("IF(`status` = ?, `updated_at`, ?)", FollowRelationStatusActive, now)},
+				{Column: clause.Column{Name: "status"}, Value: FollowRelationStatusActive},
+			},
+		}).Create(&FollowRelation{
+			Follower:  follower,
+			Followee:  followee,
+			Status:    FollowRelationStatusActive,
+			CreatedAt: now,
+			UpdatedAt: now,
+		})
+		// 插入是 1，更新是 2，已经关注了什么都没变是 0
+		if res.Error != nil || res.RowsAffected == 0 {
+			return res.Error
+		}
+		changed = true
+		return g.incrStatics(tx, follower, followee, 1, now)
+	})
+	return changed, err
+}
+
+func (g *GormFollowDAO) Unfollow(ctx context.Context, follower int64, followee int64) (bool, error) {
+	changed := false
+	now := time.Now().UnixMilli()
+	err := g.db.WithContext(ctx).Transaction(func(tx *gorm.DB) error {
+		res := tx.Model(&FollowRelation{}).
+			Where("follower = ? AND followee = ? AND status = ?", follower, followee, FollowRelationStatusActive).
+			Updates(map[string]any{
+				"status":     FollowRelationStatusInactive,
+				"updated_at": now,
+			})
+		if res.Error != nil || res.RowsAffected == 0 {
+			return res.Error
+		}
+		changed = true
+		return g.incrStatics(tx, follower, followee, -1, now)
+	})
+	return changed, err
+}
+
+// incrStatics follower 的关注数和 followee 的粉丝数一起改
+func (g *GormFollowDAO) incrStatics(tx *gorm.DB, follower int64, followee int64, delta int64, now int64) error {
+	err := tx.Clauses(clause.OnConflict{
+		DoUpdates: clause.Assignments(map[string]any{
+			"followees":  gorm.Expr("GREATEST(`followees` + ?, 0)", delta),
+			"updated_at": now,
+		}),
+	}).Create(&FollowStatics{
+		Uid:       follower,
+		Followees: max(delta, 0),
+		CreatedAt: now,
+		UpdatedAt: now,
+	}).Error
+	if err != nil {
+		return err
+	}
+	return tx.Clauses(clause.OnConflict{
+		DoUpdates: clause.Assignments(map[string]any{
+			"followers":  gorm.Expr("GREATEST(`followers` + ?, 0)", delta),
+			"updated_at": now,
+		}),
+	}).Create(&FollowStatics{
+		Uid:       followee,
+		Followers: max(delta, 0),
+		CreatedAt: now,
+		UpdatedAt: now,
+	}).Error
+}
+
+func (g *GormFollowDAO) FollowerList(ctx context.Context, followee int64, offset int, limit int) ([]FollowRelation, error) {
+	var res []FollowRelation
+	err := g.db.WithContext(ctx).
+		Where("followee = ? AND status = ?", followee, FollowRelationStatusActive).
+		Order("updated_at DESC").Offset(offset).Limit(limit).Find(&res).Error
+	return res, err
+}
+
+func (g *GormFollowDAO) FolloweeList(ctx context.Context, follower int64, offset int, limit int) ([]FollowRelation, error) {
+	var res []FollowRelation
+	err := g.db.WithContext(ctx).
+		Where("follower = ? AND status = ?", follower, FollowRelationStatusActive).
+		Order("updated_at DESC").Offset(offset).Limit(limit).Find(&res).Error
+	return res, err
+}
+
+func (g *GormFollowDAO) Statics(ctx context.Context, uid int64) (FollowStatics, error) {
+	var res FollowStatics
+	err := g.db.WithContext(ctx).Where("uid = ?", uid).First(&res).Error
+	return res, err
+}
+
+func (g *GormFollowDAO) FollowedIds(ctx context.Context, follower int64, followees []int64) ([]int64, error) {
+	var res []int64
+	err := g.db.WithContext(ctx).Model(&FollowRelation{}).
+		Where("follower = ? AND followee IN ? AND status = ?", follower, followees, FollowRelationStatusActive).
+		Pluck("followee", &res).Error
+	return res, err
+}
+
+func (g *GormFollowDAO) PopularFollowees(ctx context.Context, follower int64, minFollowers int64) ([]int64, error) {
+	var res []int64
+	err := g.db.WithContext(ctx).Model(&FollowRelation{}).
+		Joins("JOIN follow_statics ON follow_statics.uid = follow_relations.followee").
+		Where("follow_relations.follower = ? AND follow_relations.status = ? AND follow_statics.followers >= ?",
+			follower, FollowRelationStatusActive, minFollowers).
+		Pluck("follow_relations.followee", &res).Error
+	return res, err
+}
+
+// FollowRelation 取消关注只改状态，粉丝列表按 followee 查
+type FollowRelation struct {
+	Id       int64 `gorm:"primaryKey,autoIncrement"`
+	Follower int64 `gorm:"uniqueIndex:follower_followee"`
+	Followee int64 `gorm:"uniqueIndex:follower_followee;index:idx_followee_updated_at"`
+	Status   uint8
+
+	CreatedAt int64
+	UpdatedAt int64 `gorm:"index:idx_followee_updated_at"`
+}
+
+type FollowStatics struct {
+	Id  int64 `gorm:"primaryKey,autoIncrement"`
+	Uid int64 `gorm:"unique"`
+	// Followers 粉丝数
+	Followers int64
+	// Followees 关注了多少人
+	Followees int64
+
+	CreatedAt int64
+	UpdatedAt int64
+}
@@ -0,0 +1,78 @@
+package dao
+
+import (
+	"context"
+	sqlmock "github.com/DATA-DOG/go-sqlmock"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"gorm.io/driver/mysql"
+	"gorm.io/gorm"
+	"testing"
+)
+
+func TestGormFollowDAO_Follow(t *testing.T) {
+	testCases := []struct {
+		name        string
+		mock        func(mock sqlmock.Sqlmock)
+		wantChanged bool
+		wantErr     error
+	}{
+		{
+			name: "第一次关注",
+			mock: func(mock sqlmock.Sqlmock) {
+				mock.ExpectBegin()
+				mock.ExpectExec("INSERT INTO `follow_relations` .* ON DUPLICATE KEY UPDATE " +
+					"`updated_at`=IF\\(`status` = \\?, `updated_at`, \\?\\),`status`=\\?").
+					WillReturnResult(sqlmock.NewResult(1, 1))
+				mock.ExpectExec("INSERT INTO `follow_statics` .* ON DUPLICATE KEY UPDATE").
+					WillReturnResult(sqlmock.NewResult(1, 1))
+				mock.ExpectExec("INSERT INTO `follow_statics` .* ON DUPLICATE KEY UPDATE").
+					WillReturnResult(sqlmock.NewResult(2, 1))
+				mock.ExpectCommit()
+			},
+			wantChanged: true,
+		},
+		{
+			name: "取消之后重新关注",
+			mock: func(mock sqlmock.Sqlmock) {
+				mock.ExpectBegin()
+				mock.ExpectExec("INSERT INTO `follow_relations` .* ON DUPLICATE KEY UPDATE").
+					WillReturnResult(sqlmock.NewResult(1, 2))
+				mock.ExpectExec("INSERT INTO `follow_statics` .* ON DUPLICATE KEY UPDATE").
+					WillReturnResult(sqlmock.NewResult(1, 2))
+				mock.ExpectExec("INSERT INTO `follow_statics` .* ON DUPLICATE KEY UPDATE").
+					WillReturnResult(sqlmock.NewResult(2, 2))
+				mock.ExpectCommit()
+			},
+			wantChanged: true,
+		},
+		{
+			name: "已经关注了不改关注数",
+			mock: func(mock sqlmock.Sqlmock) {
+				mock.ExpectBegin()
+				mock.ExpectExec("INSERT INTO `follow_relations` .* ON DUPLICATE KEY UPDATE").
+					WillReturnResult(sqlmock.NewResult(1, 0))
+				mock.ExpectCommit()
+			},
+		},
+	}
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			mockDB, mock, err := sqlmock.New()
+			require.NoError(t, err)
+			tc.mock(mock)
+			db, err := gorm.Open(mysql.New(mysql.Config{
+				Conn:                      mockDB,
+				SkipInitializeWithVersion: true,
+			}), &gorm.Config{
+				SkipDefaultTransaction: true,
+				DisableAutomaticPing:   true,
+			})
+			require.NoError(t, err)
+			changed, err := NewFollowDAO(db).Follow(context.Background(), 1, 2)
+			assert.Equal(t, tc.wantErr, err)
+			assert.Equal(t, tc.wantChanged, changed)
+			assert.NoError(t, mock.ExpectationsWereMet())
+		})
+	}
+}
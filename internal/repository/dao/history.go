@@ -0,0 +1,84 @@
+package dao
+
+import (
+	"context"
+	"gorm.io/gorm"
+	"gorm.io/gorm/clause"
+)
+
+type HistoryRecordDAO interface {
+	// Upsert 按 uid、biz、biz_id 去重，只保留最近一次阅读的时间
+	Upsert(ctx context.Context, record HistoryRecord) error
+	// List 按阅读时间倒序
+	List(ctx context.Context, uid int64, offset int, limit int) ([]HistoryRecord, error)
+	Delete(ctx context.Context, uid int64, biz string, bizId int64) error
+	Clear(ctx context.Context, uid int64) error
+	// Trim 只保留最近的 keep 条
+	Trim(ctx context.Context, uid int64, keep int) error
+	// OverLimitUids uid 比 startUid 大并且记录数超过 keep 的人，按 uid 升序
+	OverLimitUids(ctx context.Context, startUid int64, keep int, limit int) ([]int64, error)
+}
+
+type GormHistoryRecordDAO struct {
+	db *gorm.DB
+}
+
+func NewHistoryRecordDAO(db *gorm.DB) HistoryRecordDAO {
+	return &GormHistoryRecordDAO{db: db}
+}
+
+func (g *GormHistoryRecordDAO) Upsert(ctx context.Context, record HistoryRecord) error {
+	// 消息可能乱序，旧的阅读时间不能覆盖新的
+	return g.db.WithContext(ctx).Clauses(clause.OnConflict{
+		DoUpdates: clause.Assignments(map[string]any{
+			"ctime": gorm.Expr("GREATEST(`ctime`, ?)", record.Ctime),
+		}),
+	}).Create(&record).Error
+}
+
+func (g *GormHistoryRecordDAO) List(ctx context.Context, uid int64, offset int, limit int) ([]HistoryRecord, error) {
+	var res []HistoryRecord
+	err := g.db.WithContext(ctx).Where("uid = ?", uid).
+		Order("ctime DESC, id DESC").Offset(offset).Limit(limit).Find(&res).Error
+	return res, err
+}
+
+func (g *GormHistoryRecordDAO) Delete(ctx context.Context, uid int64, biz string, bizId int64) error {
+	return g.db.WithContext(ctx).Where("uid = ? AND biz = ? AND biz_id = ?", uid, biz, bizId).
+		Delete(&HistoryRecord{}).Error
+}
+
+func (g *GormHistoryRecordDAO) Clear(ctx context.Context, uid int64) error {
+	return g.db.WithContext(ctx).Where("uid = ?", uid).Delete(&HistoryRecord{}).Error
+}
+
+func (g *GormHistoryRecordDAO) Trim(ctx context.Context, uid int64, keep int) error {
+	// 先找到第 keep + 1 条，再删掉它和比它旧的，MySQL 不支持 DELETE 里面查同一张表
+	var boundary HistoryRecord
+	err := g.db.WithContext(ctx).Where("uid = ?", uid).
+		Order("ctime DESC, id DESC").Offset(keep).Limit(1).Find(&boundary).Error
+	if err != nil || boundary.Id == 0 {
+		return err
+	}
+	return g.db.WithContext(ctx).
+		Where("uid = ? AND (ctime < ? OR (ctime = ? AND id <= ?))", uid, boundary.Ctime, boundary.Ctime, boundary.Id).
+		Delete(&HistoryRecord{}).Error
+}
+
+func (g *GormHistoryRecordDAO) OverLimitUids(ctx context.Context, startUid int64, keep int, limit int) ([]int64, error) {
+	var uids []int64
+	err := g.db.WithContext(ctx).Model(&HistoryRecord{}).
+		Where("uid > ?", startUid).
+		Group("uid").Having("COUNT(*) > ?", keep).
+		Order("uid").Limit(limit).Pluck("uid", &uids).Error
+	return uids, err
+}
+
+type HistoryRecord struct {
+	Id    int64  `gorm:"primaryKey,autoIncrement"`
+	Uid   int64  `gorm:"uniqueIndex:uid_biz_id;index:idx_uid_ctime"`
+	Biz   string `gorm:"type:varchar(128);uniqueIndex:uid_biz_id"`
+	BizId int64  `gorm:"uniqueIndex:uid_biz_id"`
+	// Ctime 最近一次阅读的时间
+	Ctime int64 `gorm:"index:idx_uid_ctime"`
+}
@@ -10,5 +10,20 @@ func InitTable(db *gorm.DB) error {
 		&User{},
 		&article.Article{},
 		&article.PublishedArticle{},
+		&article.ArticleRevision{},
+		&article.Tag{},
+		&article.PublishedArticleTag{},
+		&article.ArticleCollaborator{},
+		&article.Series{},
+		&article.SeriesArticle{},
+		&Job{},
+		&Notification{},
+		&NotificationActor{},
+		&NotificationMute{},
+		&FollowRelation{},
+		&FollowStatics{},
+		&FeedItem{},
+		&HistoryRecord{},
+		&ReadingProgress{},
 	)
 }
@@ -3,6 +3,7 @@ package dao
 import (
 	"context"
 	"gorm.io/gorm"
+	"gorm.io/gorm/clause"
 	"time"
 )
 
@@ -18,6 +19,8 @@ type JobDAO interface {
 	Stop(ctx context.Context, id int64) error
 	UpdateNextTime(ctx context.Context, id int64, next time.Time) error
 	UpdateUpdatedAt(ctx context.Context, id int64) error
+	// Insert 同名任务已经存在的时候什么也不做
+	Insert(ctx context.Context, j Job) error
 }
 
 type GormJobDAO struct {
@@ -32,13 +35,17 @@ func (g *GormJobDAO) Preempt(ctx context.Context) (Job, error) {
 		now := time.Now()
 		var j Job
 
-		// todo 找失败的job执行
-		err := g.db.WithContext(ctx).Where("status = ? and next_time <= ?", jobStatusWaiting, now.Unix()).First(&j).Error
+		// 续约超过一分钟没有更新的 running 任务，认为节点已经挂了，可以重新抢
+		err := g.db.WithContext(ctx).
+			Where("(status = ? AND next_time <= ?) OR (status = ? AND updated_at <= ?)",
+				jobStatusWaiting, now.UnixMilli(),
+				jobStatusRunning, now.Add(-time.Minute).UnixMilli()).
+			First(&j).Error
 		if err != nil {
 			return Job{}, err
 		}
 
-		res := g.db.WithContext(ctx).Where("id = ? and version = ?", j.Id, j.Version).Updates(map[string]any{
+		res := g.db.WithContext(ctx).Model(&Job{}).Where("id = ? and version = ?", j.Id, j.Version).Updates(map[string]any{
 			"status":     jobStatusRunning,
 			"updated_at": now.UnixMilli(),
 			"version":    j.Version + 1,
@@ -54,35 +61,45 @@ func (g *GormJobDAO) Preempt(ctx context.Context) (Job, error) {
 }
 
 func (g *GormJobDAO) Release(ctx context.Context, id int64) error {
-	return g.db.WithContext(ctx).Where("id = ?", id).Updates(map[string]any{
+	return g.db.WithContext(ctx).Model(&Job{}).Where("id = ?", id).Updates(map[string]any{
 		"status":     jobStatusWaiting,
 		"updated_at": time.Now().UnixMilli(),
 	}).Error
 }
 
 func (g *GormJobDAO) Stop(ctx context.Context, id int64) error {
-	return g.db.WithContext(ctx).Where("id = ?", id).Updates(map[string]any{
+	return g.db.WithContext(ctx).Model(&Job{}).Where("id = ?", id).Updates(map[string]any{
 		"status":     jobStatusPaused,
 		"updated_at": time.Now().UnixMilli(),
 	}).Error
 }
 
 func (g *GormJobDAO) UpdateNextTime(ctx context.Context, id int64, next time.Time) error {
-	return g.db.WithContext(ctx).Where("id = ?", id).Updates(map[string]any{
+	return g.db.WithContext(ctx).Model(&Job{}).Where("id = ?", id).Updates(map[string]any{
 		"next_time":  next.UnixMilli(),
 		"updated_at": time.Now().UnixMilli(),
 	}).Error
 }
 func (g *GormJobDAO) UpdateUpdatedAt(ctx context.Context, id int64) error {
-	return g.db.WithContext(ctx).Where("id = ?", id).Updates(map[string]any{
+	return g.db.WithContext(ctx).Model(&Job{}).Where("id = ?", id).Updates(map[string]any{
 		"updated_at": time.Now().UnixMilli(),
 	}).Error
 }
 
+func (g *GormJobDAO) Insert(ctx context.Context, j Job) error {
+	now := time.Now().UnixMilli()
+	j.CreatedAt = now
+	j.UpdatedAt = now
+	return g.db.WithContext(ctx).Clauses(clause.OnConflict{
+		Columns:   []clause.Column{{Name: "name"}},
+		DoNothing: true,
+	}).Create(&j).Error
+}
+
 type Job struct {
-	Id             int64  `gorm:"primaryKey,autoIncrement"`
+	Id             int64  `gorm:"primaryKey;autoIncrement"`
 	Cfg            string `gorm:"type:json"`
-	Name           string `gorm:"type:varchar(100),uniqueIndex"`
+	Name           string `gorm:"type:varchar(100);uniqueIndex"`
 	Executor       string `gorm:"type:varchar(100)"`
 	CronExpression string
 	Status         int `gorm:"index:idx_status_next_time"`
@@ -0,0 +1,127 @@
+// Code generated by MockGen. DO NOT EDIT.
+// Source: ./internal/repository/dao/history.go
+//
+// Generated by this command:
+//
+//	mockgen -source=./internal/repository/dao/history.go -destination=./internal/repository/dao/mocks/history.go -package=daomocks
+//
+
+// Package daomocks is a generated GoMock package.
+package daomocks
+
+import (
+	context "context"
+	reflect "reflect"
+
+	dao "github.com/Andras5014/gohub/internal/repository/dao"
+	gomock "go.uber.org/mock/gomock"
+)
+
+// MockHistoryRecordDAO is a mock of HistoryRecordDAO interface.
+type MockHistoryRecordDAO struct {
+	ctrl     *gomock.Controller
+	recorder *MockHistoryRecordDAOMockRecorder
+}
+
+// MockHistoryRecordDAOMockRecorder is the mock recorder for MockHistoryRecordDAO.
+type MockHistoryRecordDAOMockRecorder struct {
+	mock *MockHistoryRecordDAO
+}
+
+// NewMockHistoryRecordDAO creates a new mock instance.
+func NewMockHistoryRecordDAO(ctrl *gomock.Controller) *MockHistoryRecordDAO {
+	mock := &MockHistoryRecordDAO{ctrl: ctrl}
+	mock.recorder = &MockHistoryRecordDAOMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockHistoryRecordDAO) EXPECT() *MockHistoryRecordDAOMockRecorder {
+	return m.recorder
+}
+
+// Clear mocks base method.
+func (m *MockHistoryRecordDAO) Clear(ctx context.Context, uid int64) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Clear", ctx, uid)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// Clear indicates an expected call of Clear.
+func (mr *MockHistoryRecordDAOMockRecorder) Clear(ctx, uid any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Clear", reflect.TypeOf((*MockHistoryRecordDAO)(nil).Clear), ctx, uid)
+}
+
+// Delete mocks base method.
+func (m *MockHistoryRecordDAO) Delete(ctx context.Context, uid int64, biz string, bizId int64) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Delete", ctx, uid, biz, bizId)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// Delete indicates an expected call of Delete.
+func (mr *MockHistoryRecordDAOMockRecorder) Delete(ctx, uid, biz, bizId any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Delete", reflect.TypeOf((*MockHistoryRecordDAO)(nil).Delete), ctx, uid, biz, bizId)
+}
+
+// List mocks base method.
+func (m *MockHistoryRecordDAO) List(ctx context.Context, uid int64, offset, limit int) ([]dao.HistoryRecord, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "List", ctx, uid, offset, limit)
+	ret0, _ := ret[0].([]dao.HistoryRecord)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// List indicates an expected call of List.
+func (mr *MockHistoryRecordDAOMockRecorder) List(ctx, uid, offset, limit any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "List", reflect.TypeOf((*MockHistoryRecordDAO)(nil).List), ctx, uid, offset, limit)
+}
+
+// OverLimitUids mocks base method.
+func (m *MockHistoryRecordDAO) OverLimitUids(ctx context.Context, startUid int64, keep, limit int) ([]int64, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "OverLimitUids", ctx, startUid, keep, limit)
+	ret0, _ := ret[0].([]int64)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// OverLimitUids indicates an expected call of OverLimitUids.
+func (mr *MockHistoryRecordDAOMockRecorder) OverLimitUids(ctx, startUid, keep, limit any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "OverLimitUids", reflect.TypeOf((*MockHistoryRecordDAO)(nil).OverLimitUids), ctx, startUid, keep, limit)
+}
+
+// Trim mocks base method.
+func (m *MockHistoryRecordDAO) Trim(ctx context.Context, uid int64, keep int) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Trim", ctx, uid, keep)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// Trim indicates an expected call of Trim.
+func (mr *MockHistoryRecordDAOMockRecorder) Trim(ctx, uid, keep any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Trim", reflect.TypeOf((*MockHistoryRecordDAO)(nil).Trim), ctx, uid, keep)
+}
+
+// Upsert mocks base method.
+func (m *MockHistoryRecordDAO) Upsert(ctx context.Context, record dao.HistoryRecord) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Upsert", ctx, record)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// Upsert indicates an expected call of Upsert.
+func (mr *MockHistoryRecordDAOMockRecorder) Upsert(ctx, record any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Upsert", reflect.TypeOf((*MockHistoryRecordDAO)(nil).Upsert), ctx, record)
+}
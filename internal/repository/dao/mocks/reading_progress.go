@@ -0,0 +1,85 @@
+// Code generated by MockGen. DO NOT EDIT.
+// Source: ./internal/repository/dao/reading_progress.go
+//
+// Generated by this command:
+//
+//	mockgen -source=./internal/repository/dao/reading_progress.go -destination=./internal/repository/dao/mocks/reading_progress.go -package=daomocks
+//
+
+// Package daomocks is a generated GoMock package.
+package daomocks
+
+import (
+	context "context"
+	reflect "reflect"
+
+	dao "github.com/Andras5014/gohub/internal/repository/dao"
+	gomock "go.uber.org/mock/gomock"
+)
+
+// MockReadingProgressDAO is a mock of ReadingProgressDAO interface.
+type MockReadingProgressDAO struct {
+	ctrl     *gomock.Controller
+	recorder *MockReadingProgressDAOMockRecorder
+}
+
+// MockReadingProgressDAOMockRecorder is the mock recorder for MockReadingProgressDAO.
+type MockReadingProgressDAOMockRecorder struct {
+	mock *MockReadingProgressDAO
+}
+
+// NewMockReadingProgressDAO creates a new mock instance.
+func NewMockReadingProgressDAO(ctrl *gomock.Controller) *MockReadingProgressDAO {
+	mock := &MockReadingProgressDAO{ctrl: ctrl}
+	mock.recorder = &MockReadingProgressDAOMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockReadingProgressDAO) EXPECT() *MockReadingProgressDAOMockRecorder {
+	return m.recorder
+}
+
+// Get mocks base method.
+func (m *MockReadingProgressDAO) Get(ctx context.Context, uid, aid int64) (dao.ReadingProgress, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Get", ctx, uid, aid)
+	ret0, _ := ret[0].(dao.ReadingProgress)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// Get indicates an expected call of Get.
+func (mr *MockReadingProgressDAOMockRecorder) Get(ctx, uid, aid any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Get", reflect.TypeOf((*MockReadingProgressDAO)(nil).Get), ctx, uid, aid)
+}
+
+// ListUnfinished mocks base method.
+func (m *MockReadingProgressDAO) ListUnfinished(ctx context.Context, uid int64, finished, limit int) ([]dao.ReadingProgress, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "ListUnfinished", ctx, uid, finished, limit)
+	ret0, _ := ret[0].([]dao.ReadingProgress)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// ListUnfinished indicates an expected call of ListUnfinished.
+func (mr *MockReadingProgressDAOMockRecorder) ListUnfinished(ctx, uid, finished, limit any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ListUnfinished", reflect.TypeOf((*MockReadingProgressDAO)(nil).ListUnfinished), ctx, uid, finished, limit)
+}
+
+// Upsert mocks base method.
+func (m *MockReadingProgressDAO) Upsert(ctx context.Context, ps []dao.ReadingProgress) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Upsert", ctx, ps)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// Upsert indicates an expected call of Upsert.
+func (mr *MockReadingProgressDAOMockRecorder) Upsert(ctx, ps any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Upsert", reflect.TypeOf((*MockReadingProgressDAO)(nil).Upsert), ctx, ps)
+}
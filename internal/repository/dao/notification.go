@@ -0,0 +1,174 @@
+package dao
+
+import (
+	"context"
+	"gorm.io/gorm"
+	"gorm.io/gorm/clause"
+	"time"
+)
+
+type NotificationDAO interface {
+	// Upsert 按 uid、type、biz、biz_id 聚合，actor_cnt 是未读期间不同的人数，已读的重新开始计数
+	Upsert(ctx context.Context, n Notification) error
+	// List 按更新时间倒序
+	List(ctx context.Context, uid int64, offset int, limit int) ([]Notification, error)
+	// UnreadCnt 按类型统计未读的条数
+	UnreadCnt(ctx context.Context, uid int64) (map[uint8]int64, error)
+	// MarkRead ids 为空的时候全部标记为已读
+	MarkRead(ctx context.Context, uid int64, ids []int64) error
+
+	// SetMute mute 为 false 的时候取消屏蔽
+	SetMute(ctx context.Context, uid int64, typ uint8, mute bool) error
+	MutedTypes(ctx context.Context, uid int64) ([]uint8, error)
+}
+
+type GormNotificationDAO struct {
+	db *gorm.DB
+}
+
+func NewNotificationDAO(db *gorm.DB) NotificationDAO {
+	return &GormNotificationDAO{db: db}
+}
+
+func (g *GormNotificationDAO) Upsert(ctx context.Context, n Notification) error {
+	now := time.Now().UnixMilli()
+	n.ActorCnt = 1
+	n.Read = false
+	n.CreatedAt = now
+	n.UpdatedAt = now
+	return g.db.WithContext(ctx).Transaction(func(tx *gorm.DB) error {
+		// MySQL 按顺序执行赋值，round 要在 read 前面，这时候 `read` 还是旧的值
+		// 已读之后再来新的进入下一轮，上一轮的人不算
+		err := tx.Clauses(clause.OnConflict{
+			DoUpdates: []clause.Assignment{
+				{Column: clause.Column{Name: "round"}, Value: gorm.Expr("IF(`read`, `round` + 1, `round`)")},
+				{Column: clause.Column{Name: "read"}, Value: false},
+				{Column: clause.Column{Name: "actor_id"}, Value: n.ActorId},
+				{Column: clause.Column{Name: "ref_id"}, Value: n.RefId},
+				{Column: clause.Column{Name: "updated_at"}, Value: now},
+			},
+		}).Create(&n).Error
+		if err != nil {
+			return err
+		}
+		// 冲突的时候拿不到 id，上面的语句已经锁住了这一行，并发的会排队
+		var cur Notification
+		err = tx.Select("id", "round").
+			Where("uid = ? AND type = ? AND biz = ? AND biz_id = ?", n.Uid, n.Type, n.Biz, n.BizId).
+			First(&cur).Error
+		if err != nil {
+			return err
+		}
+		err = tx.Clauses(clause.OnConflict{
+			DoUpdates: clause.AssignmentColumns([]string{"round", "updated_at"}),
+		}).Create(&NotificationActor{
+			NotificationId: cur.Id,
+			ActorId:        n.ActorId,
+			Round:          cur.Round,
+			UpdatedAt:      now,
+		}).Error
+		if err != nil {
+			return err
+		}
+		var cnt int64
+		err = tx.Model(&NotificationActor{}).
+			Where("notification_id = ? AND round = ?", cur.Id, cur.Round).Count(&cnt).Error
+		if err != nil {
+			return err
+		}
+		return tx.Model(&Notification{}).Where("id = ?", cur.Id).
+			UpdateColumn("actor_cnt", cnt).Error
+	})
+}
+
+func (g *GormNotificationDAO) List(ctx context.Context, uid int64, offset int, limit int) ([]Notification, error) {
+	var res []Notification
+	err := g.db.WithContext(ctx).Where("uid = ?", uid).
+		Order("updated_at DESC").Offset(offset).Limit(limit).Find(&res).Error
+	return res, err
+}
+
+func (g *GormNotificationDAO) UnreadCnt(ctx context.Context, uid int64) (map[uint8]int64, error) {
+	var rows []struct {
+		Type uint8
+		Cnt  int64
+	}
+	err := g.db.WithContext(ctx).Model(&Notification{}).
+		Select("type, COUNT(*) AS cnt").
+		Where("uid = ? AND `read` = ?", uid, false).
+		Group("type").Scan(&rows).Error
+	if err != nil {
+		return nil, err
+	}
+	res := make(map[uint8]int64, len(rows))
+	for _, row := range rows {
+		res[row.Type] = row.Cnt
+	}
+	return res, nil
+}
+
+func (g *GormNotificationDAO) MarkRead(ctx context.Context, uid int64, ids []int64) error {
+	db := g.db.WithContext(ctx).Model(&Notification{}).
+		Where("uid = ? AND `read` = ?", uid, false)
+	if len(ids) > 0 {
+		db = db.Where("id IN ?", ids)
+	}
+	// 不改 updated_at，已读不影响排序
+	return db.Update("read", true).Error
+}
+
+func (g *GormNotificationDAO) SetMute(ctx context.Context, uid int64, typ uint8, mute bool) error {
+	if !mute {
+		return g.db.WithContext(ctx).Where("uid = ? AND type = ?", uid, typ).
+			Delete(&NotificationMute{}).Error
+	}
+	return g.db.WithContext(ctx).Clauses(clause.OnConflict{DoNothing: true}).
+		Create(&NotificationMute{
+			Uid:       uid,
+			Type:      typ,
+			CreatedAt: time.Now().UnixMilli(),
+		}).Error
+}
+
+func (g *GormNotificationDAO) MutedTypes(ctx context.Context, uid int64) ([]uint8, error) {
+	var res []uint8
+	err := g.db.WithContext(ctx).Model(&NotificationMute{}).
+		Where("uid = ?", uid).Pluck("type", &res).Error
+	return res, err
+}
+
+// Notification 一个人在一个资源上面同一种类型的通知只有一条
+type Notification struct {
+	Id    int64  `gorm:"primaryKey,autoIncrement"`
+	Uid   int64  `gorm:"uniqueIndex:uid_type_biz_id;index:idx_uid_updated_at"`
+	Type  uint8  `gorm:"uniqueIndex:uid_type_biz_id"`
+	Biz   string `gorm:"type:varchar(128);uniqueIndex:uid_type_biz_id"`
+	BizId int64  `gorm:"uniqueIndex:uid_type_biz_id"`
+
+	ActorId int64
+	// ActorCnt 这一轮有多少个不同的人
+	ActorCnt int64
+	RefId    int64
+	Read     bool
+	// Round 已读之后再来新的通知加一，用来区分 NotificationActor 是不是这一轮的
+	Round int64
+
+	CreatedAt int64
+	UpdatedAt int64 `gorm:"index:idx_uid_updated_at"`
+}
+
+// NotificationActor 触发过通知的人，用来给 actor_cnt 去重
+type NotificationActor struct {
+	Id             int64 `gorm:"primaryKey,autoIncrement"`
+	NotificationId int64 `gorm:"uniqueIndex:notification_actor"`
+	ActorId        int64 `gorm:"uniqueIndex:notification_actor"`
+	Round          int64
+	UpdatedAt      int64
+}
+
+type NotificationMute struct {
+	Id        int64 `gorm:"primaryKey,autoIncrement"`
+	Uid       int64 `gorm:"uniqueIndex:uid_type"`
+	Type      uint8 `gorm:"uniqueIndex:uid_type"`
+	CreatedAt int64
+}
@@ -0,0 +1,54 @@
+package dao
+
+import (
+	"context"
+	sqlmock "github.com/DATA-DOG/go-sqlmock"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"gorm.io/driver/mysql"
+	"gorm.io/gorm"
+	"testing"
+)
+
+func TestGormNotificationDAO_Upsert(t *testing.T) {
+	mockDB, mock, err := sqlmock.New()
+	require.NoError(t, err)
+	mock.ExpectBegin()
+	// round 要在 read 前面赋值，不然拿到的是新的 read
+	mock.ExpectExec("INSERT INTO `notifications` .* ON DUPLICATE KEY UPDATE " +
+		"`round`=IF\\(`read`, `round` \\+ 1, `round`\\),`read`=\\?,`actor_id`=\\?,`ref_id`=\\?,`updated_at`=\\?").
+		WillReturnResult(sqlmock.NewResult(1, 2))
+	mock.ExpectQuery("SELECT `id`,`round` FROM `notifications` WHERE uid = \\? AND type = \\? AND biz = \\? AND biz_id = \\?.*").
+		WithArgs(int64(1), uint8(2), "article", int64(3), 1).
+		WillReturnRows(sqlmock.NewRows([]string{"id", "round"}).AddRow(10, 4))
+	mock.ExpectExec("INSERT INTO `notification_actors` .* ON DUPLICATE KEY UPDATE `round`=VALUES\\(`round`\\),`updated_at`=VALUES\\(`updated_at`\\)").
+		WithArgs(int64(10), int64(7), int64(4), sqlmock.AnyArg()).
+		WillReturnResult(sqlmock.NewResult(1, 1))
+	// 同一个人重复点赞不会多算
+	mock.ExpectQuery("SELECT count\\(\\*\\) FROM `notification_actors` WHERE notification_id = \\? AND round = \\?").
+		WithArgs(int64(10), int64(4)).
+		WillReturnRows(sqlmock.NewRows([]string{"count"}).AddRow(2))
+	mock.ExpectExec("UPDATE `notifications` SET `actor_cnt`=\\? WHERE id = \\?").
+		WithArgs(int64(2), int64(10)).
+		WillReturnResult(sqlmock.NewResult(0, 1))
+	mock.ExpectCommit()
+
+	db, err := gorm.Open(mysql.New(mysql.Config{
+		Conn:                      mockDB,
+		SkipInitializeWithVersion: true,
+	}), &gorm.Config{
+		SkipDefaultTransaction: true,
+		DisableAutomaticPing:   true,
+	})
+	require.NoError(t, err)
+	d := NewNotificationDAO(db)
+	err = d.Upsert(context.Background(), Notification{
+		Uid:     1,
+		Type:    2,
+		Biz:     "article",
+		BizId:   3,
+		ActorId: 7,
+	})
+	assert.NoError(t, err)
+	assert.NoError(t, mock.ExpectationsWereMet())
+}
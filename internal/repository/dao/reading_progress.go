@@ -0,0 +1,63 @@
+package dao
+
+import (
+	"context"
+	"gorm.io/gorm"
+	"gorm.io/gorm/clause"
+)
+
+var ErrReadingProgressNotFound = gorm.ErrRecordNotFound
+
+type ReadingProgressDAO interface {
+	// Upsert 批量写，按 uid、article_id 去重，更新时间旧的不覆盖新的
+	Upsert(ctx context.Context, ps []ReadingProgress) error
+	Get(ctx context.Context, uid int64, aid int64) (ReadingProgress, error)
+	// ListUnfinished 百分比大于 0 小于 finished 的，按更新时间倒序
+	ListUnfinished(ctx context.Context, uid int64, finished int, limit int) ([]ReadingProgress, error)
+}
+
+type GormReadingProgressDAO struct {
+	db *gorm.DB
+}
+
+func NewReadingProgressDAO(db *gorm.DB) ReadingProgressDAO {
+	return &GormReadingProgressDAO{db: db}
+}
+
+func (g *GormReadingProgressDAO) Upsert(ctx context.Context, ps []ReadingProgress) error {
+	if len(ps) == 0 {
+		return nil
+	}
+	return g.db.WithContext(ctx).Clauses(clause.OnConflict{
+		// MySQL 按顺序赋值，utime 必须放在最后，前面比较的才是旧的 utime
+		DoUpdates: []clause.Assignment{
+			{Column: clause.Column{Name: "paragraph"},
+				Value: gorm.Expr("IF(VALUES(`utime`) >= `utime`, VALUES(`paragraph`), `paragraph`)")},
+			{Column: clause.Column{Name: "percent"},
+				Value: gorm.Expr("IF(VALUES(`utime`) >= `utime`, VALUES(`percent`), `percent`)")},
+			{Column: clause.Column{Name: "utime"}, Value: gorm.Expr("GREATEST(`utime`, VALUES(`utime`))")},
+		},
+	}).Create(&ps).Error
+}
+
+func (g *GormReadingProgressDAO) Get(ctx context.Context, uid int64, aid int64) (ReadingProgress, error) {
+	var res ReadingProgress
+	err := g.db.WithContext(ctx).Where("uid = ? AND article_id = ?", uid, aid).First(&res).Error
+	return res, err
+}
+
+func (g *GormReadingProgressDAO) ListUnfinished(ctx context.Context, uid int64, finished int, limit int) ([]ReadingProgress, error) {
+	var res []ReadingProgress
+	err := g.db.WithContext(ctx).Where("uid = ? AND percent > ? AND percent < ?", uid, 0, finished).
+		Order("utime DESC").Limit(limit).Find(&res).Error
+	return res, err
+}
+
+type ReadingProgress struct {
+	Id        int64 `gorm:"primaryKey,autoIncrement"`
+	Uid       int64 `gorm:"uniqueIndex:uid_article_id;index:idx_uid_utime"`
+	ArticleId int64 `gorm:"uniqueIndex:uid_article_id"`
+	Paragraph int
+	Percent   int
+	Utime     int64 `gorm:"index:idx_uid_utime"`
+}
@@ -0,0 +1,36 @@
+package dao
+
+import (
+	"context"
+	sqlmock "github.com/DATA-DOG/go-sqlmock"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"gorm.io/driver/mysql"
+	"gorm.io/gorm"
+	"testing"
+)
+
+func TestGormReadingProgressDAO_Upsert(t *testing.T) {
+	mockDB, mock, err := sqlmock.New()
+	require.NoError(t, err)
+	// utime 要在最后赋值，不然前面比较的是新的 utime
+	mock.ExpectExec("INSERT INTO `reading_progresses` .* ON DUPLICATE KEY UPDATE " +
+		"`paragraph`=IF\\(VALUES\\(`utime`\\) >= `utime`, VALUES\\(`paragraph`\\), `paragraph`\\)," +
+		"`percent`=IF\\(VALUES\\(`utime`\\) >= `utime`, VALUES\\(`percent`\\), `percent`\\)," +
+		"`utime`=GREATEST\\(`utime`, VALUES\\(`utime`\\)\\)").
+		WillReturnResult(sqlmock.NewResult(1, 1))
+
+	db, err := gorm.Open(mysql.New(mysql.Config{
+		Conn:                      mockDB,
+		SkipInitializeWithVersion: true,
+	}), &gorm.Config{
+		SkipDefaultTransaction: true,
+		DisableAutomaticPing:   true,
+	})
+	require.NoError(t, err)
+	err = NewReadingProgressDAO(db).Upsert(context.Background(), []ReadingProgress{
+		{Uid: 123, ArticleId: 1, Paragraph: 3, Percent: 30, Utime: 1700000000000},
+	})
+	assert.NoError(t, err)
+	assert.NoError(t, mock.ExpectationsWereMet())
+}
@@ -0,0 +1,64 @@
+package repository
+
+import (
+	"context"
+	"github.com/Andras5014/gohub/internal/domain"
+	"github.com/Andras5014/gohub/internal/repository/dao"
+	"github.com/ecodeclub/ekit/slice"
+	"time"
+)
+
+type FeedRepository interface {
+	// AddItems 写进收件箱，重复的更新时间
+	AddItems(ctx context.Context, items []domain.FeedItem) error
+	// List 零值游标表示第一页
+	List(ctx context.Context, uid int64, cursor domain.ArticleCursor, limit int) ([]domain.FeedItem, error)
+	DeleteByArticle(ctx context.Context, articleId int64) error
+	DeleteByAuthor(ctx context.Context, uid int64, authorId int64) error
+}
+
+type feedRepository struct {
+	dao dao.FeedDAO
+}
+
+func NewFeedRepository(dao dao.FeedDAO) FeedRepository {
+	return &feedRepository{dao: dao}
+}
+
+func (f *feedRepository) AddItems(ctx context.Context, items []domain.FeedItem) error {
+	return f.dao.Insert(ctx, slice.Map(items, func(idx int, src domain.FeedItem) dao.FeedItem {
+		return dao.FeedItem{
+			Uid:       src.Uid,
+			ArticleId: src.ArticleId,
+			AuthorId:  src.AuthorId,
+			Ctime:     src.Ctime.UnixMilli(),
+		}
+	}))
+}
+
+func (f *feedRepository) List(ctx context.Context, uid int64, cursor domain.ArticleCursor, limit int) ([]domain.FeedItem, error) {
+	var ctime int64
+	if !cursor.IsZero() {
+		ctime = cursor.UpdatedAt.UnixMilli()
+	}
+	res, err := f.dao.List(ctx, uid, ctime, cursor.Id, limit)
+	if err != nil {
+		return nil, err
+	}
+	return slice.Map(res, func(idx int, src dao.FeedItem) domain.FeedItem {
+		return domain.FeedItem{
+			Uid:       src.Uid,
+			ArticleId: src.ArticleId,
+			AuthorId:  src.AuthorId,
+			Ctime:     time.UnixMilli(src.Ctime),
+		}
+	}), nil
+}
+
+func (f *feedRepository) DeleteByArticle(ctx context.Context, articleId int64) error {
+	return f.dao.DeleteByArticle(ctx, articleId)
+}
+
+func (f *feedRepository) DeleteByAuthor(ctx context.Context, uid int64, authorId int64) error {
+	return f.dao.DeleteByAuthor(ctx, uid, authorId)
+}
@@ -0,0 +1,87 @@
+package repository
+
+import (
+	"context"
+	"errors"
+	"github.com/Andras5014/gohub/internal/domain"
+	"github.com/Andras5014/gohub/internal/repository/dao"
+	"github.com/ecodeclub/ekit/slice"
+	"time"
+)
+
+type FollowRepository interface {
+	// Follow 已经关注了返回 false
+	Follow(ctx context.Context, follower int64, followee int64) (bool, error)
+	// Unfollow 没有关注返回 false
+	Unfollow(ctx context.Context, follower int64, followee int64) (bool, error)
+	FollowerList(ctx context.Context, followee int64, offset int, limit int) ([]domain.FollowRelation, error)
+	FolloweeList(ctx context.Context, follower int64, offset int, limit int) ([]domain.FollowRelation, error)
+	// Statics 没有关注过也没有粉丝的返回 0
+	Statics(ctx context.Context, uid int64) (domain.FollowStatics, error)
+	FollowedIds(ctx context.Context, follower int64, followees []int64) ([]int64, error)
+	// PopularFollowees follower 关注的人里面粉丝数不少于 minFollowers 的
+	PopularFollowees(ctx context.Context, follower int64, minFollowers int64) ([]int64, error)
+}
+
+type followRepository struct {
+	dao dao.FollowDAO
+}
+
+func NewFollowRepository(dao dao.FollowDAO) FollowRepository {
+	return &followRepository{dao: dao}
+}
+
+func (f *followRepository) Follow(ctx context.Context, follower int64, followee int64) (bool, error) {
+	return f.dao.Follow(ctx, follower, followee)
+}
+
+func (f *followRepository) Unfollow(ctx context.Context, follower int64, followee int64) (bool, error) {
+	return f.dao.Unfollow(ctx, follower, followee)
+}
+
+func (f *followRepository) FollowerList(ctx context.Context, followee int64, offset int, limit int) ([]domain.FollowRelation, error) {
+	res, err := f.dao.FollowerList(ctx, followee, offset, limit)
+	if err != nil {
+		return nil, err
+	}
+	return slice.Map(res, f.toDomain), nil
+}
+
+func (f *followRepository) FolloweeList(ctx context.Context, follower int64, offset int, limit int) ([]domain.FollowRelation, error) {
+	res, err := f.dao.FolloweeList(ctx, follower, offset, limit)
+	if err != nil {
+		return nil, err
+	}
+	return slice.Map(res, f.toDomain), nil
+}
+
+func (f *followRepository) Statics(ctx context.Context, uid int64) (domain.FollowStatics, error) {
+	res, err := f.dao.Statics(ctx, uid)
+	if errors.Is(err, dao.ErrFollowStaticsNotFound) {
+		return domain.FollowStatics{Uid: uid}, nil
+	}
+	if err != nil {
+		return domain.FollowStatics{}, err
+	}
+	return domain.FollowStatics{
+		Uid:       res.Uid,
+		Followers: res.Followers,
+		Followees: res.Followees,
+	}, nil
+}
+
+func (f *followRepository) FollowedIds(ctx context.Context, follower int64, followees []int64) ([]int64, error) {
+	return f.dao.FollowedIds(ctx, follower, followees)
+}
+
+func (f *followRepository) PopularFollowees(ctx context.Context, follower int64, minFollowers int64) ([]int64, error) {
+	return f.dao.PopularFollowees(ctx, follower, minFollowers)
+}
+
+func (f *followRepository) toDomain(idx int, src dao.FollowRelation) domain.FollowRelation {
+	return domain.FollowRelation{
+		Follower: src.Follower,
+		Followee: src.Followee,
+		Ctime:    time.UnixMilli(src.UpdatedAt),
+	}
+}
@@ -3,8 +3,89 @@ package repository
 import (
 	"context"
 	"github.com/Andras5014/gohub/internal/domain"
+	"github.com/Andras5014/gohub/internal/repository/dao"
+	"github.com/ecodeclub/ekit/slice"
+	"time"
 )
 
 type HistoryRecordRepository interface {
+	// AddRecord 同一个资源重复阅读只更新时间，超过上限的旧记录由 TrimAll 定期删掉
 	AddRecord(ctx context.Context, record domain.HistoryRecord) error
+	List(ctx context.Context, uid int64, offset int, limit int) ([]domain.HistoryRecord, error)
+	Delete(ctx context.Context, uid int64, biz string, bizId int64) error
+	Clear(ctx context.Context, uid int64) error
+	// TrimAll 删掉所有人超过上限的旧记录，返回清理了多少个人
+	TrimAll(ctx context.Context) (int, error)
+}
+
+type historyRecordRepository struct {
+	dao dao.HistoryRecordDAO
+	// maxPerUser 每个人最多保留多少条
+	maxPerUser int
+	// trimBatchSize TrimAll 一次查多少个人
+	trimBatchSize int
+}
+
+func NewHistoryRecordRepository(dao dao.HistoryRecordDAO) HistoryRecordRepository {
+	return &historyRecordRepository{
+		dao:           dao,
+		maxPerUser:    1000,
+		trimBatchSize: 100,
+	}
+}
+
+func (h *historyRecordRepository) AddRecord(ctx context.Context, record domain.HistoryRecord) error {
+	// 每次都清理的话，读一篇文章要多扫一遍上千条记录，放到定时任务里面做
+	return h.dao.Upsert(ctx, dao.HistoryRecord{
+		Uid:   record.Uid,
+		Biz:   record.Biz,
+		BizId: record.BizId,
+		Ctime: record.Ctime.UnixMilli(),
+	})
+}
+
+func (h *historyRecordRepository) TrimAll(ctx context.Context) (int, error) {
+	var (
+		cnt      int
+		startUid int64
+	)
+	for {
+		uids, err := h.dao.OverLimitUids(ctx, startUid, h.maxPerUser, h.trimBatchSize)
+		if err != nil {
+			return cnt, err
+		}
+		for _, uid := range uids {
+			if err = h.dao.Trim(ctx, uid, h.maxPerUser); err != nil {
+				return cnt, err
+			}
+			cnt++
+		}
+		if len(uids) < h.trimBatchSize {
+			return cnt, nil
+		}
+		startUid = uids[len(uids)-1]
+	}
+}
+
+func (h *historyRecordRepository) List(ctx context.Context, uid int64, offset int, limit int) ([]domain.HistoryRecord, error) {
+	res, err := h.dao.List(ctx, uid, offset, limit)
+	if err != nil {
+		return nil, err
+	}
+	return slice.Map(res, func(idx int, src dao.HistoryRecord) domain.HistoryRecord {
+		return domain.HistoryRecord{
+			BizId: src.BizId,
+			Biz:   src.Biz,
+			Uid:   src.Uid,
+			Ctime: time.UnixMilli(src.Ctime),
+		}
+	}), nil
+}
+
+func (h *historyRecordRepository) Delete(ctx context.Context, uid int64, biz string, bizId int64) error {
+	return h.dao.Delete(ctx, uid, biz, bizId)
+}
+
+func (h *historyRecordRepository) Clear(ctx context.Context, uid int64) error {
+	return h.dao.Clear(ctx, uid)
 }
@@ -0,0 +1,107 @@
+package repository
+
+import (
+	"context"
+	"errors"
+	"github.com/Andras5014/gohub/internal/domain"
+	"github.com/Andras5014/gohub/internal/repository/dao"
+	daomocks "github.com/Andras5014/gohub/internal/repository/dao/mocks"
+	"github.com/stretchr/testify/assert"
+	"go.uber.org/mock/gomock"
+	"testing"
+	"time"
+)
+
+func TestHistoryRecordRepository_AddRecord(t *testing.T) {
+	now := time.UnixMilli(1700000000000)
+	testCases := []struct {
+		name string
+		mock func(ctrl *gomock.Controller) dao.HistoryRecordDAO
+
+		wantErr error
+	}{
+		{
+			name: "写入的时候不清理",
+			mock: func(ctrl *gomock.Controller) dao.HistoryRecordDAO {
+				d := daomocks.NewMockHistoryRecordDAO(ctrl)
+				d.EXPECT().Upsert(gomock.Any(), dao.HistoryRecord{
+					Uid:   123,
+					Biz:   "article",
+					BizId: 1,
+					Ctime: now.UnixMilli(),
+				}).Return(nil)
+				return d
+			},
+		},
+		{
+			name: "写入失败",
+			mock: func(ctrl *gomock.Controller) dao.HistoryRecordDAO {
+				d := daomocks.NewMockHistoryRecordDAO(ctrl)
+				d.EXPECT().Upsert(gomock.Any(), gomock.Any()).Return(errors.New("mock db error"))
+				return d
+			},
+			wantErr: errors.New("mock db error"),
+		},
+	}
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			ctrl := gomock.NewController(t)
+			defer ctrl.Finish()
+			repo := NewHistoryRecordRepository(tc.mock(ctrl))
+			err := repo.AddRecord(context.Background(), domain.HistoryRecord{
+				Uid:   123,
+				Biz:   "article",
+				BizId: 1,
+				Ctime: now,
+			})
+			assert.Equal(t, tc.wantErr, err)
+		})
+	}
+}
+
+func TestHistoryRecordRepository_TrimAll(t *testing.T) {
+	testCases := []struct {
+		name string
+		mock func(ctrl *gomock.Controller) dao.HistoryRecordDAO
+
+		wantCnt int
+		wantErr error
+	}{
+		{
+			name: "分批找到超过上限的人",
+			mock: func(ctrl *gomock.Controller) dao.HistoryRecordDAO {
+				d := daomocks.NewMockHistoryRecordDAO(ctrl)
+				d.EXPECT().OverLimitUids(gomock.Any(), int64(0), 1000, 2).Return([]int64{1, 5}, nil)
+				d.EXPECT().Trim(gomock.Any(), int64(1), 1000).Return(nil)
+				d.EXPECT().Trim(gomock.Any(), int64(5), 1000).Return(nil)
+				d.EXPECT().OverLimitUids(gomock.Any(), int64(5), 1000, 2).Return([]int64{8}, nil)
+				d.EXPECT().Trim(gomock.Any(), int64(8), 1000).Return(nil)
+				return d
+			},
+			wantCnt: 3,
+		},
+		{
+			name: "清理失败",
+			mock: func(ctrl *gomock.Controller) dao.HistoryRecordDAO {
+				d := daomocks.NewMockHistoryRecordDAO(ctrl)
+				d.EXPECT().OverLimitUids(gomock.Any(), int64(0), 1000, 2).Return([]int64{1, 5}, nil)
+				d.EXPECT().Trim(gomock.Any(), int64(1), 1000).Return(nil)
+				d.EXPECT().Trim(gomock.Any(), int64(5), 1000).Return(errors.New("mock db error"))
+				return d
+			},
+			wantCnt: 1,
+			wantErr: errors.New("mock db error"),
+		},
+	}
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			ctrl := gomock.NewController(t)
+			defer ctrl.Finish()
+			repo := NewHistoryRecordRepository(tc.mock(ctrl)).(*historyRecordRepository)
+			repo.trimBatchSize = 2
+			cnt, err := repo.TrimAll(context.Background())
+			assert.Equal(t, tc.wantErr, err)
+			assert.Equal(t, tc.wantCnt, cnt)
+		})
+	}
+}
@@ -13,6 +13,7 @@ type JobRepository interface {
 	UpdateUpdatedAt(ctx context.Context, id int64) error
 	UpdateNextTime(ctx context.Context, id int64, next time.Time) error
 	Stop(ctx context.Context, id int64) error
+	AddJob(ctx context.Context, j domain.Job) error
 }
 
 type jobRepository struct {
@@ -53,3 +54,17 @@ func (j *jobRepository) UpdateNextTime(ctx context.Context, id int64, next time.
 func (j *jobRepository) UpdateUpdatedAt(ctx context.Context, id int64) error {
 	return j.dao.UpdateUpdatedAt(ctx, id)
 }
+
+func (j *jobRepository) AddJob(ctx context.Context, job domain.Job) error {
+	if job.Cfg == "" {
+		// cfg 是 json 列，不能是空串
+		job.Cfg = "{}"
+	}
+	return j.dao.Insert(ctx, dao.Job{
+		Name:           job.Name,
+		Executor:       job.Executor,
+		CronExpression: job.CronExpression,
+		Cfg:            job.Cfg,
+		NextTime:       job.NextTime().UnixMilli(),
+	})
+}
@@ -0,0 +1,147 @@
+// Code generated by MockGen. DO NOT EDIT.
+// Source: ./internal/repository/ranking.go
+//
+// Generated by this command:
+//
+//	mockgen -source=./internal/repository/ranking.go -destination=./internal/repository/mocks/ranking.go -package=repomocks
+//
+
+// Package repomocks is a generated GoMock package.
+package repomocks
+
+import (
+	context "context"
+	reflect "reflect"
+	time "time"
+
+	domain "github.com/Andras5014/gohub/internal/domain"
+	gomock "go.uber.org/mock/gomock"
+)
+
+// MockRankingRepository is a mock of RankingRepository interface.
+type MockRankingRepository struct {
+	ctrl     *gomock.Controller
+	recorder *MockRankingRepositoryMockRecorder
+}
+
+// MockRankingRepositoryMockRecorder is the mock recorder for MockRankingRepository.
+type MockRankingRepositoryMockRecorder struct {
+	mock *MockRankingRepository
+}
+
+// NewMockRankingRepository creates a new mock instance.
+func NewMockRankingRepository(ctrl *gomock.Controller) *MockRankingRepository {
+	mock := &MockRankingRepository{ctrl: ctrl}
+	mock.recorder = &MockRankingRepositoryMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockRankingRepository) EXPECT() *MockRankingRepositoryMockRecorder {
+	return m.recorder
+}
+
+// DecayScores mocks base method.
+func (m *MockRankingRepository) DecayScores(ctx context.Context, name string, halfLife time.Duration, capacity int) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "DecayScores", ctx, name, halfLife, capacity)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// DecayScores indicates an expected call of DecayScores.
+func (mr *MockRankingRepositoryMockRecorder) DecayScores(ctx, name, halfLife, capacity any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "DecayScores", reflect.TypeOf((*MockRankingRepository)(nil).DecayScores), ctx, name, halfLife, capacity)
+}
+
+// GetTopN mocks base method.
+func (m *MockRankingRepository) GetTopN(ctx context.Context, name string) ([]domain.Article, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetTopN", ctx, name)
+	ret0, _ := ret[0].([]domain.Article)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetTopN indicates an expected call of GetTopN.
+func (mr *MockRankingRepositoryMockRecorder) GetTopN(ctx, name any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetTopN", reflect.TypeOf((*MockRankingRepository)(nil).GetTopN), ctx, name)
+}
+
+// IncrScore mocks base method.
+func (m *MockRankingRepository) IncrScore(ctx context.Context, name string, id int64, delta float64) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "IncrScore", ctx, name, id, delta)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// IncrScore indicates an expected call of IncrScore.
+func (mr *MockRankingRepositoryMockRecorder) IncrScore(ctx, name, id, delta any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "IncrScore", reflect.TypeOf((*MockRankingRepository)(nil).IncrScore), ctx, name, id, delta)
+}
+
+// RemoveScores mocks base method.
+func (m *MockRankingRepository) RemoveScores(ctx context.Context, name string, ids ...int64) error {
+	m.ctrl.T.Helper()
+	varargs := []any{ctx, name}
+	for _, a := range ids {
+		varargs = append(varargs, a)
+	}
+	ret := m.ctrl.Call(m, "RemoveScores", varargs...)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// RemoveScores indicates an expected call of RemoveScores.
+func (mr *MockRankingRepositoryMockRecorder) RemoveScores(ctx, name any, ids ...any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	varargs := append([]any{ctx, name}, ids...)
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "RemoveScores", reflect.TypeOf((*MockRankingRepository)(nil).RemoveScores), varargs...)
+}
+
+// ReplaceScores mocks base method.
+func (m *MockRankingRepository) ReplaceScores(ctx context.Context, name string, scores map[int64]float64) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "ReplaceScores", ctx, name, scores)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// ReplaceScores indicates an expected call of ReplaceScores.
+func (mr *MockRankingRepositoryMockRecorder) ReplaceScores(ctx, name, scores any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ReplaceScores", reflect.TypeOf((*MockRankingRepository)(nil).ReplaceScores), ctx, name, scores)
+}
+
+// ReplaceTopN mocks base method.
+func (m *MockRankingRepository) ReplaceTopN(ctx context.Context, name string, arts []domain.Article) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "ReplaceTopN", ctx, name, arts)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// ReplaceTopN indicates an expected call of ReplaceTopN.
+func (mr *MockRankingRepositoryMockRecorder) ReplaceTopN(ctx, name, arts any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ReplaceTopN", reflect.TypeOf((*MockRankingRepository)(nil).ReplaceTopN), ctx, name, arts)
+}
+
+// TopIds mocks base method.
+func (m *MockRankingRepository) TopIds(ctx context.Context, name string, n int) ([]int64, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "TopIds", ctx, name, n)
+	ret0, _ := ret[0].([]int64)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// TopIds indicates an expected call of TopIds.
+func (mr *MockRankingRepositoryMockRecorder) TopIds(ctx, name, n any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "TopIds", reflect.TypeOf((*MockRankingRepository)(nil).TopIds), ctx, name, n)
+}
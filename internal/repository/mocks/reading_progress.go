@@ -0,0 +1,101 @@
+// Code generated by MockGen. DO NOT EDIT.
+// Source: ./internal/repository/reading_progress.go
+//
+// Generated by this command:
+//
+//	mockgen -source=./internal/repository/reading_progress.go -destination=./internal/repository/mocks/reading_progress.go -package=repomocks
+//
+
+// Package repomocks is a generated GoMock package.
+package repomocks
+
+import (
+	context "context"
+	reflect "reflect"
+
+	domain "github.com/Andras5014/gohub/internal/domain"
+	gomock "go.uber.org/mock/gomock"
+)
+
+// MockReadingProgressRepository is a mock of ReadingProgressRepository interface.
+type MockReadingProgressRepository struct {
+	ctrl     *gomock.Controller
+	recorder *MockReadingProgressRepositoryMockRecorder
+}
+
+// MockReadingProgressRepositoryMockRecorder is the mock recorder for MockReadingProgressRepository.
+type MockReadingProgressRepositoryMockRecorder struct {
+	mock *MockReadingProgressRepository
+}
+
+// NewMockReadingProgressRepository creates a new mock instance.
+func NewMockReadingProgressRepository(ctrl *gomock.Controller) *MockReadingProgressRepository {
+	mock := &MockReadingProgressRepository{ctrl: ctrl}
+	mock.recorder = &MockReadingProgressRepositoryMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockReadingProgressRepository) EXPECT() *MockReadingProgressRepositoryMockRecorder {
+	return m.recorder
+}
+
+// Flush mocks base method.
+func (m *MockReadingProgressRepository) Flush(ctx context.Context, batchSize int) (int, int, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Flush", ctx, batchSize)
+	ret0, _ := ret[0].(int)
+	ret1, _ := ret[1].(int)
+	ret2, _ := ret[2].(error)
+	return ret0, ret1, ret2
+}
+
+// Flush indicates an expected call of Flush.
+func (mr *MockReadingProgressRepositoryMockRecorder) Flush(ctx, batchSize any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Flush", reflect.TypeOf((*MockReadingProgressRepository)(nil).Flush), ctx, batchSize)
+}
+
+// Get mocks base method.
+func (m *MockReadingProgressRepository) Get(ctx context.Context, uid, aid int64) (domain.ReadingProgress, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Get", ctx, uid, aid)
+	ret0, _ := ret[0].(domain.ReadingProgress)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// Get indicates an expected call of Get.
+func (mr *MockReadingProgressRepositoryMockRecorder) Get(ctx, uid, aid any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Get", reflect.TypeOf((*MockReadingProgressRepository)(nil).Get), ctx, uid, aid)
+}
+
+// ListUnfinished mocks base method.
+func (m *MockReadingProgressRepository) ListUnfinished(ctx context.Context, uid int64, limit int) ([]domain.ReadingProgress, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "ListUnfinished", ctx, uid, limit)
+	ret0, _ := ret[0].([]domain.ReadingProgress)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// ListUnfinished indicates an expected call of ListUnfinished.
+func (mr *MockReadingProgressRepositoryMockRecorder) ListUnfinished(ctx, uid, limit any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ListUnfinished", reflect.TypeOf((*MockReadingProgressRepository)(nil).ListUnfinished), ctx, uid, limit)
+}
+
+// Save mocks base method.
+func (m *MockReadingProgressRepository) Save(ctx context.Context, p domain.ReadingProgress) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Save", ctx, p)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// Save indicates an expected call of Save.
+func (mr *MockReadingProgressRepositoryMockRecorder) Save(ctx, p any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Save", reflect.TypeOf((*MockReadingProgressRepository)(nil).Save), ctx, p)
+}
@@ -0,0 +1,90 @@
+package repository
+
+import (
+	"context"
+	"github.com/Andras5014/gohub/internal/domain"
+	"github.com/Andras5014/gohub/internal/repository/dao"
+	"github.com/ecodeclub/ekit/slice"
+	"time"
+)
+
+type NotificationRepository interface {
+	Upsert(ctx context.Context, n domain.Notification) error
+	List(ctx context.Context, uid int64, offset int, limit int) ([]domain.Notification, error)
+	UnreadCnt(ctx context.Context, uid int64) (map[domain.NotificationType]int64, error)
+	// MarkRead ids 为空的时候全部标记为已读
+	MarkRead(ctx context.Context, uid int64, ids []int64) error
+	SetMute(ctx context.Context, uid int64, typ domain.NotificationType, mute bool) error
+	MutedTypes(ctx context.Context, uid int64) ([]domain.NotificationType, error)
+}
+
+type notificationRepository struct {
+	dao dao.NotificationDAO
+}
+
+func NewNotificationRepository(dao dao.NotificationDAO) NotificationRepository {
+	return &notificationRepository{dao: dao}
+}
+
+func (n *notificationRepository) Upsert(ctx context.Context, notification domain.Notification) error {
+	return n.dao.Upsert(ctx, dao.Notification{
+		Uid:     notification.Uid,
+		Type:    notification.Type.ToUint8(),
+		Biz:     notification.Biz,
+		BizId:   notification.BizId,
+		ActorId: notification.ActorId,
+		RefId:   notification.RefId,
+	})
+}
+
+func (n *notificationRepository) List(ctx context.Context, uid int64, offset int, limit int) ([]domain.Notification, error) {
+	res, err := n.dao.List(ctx, uid, offset, limit)
+	if err != nil {
+		return nil, err
+	}
+	return slice.Map(res, func(idx int, src dao.Notification) domain.Notification {
+		return domain.Notification{
+			Id:       src.Id,
+			Uid:      src.Uid,
+			Type:     domain.NotificationType(src.Type),
+			Biz:      src.Biz,
+			BizId:    src.BizId,
+			ActorId:  src.ActorId,
+			ActorCnt: src.ActorCnt,
+			RefId:    src.RefId,
+			Read:     src.Read,
+			Ctime:    time.UnixMilli(src.CreatedAt),
+			Utime:    time.UnixMilli(src.UpdatedAt),
+		}
+	}), nil
+}
+
+func (n *notificationRepository) UnreadCnt(ctx context.Context, uid int64) (map[domain.NotificationType]int64, error) {
+	cnts, err := n.dao.UnreadCnt(ctx, uid)
+	if err != nil {
+		return nil, err
+	}
+	res := make(map[domain.NotificationType]int64, len(cnts))
+	for typ, cnt := range cnts {
+		res[domain.NotificationType(typ)] = cnt
+	}
+	return res, nil
+}
+
+func (n *notificationRepository) MarkRead(ctx context.Context, uid int64, ids []int64) error {
+	return n.dao.MarkRead(ctx, uid, ids)
+}
+
+func (n *notificationRepository) SetMute(ctx context.Context, uid int64, typ domain.NotificationType, mute bool) error {
+	return n.dao.SetMute(ctx, uid, typ.ToUint8(), mute)
+}
+
+func (n *notificationRepository) MutedTypes(ctx context.Context, uid int64) ([]domain.NotificationType, error) {
+	res, err := n.dao.MutedTypes(ctx, uid)
+	if err != nil {
+		return nil, err
+	}
+	return slice.Map(res, func(idx int, src uint8) domain.NotificationType {
+		return domain.NotificationType(src)
+	}), nil
+}
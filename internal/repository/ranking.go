@@ -2,21 +2,111 @@ package repository
 
 import (
 	"context"
+	"errors"
 	"github.com/Andras5014/gohub/internal/domain"
 	"github.com/Andras5014/gohub/internal/repository/cache"
+	"github.com/Andras5014/gohub/pkg/logx"
+	"time"
 )
 
 type RankingRepository interface {
-	ReplaceTopN(ctx context.Context, arts []domain.Article) error
+	ReplaceTopN(ctx context.Context, name string, arts []domain.Article) error
+	// GetTopN 优先读本地缓存，redis 兜底
+	GetTopN(ctx context.Context, name string) ([]domain.Article, error)
+
+	// 下面是增量模式用的，分数存在 redis 的有序集合里面
+
+	IncrScore(ctx context.Context, name string, id int64, delta float64) error
+	DecayScores(ctx context.Context, name string, halfLife time.Duration, capacity int) error
+	ReplaceScores(ctx context.Context, name string, scores map[int64]float64) error
+	RemoveScores(ctx context.Context, name string, ids ...int64) error
+	// TopIds 分数最高的 n 篇文章
+	TopIds(ctx context.Context, name string, n int) ([]int64, error)
+}
+
+// CachedRankingRepository 本地缓存 + redis 二级缓存
+// 本地缓存每隔 checkInterval 和 redis 核对一次版本号，版本变了才重新加载
+type CachedRankingRepository struct {
+	redis  cache.RankingCache
+	scores cache.RankingScoreCache
+	local  *cache.RankingLocalCache
+	l      logx.Logger
+
+	checkInterval time.Duration
+}
+
+func NewRankingRepository(redis cache.RankingCache, scores cache.RankingScoreCache,
+	local *cache.RankingLocalCache, l logx.Logger) RankingRepository {
+	return &CachedRankingRepository{
+		redis:         redis,
+		scores:        scores,
+		local:         local,
+		l:             l,
+		checkInterval: time.Second * 5,
+	}
+}
+
+func (r *CachedRankingRepository) ReplaceTopN(ctx context.Context, name string, arts []domain.Article) error {
+	// 本地缓存不用管，下次核对版本的时候就会刷新
+	return r.redis.Set(ctx, name, arts)
 }
-type rankingRepository struct {
-	cache cache.RankingCache
+
+func (r *CachedRankingRepository) GetTopN(ctx context.Context, name string) ([]domain.Article, error) {
+	arts, version, checkedAt, ok := r.local.Get(name)
+	now := time.Now()
+	if ok && now.Sub(checkedAt) < r.checkInterval {
+		return arts, nil
+	}
+
+	if ok {
+		latest, err := r.redis.Version(ctx, name)
+		if errors.Is(err, cache.ErrKeyNotExist) {
+			// 热榜还没有算出来过
+			latest, err = 0, nil
+		}
+		if err != nil {
+			// redis 挂了就一直用本地的，等下一个周期再试
+			r.local.Touch(name, now)
+			r.l.Error("查询热榜版本失败，使用本地缓存", logx.String("name", name), logx.Error(err))
+			return arts, nil
+		}
+		if latest == version {
+			r.local.Touch(name, now)
+			return arts, nil
+		}
+	}
+
+	res, latest, err := r.redis.Get(ctx, name)
+	switch {
+	case errors.Is(err, cache.ErrKeyNotExist):
+		res, latest = []domain.Article{}, 0
+	case err != nil && ok:
+		r.local.Touch(name, now)
+		r.l.Error("加载热榜失败，使用本地缓存", logx.String("name", name), logx.Error(err))
+		return arts, nil
+	case err != nil:
+		return nil, err
+	}
+	r.local.Set(name, res, latest, now)
+	return res, nil
+}
+
+func (r *CachedRankingRepository) IncrScore(ctx context.Context, name string, id int64, delta float64) error {
+	return r.scores.IncrBy(ctx, name, id, delta)
+}
+
+func (r *CachedRankingRepository) DecayScores(ctx context.Context, name string, halfLife time.Duration, capacity int) error {
+	return r.scores.Decay(ctx, name, time.Now(), halfLife, capacity)
+}
+
+func (r *CachedRankingRepository) ReplaceScores(ctx context.Context, name string, scores map[int64]float64) error {
+	return r.scores.Replace(ctx, name, scores, time.Now())
 }
 
-func (r *rankingRepository) ReplaceTopN(ctx context.Context, arts []domain.Article) error {
-	return r.cache.Set(ctx, arts)
+func (r *CachedRankingRepository) RemoveScores(ctx context.Context, name string, ids ...int64) error {
+	return r.scores.Remove(ctx, name, ids...)
 }
 
-func NewRankingRepository() RankingRepository {
-	return &rankingRepository{}
+func (r *CachedRankingRepository) TopIds(ctx context.Context, name string, n int) ([]int64, error) {
+	return r.scores.TopN(ctx, name, n)
 }
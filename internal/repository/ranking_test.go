@@ -0,0 +1,134 @@
+package repository
+
+import (
+	"context"
+	"errors"
+	"github.com/Andras5014/gohub/internal/domain"
+	"github.com/Andras5014/gohub/internal/repository/cache"
+	cachemocks "github.com/Andras5014/gohub/internal/repository/cache/mocks"
+	"github.com/Andras5014/gohub/pkg/logx"
+	"github.com/stretchr/testify/assert"
+	"go.uber.org/mock/gomock"
+	"go.uber.org/zap"
+	"testing"
+	"time"
+)
+
+func TestCachedRankingRepository_GetTopN(t *testing.T) {
+	oldArts := []domain.Article{{Id: 1}}
+	newArts := []domain.Article{{Id: 2}, {Id: 1}}
+	testCases := []struct {
+		name string
+		mock func(ctrl *gomock.Controller) cache.RankingCache
+		// local 本地缓存的初始状态
+		local func() *cache.RankingLocalCache
+
+		wantArts    []domain.Article
+		wantErr     error
+		wantVersion int64
+	}{
+		{
+			name: "本地缓存还没到核对时间",
+			mock: func(ctrl *gomock.Controller) cache.RankingCache {
+				return cachemocks.NewMockRankingCache(ctrl)
+			},
+			local: func() *cache.RankingLocalCache {
+				l := cache.NewRankingLocalCache()
+				l.Set("weekly", oldArts, 1, time.Now())
+				return l
+			},
+			wantArts:    oldArts,
+			wantVersion: 1,
+		},
+		{
+			name: "版本没变",
+			mock: func(ctrl *gomock.Controller) cache.RankingCache {
+				c := cachemocks.NewMockRankingCache(ctrl)
+				c.EXPECT().Version(gomock.Any(), "weekly").Return(int64(1), nil)
+				return c
+			},
+			local: func() *cache.RankingLocalCache {
+				l := cache.NewRankingLocalCache()
+				l.Set("weekly", oldArts, 1, time.Now().Add(-time.Minute))
+				return l
+			},
+			wantArts:    oldArts,
+			wantVersion: 1,
+		},
+		{
+			name: "版本变了重新加载",
+			mock: func(ctrl *gomock.Controller) cache.RankingCache {
+				c := cachemocks.NewMockRankingCache(ctrl)
+				c.EXPECT().Version(gomock.Any(), "weekly").Return(int64(2), nil)
+				c.EXPECT().Get(gomock.Any(), "weekly").Return(newArts, int64(2), nil)
+				return c
+			},
+			local: func() *cache.RankingLocalCache {
+				l := cache.NewRankingLocalCache()
+				l.Set("weekly", oldArts, 1, time.Now().Add(-time.Minute))
+				return l
+			},
+			wantArts:    newArts,
+			wantVersion: 2,
+		},
+		{
+			name: "redis 出错用本地的",
+			mock: func(ctrl *gomock.Controller) cache.RankingCache {
+				c := cachemocks.NewMockRankingCache(ctrl)
+				c.EXPECT().Version(gomock.Any(), "weekly").Return(int64(0), errors.New("redis down"))
+				return c
+			},
+			local: func() *cache.RankingLocalCache {
+				l := cache.NewRankingLocalCache()
+				l.Set("weekly", oldArts, 1, time.Now().Add(-time.Minute))
+				return l
+			},
+			wantArts:    oldArts,
+			wantVersion: 1,
+		},
+		{
+			name: "本地没有数据，从 redis 加载",
+			mock: func(ctrl *gomock.Controller) cache.RankingCache {
+				c := cachemocks.NewMockRankingCache(ctrl)
+				c.EXPECT().Get(gomock.Any(), "weekly").Return(newArts, int64(3), nil)
+				return c
+			},
+			local:       cache.NewRankingLocalCache,
+			wantArts:    newArts,
+			wantVersion: 3,
+		},
+		{
+			name: "还没有热榜",
+			mock: func(ctrl *gomock.Controller) cache.RankingCache {
+				c := cachemocks.NewMockRankingCache(ctrl)
+				c.EXPECT().Get(gomock.Any(), "weekly").Return(nil, int64(0), cache.ErrKeyNotExist)
+				return c
+			},
+			local:    cache.NewRankingLocalCache,
+			wantArts: []domain.Article{},
+		},
+		{
+			name: "本地没有数据，redis 也出错",
+			mock: func(ctrl *gomock.Controller) cache.RankingCache {
+				c := cachemocks.NewMockRankingCache(ctrl)
+				c.EXPECT().Get(gomock.Any(), "weekly").Return(nil, int64(0), errors.New("redis down"))
+				return c
+			},
+			local:   cache.NewRankingLocalCache,
+			wantErr: errors.New("redis down"),
+		},
+	}
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			ctrl := gomock.NewController(t)
+			defer ctrl.Finish()
+			local := tc.local()
+			repo := NewRankingRepository(tc.mock(ctrl), nil, local, logx.NewZapLogger(zap.NewNop()))
+			arts, err := repo.GetTopN(context.Background(), "weekly")
+			assert.Equal(t, tc.wantErr, err)
+			assert.Equal(t, tc.wantArts, arts)
+			_, version, _, _ := local.Get("weekly")
+			assert.Equal(t, tc.wantVersion, version)
+		})
+	}
+}
@@ -0,0 +1,135 @@
+package repository
+
+import (
+	"cmp"
+	"context"
+	"errors"
+	"github.com/Andras5014/gohub/internal/domain"
+	"github.com/Andras5014/gohub/internal/repository/cache"
+	"github.com/Andras5014/gohub/internal/repository/dao"
+	"github.com/Andras5014/gohub/pkg/logx"
+	"github.com/ecodeclub/ekit/slice"
+	"slices"
+	"time"
+)
+
+type ReadingProgressRepository interface {
+	// Save 只写缓存，Flush 的时候才落库
+	Save(ctx context.Context, p domain.ReadingProgress) error
+	// Get 没读过的返回零值
+	Get(ctx context.Context, uid int64, aid int64) (domain.ReadingProgress, error)
+	// ListUnfinished 没读完的，按更新时间倒序
+	ListUnfinished(ctx context.Context, uid int64, limit int) ([]domain.ReadingProgress, error)
+	// Flush 把最多 batchSize 条缓存里面变了的写到数据库，
+	// popped 是从缓存里面取了多少条，过期了的不写，written 是真正写了多少条
+	Flush(ctx context.Context, batchSize int) (popped int, written int, err error)
+}
+
+type readingProgressRepository struct {
+	dao   dao.ReadingProgressDAO
+	cache cache.ReadingProgressCache
+	l     logx.Logger
+}
+
+func NewReadingProgressRepository(dao dao.ReadingProgressDAO, cache cache.ReadingProgressCache,
+	l logx.Logger) ReadingProgressRepository {
+	return &readingProgressRepository{
+		dao:   dao,
+		cache: cache,
+		l:     l,
+	}
+}
+
+func (r *readingProgressRepository) Save(ctx context.Context, p domain.ReadingProgress) error {
+	return r.cache.Set(ctx, p)
+}
+
+func (r *readingProgressRepository) Get(ctx context.Context, uid int64, aid int64) (domain.ReadingProgress, error) {
+	// 缓存里面的可能还没落库，所以缓存里面有就以缓存为准
+	p, err := r.cache.Get(ctx, uid, aid)
+	if err == nil {
+		return p, nil
+	}
+	if !errors.Is(err, cache.ErrKeyNotExist) {
+		r.l.Error("查询阅读进度缓存失败", logx.Int64("uid", uid), logx.Int64("aid", aid), logx.Error(err))
+	}
+	entity, err := r.dao.Get(ctx, uid, aid)
+	if errors.Is(err, dao.ErrReadingProgressNotFound) {
+		return domain.ReadingProgress{Uid: uid, ArticleId: aid}, nil
+	}
+	if err != nil {
+		return domain.ReadingProgress{}, err
+	}
+	return r.toDomain(entity), nil
+}
+
+func (r *readingProgressRepository) ListUnfinished(ctx context.Context, uid int64, limit int) ([]domain.ReadingProgress, error) {
+	cached, err := r.cache.GetAll(ctx, uid)
+	if err != nil {
+		r.l.Error("查询阅读进度缓存失败", logx.Int64("uid", uid), logx.Error(err))
+		cached = nil
+	}
+	// 数据库里面没读完的可能在缓存里面已经读完了，多查几条补上
+	entities, err := r.dao.ListUnfinished(ctx, uid, domain.ReadingFinishedPercent, limit+len(cached))
+	if err != nil {
+		return nil, err
+	}
+	latest := make(map[int64]domain.ReadingProgress, len(entities)+len(cached))
+	for _, p := range append(slice.Map(entities, func(idx int, src dao.ReadingProgress) domain.ReadingProgress {
+		return r.toDomain(src)
+	}), cached...) {
+		if old, ok := latest[p.ArticleId]; !ok || !p.Utime.Before(old.Utime) {
+			latest[p.ArticleId] = p
+		}
+	}
+	res := make([]domain.ReadingProgress, 0, len(latest))
+	for _, p := range latest {
+		if p.Unfinished() {
+			res = append(res, p)
+		}
+	}
+	slices.SortFunc(res, func(a, b domain.ReadingProgress) int {
+		if c := b.Utime.Compare(a.Utime); c != 0 {
+			return c
+		}
+		return cmp.Compare(b.ArticleId, a.ArticleId)
+	})
+	return res[:min(len(res), limit)], nil
+}
+
+func (r *readingProgressRepository) Flush(ctx context.Context, batchSize int) (int, int, error) {
+	ps, popped, err := r.cache.PopDirty(ctx, batchSize)
+	if err != nil || len(ps) == 0 {
+		return popped, 0, err
+	}
+	err = r.dao.Upsert(ctx, slice.Map(ps, func(idx int, src domain.ReadingProgress) dao.ReadingProgress {
+		return r.toEntity(src)
+	}))
+	if err != nil {
+		if er := r.cache.MarkDirty(ctx, ps); er != nil {
+			r.l.Error("放回待落库的阅读进度失败", logx.Int64("cnt", int64(len(ps))), logx.Error(er))
+		}
+		return popped, 0, err
+	}
+	return popped, len(ps), nil
+}
+
+func (r *readingProgressRepository) toDomain(p dao.ReadingProgress) domain.ReadingProgress {
+	return domain.ReadingProgress{
+		Uid:       p.Uid,
+		ArticleId: p.ArticleId,
+		Paragraph: p.Paragraph,
+		Percent:   p.Percent,
+		Utime:     time.UnixMilli(p.Utime),
+	}
+}
+
+func (r *readingProgressRepository) toEntity(p domain.ReadingProgress) dao.ReadingProgress {
+	return dao.ReadingProgress{
+		Uid:       p.Uid,
+		ArticleId: p.ArticleId,
+		Paragraph: p.Paragraph,
+		Percent:   p.Percent,
+		Utime:     p.Utime.UnixMilli(),
+	}
+}
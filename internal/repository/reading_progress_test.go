@@ -0,0 +1,166 @@
+package repository
+
+import (
+	"context"
+	"errors"
+	"github.com/Andras5014/gohub/internal/domain"
+	"github.com/Andras5014/gohub/internal/repository/cache"
+	cachemocks "github.com/Andras5014/gohub/internal/repository/cache/mocks"
+	"github.com/Andras5014/gohub/internal/repository/dao"
+	daomocks "github.com/Andras5014/gohub/internal/repository/dao/mocks"
+	"github.com/Andras5014/gohub/pkg/logx"
+	"github.com/stretchr/testify/assert"
+	"go.uber.org/mock/gomock"
+	"go.uber.org/zap"
+	"testing"
+	"time"
+)
+
+func TestReadingProgressRepository_ListUnfinished(t *testing.T) {
+	now := time.UnixMilli(1700000000000)
+	testCases := []struct {
+		name string
+		mock func(ctrl *gomock.Controller) (dao.ReadingProgressDAO, cache.ReadingProgressCache)
+
+		wantRes []domain.ReadingProgress
+		wantErr error
+	}{
+		{
+			name: "缓存里面的比数据库新",
+			mock: func(ctrl *gomock.Controller) (dao.ReadingProgressDAO, cache.ReadingProgressCache) {
+				d := daomocks.NewMockReadingProgressDAO(ctrl)
+				c := cachemocks.NewMockReadingProgressCache(ctrl)
+				c.EXPECT().GetAll(gomock.Any(), int64(123)).Return([]domain.ReadingProgress{
+					// 已经读完了，数据库里面还是旧的
+					{Uid: 123, ArticleId: 1, Percent: 100, Utime: now.Add(time.Minute)},
+					{Uid: 123, ArticleId: 2, Paragraph: 8, Percent: 60, Utime: now.Add(time.Second)},
+				}, nil)
+				d.EXPECT().ListUnfinished(gomock.Any(), int64(123), domain.ReadingFinishedPercent, 4).
+					Return([]dao.ReadingProgress{
+						{Uid: 123, ArticleId: 1, Paragraph: 3, Percent: 30, Utime: now.UnixMilli()},
+						{Uid: 123, ArticleId: 2, Paragraph: 5, Percent: 40, Utime: now.UnixMilli()},
+						{Uid: 123, ArticleId: 3, Paragraph: 1, Percent: 10, Utime: now.Add(-time.Hour).UnixMilli()},
+					}, nil)
+				return d, c
+			},
+			wantRes: []domain.ReadingProgress{
+				{Uid: 123, ArticleId: 2, Paragraph: 8, Percent: 60, Utime: now.Add(time.Second)},
+				{Uid: 123, ArticleId: 3, Paragraph: 1, Percent: 10, Utime: now.Add(-time.Hour)},
+			},
+		},
+		{
+			name: "缓存出错只查数据库",
+			mock: func(ctrl *gomock.Controller) (dao.ReadingProgressDAO, cache.ReadingProgressCache) {
+				d := daomocks.NewMockReadingProgressDAO(ctrl)
+				c := cachemocks.NewMockReadingProgressCache(ctrl)
+				c.EXPECT().GetAll(gomock.Any(), int64(123)).Return(nil, errors.New("mock redis error"))
+				d.EXPECT().ListUnfinished(gomock.Any(), int64(123), domain.ReadingFinishedPercent, 2).
+					Return([]dao.ReadingProgress{
+						{Uid: 123, ArticleId: 1, Paragraph: 3, Percent: 30, Utime: now.UnixMilli()},
+						{Uid: 123, ArticleId: 2, Paragraph: 5, Percent: 40, Utime: now.UnixMilli()},
+					}, nil)
+				return d, c
+			},
+			wantRes: []domain.ReadingProgress{
+				{Uid: 123, ArticleId: 2, Paragraph: 5, Percent: 40, Utime: now},
+				{Uid: 123, ArticleId: 1, Paragraph: 3, Percent: 30, Utime: now},
+			},
+		},
+		{
+			name: "数据库出错",
+			mock: func(ctrl *gomock.Controller) (dao.ReadingProgressDAO, cache.ReadingProgressCache) {
+				d := daomocks.NewMockReadingProgressDAO(ctrl)
+				c := cachemocks.NewMockReadingProgressCache(ctrl)
+				c.EXPECT().GetAll(gomock.Any(), int64(123)).Return(nil, nil)
+				d.EXPECT().ListUnfinished(gomock.Any(), int64(123), domain.ReadingFinishedPercent, 2).
+					Return(nil, errors.New("mock db error"))
+				return d, c
+			},
+			wantErr: errors.New("mock db error"),
+		},
+	}
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			ctrl := gomock.NewController(t)
+			defer ctrl.Finish()
+			d, c := tc.mock(ctrl)
+			repo := NewReadingProgressRepository(d, c, logx.NewZapLogger(zap.NewNop()))
+			res, err := repo.ListUnfinished(context.Background(), 123, 2)
+			assert.Equal(t, tc.wantErr, err)
+			assert.Equal(t, tc.wantRes, res)
+		})
+	}
+}
+
+func TestReadingProgressRepository_Flush(t *testing.T) {
+	now := time.UnixMilli(1700000000000)
+	ps := []domain.ReadingProgress{
+		{Uid: 123, ArticleId: 1, Paragraph: 3, Percent: 30, Utime: now},
+	}
+	testCases := []struct {
+		name string
+		mock func(ctrl *gomock.Controller) (dao.ReadingProgressDAO, cache.ReadingProgressCache)
+
+		wantPopped  int
+		wantWritten int
+		wantErr     error
+	}{
+		{
+			name: "落库",
+			mock: func(ctrl *gomock.Controller) (dao.ReadingProgressDAO, cache.ReadingProgressCache) {
+				d := daomocks.NewMockReadingProgressDAO(ctrl)
+				c := cachemocks.NewMockReadingProgressCache(ctrl)
+				// 取出来两条，有一条过期了
+				c.EXPECT().PopDirty(gomock.Any(), 10).Return(ps, 2, nil)
+				d.EXPECT().Upsert(gomock.Any(), []dao.ReadingProgress{
+					{Uid: 123, ArticleId: 1, Paragraph: 3, Percent: 30, Utime: now.UnixMilli()},
+				}).Return(nil)
+				return d, c
+			},
+			wantPopped:  2,
+			wantWritten: 1,
+		},
+		{
+			name: "没有要落库的",
+			mock: func(ctrl *gomock.Controller) (dao.ReadingProgressDAO, cache.ReadingProgressCache) {
+				c := cachemocks.NewMockReadingProgressCache(ctrl)
+				c.EXPECT().PopDirty(gomock.Any(), 10).Return(nil, 0, nil)
+				return daomocks.NewMockReadingProgressDAO(ctrl), c
+			},
+		},
+		{
+			name: "取出来的都过期了",
+			mock: func(ctrl *gomock.Controller) (dao.ReadingProgressDAO, cache.ReadingProgressCache) {
+				c := cachemocks.NewMockReadingProgressCache(ctrl)
+				c.EXPECT().PopDirty(gomock.Any(), 10).Return(nil, 10, nil)
+				return daomocks.NewMockReadingProgressDAO(ctrl), c
+			},
+			wantPopped: 10,
+		},
+		{
+			name: "落库失败放回去",
+			mock: func(ctrl *gomock.Controller) (dao.ReadingProgressDAO, cache.ReadingProgressCache) {
+				d := daomocks.NewMockReadingProgressDAO(ctrl)
+				c := cachemocks.NewMockReadingProgressCache(ctrl)
+				c.EXPECT().PopDirty(gomock.Any(), 10).Return(ps, 1, nil)
+				d.EXPECT().Upsert(gomock.Any(), gomock.Any()).Return(errors.New("mock db error"))
+				c.EXPECT().MarkDirty(gomock.Any(), ps).Return(nil)
+				return d, c
+			},
+			wantPopped: 1,
+			wantErr:    errors.New("mock db error"),
+		},
+	}
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			ctrl := gomock.NewController(t)
+			defer ctrl.Finish()
+			d, c := tc.mock(ctrl)
+			repo := NewReadingProgressRepository(d, c, logx.NewZapLogger(zap.NewNop()))
+			popped, written, err := repo.Flush(context.Background(), 10)
+			assert.Equal(t, tc.wantErr, err)
+			assert.Equal(t, tc.wantPopped, popped)
+			assert.Equal(t, tc.wantWritten, written)
+		})
+	}
+}
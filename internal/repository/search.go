@@ -0,0 +1,67 @@
+package repository
+
+import (
+	"context"
+	"github.com/Andras5014/gohub/internal/domain"
+	"github.com/Andras5014/gohub/pkg/searchx"
+	"strconv"
+	"time"
+)
+
+type SearchRepository interface {
+	InputArticle(ctx context.Context, art domain.Article) error
+	DeleteArticle(ctx context.Context, id int64) error
+	SearchArticle(ctx context.Context, q string, offset, limit int) (domain.ArticleSearchResult, error)
+}
+
+type searchRepository struct {
+	idx searchx.Index
+}
+
+func NewSearchRepository(idx searchx.Index) SearchRepository {
+	return &searchRepository{idx: idx}
+}
+
+func (s *searchRepository) InputArticle(ctx context.Context, art domain.Article) error {
+	return s.idx.Upsert(ctx, searchx.Document{
+		Id: art.Id,
+		Fields: map[string]string{
+			"title":      art.Title,
+			"content":    art.Content,
+			"author_id":  strconv.FormatInt(art.Author.Id, 10),
+			"updated_at": strconv.FormatInt(art.UpdatedAt.UnixMilli(), 10),
+		},
+	})
+}
+
+func (s *searchRepository) DeleteArticle(ctx context.Context, id int64) error {
+	return s.idx.Delete(ctx, id)
+}
+
+func (s *searchRepository) SearchArticle(ctx context.Context, q string, offset, limit int) (domain.ArticleSearchResult, error) {
+	res, err := s.idx.Search(ctx, searchx.Query{
+		Text:      q,
+		Offset:    offset,
+		Limit:     limit,
+		Highlight: []string{"title", "content"},
+	})
+	if err != nil {
+		return domain.ArticleSearchResult{}, err
+	}
+	hits := make([]domain.ArticleSearchHit, 0, len(res.Hits))
+	for _, hit := range res.Hits {
+		authorId, _ := strconv.ParseInt(hit.Fields["author_id"], 10, 64)
+		utime, _ := strconv.ParseInt(hit.Fields["updated_at"], 10, 64)
+		hits = append(hits, domain.ArticleSearchHit{
+			Article: domain.Article{
+				Id:        hit.Id,
+				Title:     hit.Fields["title"],
+				Author:    domain.Author{Id: authorId},
+				UpdatedAt: time.UnixMilli(utime),
+			},
+			Title:   hit.Highlights["title"],
+			Content: hit.Highlights["content"],
+		})
+	}
+	return domain.ArticleSearchResult{Total: res.Total, Hits: hits}, nil
+}
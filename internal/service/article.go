@@ -6,25 +6,112 @@ import (
 	"github.com/Andras5014/gohub/internal/domain"
 	articleEvent "github.com/Andras5014/gohub/internal/events/article"
 	"github.com/Andras5014/gohub/internal/repository/article"
+	"github.com/Andras5014/gohub/pkg/diffx"
 	"github.com/Andras5014/gohub/pkg/logx"
+	"github.com/Andras5014/gohub/pkg/markdownx"
+	"strings"
 	"time"
+	"unicode/utf8"
+)
+
+var (
+	ErrArticlePermissionDenied = errors.New("无权限操作该文章")
+	ErrInvalidSchedule         = errors.New("定时时间不合法")
+	// ErrArticleVersionConflict 编辑的时候带的版本号已经过期了
+	ErrArticleVersionConflict = article.ErrArticleVersionConflict
+	ErrArticleNotFound        = article.ErrArticleNotFound
+	ErrInvalidTags            = errors.New("标签不合法")
+	ErrInvalidInvitation      = errors.New("邀请不合法")
+	ErrInvitationNotFound     = article.ErrCollaboratorNotFound
+	// ErrArticleNotPendingReview 已经审核过了，或者作者改了草稿、撤回了
+	ErrArticleNotPendingReview = errors.New("文章不在待审核状态")
+)
+
+const (
+	// purgeBatchSize 清理回收站每一批的数量
+	purgeBatchSize    = 100
+	maxTagsPerArticle = 5
+	maxTagLength      = 20
+	// wordsPerMinute 估算阅读时间用的阅读速度
+	wordsPerMinute = 300
+	// scheduleRetryDelay 定时任务第一次失败之后推迟的时间，之后每次翻倍
+	scheduleRetryDelay = time.Minute
+	// maxScheduleRetries 连续失败这么多次之后取消定时，大概是八个多小时
+	maxScheduleRetries = 10
 )
 
 //go:generate mockgen -destination=mocks/article.mock.go -package=svcmocks -source=./article.go
 type ArticleService interface {
 	Save(ctx context.Context, article domain.Article) (int64, error)
-	Publish(ctx context.Context, article domain.Article) (int64, error)
+	// Publish 返回文章 id 和发表之后的状态，命中敏感词进入人工审核的是 ArticleStatusPendingReview
+	Publish(ctx context.Context, article domain.Article) (int64, domain.ArticleStatus, error)
 	PublishV1(ctx context.Context, article domain.Article) (int64, error)
 	Withdraw(ctx context.Context, article domain.Article) (int64, error)
-	List(ctx context.Context, id int64, offset int, limit int) ([]domain.Article, error)
-	ListPub(ctx context.Context, start time.Time, offset int, limit int) ([]domain.Article, error)
+	// List 作者自己的文章，按更新时间倒序翻页，零值游标表示第一页
+	List(ctx context.Context, id int64, cursor domain.ArticleCursor, limit int) ([]domain.Article, error)
+	ListPub(ctx context.Context, cursor domain.ArticleCursor, limit int) ([]domain.Article, error)
 	GetById(ctx context.Context, id int64) (domain.Article, error)
 	GetPubById(ctx context.Context, id, uid int64) (domain.Article, error)
+	// WarmPubCache 预热线上库文章的缓存，上了热榜的文章会用到
+	WarmPubCache(ctx context.Context, ids []int64) error
+	// ListPubByIds 按照 ids 的顺序返回线上库的文章，找不到的跳过，不算阅读
+	ListPubByIds(ctx context.Context, ids []int64) ([]domain.Article, error)
+	// ListPubByTag 按标签浏览已发表的文章
+	ListPubByTag(ctx context.Context, tag string, cursor domain.ArticleCursor, limit int) ([]domain.Article, error)
+	TagCounts(ctx context.Context, limit int) ([]domain.Tag, error)
+
+	// ListRevisions 历史版本只有作者本人可以查看
+	ListRevisions(ctx context.Context, artId, uid int64, offset int, limit int) ([]domain.ArticleRevision, error)
+	GetRevision(ctx context.Context, artId, revId, uid int64) (domain.ArticleRevision, error)
+	DiffRevisions(ctx context.Context, artId, from, to, uid int64) (domain.RevisionDiff, error)
+	// RestoreRevision 把某个历史版本恢复成当前草稿
+	RestoreRevision(ctx context.Context, artId, revId, uid int64) (int64, error)
+
+	// SchedulePublish 保存草稿，并且在 PublishAt 的时候发表，可以顺带设置 UnpublishAt
+	SchedulePublish(ctx context.Context, article domain.Article) (int64, error)
+	// ScheduleWithdraw 在 UnpublishAt 的时候撤回
+	ScheduleWithdraw(ctx context.Context, article domain.Article) error
+	CancelSchedule(ctx context.Context, artId, uid int64, publish, withdraw bool) error
+	// RunSchedules 由定时任务调用，处理到期的定时发表和撤回
+	RunSchedules(ctx context.Context, now time.Time) error
+
+	// Delete 放进回收站，只有作者本人可以操作
+	Delete(ctx context.Context, artId, uid int64) error
+	// Restore 从回收站恢复，原来发表了的恢复之后还是发表状态
+	Restore(ctx context.Context, artId, uid int64) error
+	ListTrash(ctx context.Context, uid int64, offset int, limit int) ([]domain.Article, error)
+	// PurgeTrash 彻底删除 before 之前放进回收站的文章，返回删除的数量
+	PurgeTrash(ctx context.Context, before time.Time) (int, error)
+
+	// GetDraft 制作库的文章，作者和所有接受了邀请的协作者都可以看
+	GetDraft(ctx context.Context, artId, uid int64) (domain.Article, error)
+	// InviteCollaborator 只有作者可以邀请，邀请已有的协作者相当于修改角色
+	InviteCollaborator(ctx context.Context, artId, uid, invitee int64, role domain.ArticleRole) error
+	AcceptInvitation(ctx context.Context, artId, uid int64) error
+	// DeclineInvitation 拒绝邀请，已经接受了的相当于退出协作
+	DeclineInvitation(ctx context.Context, artId, uid int64) error
+	// RemoveCollaborator 只有作者可以移除协作者
+	RemoveCollaborator(ctx context.Context, artId, uid, collabUid int64) error
+	ListCollaborators(ctx context.Context, artId, uid int64) ([]domain.ArticleCollaborator, error)
+	// ListCollaborations 自己收到的邀请或者参与协作的文章
+	ListCollaborations(ctx context.Context, uid int64, status domain.CollaboratorStatus,
+		offset int, limit int) ([]domain.ArticleCollaborator, error)
+
+	// ListPendingReview 审核队列，先提交的排在前面
+	ListPendingReview(ctx context.Context, offset int, limit int) ([]domain.Article, error)
+	// ApproveReview 审核通过，发表提交审核时的草稿
+	ApproveReview(ctx context.Context, artId, reviewer int64) error
+	// RejectReview 审核不通过，作者可以在自己的列表里面看到原因
+	RejectReview(ctx context.Context, artId, reviewer int64, reason string) error
 }
 
 type articleService struct {
 	// 方案一
 	repo article.Repository
+	// collabRepo 协作者，权限检查都在 service 里面做
+	collabRepo article.CollaboratorRepository
+	// moderator 发表之前的内容审核，为 nil 的时候不审核
+	moderator Moderator
 
 	// 方案二 依靠二个不同repository 来解决跨表跨库问题
 	readerRepo article.ReaderRepository
@@ -35,13 +122,17 @@ type articleService struct {
 	producer articleEvent.Producer
 }
 
-func (a *articleService) ListPub(ctx context.Context, start time.Time, offset int, limit int) ([]domain.Article, error) {
-	return a.repo.ListPub(ctx, start, offset, limit)
+func (a *articleService) ListPub(ctx context.Context, cursor domain.ArticleCursor, limit int) ([]domain.Article, error) {
+	return a.repo.ListPub(ctx, cursor, limit)
 }
 
 func (a *articleService) GetPubById(ctx context.Context, id, uid int64) (domain.Article, error) {
 	art, err := a.repo.GetPubById(ctx, id)
 	if errors.Is(err, nil) {
+		// 上线之前发表的文章没有渲染结果，现场补一份
+		if art.Rendered.HTML == "" && art.Content != "" {
+			art.Rendered = renderContent(art.Content)
+		}
 		go func() {
 			er := a.producer.ProduceReadEvent(ctx, articleEvent.ReadEvent{
 				ArticleId: id,
@@ -56,11 +147,34 @@ func (a *articleService) GetPubById(ctx context.Context, id, uid int64) (domain.
 	return art, err
 }
 
-func NewArticleService(repo article.Repository, producer articleEvent.Producer, l logx.Logger) ArticleService {
+func (a *articleService) WarmPubCache(ctx context.Context, ids []int64) error {
+	return a.repo.WarmPub(ctx, ids)
+}
+
+func (a *articleService) ListPubByIds(ctx context.Context, ids []int64) ([]domain.Article, error) {
+	res := make([]domain.Article, 0, len(ids))
+	for _, id := range ids {
+		// 走缓存，热榜上的文章基本都在缓存里面
+		art, err := a.repo.GetPubById(ctx, id)
+		if errors.Is(err, ErrArticleNotFound) {
+			continue
+		}
+		if err != nil {
+			return nil, err
+		}
+		res = append(res, art)
+	}
+	return res, nil
+}
+
+func NewArticleService(repo article.Repository, collabRepo article.CollaboratorRepository,
+	moderator Moderator, producer articleEvent.Producer, l logx.Logger) ArticleService {
 	return &articleService{
-		repo:     repo,
-		producer: producer,
-		logger:   l,
+		repo:       repo,
+		collabRepo: collabRepo,
+		moderator:  moderator,
+		producer:   producer,
+		logger:     l,
 	}
 }
 
@@ -72,7 +186,33 @@ func NewArticleServiceV1(readerRepo article.ReaderRepository, authorRepo article
 	}
 }
 
+func (a *articleService) ListPubByTag(ctx context.Context, tag string, cursor domain.ArticleCursor, limit int) ([]domain.Article, error) {
+	return a.repo.ListPubByTag(ctx, tag, cursor, limit)
+}
+
+func (a *articleService) TagCounts(ctx context.Context, limit int) ([]domain.Tag, error) {
+	return a.repo.TagCounts(ctx, limit)
+}
+
+// Save 修改已有的文章要有编辑的权限，保存的时候用的还是作者的 id，历史版本记录实际操作的人
 func (a *articleService) Save(ctx context.Context, article domain.Article) (int64, error) {
+	if article.Id > 0 {
+		art, err := a.authorize(ctx, article.Id, article.Author.Id, domain.ArticleRoleEditor)
+		if err != nil {
+			return 0, err
+		}
+		article.EditorId = article.Author.Id
+		article.Author = art.Author
+	}
+	return a.save(ctx, article)
+}
+
+func (a *articleService) save(ctx context.Context, article domain.Article) (int64, error) {
+	tags, err := normalizeTags(article.Tags)
+	if err != nil {
+		return 0, err
+	}
+	article.Tags = tags
 	article.Status = domain.ArticleStatusUnPublished
 	if article.Id > 0 {
 		return article.Id, a.repo.Update(ctx, article)
@@ -81,10 +221,52 @@ func (a *articleService) Save(ctx context.Context, article domain.Article) (int6
 
 }
 
-func (a *articleService) Publish(ctx context.Context, article domain.Article) (int64, error) {
+func (a *articleService) Publish(ctx context.Context, article domain.Article) (int64, domain.ArticleStatus, error) {
+	if article.Id > 0 {
+		art, err := a.authorize(ctx, article.Id, article.Author.Id, domain.ArticleRolePublisher)
+		if err != nil {
+			return 0, domain.ArticleStatusUnknown, err
+		}
+		article.EditorId = article.Author.Id
+		article.Author = art.Author
+	}
+	return a.publish(ctx, article)
+}
+
+func (a *articleService) publish(ctx context.Context, article domain.Article) (int64, domain.ArticleStatus, error) {
+	tags, err := normalizeTags(article.Tags)
+	if err != nil {
+		return 0, domain.ArticleStatusUnknown, err
+	}
+	article.Tags = tags
+	if a.moderator != nil {
+		var review bool
+		article, review, err = a.moderator.Moderate(ctx, article)
+		if err != nil {
+			return 0, domain.ArticleStatusUnknown, err
+		}
+		if review {
+			id, err := a.submitReview(ctx, article)
+			return id, domain.ArticleStatusPendingReview, err
+		}
+	}
+	id, err := a.syncPublished(ctx, article)
+	return id, domain.ArticleStatusPublished, err
+}
+
+// syncPublished 同步到线上库并通知下游
+func (a *articleService) syncPublished(ctx context.Context, article domain.Article) (int64, error) {
 	//制作库
 	article.Status = domain.ArticleStatusPublished
-	return a.repo.Sync(ctx, article)
+	article.ReviewReason = ""
+	article.Rendered = renderContent(article.Content)
+	id, err := a.repo.Sync(ctx, article)
+	if err != nil {
+		return id, err
+	}
+	article.Id = id
+	a.producePublishEvent(ctx, article)
+	return id, nil
 }
 func (a *articleService) PublishV1(ctx context.Context, article domain.Article) (int64, error) {
 	var (
@@ -101,6 +283,7 @@ func (a *articleService) PublishV1(ctx context.Context, article domain.Article)
 		return 0, err
 	}
 	article.Id = id
+	article.Rendered = renderContent(article.Content)
 
 	// 保存到线上库并重试处理
 	err = a.retrySaveToReaderRepo(ctx, article, 3)
@@ -111,16 +294,349 @@ func (a *articleService) PublishV1(ctx context.Context, article domain.Article)
 	return id, nil
 
 }
-func (a *articleService) List(ctx context.Context, id int64, offset int, limit int) ([]domain.Article, error) {
-	return a.repo.List(ctx, id, offset, limit)
+func (a *articleService) List(ctx context.Context, id int64, cursor domain.ArticleCursor, limit int) ([]domain.Article, error) {
+	return a.repo.List(ctx, id, cursor, limit)
 }
 func (a *articleService) GetById(ctx context.Context, id int64) (domain.Article, error) {
 	return a.repo.GetById(ctx, id)
 }
 
 func (a *articleService) Withdraw(ctx context.Context, article domain.Article) (int64, error) {
+	art, err := a.authorize(ctx, article.Id, article.Author.Id, domain.ArticleRolePublisher)
+	if err != nil {
+		return 0, err
+	}
+	article.Author = art.Author
+	return a.withdraw(ctx, article)
+}
+
+func (a *articleService) withdraw(ctx context.Context, article domain.Article) (int64, error) {
 	article.Status = domain.ArticleStatusPrivate
-	return a.repo.SyncStatus(ctx, article)
+	id, err := a.repo.SyncStatus(ctx, article)
+	if err != nil {
+		return id, err
+	}
+	a.producePublishEvent(ctx, article)
+	return id, nil
+}
+
+// producePublishEvent 发表和撤回之后通知下游，失败了只记日志
+// 同步发送，保证同一篇文章先发表后撤回的顺序
+func (a *articleService) producePublishEvent(ctx context.Context, art domain.Article) {
+	evt := articleEvent.PublishEvent{
+		ArticleId: art.Id,
+		AuthorId:  art.Author.Id,
+		Status:    art.Status.ToUint8(),
+		UpdatedAt: time.Now().UnixMilli(),
+		Deleted:   !art.DeletedAt.IsZero(),
+	}
+	if art.Status == domain.ArticleStatusPublished && !evt.Deleted {
+		evt.Title = art.Title
+		evt.Content = art.Content
+		evt.Tags = art.Tags
+	}
+	if err := a.producer.ProducePublishEvent(ctx, evt); err != nil {
+		a.logger.Error("文章发表事件写入失败", logx.Int64("article_id", art.Id), logx.Error(err))
+	}
+}
+
+func (a *articleService) ListRevisions(ctx context.Context, artId, uid int64, offset int, limit int) ([]domain.ArticleRevision, error) {
+	if _, err := a.authorize(ctx, artId, uid, domain.ArticleRoleViewer); err != nil {
+		return nil, err
+	}
+	return a.repo.ListRevisions(ctx, artId, offset, limit)
+}
+
+func (a *articleService) GetRevision(ctx context.Context, artId, revId, uid int64) (domain.ArticleRevision, error) {
+	if _, err := a.authorize(ctx, artId, uid, domain.ArticleRoleViewer); err != nil {
+		return domain.ArticleRevision{}, err
+	}
+	return a.repo.GetRevision(ctx, artId, revId)
+}
+
+func (a *articleService) DiffRevisions(ctx context.Context, artId, from, to, uid int64) (domain.RevisionDiff, error) {
+	if _, err := a.authorize(ctx, artId, uid, domain.ArticleRoleViewer); err != nil {
+		return domain.RevisionDiff{}, err
+	}
+	src, err := a.repo.GetRevision(ctx, artId, from)
+	if err != nil {
+		return domain.RevisionDiff{}, err
+	}
+	dst, err := a.repo.GetRevision(ctx, artId, to)
+	if err != nil {
+		return domain.RevisionDiff{}, err
+	}
+	return domain.RevisionDiff{
+		From:    src,
+		To:      dst,
+		Title:   diffx.Text(src.Title, dst.Title),
+		Content: diffx.Text(src.Content, dst.Content),
+	}, nil
+}
+
+func (a *articleService) RestoreRevision(ctx context.Context, artId, revId, uid int64) (int64, error) {
+	cur, err := a.authorize(ctx, artId, uid, domain.ArticleRoleEditor)
+	if err != nil {
+		return 0, err
+	}
+	rev, err := a.repo.GetRevision(ctx, artId, revId)
+	if err != nil {
+		return 0, err
+	}
+	// 恢复本身也是一次保存，会再追加一个历史版本
+	return a.save(ctx, domain.Article{
+		Id:       artId,
+		Title:    rev.Title,
+		Content:  rev.Content,
+		Author:   cur.Author,
+		EditorId: uid,
+		// 历史版本不记录标签，沿用当前的
+		Tags:    cur.Tags,
+		Version: cur.Version,
+	})
+}
+
+func (a *articleService) SchedulePublish(ctx context.Context, article domain.Article) (int64, error) {
+	if !article.PublishAt.After(time.Now()) {
+		return 0, ErrInvalidSchedule
+	}
+	if !article.UnpublishAt.IsZero() && !article.UnpublishAt.After(article.PublishAt) {
+		return 0, ErrInvalidSchedule
+	}
+	if article.Id > 0 {
+		art, err := a.authorize(ctx, article.Id, article.Author.Id, domain.ArticleRolePublisher)
+		if err != nil {
+			return 0, err
+		}
+		article.EditorId = article.Author.Id
+		article.Author = art.Author
+	}
+	id, err := a.save(ctx, article)
+	if err != nil {
+		return 0, err
+	}
+	article.Id = id
+	return id, a.repo.UpdateSchedule(ctx, article)
+}
+
+func (a *articleService) ScheduleWithdraw(ctx context.Context, article domain.Article) error {
+	art, err := a.authorize(ctx, article.Id, article.Author.Id, domain.ArticleRolePublisher)
+	if err != nil {
+		return err
+	}
+	if !article.UnpublishAt.After(time.Now()) {
+		return ErrInvalidSchedule
+	}
+	// 已经定时发表的，撤回要在发表之后
+	if !art.PublishAt.IsZero() && !article.UnpublishAt.After(art.PublishAt) {
+		return ErrInvalidSchedule
+	}
+	art.UnpublishAt = article.UnpublishAt
+	art.ScheduleRetries = 0
+	return a.repo.UpdateSchedule(ctx, art)
+}
+
+func (a *articleService) CancelSchedule(ctx context.Context, artId, uid int64, publish, withdraw bool) error {
+	art, err := a.authorize(ctx, artId, uid, domain.ArticleRolePublisher)
+	if err != nil {
+		return err
+	}
+	if publish {
+		art.PublishAt = time.Time{}
+	}
+	if withdraw {
+		art.UnpublishAt = time.Time{}
+	}
+	art.ScheduleRetries = 0
+	return a.repo.UpdateSchedule(ctx, art)
+}
+
+func (a *articleService) RunSchedules(ctx context.Context, now time.Time) error {
+	// 每次只处理一批，剩下的留给下一次调度
+	arts, err := a.repo.ListDueScheduled(ctx, now, 100)
+	if err != nil {
+		return err
+	}
+	for _, art := range arts {
+		a.runSchedule(ctx, art, now)
+	}
+	return nil
+}
+
+func (a *articleService) runSchedule(ctx context.Context, art domain.Article, now time.Time) {
+	if !art.PublishAt.IsZero() && !art.PublishAt.After(now) {
+		// 发表的是到期那一刻的草稿
+		if _, _, err := a.publish(ctx, art); err != nil {
+			a.logger.Error("定时发表失败", logx.Int64("article_id", art.Id), logx.Error(err))
+			// 内容不合规重试也没用，取消定时，作者改了之后重新发表
+			if !errors.Is(err, ErrSensitiveContent) {
+				a.retrySchedule(ctx, art, now, true)
+				return
+			}
+		}
+		art.PublishAt = time.Time{}
+	}
+	if !art.UnpublishAt.IsZero() && !art.UnpublishAt.After(now) {
+		if _, err := a.withdraw(ctx, art); err != nil {
+			a.logger.Error("定时撤回失败", logx.Int64("article_id", art.Id), logx.Error(err))
+			a.retrySchedule(ctx, art, now, false)
+			return
+		}
+		art.UnpublishAt = time.Time{}
+	}
+	art.ScheduleRetries = 0
+	// 清理失败下一轮会重复执行，发表和撤回都是幂等的
+	if err := a.repo.UpdateSchedule(ctx, art); err != nil {
+		a.logger.Error("清理定时任务失败", logx.Int64("article_id", art.Id), logx.Error(err))
+	}
+}
+
+// retrySchedule 失败的定时任务往后推，不然一直失败的会占满每一批，后面到期的轮不到
+// 连续失败太多次就取消，作者可以重新设置
+func (a *articleService) retrySchedule(ctx context.Context, art domain.Article, now time.Time, publish bool) {
+	art.ScheduleRetries++
+	next := now.Add(scheduleRetryDelay << (art.ScheduleRetries - 1))
+	switch {
+	case art.ScheduleRetries > maxScheduleRetries:
+		a.logger.Error("定时任务多次失败，取消定时", logx.Int64("article_id", art.Id))
+		if publish {
+			art.PublishAt = time.Time{}
+		} else {
+			art.UnpublishAt = time.Time{}
+		}
+		art.ScheduleRetries = 0
+	case publish:
+		// 撤回要跟着往后推，保证还是在发表之后
+		if !art.UnpublishAt.IsZero() {
+			art.UnpublishAt = art.UnpublishAt.Add(next.Sub(art.PublishAt))
+		}
+		art.PublishAt = next
+	default:
+		art.UnpublishAt = next
+	}
+	if err := a.repo.UpdateSchedule(ctx, art); err != nil {
+		a.logger.Error("推迟定时任务失败", logx.Int64("article_id", art.Id), logx.Error(err))
+	}
+}
+
+func (a *articleService) Delete(ctx context.Context, artId, uid int64) error {
+	art, err := a.authorize(ctx, artId, uid, domain.ArticleRoleOwner)
+	if err != nil {
+		return err
+	}
+	if err = a.repo.Delete(ctx, artId, uid); err != nil {
+		return err
+	}
+	art.DeletedAt = time.Now()
+	a.producePublishEvent(ctx, art)
+	return nil
+}
+
+func (a *articleService) Restore(ctx context.Context, artId, uid int64) error {
+	art, err := a.authorize(ctx, artId, uid, domain.ArticleRoleOwner)
+	if err != nil {
+		return err
+	}
+	if err = a.repo.Restore(ctx, artId, uid); err != nil {
+		return err
+	}
+	// 发表状态下制作库和线上库的内容是一致的，直接用制作库的数据通知下游
+	if art.Status == domain.ArticleStatusPublished {
+		art.DeletedAt = time.Time{}
+		a.producePublishEvent(ctx, art)
+	}
+	return nil
+}
+
+func (a *articleService) ListTrash(ctx context.Context, uid int64, offset int, limit int) ([]domain.Article, error) {
+	return a.repo.ListTrash(ctx, uid, offset, limit)
+}
+
+func (a *articleService) PurgeTrash(ctx context.Context, before time.Time) (int, error) {
+	total := 0
+	for {
+		if err := ctx.Err(); err != nil {
+			return total, err
+		}
+		n, err := a.repo.PurgeDeleted(ctx, before, purgeBatchSize)
+		total += n
+		if err != nil {
+			return total, err
+		}
+		if n < purgeBatchSize {
+			return total, nil
+		}
+	}
+}
+
+// renderContent 发表的时候渲染一次，和线上库一起保存
+func renderContent(content string) domain.RenderedContent {
+	res := markdownx.Render(content)
+	words := int64(markdownx.CountWords(res.Text))
+	readingTime := (words + wordsPerMinute - 1) / wordsPerMinute
+	if readingTime == 0 && content != "" {
+		readingTime = 1
+	}
+	var toc []domain.TocItem
+	for _, h := range res.Toc {
+		toc = append(toc, domain.TocItem{Level: h.Level, Anchor: h.Id, Text: h.Text})
+	}
+	return domain.RenderedContent{
+		HTML:        res.HTML,
+		Toc:         toc,
+		WordCount:   words,
+		ReadingTime: readingTime,
+		Abstract:    markdownx.Summary(res.Text, domain.AbstractLength),
+	}
+}
+
+// normalizeTags 去掉首尾空白和重复的标签，保持原来的顺序
+func normalizeTags(tags []string) ([]string, error) {
+	res := make([]string, 0, len(tags))
+	seen := make(map[string]struct{}, len(tags))
+	for _, tag := range tags {
+		tag = strings.TrimSpace(tag)
+		if tag == "" {
+			continue
+		}
+		if utf8.RuneCountInString(tag) > maxTagLength {
+			return nil, ErrInvalidTags
+		}
+		if _, ok := seen[tag]; ok {
+			continue
+		}
+		seen[tag] = struct{}{}
+		res = append(res, tag)
+	}
+	if len(res) > maxTagsPerArticle {
+		return nil, ErrInvalidTags
+	}
+	if len(res) == 0 {
+		return nil, nil
+	}
+	return res, nil
+}
+
+// authorize uid 对文章至少要有 need 的权限，作者本人什么都可以做
+func (a *articleService) authorize(ctx context.Context, artId, uid int64, need domain.ArticleRole) (domain.Article, error) {
+	art, err := a.repo.GetById(ctx, artId)
+	if err != nil {
+		return domain.Article{}, err
+	}
+	if art.Author.Id == uid {
+		return art, nil
+	}
+	if need == domain.ArticleRoleOwner {
+		return domain.Article{}, ErrArticlePermissionDenied
+	}
+	role, err := a.collabRepo.Role(ctx, artId, uid)
+	if err != nil {
+		return domain.Article{}, err
+	}
+	if !role.Allows(need) {
+		return domain.Article{}, ErrArticlePermissionDenied
+	}
+	return art, nil
 }
 
 // retrySaveToReaderRepo 重试保存到 readerRepo，最多重试指定次数
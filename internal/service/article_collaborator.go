@@ -0,0 +1,52 @@
+package service
+
+import (
+	"context"
+	"github.com/Andras5014/gohub/internal/domain"
+)
+
+func (a *articleService) GetDraft(ctx context.Context, artId, uid int64) (domain.Article, error) {
+	return a.authorize(ctx, artId, uid, domain.ArticleRoleViewer)
+}
+
+func (a *articleService) InviteCollaborator(ctx context.Context, artId, uid, invitee int64, role domain.ArticleRole) error {
+	if !role.Invitable() || invitee <= 0 || invitee == uid {
+		return ErrInvalidInvitation
+	}
+	if _, err := a.authorize(ctx, artId, uid, domain.ArticleRoleOwner); err != nil {
+		return err
+	}
+	return a.collabRepo.Invite(ctx, domain.ArticleCollaborator{
+		ArticleId: artId,
+		Uid:       invitee,
+		Role:      role,
+		InviterId: uid,
+	})
+}
+
+func (a *articleService) AcceptInvitation(ctx context.Context, artId, uid int64) error {
+	return a.collabRepo.Accept(ctx, artId, uid)
+}
+
+func (a *articleService) DeclineInvitation(ctx context.Context, artId, uid int64) error {
+	return a.collabRepo.Remove(ctx, artId, uid)
+}
+
+func (a *articleService) RemoveCollaborator(ctx context.Context, artId, uid, collabUid int64) error {
+	if _, err := a.authorize(ctx, artId, uid, domain.ArticleRoleOwner); err != nil {
+		return err
+	}
+	return a.collabRepo.Remove(ctx, artId, collabUid)
+}
+
+func (a *articleService) ListCollaborators(ctx context.Context, artId, uid int64) ([]domain.ArticleCollaborator, error) {
+	if _, err := a.authorize(ctx, artId, uid, domain.ArticleRoleViewer); err != nil {
+		return nil, err
+	}
+	return a.collabRepo.ListByArticle(ctx, artId)
+}
+
+func (a *articleService) ListCollaborations(ctx context.Context, uid int64, status domain.CollaboratorStatus,
+	offset int, limit int) ([]domain.ArticleCollaborator, error) {
+	return a.collabRepo.ListByUid(ctx, uid, status, offset, limit)
+}
@@ -0,0 +1,183 @@
+package service
+
+import (
+	"context"
+	"errors"
+	"github.com/Andras5014/gohub/internal/domain"
+	"github.com/Andras5014/gohub/internal/repository/article"
+	artrepomocks "github.com/Andras5014/gohub/internal/repository/article/mocks"
+	"github.com/Andras5014/gohub/pkg/logx"
+	"github.com/stretchr/testify/assert"
+	"go.uber.org/mock/gomock"
+	"go.uber.org/zap"
+	"testing"
+)
+
+func Test_articleService_SaveByCollaborator(t *testing.T) {
+	draft := domain.Article{
+		Id:      1,
+		Title:   "标题",
+		Content: "内容",
+		Author:  domain.Author{Id: 123},
+	}
+	testCases := []struct {
+		name string
+		mock func(ctrl *gomock.Controller) (article.Repository, article.CollaboratorRepository)
+		uid  int64
+
+		wantId  int64
+		wantErr error
+	}{
+		{
+			name: "编辑者可以修改，作者不变",
+			mock: func(ctrl *gomock.Controller) (article.Repository, article.CollaboratorRepository) {
+				repo := artrepomocks.NewMockRepository(ctrl)
+				collabRepo := artrepomocks.NewMockCollaboratorRepository(ctrl)
+				repo.EXPECT().GetById(gomock.Any(), int64(1)).Return(draft, nil)
+				collabRepo.EXPECT().Role(gomock.Any(), int64(1), int64(456)).
+					Return(domain.ArticleRoleEditor, nil)
+				repo.EXPECT().Update(gomock.Any(), gomock.Any()).
+					DoAndReturn(func(ctx context.Context, art domain.Article) error {
+						assert.Equal(t, int64(123), art.Author.Id)
+						// 历史版本记的是协作者
+						assert.Equal(t, int64(456), art.EditorId)
+						assert.Equal(t, "新标题", art.Title)
+						return nil
+					})
+				return repo, collabRepo
+			},
+			uid:    456,
+			wantId: 1,
+		},
+		{
+			name: "只读协作者不能修改",
+			mock: func(ctrl *gomock.Controller) (article.Repository, article.CollaboratorRepository) {
+				repo := artrepomocks.NewMockRepository(ctrl)
+				collabRepo := artrepomocks.NewMockCollaboratorRepository(ctrl)
+				repo.EXPECT().GetById(gomock.Any(), int64(1)).Return(draft, nil)
+				collabRepo.EXPECT().Role(gomock.Any(), int64(1), int64(456)).
+					Return(domain.ArticleRoleViewer, nil)
+				return repo, collabRepo
+			},
+			uid:     456,
+			wantErr: ErrArticlePermissionDenied,
+		},
+		{
+			name: "不是协作者",
+			mock: func(ctrl *gomock.Controller) (article.Repository, article.CollaboratorRepository) {
+				repo := artrepomocks.NewMockRepository(ctrl)
+				collabRepo := artrepomocks.NewMockCollaboratorRepository(ctrl)
+				repo.EXPECT().GetById(gomock.Any(), int64(1)).Return(draft, nil)
+				collabRepo.EXPECT().Role(gomock.Any(), int64(1), int64(789)).
+					Return(domain.ArticleRoleUnknown, nil)
+				return repo, collabRepo
+			},
+			uid:     789,
+			wantErr: ErrArticlePermissionDenied,
+		},
+		{
+			name: "查询角色失败",
+			mock: func(ctrl *gomock.Controller) (article.Repository, article.CollaboratorRepository) {
+				repo := artrepomocks.NewMockRepository(ctrl)
+				collabRepo := artrepomocks.NewMockCollaboratorRepository(ctrl)
+				repo.EXPECT().GetById(gomock.Any(), int64(1)).Return(draft, nil)
+				collabRepo.EXPECT().Role(gomock.Any(), int64(1), int64(456)).
+					Return(domain.ArticleRoleUnknown, errors.New("db 错误"))
+				return repo, collabRepo
+			},
+			uid:     456,
+			wantErr: errors.New("db 错误"),
+		},
+	}
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			ctrl := gomock.NewController(t)
+			defer ctrl.Finish()
+			repo, collabRepo := tc.mock(ctrl)
+			svc := NewArticleService(repo, collabRepo, nil, nil, logx.NewZapLogger(zap.NewNop()))
+			id, err := svc.Save(context.Background(), domain.Article{
+				Id:      1,
+				Title:   "新标题",
+				Content: "内容",
+				Author:  domain.Author{Id: tc.uid},
+			})
+			assert.Equal(t, tc.wantErr, err)
+			assert.Equal(t, tc.wantId, id)
+		})
+	}
+}
+
+func Test_articleService_InviteCollaborator(t *testing.T) {
+	testCases := []struct {
+		name    string
+		mock    func(ctrl *gomock.Controller) (article.Repository, article.CollaboratorRepository)
+		uid     int64
+		invitee int64
+		role    domain.ArticleRole
+
+		wantErr error
+	}{
+		{
+			name: "作者邀请",
+			mock: func(ctrl *gomock.Controller) (article.Repository, article.CollaboratorRepository) {
+				repo := artrepomocks.NewMockRepository(ctrl)
+				collabRepo := artrepomocks.NewMockCollaboratorRepository(ctrl)
+				repo.EXPECT().GetById(gomock.Any(), int64(1)).
+					Return(domain.Article{Id: 1, Author: domain.Author{Id: 123}}, nil)
+				collabRepo.EXPECT().Invite(gomock.Any(), domain.ArticleCollaborator{
+					ArticleId: 1,
+					Uid:       456,
+					Role:      domain.ArticleRoleEditor,
+					InviterId: 123,
+				}).Return(nil)
+				return repo, collabRepo
+			},
+			uid:     123,
+			invitee: 456,
+			role:    domain.ArticleRoleEditor,
+		},
+		{
+			name: "协作者不能邀请",
+			mock: func(ctrl *gomock.Controller) (article.Repository, article.CollaboratorRepository) {
+				repo := artrepomocks.NewMockRepository(ctrl)
+				repo.EXPECT().GetById(gomock.Any(), int64(1)).
+					Return(domain.Article{Id: 1, Author: domain.Author{Id: 123}}, nil)
+				return repo, artrepomocks.NewMockCollaboratorRepository(ctrl)
+			},
+			uid:     456,
+			invitee: 789,
+			role:    domain.ArticleRoleViewer,
+			wantErr: ErrArticlePermissionDenied,
+		},
+		{
+			name: "不能邀请成作者",
+			mock: func(ctrl *gomock.Controller) (article.Repository, article.CollaboratorRepository) {
+				return artrepomocks.NewMockRepository(ctrl), artrepomocks.NewMockCollaboratorRepository(ctrl)
+			},
+			uid:     123,
+			invitee: 456,
+			role:    domain.ArticleRoleOwner,
+			wantErr: ErrInvalidInvitation,
+		},
+		{
+			name: "不能邀请自己",
+			mock: func(ctrl *gomock.Controller) (article.Repository, article.CollaboratorRepository) {
+				return artrepomocks.NewMockRepository(ctrl), artrepomocks.NewMockCollaboratorRepository(ctrl)
+			},
+			uid:     123,
+			invitee: 123,
+			role:    domain.ArticleRoleEditor,
+			wantErr: ErrInvalidInvitation,
+		},
+	}
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			ctrl := gomock.NewController(t)
+			defer ctrl.Finish()
+			repo, collabRepo := tc.mock(ctrl)
+			svc := NewArticleService(repo, collabRepo, nil, nil, logx.NewZapLogger(zap.NewNop()))
+			err := svc.InviteCollaborator(context.Background(), 1, tc.uid, tc.invitee, tc.role)
+			assert.Equal(t, tc.wantErr, err)
+		})
+	}
+}
@@ -0,0 +1,68 @@
+package service
+
+import (
+	"context"
+	"errors"
+	"github.com/Andras5014/gohub/internal/domain"
+	"github.com/Andras5014/gohub/pkg/logx"
+)
+
+// submitReview 只写制作库，线上库还是之前发表的内容
+func (a *articleService) submitReview(ctx context.Context, article domain.Article) (int64, error) {
+	article.Status = domain.ArticleStatusPendingReview
+	article.ReviewReason = ""
+	if article.Id > 0 {
+		return article.Id, a.repo.Update(ctx, article)
+	}
+	return a.repo.Create(ctx, article)
+}
+
+func (a *articleService) ListPendingReview(ctx context.Context, offset int, limit int) ([]domain.Article, error) {
+	return a.repo.ListByStatus(ctx, domain.ArticleStatusPendingReview, offset, limit)
+}
+
+func (a *articleService) ApproveReview(ctx context.Context, artId, reviewer int64) error {
+	art, err := a.pendingReview(ctx, artId)
+	if err != nil {
+		return err
+	}
+	// 带着读到的版本号发表，作者在这期间改了草稿会冲突
+	_, err = a.syncPublished(ctx, art)
+	if errors.Is(err, ErrArticleVersionConflict) {
+		return ErrArticleNotPendingReview
+	}
+	if err != nil {
+		return err
+	}
+	a.logger.Info("文章审核通过", logx.Int64("article_id", artId), logx.Int64("reviewer", reviewer))
+	return nil
+}
+
+func (a *articleService) RejectReview(ctx context.Context, artId, reviewer int64, reason string) error {
+	art, err := a.pendingReview(ctx, artId)
+	if err != nil {
+		return err
+	}
+	art.Status = domain.ArticleStatusRejected
+	art.ReviewReason = reason
+	err = a.repo.UpdateReview(ctx, art, domain.ArticleStatusPendingReview)
+	if errors.Is(err, ErrArticleNotFound) {
+		return ErrArticleNotPendingReview
+	}
+	if err != nil {
+		return err
+	}
+	a.logger.Info("文章审核不通过", logx.Int64("article_id", artId), logx.Int64("reviewer", reviewer))
+	return nil
+}
+
+func (a *articleService) pendingReview(ctx context.Context, artId int64) (domain.Article, error) {
+	art, err := a.repo.GetById(ctx, artId)
+	if err != nil {
+		return domain.Article{}, err
+	}
+	if art.Status != domain.ArticleStatusPendingReview || !art.DeletedAt.IsZero() {
+		return domain.Article{}, ErrArticleNotPendingReview
+	}
+	return art, nil
+}
@@ -0,0 +1,245 @@
+package service
+
+import (
+	"context"
+	"github.com/Andras5014/gohub/internal/domain"
+	articleEvent "github.com/Andras5014/gohub/internal/events/article"
+	evtmocks "github.com/Andras5014/gohub/internal/events/article/mocks"
+	"github.com/Andras5014/gohub/internal/repository/article"
+	artrepomocks "github.com/Andras5014/gohub/internal/repository/article/mocks"
+	"github.com/Andras5014/gohub/internal/repository/cache"
+	cachemocks "github.com/Andras5014/gohub/internal/repository/cache/mocks"
+	dao "github.com/Andras5014/gohub/internal/repository/dao/article"
+	artdaomocks "github.com/Andras5014/gohub/internal/repository/dao/article/mocks"
+	repomocks "github.com/Andras5014/gohub/internal/repository/mocks"
+	"github.com/Andras5014/gohub/pkg/logx"
+	"github.com/Andras5014/gohub/pkg/sensitivex"
+	"github.com/stretchr/testify/assert"
+	"go.uber.org/mock/gomock"
+	"go.uber.org/zap"
+	"testing"
+)
+
+func Test_articleService_PublishWithModeration(t *testing.T) {
+	testCases := []struct {
+		name string
+		mock func(ctrl *gomock.Controller) article.Repository
+		mode ModerationMode
+		art  domain.Article
+
+		wantId     int64
+		wantStatus domain.ArticleStatus
+		wantErr    error
+	}{
+		{
+			name: "命中敏感词拒绝",
+			mock: func(ctrl *gomock.Controller) article.Repository {
+				return artrepomocks.NewMockRepository(ctrl)
+			},
+			mode:    ModerationReject,
+			art:     domain.Article{Title: "标题", Content: "赌博", Author: domain.Author{Id: 123}},
+			wantErr: &SensitiveContentError{Words: []string{"赌博"}},
+		},
+		{
+			name: "命中敏感词进入审核，只写制作库",
+			mock: func(ctrl *gomock.Controller) article.Repository {
+				repo := artrepomocks.NewMockRepository(ctrl)
+				repo.EXPECT().Create(gomock.Any(), domain.Article{
+					Title:   "标题",
+					Content: "赌博",
+					Author:  domain.Author{Id: 123},
+					Status:  domain.ArticleStatusPendingReview,
+				}).Return(int64(1), nil)
+				return repo
+			},
+			mode:       ModerationReview,
+			art:        domain.Article{Title: "标题", Content: "赌博", Author: domain.Author{Id: 123}},
+			wantId:     1,
+			wantStatus: domain.ArticleStatusPendingReview,
+		},
+	}
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			ctrl := gomock.NewController(t)
+			defer ctrl.Finish()
+			moderator := NewWordModerator(sensitivex.NewFilter([]string{"赌博"}), tc.mode, false)
+			svc := NewArticleService(tc.mock(ctrl), nil, moderator, evtmocks.NewMockProducer(ctrl),
+				logx.NewZapLogger(zap.NewNop()))
+			id, status, err := svc.Publish(context.Background(), tc.art)
+			assert.Equal(t, tc.wantErr, err)
+			assert.Equal(t, tc.wantId, id)
+			assert.Equal(t, tc.wantStatus, status)
+		})
+	}
+}
+
+// 进入审核之后作者马上就能在自己的列表里面看到待审核，不会读到缓存里面的旧状态
+func Test_articleService_PublishThenList(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+	d := artdaomocks.NewMockArticleDAO(ctrl)
+	d.EXPECT().GetById(gomock.Any(), int64(1)).
+		Return(dao.Article{Id: 1, AuthorId: 123, Status: domain.ArticleStatusPublished.ToUint8(), Version: 2}, nil)
+	d.EXPECT().UpdateById(gomock.Any(), gomock.Any()).Return(nil)
+	d.EXPECT().FindByAuthorId(gomock.Any(), int64(123), dao.Cursor{}, 100).
+		Return([]dao.Article{{Id: 1, AuthorId: 123, Status: domain.ArticleStatusPendingReview.ToUint8(), Version: 3}}, nil)
+
+	// 第一页的缓存里面还是发表的状态
+	firstPage := map[int64][]domain.Article{
+		123: {{Id: 1, Author: domain.Author{Id: 123}, Status: domain.ArticleStatusPublished, Version: 2}},
+	}
+	c := cachemocks.NewMockArticleCache(ctrl)
+	c.EXPECT().DelFirstPage(gomock.Any(), int64(123)).
+		DoAndReturn(func(ctx context.Context, id int64) error {
+			delete(firstPage, id)
+			return nil
+		})
+	c.EXPECT().GeFirstPage(gomock.Any(), int64(123)).
+		DoAndReturn(func(ctx context.Context, id int64) ([]domain.Article, error) {
+			arts, ok := firstPage[id]
+			if !ok {
+				return nil, cache.ErrKeyNotExist
+			}
+			return arts, nil
+		})
+	// 回写缓存是异步的，等它执行完再结束
+	written := make(chan struct{})
+	c.EXPECT().SetFirstPage(gomock.Any(), int64(123), gomock.Any()).
+		DoAndReturn(func(ctx context.Context, id int64, arts []domain.Article) error {
+			close(written)
+			return nil
+		})
+
+	l := logx.NewZapLogger(zap.NewNop())
+	repo := article.NewArticleRepository(d, c, repomocks.NewMockUserRepository(ctrl), l)
+	moderator := NewWordModerator(sensitivex.NewFilter([]string{"赌博"}), ModerationReview, false)
+	svc := NewArticleService(repo, nil, moderator, evtmocks.NewMockProducer(ctrl), l)
+
+	_, status, err := svc.Publish(context.Background(), domain.Article{
+		Id:      1,
+		Title:   "标题",
+		Content: "赌博",
+		Author:  domain.Author{Id: 123},
+		Version: 2,
+	})
+	assert.NoError(t, err)
+	assert.Equal(t, domain.ArticleStatusPendingReview, status)
+
+	arts, err := svc.List(context.Background(), 123, domain.ArticleCursor{}, 10)
+	assert.NoError(t, err)
+	assert.Len(t, arts, 1)
+	assert.Equal(t, domain.ArticleStatusPendingReview, arts[0].Status)
+	assert.Equal(t, int64(3), arts[0].Version)
+	<-written
+}
+
+func Test_articleService_ApproveReview(t *testing.T) {
+	pending := domain.Article{
+		Id:      1,
+		Title:   "标题",
+		Content: "正文",
+		Author:  domain.Author{Id: 123},
+		Status:  domain.ArticleStatusPendingReview,
+		Version: 3,
+	}
+	testCases := []struct {
+		name    string
+		mock    func(ctrl *gomock.Controller) (article.Repository, articleEvent.Producer)
+		wantErr error
+	}{
+		{
+			name: "通过之后发表",
+			mock: func(ctrl *gomock.Controller) (article.Repository, articleEvent.Producer) {
+				repo := artrepomocks.NewMockRepository(ctrl)
+				producer := evtmocks.NewMockProducer(ctrl)
+				repo.EXPECT().GetById(gomock.Any(), int64(1)).Return(pending, nil)
+				repo.EXPECT().Sync(gomock.Any(), gomock.Any()).
+					DoAndReturn(func(ctx context.Context, art domain.Article) (int64, error) {
+						assert.Equal(t, domain.ArticleStatusPublished, art.Status)
+						assert.Equal(t, int64(3), art.Version)
+						assert.NotEmpty(t, art.Rendered.HTML)
+						return 1, nil
+					})
+				producer.EXPECT().ProducePublishEvent(gomock.Any(), gomock.Any()).Return(nil)
+				return repo, producer
+			},
+		},
+		{
+			name: "已经不在审核队列",
+			mock: func(ctrl *gomock.Controller) (article.Repository, articleEvent.Producer) {
+				repo := artrepomocks.NewMockRepository(ctrl)
+				art := pending
+				art.Status = domain.ArticleStatusUnPublished
+				repo.EXPECT().GetById(gomock.Any(), int64(1)).Return(art, nil)
+				return repo, evtmocks.NewMockProducer(ctrl)
+			},
+			wantErr: ErrArticleNotPendingReview,
+		},
+		{
+			name: "审核期间作者改了草稿",
+			mock: func(ctrl *gomock.Controller) (article.Repository, articleEvent.Producer) {
+				repo := artrepomocks.NewMockRepository(ctrl)
+				repo.EXPECT().GetById(gomock.Any(), int64(1)).Return(pending, nil)
+				repo.EXPECT().Sync(gomock.Any(), gomock.Any()).Return(int64(1), ErrArticleVersionConflict)
+				return repo, evtmocks.NewMockProducer(ctrl)
+			},
+			wantErr: ErrArticleNotPendingReview,
+		},
+	}
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			ctrl := gomock.NewController(t)
+			defer ctrl.Finish()
+			repo, producer := tc.mock(ctrl)
+			svc := NewArticleService(repo, nil, nil, producer, logx.NewZapLogger(zap.NewNop()))
+			err := svc.ApproveReview(context.Background(), 1, 999)
+			assert.Equal(t, tc.wantErr, err)
+		})
+	}
+}
+
+func Test_articleService_RejectReview(t *testing.T) {
+	pending := domain.Article{
+		Id:     1,
+		Author: domain.Author{Id: 123},
+		Status: domain.ArticleStatusPendingReview,
+	}
+	rejected := pending
+	rejected.Status = domain.ArticleStatusRejected
+	rejected.ReviewReason = "广告"
+	testCases := []struct {
+		name    string
+		mock    func(ctrl *gomock.Controller) article.Repository
+		wantErr error
+	}{
+		{
+			name: "不通过",
+			mock: func(ctrl *gomock.Controller) article.Repository {
+				repo := artrepomocks.NewMockRepository(ctrl)
+				repo.EXPECT().GetById(gomock.Any(), int64(1)).Return(pending, nil)
+				repo.EXPECT().UpdateReview(gomock.Any(), rejected, domain.ArticleStatusPendingReview).Return(nil)
+				return repo
+			},
+		},
+		{
+			name: "并发被处理了",
+			mock: func(ctrl *gomock.Controller) article.Repository {
+				repo := artrepomocks.NewMockRepository(ctrl)
+				repo.EXPECT().GetById(gomock.Any(), int64(1)).Return(pending, nil)
+				repo.EXPECT().UpdateReview(gomock.Any(), rejected, domain.ArticleStatusPendingReview).
+					Return(ErrArticleNotFound)
+				return repo
+			},
+			wantErr: ErrArticleNotPendingReview,
+		},
+	}
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			ctrl := gomock.NewController(t)
+			defer ctrl.Finish()
+			svc := NewArticleService(tc.mock(ctrl), nil, nil, nil, logx.NewZapLogger(zap.NewNop()))
+			err := svc.RejectReview(context.Background(), 1, 999, "广告")
+			assert.Equal(t, tc.wantErr, err)
+		})
+	}
+}
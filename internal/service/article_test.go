@@ -2,12 +2,19 @@ package service
 
 import (
 	"context"
+	"errors"
 	"github.com/Andras5014/gohub/internal/domain"
+	articleEvent "github.com/Andras5014/gohub/internal/events/article"
+	evtmocks "github.com/Andras5014/gohub/internal/events/article/mocks"
 	"github.com/Andras5014/gohub/internal/repository/article"
 	artrepomocks "github.com/Andras5014/gohub/internal/repository/article/mocks"
+	"github.com/Andras5014/gohub/pkg/logx"
 	"github.com/stretchr/testify/assert"
 	"go.uber.org/mock/gomock"
+	"go.uber.org/zap"
+	"strings"
 	"testing"
+	"time"
 )
 
 //func Test_articleService_Publish(t *testing.T) {
@@ -72,6 +79,12 @@ func Test_articleService_PublishV1(t *testing.T) {
 					Author: domain.Author{
 						Id: 123,
 					},
+					Rendered: domain.RenderedContent{
+						HTML:        "<p>我的内容</p>\n",
+						WordCount:   4,
+						ReadingTime: 1,
+						Abstract:    "我的内容",
+					},
 				}).Return(nil)
 				return artReader, artAuthor
 			},
@@ -104,3 +117,319 @@ func Test_articleService_PublishV1(t *testing.T) {
 		})
 	}
 }
+
+func Test_articleService_RunSchedules(t *testing.T) {
+	now := time.UnixMilli(1700000000000)
+	testCases := []struct {
+		name    string
+		mock    func(ctrl *gomock.Controller) article.Repository
+		wantErr error
+	}{
+		{
+			name: "到期发表",
+			mock: func(ctrl *gomock.Controller) article.Repository {
+				repo := artrepomocks.NewMockRepository(ctrl)
+				art := domain.Article{
+					Id:        1,
+					Title:     "我的标题",
+					Author:    domain.Author{Id: 123},
+					PublishAt: now.Add(-time.Second),
+				}
+				repo.EXPECT().ListDueScheduled(gomock.Any(), now, 100).
+					Return([]domain.Article{art}, nil)
+				published := art
+				published.Status = domain.ArticleStatusPublished
+				repo.EXPECT().Sync(gomock.Any(), published).Return(int64(1), nil)
+				repo.EXPECT().UpdateSchedule(gomock.Any(), domain.Article{
+					Id:     1,
+					Title:  "我的标题",
+					Author: domain.Author{Id: 123},
+				}).Return(nil)
+				return repo
+			},
+		},
+		{
+			name: "发表后到期撤回",
+			mock: func(ctrl *gomock.Controller) article.Repository {
+				repo := artrepomocks.NewMockRepository(ctrl)
+				art := domain.Article{
+					Id:          1,
+					Author:      domain.Author{Id: 123},
+					Status:      domain.ArticleStatusPublished,
+					UnpublishAt: now,
+				}
+				repo.EXPECT().ListDueScheduled(gomock.Any(), now, 100).
+					Return([]domain.Article{art}, nil)
+				withdrawn := art
+				withdrawn.Status = domain.ArticleStatusPrivate
+				repo.EXPECT().SyncStatus(gomock.Any(), withdrawn).Return(int64(1), nil)
+				repo.EXPECT().UpdateSchedule(gomock.Any(), domain.Article{
+					Id:     1,
+					Author: domain.Author{Id: 123},
+					Status: domain.ArticleStatusPublished,
+				}).Return(nil)
+				return repo
+			},
+		},
+		{
+			name: "发表失败往后推，撤回也跟着推",
+			mock: func(ctrl *gomock.Controller) article.Repository {
+				repo := artrepomocks.NewMockRepository(ctrl)
+				art := domain.Article{
+					Id:              1,
+					Author:          domain.Author{Id: 123},
+					PublishAt:       now.Add(-time.Second),
+					UnpublishAt:     now.Add(time.Hour),
+					ScheduleRetries: 2,
+				}
+				repo.EXPECT().ListDueScheduled(gomock.Any(), now, 100).
+					Return([]domain.Article{art}, nil)
+				repo.EXPECT().Sync(gomock.Any(), gomock.Any()).Return(int64(0), errors.New("db 错误"))
+				repo.EXPECT().UpdateSchedule(gomock.Any(), domain.Article{
+					Id:              1,
+					Author:          domain.Author{Id: 123},
+					PublishAt:       now.Add(4 * time.Minute),
+					UnpublishAt:     now.Add(time.Hour + 4*time.Minute + time.Second),
+					ScheduleRetries: 3,
+				}).Return(nil)
+				return repo
+			},
+		},
+		{
+			name: "撤回失败往后推",
+			mock: func(ctrl *gomock.Controller) article.Repository {
+				repo := artrepomocks.NewMockRepository(ctrl)
+				art := domain.Article{
+					Id:          1,
+					Author:      domain.Author{Id: 123},
+					Status:      domain.ArticleStatusPublished,
+					UnpublishAt: now,
+				}
+				repo.EXPECT().ListDueScheduled(gomock.Any(), now, 100).
+					Return([]domain.Article{art}, nil)
+				repo.EXPECT().SyncStatus(gomock.Any(), gomock.Any()).Return(int64(0), errors.New("db 错误"))
+				repo.EXPECT().UpdateSchedule(gomock.Any(), domain.Article{
+					Id:              1,
+					Author:          domain.Author{Id: 123},
+					Status:          domain.ArticleStatusPublished,
+					UnpublishAt:     now.Add(time.Minute),
+					ScheduleRetries: 1,
+				}).Return(nil)
+				return repo
+			},
+		},
+		{
+			name: "连续失败太多次取消定时",
+			mock: func(ctrl *gomock.Controller) article.Repository {
+				repo := artrepomocks.NewMockRepository(ctrl)
+				art := domain.Article{
+					Id:              1,
+					Author:          domain.Author{Id: 123},
+					PublishAt:       now,
+					ScheduleRetries: maxScheduleRetries,
+				}
+				repo.EXPECT().ListDueScheduled(gomock.Any(), now, 100).
+					Return([]domain.Article{art}, nil)
+				repo.EXPECT().Sync(gomock.Any(), gomock.Any()).Return(int64(0), errors.New("db 错误"))
+				repo.EXPECT().UpdateSchedule(gomock.Any(), domain.Article{
+					Id:     1,
+					Author: domain.Author{Id: 123},
+				}).Return(nil)
+				return repo
+			},
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			ctrl := gomock.NewController(t)
+			defer ctrl.Finish()
+			producer := evtmocks.NewMockProducer(ctrl)
+			producer.EXPECT().ProducePublishEvent(gomock.Any(), gomock.Any()).Return(nil).AnyTimes()
+			svc := NewArticleService(tc.mock(ctrl), nil, nil, producer, logx.NewZapLogger(zap.NewNop()))
+			err := svc.RunSchedules(context.Background(), now)
+			assert.Equal(t, tc.wantErr, err)
+		})
+	}
+}
+
+func Test_normalizeTags(t *testing.T) {
+	testCases := []struct {
+		name    string
+		tags    []string
+		want    []string
+		wantErr error
+	}{
+		{
+			name: "去空白去重",
+			tags: []string{" Go ", "go", "Go", "", "  "},
+			want: []string{"Go", "go"},
+		},
+		{
+			name: "没有标签",
+			tags: nil,
+			want: nil,
+		},
+		{
+			name:    "标签太多",
+			tags:    []string{"a", "b", "c", "d", "e", "f"},
+			wantErr: ErrInvalidTags,
+		},
+		{
+			name:    "标签太长",
+			tags:    []string{"一二三四五六七八九十一二三四五六七八九十一"},
+			wantErr: ErrInvalidTags,
+		},
+	}
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			got, err := normalizeTags(tc.tags)
+			assert.Equal(t, tc.wantErr, err)
+			assert.Equal(t, tc.want, got)
+		})
+	}
+}
+
+func Test_articleService_Delete(t *testing.T) {
+	testCases := []struct {
+		name    string
+		mock    func(ctrl *gomock.Controller) (article.Repository, articleEvent.Producer)
+		uid     int64
+		wantErr error
+	}{
+		{
+			name: "删除成功并通知下游",
+			mock: func(ctrl *gomock.Controller) (article.Repository, articleEvent.Producer) {
+				repo := artrepomocks.NewMockRepository(ctrl)
+				repo.EXPECT().GetById(gomock.Any(), int64(1)).Return(domain.Article{
+					Id:      1,
+					Title:   "我的标题",
+					Content: "我的内容",
+					Author:  domain.Author{Id: 123},
+					Status:  domain.ArticleStatusPublished,
+				}, nil)
+				repo.EXPECT().Delete(gomock.Any(), int64(1), int64(123)).Return(nil)
+				producer := evtmocks.NewMockProducer(ctrl)
+				producer.EXPECT().ProducePublishEvent(gomock.Any(), gomock.Any()).
+					DoAndReturn(func(ctx context.Context, evt articleEvent.PublishEvent) error {
+						// 删除的事件不带正文
+						assert.True(t, evt.Deleted)
+						assert.Equal(t, int64(1), evt.ArticleId)
+						assert.Empty(t, evt.Content)
+						return nil
+					})
+				return repo, producer
+			},
+			uid: 123,
+		},
+		{
+			name: "不是作者",
+			mock: func(ctrl *gomock.Controller) (article.Repository, articleEvent.Producer) {
+				repo := artrepomocks.NewMockRepository(ctrl)
+				repo.EXPECT().GetById(gomock.Any(), int64(1)).Return(domain.Article{
+					Id:     1,
+					Author: domain.Author{Id: 123},
+				}, nil)
+				return repo, evtmocks.NewMockProducer(ctrl)
+			},
+			uid:     456,
+			wantErr: ErrArticlePermissionDenied,
+		},
+	}
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			ctrl := gomock.NewController(t)
+			defer ctrl.Finish()
+			repo, producer := tc.mock(ctrl)
+			svc := NewArticleService(repo, nil, nil, producer, logx.NewZapLogger(zap.NewNop()))
+			err := svc.Delete(context.Background(), 1, tc.uid)
+			assert.Equal(t, tc.wantErr, err)
+		})
+	}
+}
+
+func Test_articleService_PurgeTrash(t *testing.T) {
+	before := time.UnixMilli(1700000000000)
+	testCases := []struct {
+		name    string
+		mock    func(ctrl *gomock.Controller) article.Repository
+		wantCnt int
+		wantErr error
+	}{
+		{
+			name: "分批直到删完",
+			mock: func(ctrl *gomock.Controller) article.Repository {
+				repo := artrepomocks.NewMockRepository(ctrl)
+				gomock.InOrder(
+					repo.EXPECT().PurgeDeleted(gomock.Any(), before, purgeBatchSize).Return(purgeBatchSize, nil),
+					repo.EXPECT().PurgeDeleted(gomock.Any(), before, purgeBatchSize).Return(3, nil),
+				)
+				return repo
+			},
+			wantCnt: purgeBatchSize + 3,
+		},
+		{
+			name: "中途失败",
+			mock: func(ctrl *gomock.Controller) article.Repository {
+				repo := artrepomocks.NewMockRepository(ctrl)
+				gomock.InOrder(
+					repo.EXPECT().PurgeDeleted(gomock.Any(), before, purgeBatchSize).Return(purgeBatchSize, nil),
+					repo.EXPECT().PurgeDeleted(gomock.Any(), before, purgeBatchSize).Return(0, errors.New("db 错误")),
+				)
+				return repo
+			},
+			wantCnt: purgeBatchSize,
+			wantErr: errors.New("db 错误"),
+		},
+	}
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			ctrl := gomock.NewController(t)
+			defer ctrl.Finish()
+			svc := NewArticleService(tc.mock(ctrl), nil, nil, nil, logx.NewZapLogger(zap.NewNop()))
+			cnt, err := svc.PurgeTrash(context.Background(), before)
+			assert.Equal(t, tc.wantErr, err)
+			assert.Equal(t, tc.wantCnt, cnt)
+		})
+	}
+}
+
+func Test_renderContent(t *testing.T) {
+	testCases := []struct {
+		name    string
+		content string
+		want    domain.RenderedContent
+	}{
+		{
+			name: "空内容",
+		},
+		{
+			name:    "摘要去掉 Markdown 语法和代码",
+			content: "# 标题\n\n正文 **加粗** [链接](https://example.com)\n\n```go\nfmt.Println()\n```",
+			want: domain.RenderedContent{
+				HTML: "<h1 id=\"标题\">标题</h1>\n<p>正文 <strong>加粗</strong> " +
+					"<a href=\"https://example.com\" rel=\"nofollow noopener\" target=\"_blank\">链接</a></p>\n" +
+					"<pre><code class=\"language-go\">fmt.Println()\n</code></pre>\n",
+				Toc:         []domain.TocItem{{Level: 1, Anchor: "标题", Text: "标题"}},
+				WordCount:   8,
+				ReadingTime: 1,
+				Abstract:    "标题 正文 加粗 链接",
+			},
+		},
+		{
+			name:    "阅读时间向上取整",
+			content: strings.Repeat("字", 301),
+			want: domain.RenderedContent{
+				HTML:        "<p>" + strings.Repeat("字", 301) + "</p>\n",
+				WordCount:   301,
+				ReadingTime: 2,
+				Abstract:    strings.Repeat("字", domain.AbstractLength),
+			},
+		},
+	}
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			assert.Equal(t, tc.want, renderContent(tc.content))
+		})
+	}
+}
@@ -0,0 +1,100 @@
+package service
+
+import (
+	"context"
+	"errors"
+	interactivev1 "github.com/Andras5014/gohub/api/proto/gen/interactive/v1"
+	"github.com/Andras5014/gohub/internal/domain"
+	"github.com/Andras5014/gohub/internal/repository"
+	"github.com/Andras5014/gohub/internal/repository/article"
+)
+
+var ErrAuthorNotFound = errors.New("作者不存在")
+
+// AuthorService 读者看到的作者主页
+type AuthorService interface {
+	Profile(ctx context.Context, id int64) (domain.AuthorProfile, error)
+	// ListPub 作者已发表的文章，按更新时间倒序翻页
+	ListPub(ctx context.Context, id int64, cursor domain.ArticleCursor, limit int) ([]domain.Article, error)
+}
+
+type authorService struct {
+	artRepo    article.Repository
+	userRepo   repository.UserRepository
+	followRepo repository.FollowRepository
+	intrSvc    interactivev1.InteractiveServiceClient
+	// likeBatchSize 一次向互动服务查询多少篇文章
+	likeBatchSize int
+}
+
+func NewAuthorService(artRepo article.Repository, userRepo repository.UserRepository,
+	followRepo repository.FollowRepository, intrSvc interactivev1.InteractiveServiceClient) AuthorService {
+	return &authorService{
+		artRepo:       artRepo,
+		userRepo:      userRepo,
+		followRepo:    followRepo,
+		intrSvc:       intrSvc,
+		likeBatchSize: 100,
+	}
+}
+
+func (a *authorService) Profile(ctx context.Context, id int64) (domain.AuthorProfile, error) {
+	u, err := a.userRepo.FindById(ctx, id)
+	if errors.Is(err, repository.ErrUserNotFound) {
+		return domain.AuthorProfile{}, ErrAuthorNotFound
+	}
+	if err != nil {
+		return domain.AuthorProfile{}, err
+	}
+	artCnt, likeCnt, err := a.pubStatics(ctx, id)
+	if err != nil {
+		return domain.AuthorProfile{}, err
+	}
+	statics, err := a.followRepo.Statics(ctx, id)
+	if err != nil {
+		return domain.AuthorProfile{}, err
+	}
+	return domain.AuthorProfile{
+		Id:          u.Id,
+		NickName:    u.NickName,
+		AboutMe:     u.AboutMe,
+		ArticleCnt:  artCnt,
+		LikeCnt:     likeCnt,
+		FollowerCnt: statics.Followers,
+		FolloweeCnt: statics.Followees,
+	}, nil
+}
+
+// pubStatics 已发表的文章数和获得的点赞数
+// 文章 id 分批从数据库拿，每一批查一次点赞数再加起来，不会一次把作者所有的文章都加载到内存里面
+func (a *authorService) pubStatics(ctx context.Context, id int64) (int64, int64, error) {
+	var artCnt, likeCnt, maxId int64
+	for {
+		ids, err := a.artRepo.PubIdsByAuthor(ctx, id, maxId, a.likeBatchSize)
+		if err != nil {
+			return 0, 0, err
+		}
+		if len(ids) > 0 {
+			resp, err := a.intrSvc.GetByIds(ctx, &interactivev1.GetByIdsRequest{
+				Biz:    "article",
+				BizIds: ids,
+			})
+			if err != nil {
+				return 0, 0, err
+			}
+			for _, intr := range resp.Intrs {
+				likeCnt += intr.LikeCnt
+			}
+			artCnt += int64(len(ids))
+			maxId = ids[len(ids)-1]
+		}
+		// 不满一批说明已经到底了
+		if len(ids) < a.likeBatchSize {
+			return artCnt, likeCnt, nil
+		}
+	}
+}
+
+func (a *authorService) ListPub(ctx context.Context, id int64, cursor domain.ArticleCursor, limit int) ([]domain.Article, error) {
+	return a.artRepo.ListPubByAuthor(ctx, id, cursor, limit)
+}
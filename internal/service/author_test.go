@@ -0,0 +1,96 @@
+package service
+
+import (
+	"context"
+	"errors"
+	interactivev1 "github.com/Andras5014/gohub/api/proto/gen/interactive/v1"
+	intrv1mocks "github.com/Andras5014/gohub/api/proto/gen/interactive/v1/mocks"
+	"github.com/Andras5014/gohub/internal/domain"
+	artrepomocks "github.com/Andras5014/gohub/internal/repository/article/mocks"
+	repomocks "github.com/Andras5014/gohub/internal/repository/mocks"
+	"github.com/stretchr/testify/assert"
+	"go.uber.org/mock/gomock"
+	"testing"
+)
+
+func TestAuthorService_Profile(t *testing.T) {
+	const batchSize = 2
+	testCases := []struct {
+		name string
+		mock func(ctrl *gomock.Controller) (*artrepomocks.MockRepository, *intrv1mocks.MockInteractiveServiceClient)
+
+		wantProfile domain.AuthorProfile
+		wantErr     error
+	}{
+		{
+			name: "文章 id 分批查点赞数",
+			mock: func(ctrl *gomock.Controller) (*artrepomocks.MockRepository, *intrv1mocks.MockInteractiveServiceClient) {
+				artRepo := artrepomocks.NewMockRepository(ctrl)
+				intrSvc := intrv1mocks.NewMockInteractiveServiceClient(ctrl)
+				artRepo.EXPECT().PubIdsByAuthor(gomock.Any(), int64(1), int64(0), batchSize).
+					Return([]int64{2, 5}, nil)
+				intrSvc.EXPECT().GetByIds(gomock.Any(), &interactivev1.GetByIdsRequest{Biz: "article", BizIds: []int64{2, 5}}).
+					Return(&interactivev1.GetByIdsResponse{Intrs: map[int64]*interactivev1.Interactive{
+						2: {LikeCnt: 3},
+						5: {LikeCnt: 4},
+					}}, nil)
+				artRepo.EXPECT().PubIdsByAuthor(gomock.Any(), int64(1), int64(5), batchSize).
+					Return([]int64{7}, nil)
+				intrSvc.EXPECT().GetByIds(gomock.Any(), &interactivev1.GetByIdsRequest{Biz: "article", BizIds: []int64{7}}).
+					Return(&interactivev1.GetByIdsResponse{Intrs: map[int64]*interactivev1.Interactive{
+						7: {LikeCnt: 1},
+					}}, nil)
+				return artRepo, intrSvc
+			},
+			wantProfile: domain.AuthorProfile{Id: 1, NickName: "作者", ArticleCnt: 3, LikeCnt: 8, FollowerCnt: 10},
+		},
+		{
+			name: "刚好整批，多查一次空的",
+			mock: func(ctrl *gomock.Controller) (*artrepomocks.MockRepository, *intrv1mocks.MockInteractiveServiceClient) {
+				artRepo := artrepomocks.NewMockRepository(ctrl)
+				intrSvc := intrv1mocks.NewMockInteractiveServiceClient(ctrl)
+				artRepo.EXPECT().PubIdsByAuthor(gomock.Any(), int64(1), int64(0), batchSize).
+					Return([]int64{2, 5}, nil)
+				intrSvc.EXPECT().GetByIds(gomock.Any(), gomock.Any()).
+					Return(&interactivev1.GetByIdsResponse{Intrs: map[int64]*interactivev1.Interactive{
+						2: {LikeCnt: 3},
+					}}, nil)
+				artRepo.EXPECT().PubIdsByAuthor(gomock.Any(), int64(1), int64(5), batchSize).
+					Return(nil, nil)
+				return artRepo, intrSvc
+			},
+			wantProfile: domain.AuthorProfile{Id: 1, NickName: "作者", ArticleCnt: 2, LikeCnt: 3, FollowerCnt: 10},
+		},
+		{
+			name: "查询点赞数失败",
+			mock: func(ctrl *gomock.Controller) (*artrepomocks.MockRepository, *intrv1mocks.MockInteractiveServiceClient) {
+				artRepo := artrepomocks.NewMockRepository(ctrl)
+				intrSvc := intrv1mocks.NewMockInteractiveServiceClient(ctrl)
+				artRepo.EXPECT().PubIdsByAuthor(gomock.Any(), int64(1), int64(0), batchSize).
+					Return([]int64{2}, nil)
+				intrSvc.EXPECT().GetByIds(gomock.Any(), gomock.Any()).
+					Return(nil, errors.New("mock grpc error"))
+				return artRepo, intrSvc
+			},
+			wantErr: errors.New("mock grpc error"),
+		},
+	}
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			ctrl := gomock.NewController(t)
+			defer ctrl.Finish()
+			artRepo, intrSvc := tc.mock(ctrl)
+			userRepo := repomocks.NewMockUserRepository(ctrl)
+			userRepo.EXPECT().FindById(gomock.Any(), int64(1)).
+				Return(domain.User{Id: 1, NickName: "作者"}, nil)
+			followRepo := repomocks.NewMockFollowRepository(ctrl)
+			followRepo.EXPECT().Statics(gomock.Any(), int64(1)).
+				Return(domain.FollowStatics{Followers: 10}, nil).AnyTimes()
+			svc := NewAuthorService(artRepo, userRepo, followRepo, intrSvc).(*authorService)
+			svc.likeBatchSize = batchSize
+			profile, err := svc.Profile(context.Background(), 1)
+			assert.Equal(t, tc.wantErr, err)
+			assert.Equal(t, tc.wantProfile, profile)
+		})
+	}
+}
@@ -0,0 +1,255 @@
+package service
+
+import (
+	"cmp"
+	"context"
+	"errors"
+	"github.com/Andras5014/gohub/internal/domain"
+	"github.com/Andras5014/gohub/internal/repository"
+	"github.com/Andras5014/gohub/internal/repository/article"
+	"github.com/ecodeclub/ekit/slice"
+	"golang.org/x/sync/errgroup"
+	"slices"
+	"sync"
+)
+
+// FeedService 推拉结合的关注流：普通作者发表的时候推到粉丝的收件箱，
+// 粉丝数不少于 pullThreshold 的作者不推，读的时候再去拉
+type FeedService interface {
+	// Feed 按发表时间倒序，零值游标表示第一页
+	Feed(ctx context.Context, uid int64, cursor domain.ArticleCursor, limit int) ([]domain.Article, error)
+	// Push 文章发表之后推给粉丝
+	Push(ctx context.Context, artId int64) error
+	// Remove 文章撤回或者删除之后从收件箱里面删掉
+	Remove(ctx context.Context, artId int64) error
+	// Backfill 新关注一个作者的时候把他最近的文章补进收件箱
+	Backfill(ctx context.Context, uid int64, authorId int64) error
+	// DropAuthor 取消关注之后删掉这个作者推过来的，
+	// 作者因此掉到 pullThreshold 以下的时候把他最近的文章补给剩下的粉丝
+	DropAuthor(ctx context.Context, uid int64, authorId int64) error
+}
+
+type feedService struct {
+	repo       repository.FeedRepository
+	followRepo repository.FollowRepository
+	artRepo    article.Repository
+
+	// pullThreshold 粉丝数不少于这个的作者走拉模式
+	pullThreshold int64
+	// pushBatchSize 推的时候一次处理多少个粉丝
+	pushBatchSize int
+	// backfillSize 新关注的时候补多少篇
+	backfillSize int
+	// maxPullAuthors 一次最多拉多少个作者，关注了太多大 V 的只看其中一部分
+	maxPullAuthors int
+	// demoteWindow 粉丝数落在 [pullThreshold-demoteWindow, pullThreshold) 的时候认为刚从拉模式退下来
+	demoteWindow int64
+}
+
+func NewFeedService(repo repository.FeedRepository, followRepo repository.FollowRepository,
+	artRepo article.Repository) FeedService {
+	return &feedService{
+		repo:           repo,
+		followRepo:     followRepo,
+		artRepo:        artRepo,
+		pullThreshold:  1000,
+		pushBatchSize:  500,
+		backfillSize:   20,
+		maxPullAuthors: 50,
+		demoteWindow:   10,
+	}
+}
+
+func (f *feedService) Feed(ctx context.Context, uid int64, cursor domain.ArticleCursor, limit int) ([]domain.Article, error) {
+	// 先查完再起 goroutine，出错直接返回不会留下没人等的 goroutine
+	authors, err := f.followRepo.PopularFollowees(ctx, uid, f.pullThreshold)
+	if err != nil {
+		return nil, err
+	}
+	if len(authors) > f.maxPullAuthors {
+		authors = authors[:f.maxPullAuthors]
+	}
+	var (
+		eg     errgroup.Group
+		mu     sync.Mutex
+		pushed []domain.Article
+		pulled []domain.Article
+	)
+	eg.Go(func() error {
+		var er error
+		pushed, er = f.pushedArticles(ctx, uid, cursor, limit)
+		return er
+	})
+	for _, authorId := range authors {
+		eg.Go(func() error {
+			arts, er := f.artRepo.ListPubByAuthor(ctx, authorId, cursor, limit)
+			if er != nil {
+				return er
+			}
+			mu.Lock()
+			pulled = append(pulled, arts...)
+			mu.Unlock()
+			return nil
+		})
+	}
+	if err = eg.Wait(); err != nil {
+		return nil, err
+	}
+	return f.merge(append(pushed, pulled...), limit), nil
+}
+
+// pushedArticles 收件箱里面撤回了还没删的会被跳过，凑不满 limit 篇就接着往后读，
+// 不然这一页不满会被当成已经到底了
+func (f *feedService) pushedArticles(ctx context.Context, uid int64, cursor domain.ArticleCursor, limit int) ([]domain.Article, error) {
+	res := make([]domain.Article, 0, limit)
+	for {
+		items, err := f.repo.List(ctx, uid, cursor, limit)
+		if err != nil {
+			return nil, err
+		}
+		arts, err := f.pubArticles(ctx, items)
+		if err != nil {
+			return nil, err
+		}
+		res = append(res, arts...)
+		if len(res) >= limit || len(items) < limit {
+			return res, nil
+		}
+		last := items[len(items)-1]
+		cursor = domain.ArticleCursor{UpdatedAt: last.Ctime, Id: last.ArticleId}
+	}
+}
+
+// pubArticles 收件箱里面只有 id，撤回了还没来得及删的跳过
+func (f *feedService) pubArticles(ctx context.Context, items []domain.FeedItem) ([]domain.Article, error) {
+	res := make([]domain.Article, 0, len(items))
+	for _, item := range items {
+		art, err := f.artRepo.GetPubById(ctx, item.ArticleId)
+		if errors.Is(err, ErrArticleNotFound) {
+			continue
+		}
+		if err != nil {
+			return nil, err
+		}
+		if art.Status == domain.ArticleStatusPublished {
+			res = append(res, art)
+		}
+	}
+	return res, nil
+}
+
+// merge 作者粉丝数变了之后同一篇文章可能推拉都有，按 id 去重
+func (f *feedService) merge(arts []domain.Article, limit int) []domain.Article {
+	slices.SortFunc(arts, func(a, b domain.Article) int {
+		if c := b.UpdatedAt.Compare(a.UpdatedAt); c != 0 {
+			return c
+		}
+		return cmp.Compare(b.Id, a.Id)
+	})
+	arts = slices.CompactFunc(arts, func(a, b domain.Article) bool {
+		return a.Id == b.Id
+	})
+	return arts[:min(len(arts), limit)]
+}
+
+func (f *feedService) Push(ctx context.Context, artId int64) error {
+	// 用线上库的更新时间，不用事件里面的，这样推过去的和拉过来的按同一个时间排序
+	art, err := f.artRepo.GetPubById(ctx, artId)
+	if errors.Is(err, ErrArticleNotFound) {
+		return nil
+	}
+	if err != nil || art.Status != domain.ArticleStatusPublished {
+		return err
+	}
+	popular, err := f.popular(ctx, art.Author.Id)
+	if err != nil || popular {
+		return err
+	}
+	return f.pushToFollowers(ctx, art.Author.Id, []domain.Article{art})
+}
+
+// pushToFollowers 分批把文章写进作者所有粉丝的收件箱
+func (f *feedService) pushToFollowers(ctx context.Context, authorId int64, arts []domain.Article) error {
+	for offset := 0; ; offset += f.pushBatchSize {
+		followers, err := f.followRepo.FollowerList(ctx, authorId, offset, f.pushBatchSize)
+		if err != nil {
+			return err
+		}
+		items := make([]domain.FeedItem, 0, len(followers)*len(arts))
+		for _, follower := range followers {
+			for _, art := range arts {
+				items = append(items, domain.FeedItem{
+					Uid:       follower.Follower,
+					ArticleId: art.Id,
+					AuthorId:  authorId,
+					Ctime:     art.UpdatedAt,
+				})
+			}
+		}
+		if len(items) > 0 {
+			if err = f.repo.AddItems(ctx, items); err != nil {
+				return err
+			}
+		}
+		if len(followers) < f.pushBatchSize {
+			return nil
+		}
+	}
+}
+
+func (f *feedService) Remove(ctx context.Context, artId int64) error {
+	return f.repo.DeleteByArticle(ctx, artId)
+}
+
+func (f *feedService) Backfill(ctx context.Context, uid int64, authorId int64) error {
+	popular, err := f.popular(ctx, authorId)
+	if err != nil || popular {
+		return err
+	}
+	arts, err := f.artRepo.ListPubByAuthor(ctx, authorId, domain.ArticleCursor{}, f.backfillSize)
+	if err != nil {
+		return err
+	}
+	return f.repo.AddItems(ctx, slice.Map(arts, func(idx int, src domain.Article) domain.FeedItem {
+		return domain.FeedItem{
+			Uid:       uid,
+			ArticleId: src.Id,
+			AuthorId:  authorId,
+			Ctime:     src.UpdatedAt,
+		}
+	}))
+}
+
+func (f *feedService) DropAuthor(ctx context.Context, uid int64, authorId int64) error {
+	if err := f.repo.DeleteByAuthor(ctx, uid, authorId); err != nil {
+		return err
+	}
+	return f.demote(ctx, authorId)
+}
+
+// demote 作者刚从拉模式退下来的时候，之前发表的没有推过，读的时候也不再拉了，
+// 不补的话粉丝的关注流里面就看不到了。
+// 并发取消关注的时候读到的粉丝数可能已经少了好几个，落在 demoteWindow 里面的都补一次，重复写收件箱是幂等的
+func (f *feedService) demote(ctx context.Context, authorId int64) error {
+	statics, err := f.followRepo.Statics(ctx, authorId)
+	if err != nil {
+		return err
+	}
+	if statics.Followers >= f.pullThreshold || statics.Followers < f.pullThreshold-f.demoteWindow {
+		return nil
+	}
+	arts, err := f.artRepo.ListPubByAuthor(ctx, authorId, domain.ArticleCursor{}, f.backfillSize)
+	if err != nil || len(arts) == 0 {
+		return err
+	}
+	return f.pushToFollowers(ctx, authorId, arts)
+}
+
+// popular 粉丝多的作者读的时候拉，不往收件箱里面写
+func (f *feedService) popular(ctx context.Context, authorId int64) (bool, error) {
+	statics, err := f.followRepo.Statics(ctx, authorId)
+	if err != nil {
+		return false, err
+	}
+	return statics.Followers >= f.pullThreshold, nil
+}
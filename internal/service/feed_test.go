@@ -0,0 +1,267 @@
+package service
+
+import (
+	"context"
+	"errors"
+	"github.com/Andras5014/gohub/internal/domain"
+	"github.com/Andras5014/gohub/internal/repository"
+	"github.com/Andras5014/gohub/internal/repository/article"
+	artrepomocks "github.com/Andras5014/gohub/internal/repository/article/mocks"
+	repomocks "github.com/Andras5014/gohub/internal/repository/mocks"
+	"github.com/ecodeclub/ekit/slice"
+	"github.com/stretchr/testify/assert"
+	"go.uber.org/mock/gomock"
+	"testing"
+	"time"
+)
+
+func Test_feedService_Feed(t *testing.T) {
+	now := time.UnixMilli(1700000000000)
+	pub := func(id int64, authorId int64, ago time.Duration) domain.Article {
+		return domain.Article{
+			Id:        id,
+			Author:    domain.Author{Id: authorId},
+			Status:    domain.ArticleStatusPublished,
+			UpdatedAt: now.Add(-ago),
+		}
+	}
+	testCases := []struct {
+		name string
+		mock func(ctrl *gomock.Controller) (repository.FeedRepository, repository.FollowRepository, article.Repository)
+
+		wantIds []int64
+		wantErr error
+	}{
+		{
+			name: "推拉合并按时间倒序",
+			mock: func(ctrl *gomock.Controller) (repository.FeedRepository, repository.FollowRepository, article.Repository) {
+				repo := repomocks.NewMockFeedRepository(ctrl)
+				followRepo := repomocks.NewMockFollowRepository(ctrl)
+				artRepo := artrepomocks.NewMockRepository(ctrl)
+				repo.EXPECT().List(gomock.Any(), int64(123), domain.ArticleCursor{}, 3).
+					Return([]domain.FeedItem{{ArticleId: 1}, {ArticleId: 3}}, nil)
+				artRepo.EXPECT().GetPubById(gomock.Any(), int64(1)).Return(pub(1, 10, time.Minute), nil)
+				artRepo.EXPECT().GetPubById(gomock.Any(), int64(3)).Return(pub(3, 10, 3*time.Minute), nil)
+				followRepo.EXPECT().PopularFollowees(gomock.Any(), int64(123), int64(1000)).Return([]int64{20}, nil)
+				artRepo.EXPECT().ListPubByAuthor(gomock.Any(), int64(20), domain.ArticleCursor{}, 3).
+					Return([]domain.Article{pub(2, 20, 2*time.Minute), pub(4, 20, 4*time.Minute)}, nil)
+				return repo, followRepo, artRepo
+			},
+			wantIds: []int64{1, 2, 3},
+		},
+		{
+			name: "推拉都有的去重，撤回了的跳过",
+			mock: func(ctrl *gomock.Controller) (repository.FeedRepository, repository.FollowRepository, article.Repository) {
+				repo := repomocks.NewMockFeedRepository(ctrl)
+				followRepo := repomocks.NewMockFollowRepository(ctrl)
+				artRepo := artrepomocks.NewMockRepository(ctrl)
+				repo.EXPECT().List(gomock.Any(), int64(123), domain.ArticleCursor{}, 3).
+					Return([]domain.FeedItem{{ArticleId: 1}, {ArticleId: 2}, {ArticleId: 5}}, nil)
+				artRepo.EXPECT().GetPubById(gomock.Any(), int64(1)).Return(pub(1, 20, time.Minute), nil)
+				artRepo.EXPECT().GetPubById(gomock.Any(), int64(2)).Return(domain.Article{}, ErrArticleNotFound)
+				withdrawn := pub(5, 10, 5*time.Minute)
+				withdrawn.Status = domain.ArticleStatusUnPublished
+				artRepo.EXPECT().GetPubById(gomock.Any(), int64(5)).Return(withdrawn, nil)
+				repo.EXPECT().List(gomock.Any(), int64(123), domain.ArticleCursor{Id: 5}, 3).Return(nil, nil)
+				followRepo.EXPECT().PopularFollowees(gomock.Any(), int64(123), int64(1000)).Return([]int64{20}, nil)
+				artRepo.EXPECT().ListPubByAuthor(gomock.Any(), int64(20), domain.ArticleCursor{}, 3).
+					Return([]domain.Article{pub(1, 20, time.Minute)}, nil)
+				return repo, followRepo, artRepo
+			},
+			wantIds: []int64{1},
+		},
+		{
+			name: "收件箱这一页有撤回的，接着往后读凑满一页",
+			mock: func(ctrl *gomock.Controller) (repository.FeedRepository, repository.FollowRepository, article.Repository) {
+				repo := repomocks.NewMockFeedRepository(ctrl)
+				followRepo := repomocks.NewMockFollowRepository(ctrl)
+				artRepo := artrepomocks.NewMockRepository(ctrl)
+				followRepo.EXPECT().PopularFollowees(gomock.Any(), int64(123), int64(1000)).Return(nil, nil)
+				repo.EXPECT().List(gomock.Any(), int64(123), domain.ArticleCursor{}, 3).
+					Return([]domain.FeedItem{
+						{ArticleId: 1, Ctime: now.Add(-time.Minute)},
+						{ArticleId: 2, Ctime: now.Add(-2 * time.Minute)},
+						{ArticleId: 3, Ctime: now.Add(-3 * time.Minute)},
+					}, nil)
+				artRepo.EXPECT().GetPubById(gomock.Any(), int64(1)).Return(pub(1, 10, time.Minute), nil)
+				artRepo.EXPECT().GetPubById(gomock.Any(), int64(2)).Return(domain.Article{}, ErrArticleNotFound)
+				artRepo.EXPECT().GetPubById(gomock.Any(), int64(3)).Return(domain.Article{}, ErrArticleNotFound)
+				// 从这一页最后一条接着读，不是从最后一篇还在的文章
+				repo.EXPECT().List(gomock.Any(), int64(123),
+					domain.ArticleCursor{UpdatedAt: now.Add(-3 * time.Minute), Id: 3}, 3).
+					Return([]domain.FeedItem{
+						{ArticleId: 4, Ctime: now.Add(-4 * time.Minute)},
+						{ArticleId: 5, Ctime: now.Add(-5 * time.Minute)},
+						{ArticleId: 6, Ctime: now.Add(-6 * time.Minute)},
+					}, nil)
+				artRepo.EXPECT().GetPubById(gomock.Any(), int64(4)).Return(pub(4, 10, 4*time.Minute), nil)
+				artRepo.EXPECT().GetPubById(gomock.Any(), int64(5)).Return(pub(5, 10, 5*time.Minute), nil)
+				artRepo.EXPECT().GetPubById(gomock.Any(), int64(6)).Return(pub(6, 10, 6*time.Minute), nil)
+				return repo, followRepo, artRepo
+			},
+			wantIds: []int64{1, 4, 5},
+		},
+		{
+			name: "查大 V 失败，收件箱也不用查了",
+			mock: func(ctrl *gomock.Controller) (repository.FeedRepository, repository.FollowRepository, article.Repository) {
+				followRepo := repomocks.NewMockFollowRepository(ctrl)
+				followRepo.EXPECT().PopularFollowees(gomock.Any(), int64(123), int64(1000)).
+					Return(nil, errors.New("mock db error"))
+				return repomocks.NewMockFeedRepository(ctrl), followRepo, artrepomocks.NewMockRepository(ctrl)
+			},
+			wantIds: []int64{},
+			wantErr: errors.New("mock db error"),
+		},
+	}
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			ctrl := gomock.NewController(t)
+			defer ctrl.Finish()
+			repo, followRepo, artRepo := tc.mock(ctrl)
+			svc := NewFeedService(repo, followRepo, artRepo)
+			arts, err := svc.Feed(context.Background(), 123, domain.ArticleCursor{}, 3)
+			assert.Equal(t, tc.wantErr, err)
+			assert.Equal(t, tc.wantIds, slice.Map(arts, func(idx int, src domain.Article) int64 {
+				return src.Id
+			}))
+		})
+	}
+}
+
+func Test_feedService_Push(t *testing.T) {
+	now := time.UnixMilli(1700000000000)
+	art := domain.Article{
+		Id:        1,
+		Author:    domain.Author{Id: 10},
+		Status:    domain.ArticleStatusPublished,
+		UpdatedAt: now,
+	}
+	testCases := []struct {
+		name string
+		mock func(ctrl *gomock.Controller) (repository.FeedRepository, repository.FollowRepository, article.Repository)
+	}{
+		{
+			name: "分批推给粉丝",
+			mock: func(ctrl *gomock.Controller) (repository.FeedRepository, repository.FollowRepository, article.Repository) {
+				repo := repomocks.NewMockFeedRepository(ctrl)
+				followRepo := repomocks.NewMockFollowRepository(ctrl)
+				artRepo := artrepomocks.NewMockRepository(ctrl)
+				artRepo.EXPECT().GetPubById(gomock.Any(), int64(1)).Return(art, nil)
+				followRepo.EXPECT().Statics(gomock.Any(), int64(10)).
+					Return(domain.FollowStatics{Uid: 10, Followers: 3}, nil)
+				followRepo.EXPECT().FollowerList(gomock.Any(), int64(10), 0, 2).
+					Return([]domain.FollowRelation{{Follower: 100}, {Follower: 101}}, nil)
+				followRepo.EXPECT().FollowerList(gomock.Any(), int64(10), 2, 2).
+					Return([]domain.FollowRelation{{Follower: 102}}, nil)
+				repo.EXPECT().AddItems(gomock.Any(), []domain.FeedItem{
+					{Uid: 100, ArticleId: 1, AuthorId: 10, Ctime: now},
+					{Uid: 101, ArticleId: 1, AuthorId: 10, Ctime: now},
+				}).Return(nil)
+				repo.EXPECT().AddItems(gomock.Any(), []domain.FeedItem{
+					{Uid: 102, ArticleId: 1, AuthorId: 10, Ctime: now},
+				}).Return(nil)
+				return repo, followRepo, artRepo
+			},
+		},
+		{
+			name: "粉丝多的作者不推",
+			mock: func(ctrl *gomock.Controller) (repository.FeedRepository, repository.FollowRepository, article.Repository) {
+				followRepo := repomocks.NewMockFollowRepository(ctrl)
+				artRepo := artrepomocks.NewMockRepository(ctrl)
+				artRepo.EXPECT().GetPubById(gomock.Any(), int64(1)).Return(art, nil)
+				followRepo.EXPECT().Statics(gomock.Any(), int64(10)).
+					Return(domain.FollowStatics{Uid: 10, Followers: 1000}, nil)
+				return repomocks.NewMockFeedRepository(ctrl), followRepo, artRepo
+			},
+		},
+		{
+			name: "已经撤回了不推",
+			mock: func(ctrl *gomock.Controller) (repository.FeedRepository, repository.FollowRepository, article.Repository) {
+				artRepo := artrepomocks.NewMockRepository(ctrl)
+				artRepo.EXPECT().GetPubById(gomock.Any(), int64(1)).Return(domain.Article{}, ErrArticleNotFound)
+				return repomocks.NewMockFeedRepository(ctrl), repomocks.NewMockFollowRepository(ctrl), artRepo
+			},
+		},
+	}
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			ctrl := gomock.NewController(t)
+			defer ctrl.Finish()
+			repo, followRepo, artRepo := tc.mock(ctrl)
+			svc := NewFeedService(repo, followRepo, artRepo).(*feedService)
+			svc.pushBatchSize = 2
+			err := svc.Push(context.Background(), 1)
+			assert.NoError(t, err)
+		})
+	}
+}
+
+func Test_feedService_DropAuthor(t *testing.T) {
+	now := time.UnixMilli(1700000000000)
+	arts := []domain.Article{
+		{Id: 2, Author: domain.Author{Id: 10}, UpdatedAt: now},
+		{Id: 1, Author: domain.Author{Id: 10}, UpdatedAt: now.Add(-time.Minute)},
+	}
+	testCases := []struct {
+		name string
+		mock func(ctrl *gomock.Controller) (repository.FeedRepository, repository.FollowRepository, article.Repository)
+	}{
+		{
+			name: "普通作者只删自己的收件箱",
+			mock: func(ctrl *gomock.Controller) (repository.FeedRepository, repository.FollowRepository, article.Repository) {
+				repo := repomocks.NewMockFeedRepository(ctrl)
+				followRepo := repomocks.NewMockFollowRepository(ctrl)
+				repo.EXPECT().DeleteByAuthor(gomock.Any(), int64(123), int64(10)).Return(nil)
+				followRepo.EXPECT().Statics(gomock.Any(), int64(10)).
+					Return(domain.FollowStatics{Uid: 10, Followers: 500}, nil)
+				return repo, followRepo, artrepomocks.NewMockRepository(ctrl)
+			},
+		},
+		{
+			name: "还是大 V",
+			mock: func(ctrl *gomock.Controller) (repository.FeedRepository, repository.FollowRepository, article.Repository) {
+				repo := repomocks.NewMockFeedRepository(ctrl)
+				followRepo := repomocks.NewMockFollowRepository(ctrl)
+				repo.EXPECT().DeleteByAuthor(gomock.Any(), int64(123), int64(10)).Return(nil)
+				followRepo.EXPECT().Statics(gomock.Any(), int64(10)).
+					Return(domain.FollowStatics{Uid: 10, Followers: 1000}, nil)
+				return repo, followRepo, artrepomocks.NewMockRepository(ctrl)
+			},
+		},
+		{
+			name: "刚掉到阈值以下，最近的文章补给所有粉丝",
+			mock: func(ctrl *gomock.Controller) (repository.FeedRepository, repository.FollowRepository, article.Repository) {
+				repo := repomocks.NewMockFeedRepository(ctrl)
+				followRepo := repomocks.NewMockFollowRepository(ctrl)
+				artRepo := artrepomocks.NewMockRepository(ctrl)
+				repo.EXPECT().DeleteByAuthor(gomock.Any(), int64(123), int64(10)).Return(nil)
+				followRepo.EXPECT().Statics(gomock.Any(), int64(10)).
+					Return(domain.FollowStatics{Uid: 10, Followers: 999}, nil)
+				artRepo.EXPECT().ListPubByAuthor(gomock.Any(), int64(10), domain.ArticleCursor{}, 20).
+					Return(arts, nil)
+				followRepo.EXPECT().FollowerList(gomock.Any(), int64(10), 0, 2).
+					Return([]domain.FollowRelation{{Follower: 100}, {Follower: 101}}, nil)
+				followRepo.EXPECT().FollowerList(gomock.Any(), int64(10), 2, 2).
+					Return(nil, nil)
+				repo.EXPECT().AddItems(gomock.Any(), []domain.FeedItem{
+					{Uid: 100, ArticleId: 2, AuthorId: 10, Ctime: now},
+					{Uid: 100, ArticleId: 1, AuthorId: 10, Ctime: now.Add(-time.Minute)},
+					{Uid: 101, ArticleId: 2, AuthorId: 10, Ctime: now},
+					{Uid: 101, ArticleId: 1, AuthorId: 10, Ctime: now.Add(-time.Minute)},
+				}).Return(nil)
+				return repo, followRepo, artRepo
+			},
+		},
+	}
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			ctrl := gomock.NewController(t)
+			defer ctrl.Finish()
+			repo, followRepo, artRepo := tc.mock(ctrl)
+			svc := NewFeedService(repo, followRepo, artRepo).(*feedService)
+			svc.pushBatchSize = 2
+			err := svc.DropAuthor(context.Background(), 123, 10)
+			assert.NoError(t, err)
+		})
+	}
+}
@@ -0,0 +1,109 @@
+package service
+
+import (
+	"context"
+	"errors"
+	"github.com/Andras5014/gohub/internal/domain"
+	"github.com/Andras5014/gohub/internal/events/follow"
+	"github.com/Andras5014/gohub/internal/repository"
+	"github.com/Andras5014/gohub/pkg/logx"
+)
+
+var (
+	ErrFollowSelf       = errors.New("不能关注自己")
+	ErrFolloweeNotFound = errors.New("关注的用户不存在")
+)
+
+type FollowService interface {
+	// Follow 重复关注不报错
+	Follow(ctx context.Context, follower int64, followee int64) error
+	Unfollow(ctx context.Context, follower int64, followee int64) error
+	// FollowerList uid 的粉丝
+	FollowerList(ctx context.Context, uid int64, offset int, limit int) ([]domain.FollowRelation, error)
+	// FolloweeList uid 关注的人
+	FolloweeList(ctx context.Context, uid int64, offset int, limit int) ([]domain.FollowRelation, error)
+	Statics(ctx context.Context, uid int64) (domain.FollowStatics, error)
+	// Followed 批量判断 follower 有没有关注 followees，给文章详情、作者主页用
+	Followed(ctx context.Context, follower int64, followees []int64) (map[int64]bool, error)
+}
+
+type followService struct {
+	repo     repository.FollowRepository
+	userRepo repository.UserRepository
+	producer follow.Producer
+	l        logx.Logger
+}
+
+func NewFollowService(repo repository.FollowRepository, userRepo repository.UserRepository,
+	producer follow.Producer, l logx.Logger) FollowService {
+	return &followService{
+		repo:     repo,
+		userRepo: userRepo,
+		producer: producer,
+		l:        l,
+	}
+}
+
+func (f *followService) Follow(ctx context.Context, follower int64, followee int64) error {
+	if follower == followee {
+		return ErrFollowSelf
+	}
+	_, err := f.userRepo.FindById(ctx, followee)
+	if errors.Is(err, repository.ErrUserNotFound) {
+		return ErrFolloweeNotFound
+	}
+	if err != nil {
+		return err
+	}
+	changed, err := f.repo.Follow(ctx, follower, followee)
+	if err != nil || !changed {
+		return err
+	}
+	f.produceEvent(ctx, follow.FollowEvent{Follower: follower, Followee: followee, Delta: 1})
+	return nil
+}
+
+func (f *followService) Unfollow(ctx context.Context, follower int64, followee int64) error {
+	changed, err := f.repo.Unfollow(ctx, follower, followee)
+	if err != nil || !changed {
+		return err
+	}
+	f.produceEvent(ctx, follow.FollowEvent{Follower: follower, Followee: followee, Delta: -1})
+	return nil
+}
+
+func (f *followService) FollowerList(ctx context.Context, uid int64, offset int, limit int) ([]domain.FollowRelation, error) {
+	return f.repo.FollowerList(ctx, uid, offset, limit)
+}
+
+func (f *followService) FolloweeList(ctx context.Context, uid int64, offset int, limit int) ([]domain.FollowRelation, error) {
+	return f.repo.FolloweeList(ctx, uid, offset, limit)
+}
+
+func (f *followService) Statics(ctx context.Context, uid int64) (domain.FollowStatics, error) {
+	return f.repo.Statics(ctx, uid)
+}
+
+func (f *followService) Followed(ctx context.Context, follower int64, followees []int64) (map[int64]bool, error) {
+	res := make(map[int64]bool, len(followees))
+	if follower <= 0 || len(followees) == 0 {
+		return res, nil
+	}
+	ids, err := f.repo.FollowedIds(ctx, follower, followees)
+	if err != nil {
+		return nil, err
+	}
+	for _, id := range ids {
+		res[id] = true
+	}
+	return res, nil
+}
+
+// produceEvent 关系已经改了，发消息失败只记日志
+func (f *followService) produceEvent(ctx context.Context, evt follow.FollowEvent) {
+	err := f.producer.ProduceFollowEvent(ctx, evt)
+	if err != nil {
+		f.l.Error("发送关注事件失败",
+			logx.Int64("follower", evt.Follower), logx.Int64("followee", evt.Followee), logx.Error(err))
+	}
+}
@@ -0,0 +1,120 @@
+package service
+
+import (
+	"context"
+	"errors"
+	"github.com/Andras5014/gohub/internal/domain"
+	"github.com/Andras5014/gohub/internal/events/follow"
+	evtmocks "github.com/Andras5014/gohub/internal/events/follow/mocks"
+	"github.com/Andras5014/gohub/internal/repository"
+	repomocks "github.com/Andras5014/gohub/internal/repository/mocks"
+	"github.com/Andras5014/gohub/pkg/logx"
+	"github.com/stretchr/testify/assert"
+	"go.uber.org/mock/gomock"
+	"go.uber.org/zap"
+	"testing"
+)
+
+func Test_followService_Follow(t *testing.T) {
+	testCases := []struct {
+		name string
+		mock func(ctrl *gomock.Controller) (repository.FollowRepository, repository.UserRepository, follow.Producer)
+
+		follower int64
+		followee int64
+
+		wantErr error
+	}{
+		{
+			name: "关注成功发送事件",
+			mock: func(ctrl *gomock.Controller) (repository.FollowRepository, repository.UserRepository, follow.Producer) {
+				repo := repomocks.NewMockFollowRepository(ctrl)
+				userRepo := repomocks.NewMockUserRepository(ctrl)
+				producer := evtmocks.NewMockProducer(ctrl)
+				userRepo.EXPECT().FindById(gomock.Any(), int64(456)).Return(domain.User{Id: 456}, nil)
+				repo.EXPECT().Follow(gomock.Any(), int64(123), int64(456)).Return(true, nil)
+				producer.EXPECT().ProduceFollowEvent(gomock.Any(), follow.FollowEvent{
+					Follower: 123,
+					Followee: 456,
+					Delta:    1,
+				}).Return(nil)
+				return repo, userRepo, producer
+			},
+			follower: 123,
+			followee: 456,
+		},
+		{
+			name: "重复关注不发送事件",
+			mock: func(ctrl *gomock.Controller) (repository.FollowRepository, repository.UserRepository, follow.Producer) {
+				repo := repomocks.NewMockFollowRepository(ctrl)
+				userRepo := repomocks.NewMockUserRepository(ctrl)
+				userRepo.EXPECT().FindById(gomock.Any(), int64(456)).Return(domain.User{Id: 456}, nil)
+				repo.EXPECT().Follow(gomock.Any(), int64(123), int64(456)).Return(false, nil)
+				return repo, userRepo, evtmocks.NewMockProducer(ctrl)
+			},
+			follower: 123,
+			followee: 456,
+		},
+		{
+			name: "发送事件失败不影响关注",
+			mock: func(ctrl *gomock.Controller) (repository.FollowRepository, repository.UserRepository, follow.Producer) {
+				repo := repomocks.NewMockFollowRepository(ctrl)
+				userRepo := repomocks.NewMockUserRepository(ctrl)
+				producer := evtmocks.NewMockProducer(ctrl)
+				userRepo.EXPECT().FindById(gomock.Any(), int64(456)).Return(domain.User{Id: 456}, nil)
+				repo.EXPECT().Follow(gomock.Any(), int64(123), int64(456)).Return(true, nil)
+				producer.EXPECT().ProduceFollowEvent(gomock.Any(), gomock.Any()).Return(errors.New("kafka 错误"))
+				return repo, userRepo, producer
+			},
+			follower: 123,
+			followee: 456,
+		},
+		{
+			name: "不能关注自己",
+			mock: func(ctrl *gomock.Controller) (repository.FollowRepository, repository.UserRepository, follow.Producer) {
+				return repomocks.NewMockFollowRepository(ctrl), repomocks.NewMockUserRepository(ctrl), evtmocks.NewMockProducer(ctrl)
+			},
+			follower: 123,
+			followee: 123,
+			wantErr:  ErrFollowSelf,
+		},
+		{
+			name: "关注的用户不存在",
+			mock: func(ctrl *gomock.Controller) (repository.FollowRepository, repository.UserRepository, follow.Producer) {
+				userRepo := repomocks.NewMockUserRepository(ctrl)
+				userRepo.EXPECT().FindById(gomock.Any(), int64(456)).Return(domain.User{}, repository.ErrUserNotFound)
+				return repomocks.NewMockFollowRepository(ctrl), userRepo, evtmocks.NewMockProducer(ctrl)
+			},
+			follower: 123,
+			followee: 456,
+			wantErr:  ErrFolloweeNotFound,
+		},
+	}
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			ctrl := gomock.NewController(t)
+			defer ctrl.Finish()
+			repo, userRepo, producer := tc.mock(ctrl)
+			svc := NewFollowService(repo, userRepo, producer, logx.NewZapLogger(zap.NewNop()))
+			err := svc.Follow(context.Background(), tc.follower, tc.followee)
+			assert.Equal(t, tc.wantErr, err)
+		})
+	}
+}
+
+func Test_followService_Followed(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+	repo := repomocks.NewMockFollowRepository(ctrl)
+	repo.EXPECT().FollowedIds(gomock.Any(), int64(123), []int64{1, 2, 3}).Return([]int64{2}, nil)
+	svc := NewFollowService(repo, nil, nil, logx.NewZapLogger(zap.NewNop()))
+
+	res, err := svc.Followed(context.Background(), 123, []int64{1, 2, 3})
+	assert.NoError(t, err)
+	assert.Equal(t, map[int64]bool{2: true}, res)
+
+	// 没登录的不查
+	res, err = svc.Followed(context.Background(), 0, []int64{1, 2, 3})
+	assert.NoError(t, err)
+	assert.Empty(t, res)
+}
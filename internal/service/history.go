@@ -0,0 +1,41 @@
+package service
+
+import (
+	"context"
+	"github.com/Andras5014/gohub/internal/domain"
+	"github.com/Andras5014/gohub/internal/repository"
+)
+
+// HistoryService 阅读历史，记录是消费阅读事件写进去的，这里只有查和删
+type HistoryService interface {
+	// List 按阅读时间倒序
+	List(ctx context.Context, uid int64, offset int, limit int) ([]domain.HistoryRecord, error)
+	Delete(ctx context.Context, uid int64, biz string, bizId int64) error
+	Clear(ctx context.Context, uid int64) error
+	// Trim 删掉超过上限的旧记录，定时任务调用
+	Trim(ctx context.Context) (int, error)
+}
+
+type historyService struct {
+	repo repository.HistoryRecordRepository
+}
+
+func NewHistoryService(repo repository.HistoryRecordRepository) HistoryService {
+	return &historyService{repo: repo}
+}
+
+func (h *historyService) List(ctx context.Context, uid int64, offset int, limit int) ([]domain.HistoryRecord, error) {
+	return h.repo.List(ctx, uid, offset, limit)
+}
+
+func (h *historyService) Delete(ctx context.Context, uid int64, biz string, bizId int64) error {
+	return h.repo.Delete(ctx, uid, biz, bizId)
+}
+
+func (h *historyService) Clear(ctx context.Context, uid int64) error {
+	return h.repo.Clear(ctx, uid)
+}
+
+func (h *historyService) Trim(ctx context.Context) (int, error) {
+	return h.repo.TrimAll(ctx)
+}
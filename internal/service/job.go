@@ -13,6 +13,8 @@ type JobService interface {
 	Release(ctx context.Context, id int64) error
 	ResetNextTime(ctx context.Context, j domain.Job) error
 	Stop(ctx context.Context, j domain.Job) error
+	// AddJob 注册任务，同名任务已经存在就忽略
+	AddJob(ctx context.Context, j domain.Job) error
 }
 
 type CronJobService struct {
@@ -21,6 +23,14 @@ type CronJobService struct {
 	l               logx.Logger
 }
 
+func NewCronJobService(repo repository.JobRepository, l logx.Logger) JobService {
+	return &CronJobService{
+		repo:            repo,
+		refreshInterval: time.Second * 10,
+		l:               l,
+	}
+}
+
 func (c *CronJobService) Preempt(ctx context.Context) (domain.Job, error) {
 	j, err := c.repo.Preempt(ctx)
 	if err != nil {
@@ -28,16 +38,23 @@ func (c *CronJobService) Preempt(ctx context.Context) (domain.Job, error) {
 	}
 
 	ticker := time.NewTicker(c.refreshInterval)
+	done := make(chan struct{})
 
 	go func() {
 		// 续约
-		for range ticker.C {
-			c.refresh(j.Id)
+		for {
+			select {
+			case <-ticker.C:
+				c.refresh(j.Id)
+			case <-done:
+				return
+			}
 		}
 	}()
 
 	j.CancelFunc = func() error {
 		ticker.Stop()
+		close(done)
 		ctx, cancel := context.WithTimeout(ctx, time.Second)
 		defer cancel()
 		return c.repo.Release(ctx, j.Id)
@@ -68,3 +85,7 @@ func (c *CronJobService) ResetNextTime(ctx context.Context, j domain.Job) error
 func (c *CronJobService) Stop(ctx context.Context, j domain.Job) error {
 	return c.repo.Stop(ctx, j.Id)
 }
+
+func (c *CronJobService) AddJob(ctx context.Context, j domain.Job) error {
+	return c.repo.AddJob(ctx, j)
+}
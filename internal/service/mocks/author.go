@@ -0,0 +1,71 @@
+// Code generated by MockGen. DO NOT EDIT.
+// Source: ./internal/service/author.go
+//
+// Generated by this command:
+//
+//	mockgen -source=./internal/service/author.go -destination=./internal/service/mocks/author.go -package=svcmocks
+//
+
+// Package svcmocks is a generated GoMock package.
+package svcmocks
+
+import (
+	context "context"
+	reflect "reflect"
+
+	domain "github.com/Andras5014/gohub/internal/domain"
+	gomock "go.uber.org/mock/gomock"
+)
+
+// MockAuthorService is a mock of AuthorService interface.
+type MockAuthorService struct {
+	ctrl     *gomock.Controller
+	recorder *MockAuthorServiceMockRecorder
+}
+
+// MockAuthorServiceMockRecorder is the mock recorder for MockAuthorService.
+type MockAuthorServiceMockRecorder struct {
+	mock *MockAuthorService
+}
+
+// NewMockAuthorService creates a new mock instance.
+func NewMockAuthorService(ctrl *gomock.Controller) *MockAuthorService {
+	mock := &MockAuthorService{ctrl: ctrl}
+	mock.recorder = &MockAuthorServiceMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockAuthorService) EXPECT() *MockAuthorServiceMockRecorder {
+	return m.recorder
+}
+
+// ListPub mocks base method.
+func (m *MockAuthorService) ListPub(ctx context.Context, id int64, cursor domain.ArticleCursor, limit int) ([]domain.Article, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "ListPub", ctx, id, cursor, limit)
+	ret0, _ := ret[0].([]domain.Article)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// ListPub indicates an expected call of ListPub.
+func (mr *MockAuthorServiceMockRecorder) ListPub(ctx, id, cursor, limit any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ListPub", reflect.TypeOf((*MockAuthorService)(nil).ListPub), ctx, id, cursor, limit)
+}
+
+// Profile mocks base method.
+func (m *MockAuthorService) Profile(ctx context.Context, id int64) (domain.AuthorProfile, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Profile", ctx, id)
+	ret0, _ := ret[0].(domain.AuthorProfile)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// Profile indicates an expected call of Profile.
+func (mr *MockAuthorServiceMockRecorder) Profile(ctx, id any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Profile", reflect.TypeOf((*MockAuthorService)(nil).Profile), ctx, id)
+}
@@ -0,0 +1,100 @@
+// Code generated by MockGen. DO NOT EDIT.
+// Source: ./internal/service/reading_progress.go
+//
+// Generated by this command:
+//
+//	mockgen -source=./internal/service/reading_progress.go -destination=./internal/service/mocks/reading_progress.go -package=svcmocks
+//
+
+// Package svcmocks is a generated GoMock package.
+package svcmocks
+
+import (
+	context "context"
+	reflect "reflect"
+
+	domain "github.com/Andras5014/gohub/internal/domain"
+	gomock "go.uber.org/mock/gomock"
+)
+
+// MockReadingProgressService is a mock of ReadingProgressService interface.
+type MockReadingProgressService struct {
+	ctrl     *gomock.Controller
+	recorder *MockReadingProgressServiceMockRecorder
+}
+
+// MockReadingProgressServiceMockRecorder is the mock recorder for MockReadingProgressService.
+type MockReadingProgressServiceMockRecorder struct {
+	mock *MockReadingProgressService
+}
+
+// NewMockReadingProgressService creates a new mock instance.
+func NewMockReadingProgressService(ctrl *gomock.Controller) *MockReadingProgressService {
+	mock := &MockReadingProgressService{ctrl: ctrl}
+	mock.recorder = &MockReadingProgressServiceMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockReadingProgressService) EXPECT() *MockReadingProgressServiceMockRecorder {
+	return m.recorder
+}
+
+// ContinueReading mocks base method.
+func (m *MockReadingProgressService) ContinueReading(ctx context.Context, uid int64, limit int) ([]domain.ReadingProgress, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "ContinueReading", ctx, uid, limit)
+	ret0, _ := ret[0].([]domain.ReadingProgress)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// ContinueReading indicates an expected call of ContinueReading.
+func (mr *MockReadingProgressServiceMockRecorder) ContinueReading(ctx, uid, limit any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ContinueReading", reflect.TypeOf((*MockReadingProgressService)(nil).ContinueReading), ctx, uid, limit)
+}
+
+// Flush mocks base method.
+func (m *MockReadingProgressService) Flush(ctx context.Context) (int, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Flush", ctx)
+	ret0, _ := ret[0].(int)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// Flush indicates an expected call of Flush.
+func (mr *MockReadingProgressServiceMockRecorder) Flush(ctx any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Flush", reflect.TypeOf((*MockReadingProgressService)(nil).Flush), ctx)
+}
+
+// Get mocks base method.
+func (m *MockReadingProgressService) Get(ctx context.Context, uid, aid int64) (domain.ReadingProgress, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Get", ctx, uid, aid)
+	ret0, _ := ret[0].(domain.ReadingProgress)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// Get indicates an expected call of Get.
+func (mr *MockReadingProgressServiceMockRecorder) Get(ctx, uid, aid any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Get", reflect.TypeOf((*MockReadingProgressService)(nil).Get), ctx, uid, aid)
+}
+
+// Report mocks base method.
+func (m *MockReadingProgressService) Report(ctx context.Context, p domain.ReadingProgress) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Report", ctx, p)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// Report indicates an expected call of Report.
+func (mr *MockReadingProgressServiceMockRecorder) Report(ctx, p any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Report", reflect.TypeOf((*MockReadingProgressService)(nil).Report), ctx, p)
+}
@@ -0,0 +1,102 @@
+package service
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"github.com/Andras5014/gohub/internal/domain"
+	"github.com/Andras5014/gohub/pkg/sensitivex"
+	"strings"
+)
+
+// ModerationMode 命中敏感词之后怎么处理
+type ModerationMode string
+
+const (
+	// ModerationReject 直接拒绝发表
+	ModerationReject ModerationMode = "reject"
+	// ModerationMask 把敏感词替换成 * 之后发表
+	ModerationMask ModerationMode = "mask"
+	// ModerationReview 进入人工审核
+	ModerationReview ModerationMode = "review"
+)
+
+const moderationMask = '*'
+
+var ErrSensitiveContent = errors.New("内容包含敏感词")
+
+// SensitiveContentError 带上命中的词，方便作者修改
+type SensitiveContentError struct {
+	Words []string
+}
+
+func (e *SensitiveContentError) Error() string {
+	return fmt.Sprintf("%s: %s", ErrSensitiveContent.Error(), strings.Join(e.Words, ","))
+}
+
+func (e *SensitiveContentError) Is(target error) bool {
+	return target == ErrSensitiveContent
+}
+
+// Moderator 发表之前的内容审核
+type Moderator interface {
+	// Moderate 返回处理之后的文章，review 为 true 表示要进入人工审核
+	Moderate(ctx context.Context, art domain.Article) (res domain.Article, review bool, err error)
+}
+
+type WordModerator struct {
+	filter *sensitivex.Filter
+	mode   ModerationMode
+	// reviewAll 没有命中也要人工审核
+	reviewAll bool
+}
+
+func NewWordModerator(filter *sensitivex.Filter, mode ModerationMode, reviewAll bool) Moderator {
+	return &WordModerator{
+		filter:    filter,
+		mode:      mode,
+		reviewAll: reviewAll,
+	}
+}
+
+func (w *WordModerator) Moderate(ctx context.Context, art domain.Article) (domain.Article, bool, error) {
+	var hits []sensitivex.Hit
+	for _, text := range append([]string{art.Title, art.Content}, art.Tags...) {
+		hits = append(hits, w.filter.FindAll(text)...)
+	}
+	if len(hits) == 0 {
+		return art, w.reviewAll, nil
+	}
+	switch w.mode {
+	case ModerationMask:
+		art.Title, _ = w.filter.Mask(art.Title, moderationMask)
+		art.Content, _ = w.filter.Mask(art.Content, moderationMask)
+		// 标签替换之后没有意义，命中的直接去掉
+		tags := make([]string, 0, len(art.Tags))
+		for _, tag := range art.Tags {
+			if len(w.filter.FindAll(tag)) == 0 {
+				tags = append(tags, tag)
+			}
+		}
+		art.Tags = tags
+		return art, w.reviewAll, nil
+	case ModerationReview:
+		return art, true, nil
+	default:
+		return art, false, &SensitiveContentError{Words: hitWords(hits)}
+	}
+}
+
+// hitWords 去重，保持第一次出现的顺序
+func hitWords(hits []sensitivex.Hit) []string {
+	seen := make(map[string]struct{}, len(hits))
+	res := make([]string, 0, len(hits))
+	for _, h := range hits {
+		if _, ok := seen[h.Word]; ok {
+			continue
+		}
+		seen[h.Word] = struct{}{}
+		res = append(res, h.Word)
+	}
+	return res
+}
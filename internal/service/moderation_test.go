@@ -0,0 +1,75 @@
+package service
+
+import (
+	"context"
+	"github.com/Andras5014/gohub/internal/domain"
+	"github.com/Andras5014/gohub/pkg/sensitivex"
+	"github.com/stretchr/testify/assert"
+	"testing"
+)
+
+func TestWordModerator_Moderate(t *testing.T) {
+	art := domain.Article{
+		Title:   "标题",
+		Content: "正文里面有赌博",
+		Tags:    []string{"go", "博彩"},
+	}
+	testCases := []struct {
+		name      string
+		mode      ModerationMode
+		reviewAll bool
+		art       domain.Article
+
+		wantArt    domain.Article
+		wantReview bool
+		wantErr    error
+	}{
+		{
+			name:    "没有命中",
+			mode:    ModerationReject,
+			art:     domain.Article{Title: "标题", Content: "干净的正文"},
+			wantArt: domain.Article{Title: "标题", Content: "干净的正文"},
+		},
+		{
+			name:       "没有命中也要审核",
+			mode:       ModerationReject,
+			reviewAll:  true,
+			art:        domain.Article{Title: "标题", Content: "干净的正文"},
+			wantArt:    domain.Article{Title: "标题", Content: "干净的正文"},
+			wantReview: true,
+		},
+		{
+			name:    "拒绝",
+			mode:    ModerationReject,
+			art:     art,
+			wantArt: art,
+			wantErr: &SensitiveContentError{Words: []string{"赌博", "博彩"}},
+		},
+		{
+			name: "替换，命中的标签去掉",
+			mode: ModerationMask,
+			art:  art,
+			wantArt: domain.Article{
+				Title:   "标题",
+				Content: "正文里面有**",
+				Tags:    []string{"go"},
+			},
+		},
+		{
+			name:       "进入人工审核",
+			mode:       ModerationReview,
+			art:        art,
+			wantArt:    art,
+			wantReview: true,
+		},
+	}
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			m := NewWordModerator(sensitivex.NewFilter([]string{"赌博", "博彩"}), tc.mode, tc.reviewAll)
+			res, review, err := m.Moderate(context.Background(), tc.art)
+			assert.Equal(t, tc.wantErr, err)
+			assert.Equal(t, tc.wantArt, res)
+			assert.Equal(t, tc.wantReview, review)
+		})
+	}
+}
@@ -0,0 +1,106 @@
+package service
+
+import (
+	"context"
+	"errors"
+	"github.com/Andras5014/gohub/internal/domain"
+	"github.com/Andras5014/gohub/internal/repository"
+	"github.com/Andras5014/gohub/internal/repository/article"
+	"slices"
+)
+
+var ErrInvalidNotificationType = errors.New("通知类型不合法")
+
+type NotificationService interface {
+	// Notify Uid 为 0 的时候通知资源的作者，自己触发的和屏蔽了的不通知
+	Notify(ctx context.Context, n domain.Notification) error
+	List(ctx context.Context, uid int64, offset int, limit int) ([]domain.Notification, error)
+	UnreadCnt(ctx context.Context, uid int64) (map[domain.NotificationType]int64, error)
+	MarkRead(ctx context.Context, uid int64, ids []int64) error
+	MarkAllRead(ctx context.Context, uid int64) error
+	// Mute mute 为 false 的时候取消屏蔽，屏蔽之前已经收到的通知不动
+	Mute(ctx context.Context, uid int64, typ domain.NotificationType, mute bool) error
+	MutedTypes(ctx context.Context, uid int64) ([]domain.NotificationType, error)
+}
+
+type notificationService struct {
+	repo    repository.NotificationRepository
+	artRepo article.Repository
+}
+
+func NewNotificationService(repo repository.NotificationRepository, artRepo article.Repository) NotificationService {
+	return &notificationService{
+		repo:    repo,
+		artRepo: artRepo,
+	}
+}
+
+func (s *notificationService) Notify(ctx context.Context, n domain.Notification) error {
+	if !n.Type.Valid() {
+		return ErrInvalidNotificationType
+	}
+	if n.Uid == 0 {
+		owner, err := s.ownerOf(ctx, n.Biz, n.BizId)
+		if err != nil {
+			return err
+		}
+		n.Uid = owner
+	}
+	if n.Uid == 0 || n.Uid == n.ActorId {
+		return nil
+	}
+	muted, err := s.repo.MutedTypes(ctx, n.Uid)
+	if err != nil {
+		return err
+	}
+	if slices.Contains(muted, n.Type) {
+		return nil
+	}
+	return s.repo.Upsert(ctx, n)
+}
+
+// ownerOf 目前只有文章，找不到作者的返回 0
+func (s *notificationService) ownerOf(ctx context.Context, biz string, bizId int64) (int64, error) {
+	if biz != "article" {
+		return 0, nil
+	}
+	art, err := s.artRepo.GetPubById(ctx, bizId)
+	if errors.Is(err, ErrArticleNotFound) {
+		return 0, nil
+	}
+	if err != nil {
+		return 0, err
+	}
+	return art.Author.Id, nil
+}
+
+func (s *notificationService) List(ctx context.Context, uid int64, offset int, limit int) ([]domain.Notification, error) {
+	return s.repo.List(ctx, uid, offset, limit)
+}
+
+func (s *notificationService) UnreadCnt(ctx context.Context, uid int64) (map[domain.NotificationType]int64, error) {
+	return s.repo.UnreadCnt(ctx, uid)
+}
+
+func (s *notificationService) MarkRead(ctx context.Context, uid int64, ids []int64) error {
+	// 空的 ids 在 repository 里面表示全部，这里不能透传下去
+	if len(ids) == 0 {
+		return nil
+	}
+	return s.repo.MarkRead(ctx, uid, ids)
+}
+
+func (s *notificationService) MarkAllRead(ctx context.Context, uid int64) error {
+	return s.repo.MarkRead(ctx, uid, nil)
+}
+
+func (s *notificationService) Mute(ctx context.Context, uid int64, typ domain.NotificationType, mute bool) error {
+	if !typ.Valid() {
+		return ErrInvalidNotificationType
+	}
+	return s.repo.SetMute(ctx, uid, typ, mute)
+}
+
+func (s *notificationService) MutedTypes(ctx context.Context, uid int64) ([]domain.NotificationType, error) {
+	return s.repo.MutedTypes(ctx, uid)
+}
@@ -0,0 +1,141 @@
+package service
+
+import (
+	"context"
+	"github.com/Andras5014/gohub/internal/domain"
+	"github.com/Andras5014/gohub/internal/repository"
+	"github.com/Andras5014/gohub/internal/repository/article"
+	artrepomocks "github.com/Andras5014/gohub/internal/repository/article/mocks"
+	repomocks "github.com/Andras5014/gohub/internal/repository/mocks"
+	"github.com/stretchr/testify/assert"
+	"go.uber.org/mock/gomock"
+	"testing"
+)
+
+func Test_notificationService_Notify(t *testing.T) {
+	testCases := []struct {
+		name         string
+		mock         func(ctrl *gomock.Controller) (repository.NotificationRepository, article.Repository)
+		notification domain.Notification
+
+		wantErr error
+	}{
+		{
+			name: "点赞通知文章作者",
+			mock: func(ctrl *gomock.Controller) (repository.NotificationRepository, article.Repository) {
+				repo := repomocks.NewMockNotificationRepository(ctrl)
+				artRepo := artrepomocks.NewMockRepository(ctrl)
+				artRepo.EXPECT().GetPubById(gomock.Any(), int64(1)).
+					Return(domain.Article{Id: 1, Author: domain.Author{Id: 123}}, nil)
+				repo.EXPECT().MutedTypes(gomock.Any(), int64(123)).
+					Return([]domain.NotificationType{domain.NotificationTypeCollect}, nil)
+				repo.EXPECT().Upsert(gomock.Any(), domain.Notification{
+					Uid:     123,
+					Type:    domain.NotificationTypeLike,
+					Biz:     "article",
+					BizId:   1,
+					ActorId: 456,
+				}).Return(nil)
+				return repo, artRepo
+			},
+			notification: domain.Notification{
+				Type:    domain.NotificationTypeLike,
+				Biz:     "article",
+				BizId:   1,
+				ActorId: 456,
+			},
+		},
+		{
+			name: "回复直接通知被回复的人",
+			mock: func(ctrl *gomock.Controller) (repository.NotificationRepository, article.Repository) {
+				repo := repomocks.NewMockNotificationRepository(ctrl)
+				repo.EXPECT().MutedTypes(gomock.Any(), int64(789)).Return(nil, nil)
+				repo.EXPECT().Upsert(gomock.Any(), gomock.Any()).Return(nil)
+				return repo, artrepomocks.NewMockRepository(ctrl)
+			},
+			notification: domain.Notification{
+				Uid:     789,
+				Type:    domain.NotificationTypeReply,
+				Biz:     "article",
+				BizId:   1,
+				ActorId: 456,
+				RefId:   10,
+			},
+		},
+		{
+			name: "给自己点赞不通知",
+			mock: func(ctrl *gomock.Controller) (repository.NotificationRepository, article.Repository) {
+				artRepo := artrepomocks.NewMockRepository(ctrl)
+				artRepo.EXPECT().GetPubById(gomock.Any(), int64(1)).
+					Return(domain.Article{Id: 1, Author: domain.Author{Id: 123}}, nil)
+				return repomocks.NewMockNotificationRepository(ctrl), artRepo
+			},
+			notification: domain.Notification{
+				Type:    domain.NotificationTypeLike,
+				Biz:     "article",
+				BizId:   1,
+				ActorId: 123,
+			},
+		},
+		{
+			name: "屏蔽了的类型不通知",
+			mock: func(ctrl *gomock.Controller) (repository.NotificationRepository, article.Repository) {
+				repo := repomocks.NewMockNotificationRepository(ctrl)
+				repo.EXPECT().MutedTypes(gomock.Any(), int64(789)).
+					Return([]domain.NotificationType{domain.NotificationTypeReply}, nil)
+				return repo, artrepomocks.NewMockRepository(ctrl)
+			},
+			notification: domain.Notification{
+				Uid:     789,
+				Type:    domain.NotificationTypeReply,
+				Biz:     "article",
+				BizId:   1,
+				ActorId: 456,
+			},
+		},
+		{
+			name: "文章不存在不通知",
+			mock: func(ctrl *gomock.Controller) (repository.NotificationRepository, article.Repository) {
+				artRepo := artrepomocks.NewMockRepository(ctrl)
+				artRepo.EXPECT().GetPubById(gomock.Any(), int64(1)).
+					Return(domain.Article{}, ErrArticleNotFound)
+				return repomocks.NewMockNotificationRepository(ctrl), artRepo
+			},
+			notification: domain.Notification{
+				Type:    domain.NotificationTypeCollect,
+				Biz:     "article",
+				BizId:   1,
+				ActorId: 456,
+			},
+		},
+		{
+			name: "类型不合法",
+			mock: func(ctrl *gomock.Controller) (repository.NotificationRepository, article.Repository) {
+				return repomocks.NewMockNotificationRepository(ctrl), artrepomocks.NewMockRepository(ctrl)
+			},
+			notification: domain.Notification{Biz: "article", BizId: 1, ActorId: 456},
+			wantErr:      ErrInvalidNotificationType,
+		},
+	}
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			ctrl := gomock.NewController(t)
+			defer ctrl.Finish()
+			repo, artRepo := tc.mock(ctrl)
+			svc := NewNotificationService(repo, artRepo)
+			err := svc.Notify(context.Background(), tc.notification)
+			assert.Equal(t, tc.wantErr, err)
+		})
+	}
+}
+
+func Test_notificationService_MarkRead(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+	repo := repomocks.NewMockNotificationRepository(ctrl)
+	repo.EXPECT().MarkRead(gomock.Any(), int64(123), []int64{1, 2}).Return(nil)
+	svc := NewNotificationService(repo, nil)
+	// 空的 ids 不能变成全部已读
+	assert.NoError(t, svc.MarkRead(context.Background(), 123, nil))
+	assert.NoError(t, svc.MarkRead(context.Background(), 123, []int64{1, 2}))
+}
@@ -8,12 +8,37 @@ import (
 	"github.com/Andras5014/gohub/internal/repository"
 	"github.com/ecodeclub/ekit/queue"
 	"github.com/ecodeclub/ekit/slice"
-	"math"
 	"time"
 )
 
+var ErrRankingNotFound = errors.New("热榜不存在")
+
 type RankingService interface {
-	TopN(ctx context.Context, n int) error
+	// TopN 全量计算所有的热榜并且发布出去，增量热榜的分数也会跟着重建
+	TopN(ctx context.Context) error
+	// GetTopN 读取某个热榜最近一次发布的结果，name 为空表示默认的热榜
+	GetTopN(ctx context.Context, name string) ([]domain.Article, error)
+	// IncrScore 收到互动事件，累加增量热榜的分数
+	IncrScore(ctx context.Context, artId int64, typ string, delta int64) error
+	// RefreshStream 衰减增量热榜的分数，再把前 N 发布出去
+	RefreshStream(ctx context.Context) error
+}
+
+// RankingBoard 一个具名的热榜
+type RankingBoard struct {
+	Name string
+	// Window 只统计这段时间内更新过的文章，0 表示不限
+	Window time.Duration
+	Size   int
+	Scorer Scorer
+	// Streaming 增量模式，Scorer 必须是 StreamScorer
+	Streaming bool
+}
+
+// streamCapacity 增量热榜在 redis 里面多留一些候选，
+// 不然刚被挤出去的文章再有互动只能从 0 开始算
+func (r RankingBoard) streamCapacity() int {
+	return r.Size * 10
 }
 
 type BatchRankingService struct {
@@ -21,48 +46,169 @@ type BatchRankingService struct {
 	intrSvc   interactivev1.InteractiveServiceClient
 	batchSize int
 	repo      repository.RankingRepository
-	scoreFunc func(likeCnt int64, updateTime time.Time) float64
+	// boards 第一个是默认的热榜
+	boards []RankingBoard
+	now    func() time.Time
 }
 
-func NewRankingService(artSvc ArticleService, intrSvc interactivev1.InteractiveServiceClient) RankingService {
+func NewRankingService(artSvc ArticleService, intrSvc interactivev1.InteractiveServiceClient,
+	repo repository.RankingRepository, boards []RankingBoard) RankingService {
 	return &BatchRankingService{
 		artSvc:    artSvc,
 		intrSvc:   intrSvc,
+		repo:      repo,
 		batchSize: 100,
-		scoreFunc: func(likeCnt int64, updateTime time.Time) float64 {
-			ms := time.Since(updateTime).Seconds()
-			return float64(likeCnt+1) / math.Pow(ms+2, 1.5)
-		},
+		boards:    boards,
+		now:       time.Now,
 	}
 }
-func (b *BatchRankingService) TopN(ctx context.Context, n int) error {
-	arts, err := b.topN(ctx, n)
+
+func (b *BatchRankingService) TopN(ctx context.Context) error {
+	res, err := b.topN(ctx)
 	if err != nil {
 		return err
 	}
-	return b.repo.ReplaceTopN(ctx, arts)
+	var ids []int64
+	for i, board := range b.boards {
+		scores := res[i]
+		if board.Streaming {
+			// 顺便校准增量的分数，丢了的事件在这里补回来
+			if err = b.repo.ReplaceScores(ctx, board.Name, toScoreMap(scores)); err != nil {
+				return err
+			}
+			scores = scores[:min(len(scores), board.Size)]
+		}
+		arts := slice.Map[rankingScore, domain.Article](scores, func(idx int, src rankingScore) domain.Article {
+			return src.art
+		})
+		if err = b.repo.ReplaceTopN(ctx, board.Name, arts); err != nil {
+			return err
+		}
+		ids = append(ids, slice.Map[domain.Article, int64](arts, func(idx int, src domain.Article) int64 {
+			return src.Id
+		})...)
+	}
+	// 上了热榜的文章马上会有大量读请求，提前放进缓存
+	return b.artSvc.WarmPubCache(ctx, uniqueIds(ids))
 }
 
-func (b *BatchRankingService) topN(ctx context.Context, n int) ([]domain.Article, error) {
-	// 计算一周内的文章
-	startTime := time.Now().Add(time.Hour * 24 * 7)
-	offset := 0
-	type Score struct {
-		art   domain.Article
-		score float64
+func (b *BatchRankingService) GetTopN(ctx context.Context, name string) ([]domain.Article, error) {
+	if name == "" && len(b.boards) > 0 {
+		name = b.boards[0].Name
 	}
+	for _, board := range b.boards {
+		if board.Name == name {
+			return b.repo.GetTopN(ctx, name)
+		}
+	}
+	return nil, ErrRankingNotFound
+}
 
-	topN := queue.NewPriorityQueue[Score](n, func(src Score, dst Score) int {
-		if src.score > dst.score {
-			return 1
-		} else if src.score < dst.score {
-			return -1
-		} else {
-			return 0
+func (b *BatchRankingService) IncrScore(ctx context.Context, artId int64, typ string, delta int64) error {
+	for _, board := range b.boards {
+		if !board.Streaming {
+			continue
+		}
+		score := board.Scorer.(StreamScorer).Incr(typ, delta)
+		if score == 0 {
+			continue
+		}
+		if err := b.repo.IncrScore(ctx, board.Name, artId, score); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+func (b *BatchRankingService) RefreshStream(ctx context.Context) error {
+	for _, board := range b.boards {
+		if !board.Streaming {
+			continue
+		}
+		if err := b.refreshStream(ctx, board); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+func (b *BatchRankingService) refreshStream(ctx context.Context, board RankingBoard) error {
+	halfLife := board.Scorer.(StreamScorer).HalfLife()
+	if err := b.repo.DecayScores(ctx, board.Name, halfLife, board.streamCapacity()); err != nil {
+		return err
+	}
+	ids, err := b.repo.TopIds(ctx, board.Name, board.Size)
+	if err != nil {
+		return err
+	}
+	arts, err := b.artSvc.ListPubByIds(ctx, ids)
+	if err != nil {
+		return err
+	}
+	if len(arts) < len(ids) {
+		// 撤回或者删除了的文章，从候选里面去掉
+		found := make(map[int64]struct{}, len(arts))
+		for _, art := range arts {
+			found[art.Id] = struct{}{}
+		}
+		missing := slice.FilterMap[int64, int64](ids, func(idx int, src int64) (int64, bool) {
+			_, ok := found[src]
+			return src, !ok
+		})
+		if err = b.repo.RemoveScores(ctx, board.Name, missing...); err != nil {
+			return err
 		}
-	})
+	}
+	// 名次没变就不发布了，免得各个实例的本地缓存白白重新加载
+	current, err := b.repo.GetTopN(ctx, board.Name)
+	if err == nil && sameIds(current, arts) {
+		return nil
+	}
+	return b.repo.ReplaceTopN(ctx, board.Name, arts)
+}
+
+type rankingScore struct {
+	art   domain.Article
+	score float64
+}
+
+// topN 只扫一遍文章，同时计算所有的热榜，返回值和 boards 一一对应，按分数从高到低
+// 增量热榜会多算一些候选，用来重建 redis 里面的分数
+func (b *BatchRankingService) topN(ctx context.Context) ([][]rankingScore, error) {
+	now := b.now()
+	// 扫描的范围取最大的时间窗口，有一个不限时间的就要扫全部
+	var (
+		oldest    time.Time
+		unbounded bool
+	)
+	queues := make([]*queue.PriorityQueue[rankingScore], len(b.boards))
+	for i, board := range b.boards {
+		size := board.Size
+		if board.Streaming {
+			size = board.streamCapacity()
+		}
+		queues[i] = queue.NewPriorityQueue[rankingScore](size, func(src rankingScore, dst rankingScore) int {
+			if src.score > dst.score {
+				return 1
+			} else if src.score < dst.score {
+				return -1
+			} else {
+				return 0
+			}
+		})
+		if board.Window <= 0 {
+			unbounded = true
+		} else if start := now.Add(-board.Window); oldest.IsZero() || start.Before(oldest) {
+			oldest = start
+		}
+	}
+	if unbounded {
+		oldest = time.Time{}
+	}
+
+	var cursor domain.ArticleCursor
 	for {
-		arts, err := b.artSvc.ListPub(ctx, startTime, offset, b.batchSize)
+		arts, err := b.artSvc.ListPub(ctx, cursor, b.batchSize)
 		if err != nil {
 			return nil, err
 		}
@@ -77,42 +223,87 @@ func (b *BatchRankingService) topN(ctx context.Context, n int) ([]domain.Article
 			return nil, err
 		}
 
-		// 计算score
 		for _, art := range arts {
-			intr, ok := intrs.Intrs[art.Id]
-			if !ok {
-				continue
-			}
-			score := b.scoreFunc(intr.LikeCnt+2, art.UpdatedAt)
-			rankScore := Score{art: art, score: score}
-
-			err := topN.Enqueue(rankScore)
-			if errors.Is(err, queue.ErrOutOfCapacity) {
-				// 队满取出最小元素
-				val, _ := topN.Dequeue()
-				if val.score < score {
-					_ = topN.Enqueue(rankScore)
-				} else {
-					_ = topN.Enqueue(val)
-
+			for i, board := range b.boards {
+				if board.Window > 0 && now.Sub(art.UpdatedAt) > board.Window {
+					continue
 				}
+				// 没有互动数据的按 0 处理
+				score := board.Scorer.Score(intrs.GetIntrs()[art.Id], art.UpdatedAt, now)
+				enqueue(queues[i], rankingScore{art: art, score: score})
 			}
 		}
-		// 判断是否还有剩余
-		if len(arts) < b.batchSize {
+		// 判断是否还有剩余，文章按更新时间倒序，超出时间窗口就不用再往后扫了
+		if len(arts) < b.batchSize ||
+			!oldest.IsZero() && arts[len(arts)-1].UpdatedAt.Before(oldest) {
 			break
 		}
-		offset += b.batchSize
+		cursor = arts[len(arts)-1].Cursor()
 	}
-	res := make([]domain.Article, topN.Len())
-	for i := topN.Len() - 1; i >= 0; i-- {
-		val, err := topN.Dequeue()
+
+	res := make([][]rankingScore, len(queues))
+	for i, q := range queues {
+		res[i] = drain(q)
+	}
+	return res, nil
+}
+
+// enqueue 队满的时候和最小的比较，留下分数高的
+func enqueue(q *queue.PriorityQueue[rankingScore], item rankingScore) {
+	err := q.Enqueue(item)
+	if errors.Is(err, queue.ErrOutOfCapacity) {
+		val, _ := q.Dequeue()
+		if val.score < item.score {
+			_ = q.Enqueue(item)
+		} else {
+			_ = q.Enqueue(val)
+		}
+	}
+}
+
+// drain 按分数从高到低取出来
+func drain(q *queue.PriorityQueue[rankingScore]) []rankingScore {
+	res := make([]rankingScore, q.Len())
+	for i := q.Len() - 1; i >= 0; i-- {
+		val, err := q.Dequeue()
 		if err != nil {
 			// 空队列
 			break
 		}
-		res[i] = val.art
+		res[i] = val
 	}
-	return res, nil
+	return res
+}
+
+func toScoreMap(scores []rankingScore) map[int64]float64 {
+	res := make(map[int64]float64, len(scores))
+	for _, s := range scores {
+		res[s.art.Id] = s.score
+	}
+	return res
+}
 
+func sameIds(a, b []domain.Article) bool {
+	if len(a) != len(b) {
+		return false
+	}
+	for i := range a {
+		if a[i].Id != b[i].Id {
+			return false
+		}
+	}
+	return true
+}
+
+func uniqueIds(ids []int64) []int64 {
+	seen := make(map[int64]struct{}, len(ids))
+	res := make([]int64, 0, len(ids))
+	for _, id := range ids {
+		if _, ok := seen[id]; ok {
+			continue
+		}
+		seen[id] = struct{}{}
+		res = append(res, id)
+	}
+	return res
 }
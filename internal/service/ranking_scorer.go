@@ -0,0 +1,131 @@
+package service
+
+import (
+	"fmt"
+	interactivev1 "github.com/Andras5014/gohub/api/proto/gen/interactive/v1"
+	"math"
+	"time"
+)
+
+const (
+	// ScorerHackerNews 互动数随着时间衰减，适合日榜、周榜
+	ScorerHackerNews = "hacker_news"
+	// ScorerWeighted 只看互动数，适合总榜
+	ScorerWeighted = "weighted"
+	// ScorerDecay 互动数按照半衰期衰减，可以增量计算
+	ScorerDecay = "decay"
+)
+
+// 增量模式下的互动类型，和互动服务发出来的事件一致
+const (
+	RankingEventRead    = "read"
+	RankingEventLike    = "like"
+	RankingEventCollect = "collect"
+)
+
+// Scorer 热榜的打分算法，分数越高越靠前
+type Scorer interface {
+	Score(intr *interactivev1.Interactive, utime time.Time, now time.Time) float64
+}
+
+// StreamScorer 可以增量计算的打分算法，互动事件来了就累加分数，再定期衰减
+type StreamScorer interface {
+	Scorer
+	// Incr 一次互动变化带来的分数变化
+	Incr(typ string, delta int64) float64
+	// HalfLife 分数减半需要的时间，0 表示不衰减
+	HalfLife() time.Duration
+}
+
+// RankingWeights 阅读、点赞、收藏各自的权重
+type RankingWeights struct {
+	Read    float64
+	Like    float64
+	Collect float64
+}
+
+func (w RankingWeights) sum(intr *interactivev1.Interactive) float64 {
+	return w.Read*float64(intr.GetReadCnt()) +
+		w.Like*float64(intr.GetLikeCnt()) +
+		w.Collect*float64(intr.GetCollectCnt())
+}
+
+func (w RankingWeights) incr(typ string, delta int64) float64 {
+	switch typ {
+	case RankingEventRead:
+		return w.Read * float64(delta)
+	case RankingEventLike:
+		return w.Like * float64(delta)
+	case RankingEventCollect:
+		return w.Collect * float64(delta)
+	default:
+		return 0
+	}
+}
+
+// HackerNewsScorer (加权互动数 + 1) / (发表了多少小时 + 2) ^ Gravity
+type HackerNewsScorer struct {
+	Weights RankingWeights
+	Gravity float64
+}
+
+func (h HackerNewsScorer) Score(intr *interactivev1.Interactive, utime time.Time, now time.Time) float64 {
+	hours := math.Max(now.Sub(utime).Hours(), 0)
+	return (h.Weights.sum(intr) + 1) / math.Pow(hours+2, h.Gravity)
+}
+
+type WeightedScorer struct {
+	Weights RankingWeights
+}
+
+func (w WeightedScorer) Score(intr *interactivev1.Interactive, utime time.Time, now time.Time) float64 {
+	return w.Weights.sum(intr)
+}
+
+func (w WeightedScorer) Incr(typ string, delta int64) float64 {
+	return w.Weights.incr(typ, delta)
+}
+
+func (w WeightedScorer) HalfLife() time.Duration {
+	return 0
+}
+
+// DecayScorer 加权互动数 * 0.5 ^ (发表了多久 / 半衰期)
+// 全量计算的时候拿不到每次互动的时间，只能当成都发生在更新的时候，和增量的结果是近似的
+type DecayScorer struct {
+	Weights  RankingWeights
+	HalfTime time.Duration
+}
+
+func (d DecayScorer) Score(intr *interactivev1.Interactive, utime time.Time, now time.Time) float64 {
+	age := math.Max(float64(now.Sub(utime)), 0)
+	return d.Weights.sum(intr) * math.Pow(0.5, age/float64(d.HalfTime))
+}
+
+func (d DecayScorer) Incr(typ string, delta int64) float64 {
+	return d.Weights.incr(typ, delta)
+}
+
+func (d DecayScorer) HalfLife() time.Duration {
+	return d.HalfTime
+}
+
+// NewScorer 按照名字创建打分算法，gravity 只对 hacker_news 有用，halfLife 只对 decay 有用
+func NewScorer(name string, weights RankingWeights, gravity float64, halfLife time.Duration) (Scorer, error) {
+	switch name {
+	case ScorerHackerNews:
+		if gravity <= 0 {
+			gravity = 1.5
+		}
+		return HackerNewsScorer{Weights: weights, Gravity: gravity}, nil
+	case ScorerWeighted:
+		return WeightedScorer{Weights: weights}, nil
+	case ScorerDecay:
+		if halfLife <= 0 {
+			halfLife = time.Hour * 24
+		}
+		return DecayScorer{Weights: weights, HalfTime: halfLife}, nil
+	default:
+		return nil, fmt.Errorf("未知的热榜打分算法 %s", name)
+	}
+}
@@ -2,10 +2,14 @@ package service
 
 import (
 	"context"
+	"errors"
 	interactivev1 "github.com/Andras5014/gohub/api/proto/gen/interactive/v1"
-	domain2 "github.com/Andras5014/gohub/interactive/domain"
+	intrv1mocks "github.com/Andras5014/gohub/api/proto/gen/interactive/v1/mocks"
 	"github.com/Andras5014/gohub/internal/domain"
+	"github.com/Andras5014/gohub/internal/repository"
+	repomocks "github.com/Andras5014/gohub/internal/repository/mocks"
 	svcmocks "github.com/Andras5014/gohub/internal/service/mocks"
+	"github.com/ecodeclub/ekit/slice"
 	"github.com/stretchr/testify/assert"
 	"go.uber.org/mock/gomock"
 	"testing"
@@ -15,64 +19,91 @@ import (
 func TestBatchRankingService_TopN(t *testing.T) {
 	const batchSize = 2
 	now := time.Now()
+	// 只看点赞数，方便算
+	scorer := WeightedScorer{Weights: RankingWeights{Like: 1}}
+	weekly := RankingBoard{Name: "weekly", Window: time.Hour * 48, Size: 2, Scorer: scorer}
+	allTime := RankingBoard{Name: "all_time", Size: 3, Scorer: scorer}
+
+	batch1 := []domain.Article{
+		{Id: 1, UpdatedAt: now},
+		{Id: 2, UpdatedAt: now.Add(-time.Hour)},
+	}
+	batch2 := []domain.Article{
+		{Id: 3, UpdatedAt: now.Add(-time.Hour * 72)},
+		{Id: 4, UpdatedAt: now.Add(-time.Hour * 96)},
+	}
+	intrs1 := &interactivev1.GetByIdsResponse{Intrs: map[int64]*interactivev1.Interactive{
+		1: {LikeCnt: 1},
+		2: {LikeCnt: 5},
+	}}
+	intrs2 := &interactivev1.GetByIdsResponse{Intrs: map[int64]*interactivev1.Interactive{
+		3: {LikeCnt: 10},
+		// 4 没有互动数据
+	}}
+
 	testCases := []struct {
-		name string
+		name   string
+		boards []RankingBoard
 
 		mock func(ctrl *gomock.Controller) (interactivev1.InteractiveServiceClient, ArticleService)
 
-		wantArts []domain.Article
+		wantArts [][]domain.Article
 		wantErr  error
 	}{
 		{
-			name: "成功获取",
+			name:   "多个热榜一起算",
+			boards: []RankingBoard{weekly, allTime},
 			mock: func(ctrl *gomock.Controller) (interactivev1.InteractiveServiceClient, ArticleService) {
-				intrSvc := svcmocks.NewMockInteractiveService(ctrl)
+				intrSvc := intrv1mocks.NewMockInteractiveServiceClient(ctrl)
 				artSvc := svcmocks.NewMockArticleService(ctrl)
-				// 先模拟批量获取数据
-				// 先模拟第一批
-				artSvc.EXPECT().ListPub(gomock.Any(), gomock.Any(), 0, 2).
-					Return([]domain.Article{
-						{Id: 1, UpdatedAt: now},
-						{Id: 2, UpdatedAt: now},
-					}, nil)
-				// 模拟第二批
-				artSvc.EXPECT().ListPub(gomock.Any(), gomock.Any(), 2, 2).
-					Return([]domain.Article{
-						{Id: 3, UpdatedAt: now},
-						{Id: 4, UpdatedAt: now},
-					}, nil)
-				// 模拟第三批
-				artSvc.EXPECT().ListPub(gomock.Any(), gomock.Any(), 4, 2).
-					// 没数据了
-					Return([]domain.Article{}, nil)
-
-				// 第一批的点赞数据
-				intrSvc.EXPECT().GetByIds(gomock.Any(), "article", []int64{1, 2}).
-					Return(map[int64]domain2.Interactive{
-						1: {LikeCnt: 1},
-						2: {LikeCnt: 2},
-					}, nil)
-
-				// 第二批的点赞数据
-				intrSvc.EXPECT().GetByIds(gomock.Any(), "article", []int64{3, 4}).
-					Return(map[int64]domain2.Interactive{
-						3: {LikeCnt: 3},
-						4: {LikeCnt: 4},
-					}, nil)
-
-				// 第三批的点赞数据
-				intrSvc.EXPECT().GetByIds(gomock.Any(), "article", []int64{}).
-					Return(map[int64]domain2.Interactive{}, nil)
+				artSvc.EXPECT().ListPub(gomock.Any(), domain.ArticleCursor{}, batchSize).Return(batch1, nil)
+				artSvc.EXPECT().ListPub(gomock.Any(), batch1[1].Cursor(), batchSize).Return(batch2, nil)
+				// 有不限时间的热榜，要扫到最后
+				artSvc.EXPECT().ListPub(gomock.Any(), batch2[1].Cursor(), batchSize).Return([]domain.Article{}, nil)
 
+				intrSvc.EXPECT().GetByIds(gomock.Any(), &interactivev1.GetByIdsRequest{
+					Biz: "article", BizIds: []int64{1, 2},
+				}).Return(intrs1, nil)
+				intrSvc.EXPECT().GetByIds(gomock.Any(), &interactivev1.GetByIdsRequest{
+					Biz: "article", BizIds: []int64{3, 4},
+				}).Return(intrs2, nil)
+				intrSvc.EXPECT().GetByIds(gomock.Any(), gomock.Any()).
+					Return(&interactivev1.GetByIdsResponse{}, nil)
 				return intrSvc, artSvc
 			},
-
-			wantErr: nil,
-			wantArts: []domain.Article{
-				{Id: 4, UpdatedAt: now},
-				{Id: 3, UpdatedAt: now},
-				{Id: 2, UpdatedAt: now},
+			wantArts: [][]domain.Article{
+				// 3 和 4 超出了时间窗口
+				{batch1[1], batch1[0]},
+				{batch2[0], batch1[1], batch1[0]},
+			},
+		},
+		{
+			name:   "超出时间窗口不再往后扫",
+			boards: []RankingBoard{weekly},
+			mock: func(ctrl *gomock.Controller) (interactivev1.InteractiveServiceClient, ArticleService) {
+				intrSvc := intrv1mocks.NewMockInteractiveServiceClient(ctrl)
+				artSvc := svcmocks.NewMockArticleService(ctrl)
+				artSvc.EXPECT().ListPub(gomock.Any(), domain.ArticleCursor{}, batchSize).Return(batch1, nil)
+				artSvc.EXPECT().ListPub(gomock.Any(), batch1[1].Cursor(), batchSize).Return(batch2, nil)
+				intrSvc.EXPECT().GetByIds(gomock.Any(), gomock.Any()).Return(intrs1, nil)
+				intrSvc.EXPECT().GetByIds(gomock.Any(), gomock.Any()).Return(intrs2, nil)
+				return intrSvc, artSvc
+			},
+			wantArts: [][]domain.Article{
+				{batch1[1], batch1[0]},
+			},
+		},
+		{
+			name:   "查询互动数据失败",
+			boards: []RankingBoard{weekly},
+			mock: func(ctrl *gomock.Controller) (interactivev1.InteractiveServiceClient, ArticleService) {
+				intrSvc := intrv1mocks.NewMockInteractiveServiceClient(ctrl)
+				artSvc := svcmocks.NewMockArticleService(ctrl)
+				artSvc.EXPECT().ListPub(gomock.Any(), domain.ArticleCursor{}, batchSize).Return(batch1, nil)
+				intrSvc.EXPECT().GetByIds(gomock.Any(), gomock.Any()).Return(nil, errors.New("mock error"))
+				return intrSvc, artSvc
 			},
+			wantErr: errors.New("mock error"),
 		},
 	}
 
@@ -85,14 +116,117 @@ func TestBatchRankingService_TopN(t *testing.T) {
 				intrSvc:   intrSvc,
 				artSvc:    artSvc,
 				batchSize: batchSize,
-
-				scoreFunc: func(likeCnt int64, utime time.Time) float64 {
-					return float64(likeCnt)
+				boards:    tc.boards,
+				now: func() time.Time {
+					return now
 				},
 			}
-			arts, err := svc.topN(context.Background(), 3)
+			res, err := svc.topN(context.Background())
 			assert.Equal(t, tc.wantErr, err)
+			if err != nil {
+				return
+			}
+			arts := make([][]domain.Article, len(res))
+			for i, scores := range res {
+				arts[i] = slice.Map[rankingScore, domain.Article](scores, func(idx int, src rankingScore) domain.Article {
+					return src.art
+				})
+			}
 			assert.Equal(t, tc.wantArts, arts)
 		})
 	}
 }
+
+func TestBatchRankingService_RefreshStream(t *testing.T) {
+	realtime := RankingBoard{Name: "realtime", Size: 2, Streaming: true,
+		Scorer: DecayScorer{Weights: RankingWeights{Like: 1}, HalfTime: time.Hour}}
+	weekly := RankingBoard{Name: "weekly", Size: 2, Window: time.Hour,
+		Scorer: WeightedScorer{Weights: RankingWeights{Like: 1}}}
+	testCases := []struct {
+		name string
+		mock func(ctrl *gomock.Controller) (repository.RankingRepository, ArticleService)
+
+		wantErr error
+	}{
+		{
+			name: "名次变了，发布",
+			mock: func(ctrl *gomock.Controller) (repository.RankingRepository, ArticleService) {
+				repo := repomocks.NewMockRankingRepository(ctrl)
+				artSvc := svcmocks.NewMockArticleService(ctrl)
+				// 只处理增量热榜
+				repo.EXPECT().DecayScores(gomock.Any(), "realtime", time.Hour, 20).Return(nil)
+				repo.EXPECT().TopIds(gomock.Any(), "realtime", 2).Return([]int64{2, 1}, nil)
+				artSvc.EXPECT().ListPubByIds(gomock.Any(), []int64{2, 1}).
+					Return([]domain.Article{{Id: 2}, {Id: 1}}, nil)
+				repo.EXPECT().GetTopN(gomock.Any(), "realtime").Return([]domain.Article{{Id: 1}, {Id: 2}}, nil)
+				repo.EXPECT().ReplaceTopN(gomock.Any(), "realtime", []domain.Article{{Id: 2}, {Id: 1}}).Return(nil)
+				return repo, artSvc
+			},
+		},
+		{
+			name: "名次没变，不发布",
+			mock: func(ctrl *gomock.Controller) (repository.RankingRepository, ArticleService) {
+				repo := repomocks.NewMockRankingRepository(ctrl)
+				artSvc := svcmocks.NewMockArticleService(ctrl)
+				repo.EXPECT().DecayScores(gomock.Any(), "realtime", time.Hour, 20).Return(nil)
+				repo.EXPECT().TopIds(gomock.Any(), "realtime", 2).Return([]int64{2, 1}, nil)
+				artSvc.EXPECT().ListPubByIds(gomock.Any(), []int64{2, 1}).
+					Return([]domain.Article{{Id: 2}, {Id: 1}}, nil)
+				repo.EXPECT().GetTopN(gomock.Any(), "realtime").Return([]domain.Article{{Id: 2}, {Id: 1}}, nil)
+				return repo, artSvc
+			},
+		},
+		{
+			name: "撤回了的文章从候选里面去掉",
+			mock: func(ctrl *gomock.Controller) (repository.RankingRepository, ArticleService) {
+				repo := repomocks.NewMockRankingRepository(ctrl)
+				artSvc := svcmocks.NewMockArticleService(ctrl)
+				repo.EXPECT().DecayScores(gomock.Any(), "realtime", time.Hour, 20).Return(nil)
+				repo.EXPECT().TopIds(gomock.Any(), "realtime", 2).Return([]int64{3, 1}, nil)
+				artSvc.EXPECT().ListPubByIds(gomock.Any(), []int64{3, 1}).
+					Return([]domain.Article{{Id: 1}}, nil)
+				repo.EXPECT().RemoveScores(gomock.Any(), "realtime", int64(3)).Return(nil)
+				repo.EXPECT().GetTopN(gomock.Any(), "realtime").Return([]domain.Article{{Id: 3}, {Id: 1}}, nil)
+				repo.EXPECT().ReplaceTopN(gomock.Any(), "realtime", []domain.Article{{Id: 1}}).Return(nil)
+				return repo, artSvc
+			},
+		},
+		{
+			name: "衰减失败",
+			mock: func(ctrl *gomock.Controller) (repository.RankingRepository, ArticleService) {
+				repo := repomocks.NewMockRankingRepository(ctrl)
+				repo.EXPECT().DecayScores(gomock.Any(), "realtime", time.Hour, 20).Return(errors.New("mock error"))
+				return repo, svcmocks.NewMockArticleService(ctrl)
+			},
+			wantErr: errors.New("mock error"),
+		},
+	}
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			ctrl := gomock.NewController(t)
+			defer ctrl.Finish()
+			repo, artSvc := tc.mock(ctrl)
+			svc := NewRankingService(artSvc, nil, repo, []RankingBoard{weekly, realtime})
+			err := svc.RefreshStream(context.Background())
+			assert.Equal(t, tc.wantErr, err)
+		})
+	}
+}
+
+func TestBatchRankingService_IncrScore(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+	repo := repomocks.NewMockRankingRepository(ctrl)
+	weights := RankingWeights{Read: 0.1, Like: 1, Collect: 2}
+	svc := NewRankingService(nil, nil, repo, []RankingBoard{
+		{Name: "weekly", Size: 10, Scorer: HackerNewsScorer{Weights: weights, Gravity: 1.5}},
+		{Name: "realtime", Size: 10, Streaming: true, Scorer: DecayScorer{Weights: weights, HalfTime: time.Hour}},
+		{Name: "all_time", Size: 10, Streaming: true, Scorer: WeightedScorer{Weights: weights}},
+	})
+	// 只累加增量热榜，取消点赞是负数
+	repo.EXPECT().IncrScore(gomock.Any(), "realtime", int64(1), float64(-1)).Return(nil)
+	repo.EXPECT().IncrScore(gomock.Any(), "all_time", int64(1), float64(-1)).Return(nil)
+	assert.NoError(t, svc.IncrScore(context.Background(), 1, RankingEventLike, -1))
+	// 不认识的类型直接忽略
+	assert.NoError(t, svc.IncrScore(context.Background(), 1, "share", 1))
+}
@@ -0,0 +1,76 @@
+package service
+
+import (
+	"context"
+	"errors"
+	"github.com/Andras5014/gohub/internal/domain"
+	"github.com/Andras5014/gohub/internal/repository"
+	"github.com/Andras5014/gohub/internal/repository/article"
+	"time"
+)
+
+var ErrInvalidReadingProgress = errors.New("阅读进度不合法")
+
+// ReadingProgressService 阅读进度，上报的只写缓存，定时任务调 Flush 批量落库
+type ReadingProgressService interface {
+	// Report 只能上报线上的文章
+	Report(ctx context.Context, p domain.ReadingProgress) error
+	// Get 没读过的返回零值
+	Get(ctx context.Context, uid int64, aid int64) (domain.ReadingProgress, error)
+	// ContinueReading 读了一部分还没读完的，按最近阅读时间倒序
+	ContinueReading(ctx context.Context, uid int64, limit int) ([]domain.ReadingProgress, error)
+	// Flush 把缓存里面变了的都写到数据库，返回写了多少条
+	Flush(ctx context.Context) (int, error)
+}
+
+type readingProgressService struct {
+	repo    repository.ReadingProgressRepository
+	artRepo article.Repository
+	// flushBatchSize 落库的时候一批写多少条
+	flushBatchSize int
+}
+
+func NewReadingProgressService(repo repository.ReadingProgressRepository, artRepo article.Repository) ReadingProgressService {
+	return &readingProgressService{
+		repo:           repo,
+		artRepo:        artRepo,
+		flushBatchSize: 200,
+	}
+}
+
+func (s *readingProgressService) Report(ctx context.Context, p domain.ReadingProgress) error {
+	if p.ArticleId <= 0 || p.Paragraph < 0 || p.Percent < 0 || p.Percent > 100 {
+		return ErrInvalidReadingProgress
+	}
+	// 线上库的文章有缓存，这里不会打到数据库
+	art, err := s.artRepo.GetPubById(ctx, p.ArticleId)
+	if err != nil {
+		return err
+	}
+	if art.Status != domain.ArticleStatusPublished {
+		return ErrArticleNotFound
+	}
+	// 客户端的时间不可信，用服务端收到的时间
+	p.Utime = time.Now()
+	return s.repo.Save(ctx, p)
+}
+
+func (s *readingProgressService) Get(ctx context.Context, uid int64, aid int64) (domain.ReadingProgress, error) {
+	return s.repo.Get(ctx, uid, aid)
+}
+
+func (s *readingProgressService) ContinueReading(ctx context.Context, uid int64, limit int) ([]domain.ReadingProgress, error) {
+	return s.repo.ListUnfinished(ctx, uid, limit)
+}
+
+func (s *readingProgressService) Flush(ctx context.Context) (int, error) {
+	total := 0
+	for {
+		// 按取出来的条数判断有没有取完，过期跳过的也算，不然一批里面有过期的就提前停了
+		popped, written, err := s.repo.Flush(ctx, s.flushBatchSize)
+		total += written
+		if err != nil || popped < s.flushBatchSize {
+			return total, err
+		}
+	}
+}
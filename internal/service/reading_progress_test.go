@@ -0,0 +1,92 @@
+package service
+
+import (
+	"context"
+	"errors"
+	"github.com/Andras5014/gohub/internal/domain"
+	"github.com/Andras5014/gohub/internal/repository"
+	"github.com/Andras5014/gohub/internal/repository/article"
+	artrepomocks "github.com/Andras5014/gohub/internal/repository/article/mocks"
+	repomocks "github.com/Andras5014/gohub/internal/repository/mocks"
+	"github.com/stretchr/testify/assert"
+	"go.uber.org/mock/gomock"
+	"testing"
+)
+
+func Test_readingProgressService_Report(t *testing.T) {
+	testCases := []struct {
+		name     string
+		mock     func(ctrl *gomock.Controller) (repository.ReadingProgressRepository, article.Repository)
+		progress domain.ReadingProgress
+
+		wantErr error
+	}{
+		{
+			name: "上报成功",
+			mock: func(ctrl *gomock.Controller) (repository.ReadingProgressRepository, article.Repository) {
+				repo := repomocks.NewMockReadingProgressRepository(ctrl)
+				artRepo := artrepomocks.NewMockRepository(ctrl)
+				artRepo.EXPECT().GetPubById(gomock.Any(), int64(1)).
+					Return(domain.Article{Id: 1, Status: domain.ArticleStatusPublished}, nil)
+				repo.EXPECT().Save(gomock.Any(), gomock.Any()).
+					DoAndReturn(func(ctx context.Context, p domain.ReadingProgress) error {
+						assert.False(t, p.Utime.IsZero())
+						assert.Equal(t, 5, p.Paragraph)
+						assert.Equal(t, 40, p.Percent)
+						return nil
+					})
+				return repo, artRepo
+			},
+			progress: domain.ReadingProgress{Uid: 123, ArticleId: 1, Paragraph: 5, Percent: 40},
+		},
+		{
+			name: "百分比不合法",
+			mock: func(ctrl *gomock.Controller) (repository.ReadingProgressRepository, article.Repository) {
+				return repomocks.NewMockReadingProgressRepository(ctrl), artrepomocks.NewMockRepository(ctrl)
+			},
+			progress: domain.ReadingProgress{Uid: 123, ArticleId: 1, Percent: 101},
+			wantErr:  ErrInvalidReadingProgress,
+		},
+		{
+			name: "文章已经撤回",
+			mock: func(ctrl *gomock.Controller) (repository.ReadingProgressRepository, article.Repository) {
+				artRepo := artrepomocks.NewMockRepository(ctrl)
+				artRepo.EXPECT().GetPubById(gomock.Any(), int64(1)).
+					Return(domain.Article{Id: 1, Status: domain.ArticleStatusPrivate}, nil)
+				return repomocks.NewMockReadingProgressRepository(ctrl), artRepo
+			},
+			progress: domain.ReadingProgress{Uid: 123, ArticleId: 1, Percent: 40},
+			wantErr:  ErrArticleNotFound,
+		},
+	}
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			ctrl := gomock.NewController(t)
+			defer ctrl.Finish()
+			repo, artRepo := tc.mock(ctrl)
+			svc := NewReadingProgressService(repo, artRepo)
+			err := svc.Report(context.Background(), tc.progress)
+			assert.Equal(t, tc.wantErr, err)
+		})
+	}
+}
+
+func Test_readingProgressService_Flush(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+	repo := repomocks.NewMockReadingProgressRepository(ctrl)
+	// 一批取满了就接着取，不满说明取完了，有过期跳过的不影响
+	gomock.InOrder(
+		repo.EXPECT().Flush(gomock.Any(), 200).Return(200, 200, nil),
+		repo.EXPECT().Flush(gomock.Any(), 200).Return(200, 190, nil),
+		repo.EXPECT().Flush(gomock.Any(), 200).Return(15, 15, nil),
+	)
+	svc := NewReadingProgressService(repo, nil)
+	cnt, err := svc.Flush(context.Background())
+	assert.NoError(t, err)
+	assert.Equal(t, 405, cnt)
+
+	repo.EXPECT().Flush(gomock.Any(), 200).Return(200, 0, errors.New("mock db error"))
+	_, err = svc.Flush(context.Background())
+	assert.Equal(t, errors.New("mock db error"), err)
+}
@@ -0,0 +1,78 @@
+package service
+
+import (
+	"context"
+	"errors"
+	"github.com/Andras5014/gohub/internal/domain"
+	"github.com/Andras5014/gohub/internal/repository"
+	"github.com/Andras5014/gohub/internal/repository/article"
+	"github.com/Andras5014/gohub/pkg/logx"
+)
+
+type SearchService interface {
+	SearchArticle(ctx context.Context, q string, offset, limit int) (domain.ArticleSearchResult, error)
+	// RebuildArticleIndex 从线上库全量建索引，进程内的索引启动的时候要调用一次
+	RebuildArticleIndex(ctx context.Context) error
+}
+
+type searchService struct {
+	repo    repository.SearchRepository
+	artRepo article.Repository
+	l       logx.Logger
+}
+
+func NewSearchService(repo repository.SearchRepository, artRepo article.Repository, l logx.Logger) SearchService {
+	return &searchService{
+		repo:    repo,
+		artRepo: artRepo,
+		l:       l,
+	}
+}
+
+func (s *searchService) SearchArticle(ctx context.Context, q string, offset, limit int) (domain.ArticleSearchResult, error) {
+	return s.repo.SearchArticle(ctx, q, offset, limit)
+}
+
+func (s *searchService) RebuildArticleIndex(ctx context.Context) error {
+	const batchSize = 100
+	var cursor domain.ArticleCursor
+	cnt := 0
+	for {
+		arts, err := s.artRepo.ListPub(ctx, cursor, batchSize)
+		if err != nil {
+			return err
+		}
+		for _, art := range arts {
+			// 列表不一定带正文，比如正文放在对象存储的时候
+			if art.Content == "" {
+				art, err = s.fullArticle(ctx, art)
+				if err != nil {
+					return err
+				}
+			}
+			if err = s.repo.InputArticle(ctx, art); err != nil {
+				return err
+			}
+		}
+		if len(arts) < batchSize {
+			s.l.Info("文章索引重建完成", logx.Int64("cnt", int64(cnt+len(arts))))
+			return nil
+		}
+		cnt += len(arts)
+		cursor = arts[len(arts)-1].Cursor()
+	}
+}
+
+// fullArticle 从详情里面补上正文
+func (s *searchService) fullArticle(ctx context.Context, art domain.Article) (domain.Article, error) {
+	pub, err := s.artRepo.GetPubById(ctx, art.Id)
+	switch {
+	case errors.Is(err, article.ErrArticleNotFound):
+		// 刚好被撤回了，撤回事件会把它从索引里面删掉
+		return art, nil
+	case err != nil:
+		return domain.Article{}, err
+	}
+	art.Content = pub.Content
+	return art, nil
+}
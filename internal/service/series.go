@@ -0,0 +1,211 @@
+package service
+
+import (
+	"context"
+	"errors"
+	"github.com/Andras5014/gohub/internal/domain"
+	"github.com/Andras5014/gohub/internal/repository/article"
+	"strings"
+	"unicode/utf8"
+)
+
+var (
+	ErrSeriesNotFound      = article.ErrSeriesNotFound
+	ErrArticleInSeries     = article.ErrArticleInSeries
+	ErrSeriesOrderMismatch = article.ErrSeriesOrderMismatch
+	ErrInvalidSeries       = errors.New("系列标题或者简介不合法")
+)
+
+const (
+	maxSeriesTitleLength       = 100
+	maxSeriesDescriptionLength = 500
+)
+
+// SeriesService 作者管理自己的系列，只能把自己的文章放进去
+type SeriesService interface {
+	Create(ctx context.Context, s domain.Series) (int64, error)
+	Update(ctx context.Context, s domain.Series) error
+	Delete(ctx context.Context, id, uid int64) error
+	// Get 带上系列里面的全部文章，包括还没有发表的
+	Get(ctx context.Context, id, uid int64) (domain.Series, error)
+	List(ctx context.Context, uid int64, offset int, limit int) ([]domain.Series, error)
+
+	AddArticle(ctx context.Context, seriesId, artId, uid int64) error
+	RemoveArticle(ctx context.Context, seriesId, artId, uid int64) error
+	// Reorder artIds 是调整之后的完整顺序
+	Reorder(ctx context.Context, seriesId, uid int64, artIds []int64) error
+
+	// Nav 读者看文章的时候的上一篇和下一篇
+	Nav(ctx context.Context, artId int64) (domain.SeriesNav, error)
+}
+
+type seriesService struct {
+	repo    article.SeriesRepository
+	artRepo article.Repository
+}
+
+func NewSeriesService(repo article.SeriesRepository, artRepo article.Repository) SeriesService {
+	return &seriesService{
+		repo:    repo,
+		artRepo: artRepo,
+	}
+}
+
+func (s *seriesService) Create(ctx context.Context, series domain.Series) (int64, error) {
+	series, err := normalizeSeries(series)
+	if err != nil {
+		return 0, err
+	}
+	return s.repo.Create(ctx, series)
+}
+
+func (s *seriesService) Update(ctx context.Context, series domain.Series) error {
+	series, err := normalizeSeries(series)
+	if err != nil {
+		return err
+	}
+	if _, err = s.owned(ctx, series.Id, series.AuthorId); err != nil {
+		return err
+	}
+	return s.repo.Update(ctx, series)
+}
+
+func (s *seriesService) Delete(ctx context.Context, id, uid int64) error {
+	if _, err := s.owned(ctx, id, uid); err != nil {
+		return err
+	}
+	return s.repo.Delete(ctx, id, uid)
+}
+
+func (s *seriesService) Get(ctx context.Context, id, uid int64) (domain.Series, error) {
+	series, err := s.owned(ctx, id, uid)
+	if err != nil {
+		return domain.Series{}, err
+	}
+	ids, err := s.repo.ArticleIds(ctx, id)
+	if err != nil {
+		return domain.Series{}, err
+	}
+	series.Articles = make([]domain.Article, 0, len(ids))
+	for _, artId := range ids {
+		art, er := s.artRepo.GetById(ctx, artId)
+		// 彻底删除的文章跳过
+		if errors.Is(er, ErrArticleNotFound) {
+			continue
+		}
+		if er != nil {
+			return domain.Series{}, er
+		}
+		series.Articles = append(series.Articles, art)
+	}
+	return series, nil
+}
+
+func (s *seriesService) List(ctx context.Context, uid int64, offset int, limit int) ([]domain.Series, error) {
+	return s.repo.ListByAuthor(ctx, uid, offset, limit)
+}
+
+func (s *seriesService) AddArticle(ctx context.Context, seriesId, artId, uid int64) error {
+	if _, err := s.owned(ctx, seriesId, uid); err != nil {
+		return err
+	}
+	// 协作者也不能把别人的文章放进自己的系列
+	art, err := s.artRepo.GetById(ctx, artId)
+	if err != nil {
+		return err
+	}
+	if art.Author.Id != uid {
+		return ErrArticlePermissionDenied
+	}
+	return s.repo.AddArticle(ctx, seriesId, artId)
+}
+
+func (s *seriesService) RemoveArticle(ctx context.Context, seriesId, artId, uid int64) error {
+	if _, err := s.owned(ctx, seriesId, uid); err != nil {
+		return err
+	}
+	return s.repo.RemoveArticle(ctx, seriesId, artId)
+}
+
+func (s *seriesService) Reorder(ctx context.Context, seriesId, uid int64, artIds []int64) error {
+	if _, err := s.owned(ctx, seriesId, uid); err != nil {
+		return err
+	}
+	return s.repo.Reorder(ctx, seriesId, artIds)
+}
+
+func (s *seriesService) Nav(ctx context.Context, artId int64) (domain.SeriesNav, error) {
+	seriesId, err := s.repo.SeriesIdOf(ctx, artId)
+	if err != nil || seriesId == 0 {
+		return domain.SeriesNav{}, err
+	}
+	series, err := s.repo.GetById(ctx, seriesId)
+	if err != nil {
+		return domain.SeriesNav{}, err
+	}
+	ids, err := s.repo.ArticleIds(ctx, seriesId)
+	if err != nil {
+		return domain.SeriesNav{}, err
+	}
+	pos := -1
+	for i, id := range ids {
+		if id == artId {
+			pos = i
+			break
+		}
+	}
+	nav := domain.SeriesNav{
+		Series: domain.Series{Id: series.Id, Title: series.Title},
+	}
+	if pos < 0 {
+		return nav, nil
+	}
+	// 前后的文章一次查出来，不用一篇一篇地查
+	others := make([]int64, 0, len(ids)-1)
+	others = append(append(others, ids[:pos]...), ids[pos+1:]...)
+	pubs, err := s.artRepo.ListPubByIds(ctx, others)
+	if err != nil {
+		return domain.SeriesNav{}, err
+	}
+	titles := make(map[int64]string, len(pubs))
+	for _, art := range pubs {
+		titles[art.Id] = art.Title
+	}
+	// 没有发表的跳过，接着往前往后找
+	for i := pos - 1; i >= 0; i-- {
+		if title, ok := titles[ids[i]]; ok {
+			nav.Prev = domain.Article{Id: ids[i], Title: title}
+			break
+		}
+	}
+	for i := pos + 1; i < len(ids); i++ {
+		if title, ok := titles[ids[i]]; ok {
+			nav.Next = domain.Article{Id: ids[i], Title: title}
+			break
+		}
+	}
+	return nav, nil
+}
+
+// owned 别人的系列当成不存在
+func (s *seriesService) owned(ctx context.Context, id, uid int64) (domain.Series, error) {
+	series, err := s.repo.GetById(ctx, id)
+	if err != nil {
+		return domain.Series{}, err
+	}
+	if series.AuthorId != uid {
+		return domain.Series{}, ErrSeriesNotFound
+	}
+	return series, nil
+}
+
+func normalizeSeries(series domain.Series) (domain.Series, error) {
+	series.Title = strings.TrimSpace(series.Title)
+	series.Description = strings.TrimSpace(series.Description)
+	if series.Title == "" ||
+		utf8.RuneCountInString(series.Title) > maxSeriesTitleLength ||
+		utf8.RuneCountInString(series.Description) > maxSeriesDescriptionLength {
+		return domain.Series{}, ErrInvalidSeries
+	}
+	return series, nil
+}
@@ -0,0 +1,215 @@
+package service
+
+import (
+	"context"
+	"github.com/Andras5014/gohub/internal/domain"
+	"github.com/Andras5014/gohub/internal/repository/article"
+	artrepomocks "github.com/Andras5014/gohub/internal/repository/article/mocks"
+	"github.com/stretchr/testify/assert"
+	"go.uber.org/mock/gomock"
+	"strings"
+	"testing"
+)
+
+func Test_seriesService_Create(t *testing.T) {
+	testCases := []struct {
+		name   string
+		mock   func(ctrl *gomock.Controller) article.SeriesRepository
+		series domain.Series
+
+		wantId  int64
+		wantErr error
+	}{
+		{
+			name: "创建成功，去掉首尾空白",
+			mock: func(ctrl *gomock.Controller) article.SeriesRepository {
+				repo := artrepomocks.NewMockSeriesRepository(ctrl)
+				repo.EXPECT().Create(gomock.Any(), domain.Series{
+					AuthorId: 123,
+					Title:    "Go 入门",
+				}).Return(int64(1), nil)
+				return repo
+			},
+			series: domain.Series{AuthorId: 123, Title: "  Go 入门 "},
+			wantId: 1,
+		},
+		{
+			name: "标题为空",
+			mock: func(ctrl *gomock.Controller) article.SeriesRepository {
+				return artrepomocks.NewMockSeriesRepository(ctrl)
+			},
+			series:  domain.Series{AuthorId: 123, Title: "   "},
+			wantErr: ErrInvalidSeries,
+		},
+		{
+			name: "简介太长",
+			mock: func(ctrl *gomock.Controller) article.SeriesRepository {
+				return artrepomocks.NewMockSeriesRepository(ctrl)
+			},
+			series: domain.Series{
+				AuthorId:    123,
+				Title:       "Go 入门",
+				Description: strings.Repeat("长", maxSeriesDescriptionLength+1),
+			},
+			wantErr: ErrInvalidSeries,
+		},
+	}
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			ctrl := gomock.NewController(t)
+			defer ctrl.Finish()
+			svc := NewSeriesService(tc.mock(ctrl), nil)
+			id, err := svc.Create(context.Background(), tc.series)
+			assert.Equal(t, tc.wantErr, err)
+			assert.Equal(t, tc.wantId, id)
+		})
+	}
+}
+
+func Test_seriesService_AddArticle(t *testing.T) {
+	testCases := []struct {
+		name string
+		mock func(ctrl *gomock.Controller) (article.SeriesRepository, article.Repository)
+
+		wantErr error
+	}{
+		{
+			name: "添加成功",
+			mock: func(ctrl *gomock.Controller) (article.SeriesRepository, article.Repository) {
+				repo := artrepomocks.NewMockSeriesRepository(ctrl)
+				artRepo := artrepomocks.NewMockRepository(ctrl)
+				repo.EXPECT().GetById(gomock.Any(), int64(1)).
+					Return(domain.Series{Id: 1, AuthorId: 123}, nil)
+				artRepo.EXPECT().GetById(gomock.Any(), int64(10)).
+					Return(domain.Article{Id: 10, Author: domain.Author{Id: 123}}, nil)
+				repo.EXPECT().AddArticle(gomock.Any(), int64(1), int64(10)).Return(nil)
+				return repo, artRepo
+			},
+		},
+		{
+			name: "别人的系列",
+			mock: func(ctrl *gomock.Controller) (article.SeriesRepository, article.Repository) {
+				repo := artrepomocks.NewMockSeriesRepository(ctrl)
+				repo.EXPECT().GetById(gomock.Any(), int64(1)).
+					Return(domain.Series{Id: 1, AuthorId: 456}, nil)
+				return repo, artrepomocks.NewMockRepository(ctrl)
+			},
+			wantErr: ErrSeriesNotFound,
+		},
+		{
+			name: "别人的文章",
+			mock: func(ctrl *gomock.Controller) (article.SeriesRepository, article.Repository) {
+				repo := artrepomocks.NewMockSeriesRepository(ctrl)
+				artRepo := artrepomocks.NewMockRepository(ctrl)
+				repo.EXPECT().GetById(gomock.Any(), int64(1)).
+					Return(domain.Series{Id: 1, AuthorId: 123}, nil)
+				artRepo.EXPECT().GetById(gomock.Any(), int64(10)).
+					Return(domain.Article{Id: 10, Author: domain.Author{Id: 456}}, nil)
+				return repo, artRepo
+			},
+			wantErr: ErrArticlePermissionDenied,
+		},
+		{
+			name: "文章已经在别的系列里面",
+			mock: func(ctrl *gomock.Controller) (article.SeriesRepository, article.Repository) {
+				repo := artrepomocks.NewMockSeriesRepository(ctrl)
+				artRepo := artrepomocks.NewMockRepository(ctrl)
+				repo.EXPECT().GetById(gomock.Any(), int64(1)).
+					Return(domain.Series{Id: 1, AuthorId: 123}, nil)
+				artRepo.EXPECT().GetById(gomock.Any(), int64(10)).
+					Return(domain.Article{Id: 10, Author: domain.Author{Id: 123}}, nil)
+				repo.EXPECT().AddArticle(gomock.Any(), int64(1), int64(10)).
+					Return(ErrArticleInSeries)
+				return repo, artRepo
+			},
+			wantErr: ErrArticleInSeries,
+		},
+	}
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			ctrl := gomock.NewController(t)
+			defer ctrl.Finish()
+			repo, artRepo := tc.mock(ctrl)
+			svc := NewSeriesService(repo, artRepo)
+			err := svc.AddArticle(context.Background(), 1, 10, 123)
+			assert.Equal(t, tc.wantErr, err)
+		})
+	}
+}
+
+func Test_seriesService_Nav(t *testing.T) {
+	testCases := []struct {
+		name  string
+		mock  func(ctrl *gomock.Controller) (article.SeriesRepository, article.Repository)
+		artId int64
+
+		wantNav domain.SeriesNav
+		wantErr error
+	}{
+		{
+			name: "不在系列里面",
+			mock: func(ctrl *gomock.Controller) (article.SeriesRepository, article.Repository) {
+				repo := artrepomocks.NewMockSeriesRepository(ctrl)
+				repo.EXPECT().SeriesIdOf(gomock.Any(), int64(10)).Return(int64(0), nil)
+				return repo, artrepomocks.NewMockRepository(ctrl)
+			},
+			artId: 10,
+		},
+		{
+			name: "跳过没有发表的文章",
+			mock: func(ctrl *gomock.Controller) (article.SeriesRepository, article.Repository) {
+				repo := artrepomocks.NewMockSeriesRepository(ctrl)
+				artRepo := artrepomocks.NewMockRepository(ctrl)
+				repo.EXPECT().SeriesIdOf(gomock.Any(), int64(12)).Return(int64(1), nil)
+				repo.EXPECT().GetById(gomock.Any(), int64(1)).
+					Return(domain.Series{Id: 1, AuthorId: 123, Title: "Go 入门"}, nil)
+				repo.EXPECT().ArticleIds(gomock.Any(), int64(1)).
+					Return([]int64{10, 11, 12, 13, 14}, nil)
+				// 11 撤回了，13 还没有发表过，一次查出来
+				artRepo.EXPECT().ListPubByIds(gomock.Any(), []int64{10, 11, 13, 14}).
+					Return([]domain.Article{
+						{Id: 10, Title: "第一篇", Status: domain.ArticleStatusPublished},
+						{Id: 14, Title: "第五篇", Status: domain.ArticleStatusPublished},
+					}, nil)
+				return repo, artRepo
+			},
+			artId: 12,
+			wantNav: domain.SeriesNav{
+				Series: domain.Series{Id: 1, Title: "Go 入门"},
+				Prev:   domain.Article{Id: 10, Title: "第一篇"},
+				Next:   domain.Article{Id: 14, Title: "第五篇"},
+			},
+		},
+		{
+			name: "第一篇没有上一篇",
+			mock: func(ctrl *gomock.Controller) (article.SeriesRepository, article.Repository) {
+				repo := artrepomocks.NewMockSeriesRepository(ctrl)
+				artRepo := artrepomocks.NewMockRepository(ctrl)
+				repo.EXPECT().SeriesIdOf(gomock.Any(), int64(10)).Return(int64(1), nil)
+				repo.EXPECT().GetById(gomock.Any(), int64(1)).
+					Return(domain.Series{Id: 1, AuthorId: 123, Title: "Go 入门"}, nil)
+				repo.EXPECT().ArticleIds(gomock.Any(), int64(1)).
+					Return([]int64{10, 11}, nil)
+				artRepo.EXPECT().ListPubByIds(gomock.Any(), []int64{11}).
+					Return([]domain.Article{{Id: 11, Title: "第二篇", Status: domain.ArticleStatusPublished}}, nil)
+				return repo, artRepo
+			},
+			artId: 10,
+			wantNav: domain.SeriesNav{
+				Series: domain.Series{Id: 1, Title: "Go 入门"},
+				Next:   domain.Article{Id: 11, Title: "第二篇"},
+			},
+		},
+	}
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			ctrl := gomock.NewController(t)
+			defer ctrl.Finish()
+			repo, artRepo := tc.mock(ctrl)
+			svc := NewSeriesService(repo, artRepo)
+			nav, err := svc.Nav(context.Background(), tc.artId)
+			assert.Equal(t, tc.wantErr, err)
+			assert.Equal(t, tc.wantNav, nav)
+		})
+	}
+}
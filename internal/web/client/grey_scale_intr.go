@@ -38,6 +38,34 @@ func (g *GreyScaleInteractiveServiceClient) Collect(ctx context.Context, in *int
 	return g.client().Collect(ctx, in, opts...)
 }
 
+func (g *GreyScaleInteractiveServiceClient) Uncollect(ctx context.Context, in *interactivev1.UncollectRequest, opts ...grpc.CallOption) (*interactivev1.UncollectResponse, error) {
+	return g.client().Uncollect(ctx, in, opts...)
+}
+
+func (g *GreyScaleInteractiveServiceClient) MoveCollectionItem(ctx context.Context, in *interactivev1.MoveCollectionItemRequest, opts ...grpc.CallOption) (*interactivev1.MoveCollectionItemResponse, error) {
+	return g.client().MoveCollectionItem(ctx, in, opts...)
+}
+
+func (g *GreyScaleInteractiveServiceClient) CreateCollection(ctx context.Context, in *interactivev1.CreateCollectionRequest, opts ...grpc.CallOption) (*interactivev1.CreateCollectionResponse, error) {
+	return g.client().CreateCollection(ctx, in, opts...)
+}
+
+func (g *GreyScaleInteractiveServiceClient) UpdateCollection(ctx context.Context, in *interactivev1.UpdateCollectionRequest, opts ...grpc.CallOption) (*interactivev1.UpdateCollectionResponse, error) {
+	return g.client().UpdateCollection(ctx, in, opts...)
+}
+
+func (g *GreyScaleInteractiveServiceClient) DeleteCollection(ctx context.Context, in *interactivev1.DeleteCollectionRequest, opts ...grpc.CallOption) (*interactivev1.DeleteCollectionResponse, error) {
+	return g.client().DeleteCollection(ctx, in, opts...)
+}
+
+func (g *GreyScaleInteractiveServiceClient) ListCollections(ctx context.Context, in *interactivev1.ListCollectionsRequest, opts ...grpc.CallOption) (*interactivev1.ListCollectionsResponse, error) {
+	return g.client().ListCollections(ctx, in, opts...)
+}
+
+func (g *GreyScaleInteractiveServiceClient) ListCollectionItems(ctx context.Context, in *interactivev1.ListCollectionItemsRequest, opts ...grpc.CallOption) (*interactivev1.ListCollectionItemsResponse, error) {
+	return g.client().ListCollectionItems(ctx, in, opts...)
+}
+
 func (g *GreyScaleInteractiveServiceClient) Get(ctx context.Context, in *interactivev1.GetRequest, opts ...grpc.CallOption) (*interactivev1.GetResponse, error) {
 	return g.client().Get(ctx, in, opts...)
 }
@@ -4,7 +4,9 @@ import (
 	"context"
 	interactivev1 "github.com/Andras5014/gohub/api/proto/gen/interactive/v1"
 	"github.com/Andras5014/gohub/interactive/domain"
+	intrgrpc "github.com/Andras5014/gohub/interactive/grpc"
 	"github.com/Andras5014/gohub/interactive/service"
+	"github.com/ecodeclub/ekit/slice"
 	"google.golang.org/grpc"
 )
 
@@ -45,11 +47,75 @@ func (i *InteractiveServiceAdapter) CancelLike(ctx context.Context, in *interact
 func (i *InteractiveServiceAdapter) Collect(ctx context.Context, in *interactivev1.CollectRequest, opts ...grpc.CallOption) (*interactivev1.CollectResponse, error) {
 	err := i.svc.Collect(ctx, in.Biz, in.BizId, in.Cid, in.Uid)
 	if err != nil {
-		return nil, err
+		return nil, intrgrpc.ToStatus(err)
 	}
 	return &interactivev1.CollectResponse{}, nil
 }
 
+func (i *InteractiveServiceAdapter) Uncollect(ctx context.Context, in *interactivev1.UncollectRequest, opts ...grpc.CallOption) (*interactivev1.UncollectResponse, error) {
+	err := i.svc.Uncollect(ctx, in.Biz, in.BizId, in.Cid, in.Uid)
+	if err != nil {
+		return nil, intrgrpc.ToStatus(err)
+	}
+	return &interactivev1.UncollectResponse{}, nil
+}
+
+func (i *InteractiveServiceAdapter) MoveCollectionItem(ctx context.Context, in *interactivev1.MoveCollectionItemRequest, opts ...grpc.CallOption) (*interactivev1.MoveCollectionItemResponse, error) {
+	err := i.svc.MoveCollectionItem(ctx, in.Biz, in.BizId, in.FromCid, in.ToCid, in.Uid)
+	if err != nil {
+		return nil, intrgrpc.ToStatus(err)
+	}
+	return &interactivev1.MoveCollectionItemResponse{}, nil
+}
+
+func (i *InteractiveServiceAdapter) CreateCollection(ctx context.Context, in *interactivev1.CreateCollectionRequest, opts ...grpc.CallOption) (*interactivev1.CreateCollectionResponse, error) {
+	id, err := i.svc.CreateCollection(ctx, intrgrpc.CollectionToDomain(in.GetCollection()))
+	if err != nil {
+		return nil, intrgrpc.ToStatus(err)
+	}
+	return &interactivev1.CreateCollectionResponse{Id: id}, nil
+}
+
+func (i *InteractiveServiceAdapter) UpdateCollection(ctx context.Context, in *interactivev1.UpdateCollectionRequest, opts ...grpc.CallOption) (*interactivev1.UpdateCollectionResponse, error) {
+	err := i.svc.UpdateCollection(ctx, intrgrpc.CollectionToDomain(in.GetCollection()))
+	if err != nil {
+		return nil, intrgrpc.ToStatus(err)
+	}
+	return &interactivev1.UpdateCollectionResponse{}, nil
+}
+
+func (i *InteractiveServiceAdapter) DeleteCollection(ctx context.Context, in *interactivev1.DeleteCollectionRequest, opts ...grpc.CallOption) (*interactivev1.DeleteCollectionResponse, error) {
+	err := i.svc.DeleteCollection(ctx, in.Id, in.Uid)
+	if err != nil {
+		return nil, intrgrpc.ToStatus(err)
+	}
+	return &interactivev1.DeleteCollectionResponse{}, nil
+}
+
+func (i *InteractiveServiceAdapter) ListCollections(ctx context.Context, in *interactivev1.ListCollectionsRequest, opts ...grpc.CallOption) (*interactivev1.ListCollectionsResponse, error) {
+	res, err := i.svc.ListCollections(ctx, in.Uid, in.ViewerUid, int(in.Offset), int(in.Limit))
+	if err != nil {
+		return nil, intrgrpc.ToStatus(err)
+	}
+	return &interactivev1.ListCollectionsResponse{
+		Collections: slice.Map(res, func(idx int, src domain.Collection) *interactivev1.Collection {
+			return intrgrpc.CollectionToDTO(src)
+		}),
+	}, nil
+}
+
+func (i *InteractiveServiceAdapter) ListCollectionItems(ctx context.Context, in *interactivev1.ListCollectionItemsRequest, opts ...grpc.CallOption) (*interactivev1.ListCollectionItemsResponse, error) {
+	res, err := i.svc.ListCollectionItems(ctx, in.Cid, in.ViewerUid, int(in.Offset), int(in.Limit))
+	if err != nil {
+		return nil, intrgrpc.ToStatus(err)
+	}
+	return &interactivev1.ListCollectionItemsResponse{
+		Items: slice.Map(res, func(idx int, src domain.CollectionItem) *interactivev1.CollectionItem {
+			return intrgrpc.CollectionItemToDTO(src)
+		}),
+	}, nil
+}
+
 func (i *InteractiveServiceAdapter) Get(ctx context.Context, in *interactivev1.GetRequest, opts ...grpc.CallOption) (*interactivev1.GetResponse, error) {
 	intr, err := i.svc.Get(ctx, in.Biz, in.BizId, in.Uid)
 	if err != nil {
@@ -87,5 +153,6 @@ func (i *InteractiveServiceAdapter) toDTO(intr domain.Interactive) *interactivev
 		LikeCnt:    intr.LikeCnt,
 		Liked:      intr.Liked,
 		ReadCnt:    intr.ReadCnt,
+		CommentCnt: intr.CommentCnt,
 	}
 }
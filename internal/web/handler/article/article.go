@@ -1,9 +1,11 @@
 package article
 
 import (
+	"context"
 	"errors"
 	interactivev1 "github.com/Andras5014/gohub/api/proto/gen/interactive/v1"
 	"github.com/Andras5014/gohub/internal/domain"
+	"github.com/Andras5014/gohub/internal/errs"
 	"github.com/Andras5014/gohub/internal/service"
 	"github.com/Andras5014/gohub/internal/web/handler"
 	"github.com/Andras5014/gohub/internal/web/result"
@@ -19,19 +21,26 @@ import (
 var _ handler.Handler = &Handler{}
 
 type Handler struct {
-	svc    service.ArticleService
-	logger logx.Logger
+	svc         service.ArticleService
+	seriesSvc   service.SeriesService
+	followSvc   service.FollowService
+	progressSvc service.ReadingProgressService
+	logger      logx.Logger
 
 	intrSvc interactivev1.InteractiveServiceClient
 	biz     string
 }
 
-func NewArticleHandler(svc service.ArticleService, intrSvc interactivev1.InteractiveServiceClient, logger logx.Logger) *Handler {
+func NewArticleHandler(svc service.ArticleService, seriesSvc service.SeriesService, followSvc service.FollowService,
+	progressSvc service.ReadingProgressService, intrSvc interactivev1.InteractiveServiceClient, logger logx.Logger) *Handler {
 	return &Handler{
-		svc:     svc,
-		intrSvc: intrSvc,
-		logger:  logger,
-		biz:     "article",
+		svc:         svc,
+		seriesSvc:   seriesSvc,
+		followSvc:   followSvc,
+		progressSvc: progressSvc,
+		intrSvc:     intrSvc,
+		logger:      logger,
+		biz:         "article",
 	}
 }
 func (h *Handler) RegisterRoutes(engine *gin.Engine) {
@@ -41,14 +50,61 @@ func (h *Handler) RegisterRoutes(engine *gin.Engine) {
 		ug.POST("/edit", h.Edit)
 		ug.POST("/publish", h.Publish)
 		ug.POST("/withdraw", h.Withdraw)
+		// 定时发表/撤回
+		ug.POST("/publish/schedule", ginx.WrapBody(h.logger, h.SchedulePublish))
+		ug.POST("/withdraw/schedule", ginx.WrapBody(h.logger, h.ScheduleWithdraw))
+		ug.POST("/schedule/cancel", ginx.WrapBody(h.logger, h.CancelSchedule))
 		ug.POST("/list", ginx.WrapBody(h.logger, h.List))
+		// 回收站
+		ug.POST("/delete", ginx.WrapBody(h.logger, h.Delete))
+		ug.POST("/restore", ginx.WrapBody(h.logger, h.Restore))
+		ug.POST("/trash", ginx.WrapBody(h.logger, h.Trash))
 		ug.GET("/detail/:id", ginx.Wrap(h.logger, h.Detail))
+
+		// 历史版本
+		ug.GET("/:id/revisions", ginx.Wrap(h.logger, h.Revisions))
+		ug.GET("/:id/revisions/diff", ginx.Wrap(h.logger, h.DiffRevisions))
+		ug.GET("/:id/revisions/:rid", ginx.Wrap(h.logger, h.RevisionDetail))
+		ug.POST("/:id/revisions/:rid/restore", ginx.Wrap(h.logger, h.RestoreRevision))
+
+		// 协作者
+		ug.POST("/collaborators/invite", ginx.WrapBody(h.logger, h.InviteCollaborator))
+		ug.POST("/collaborators/remove", ginx.WrapBody(h.logger, h.RemoveCollaborator))
+		ug.GET("/:id/collaborators", ginx.Wrap(h.logger, h.Collaborators))
+		ug.POST("/invitations/accept", ginx.WrapBody(h.logger, h.AcceptInvitation))
+		ug.POST("/invitations/decline", ginx.WrapBody(h.logger, h.DeclineInvitation))
+		ug.POST("/collaborations", ginx.WrapBody(h.logger, h.Collaborations))
+
+		// 系列
+		ug.POST("/series/create", ginx.WrapBody(h.logger, h.CreateSeries))
+		ug.POST("/series/edit", ginx.WrapBody(h.logger, h.EditSeries))
+		ug.POST("/series/delete", ginx.WrapBody(h.logger, h.DeleteSeries))
+		ug.POST("/series/list", ginx.WrapBody(h.logger, h.ListSeries))
+		ug.GET("/series/:id", ginx.Wrap(h.logger, h.SeriesDetail))
+		ug.POST("/series/articles/add", ginx.WrapBody(h.logger, h.AddSeriesArticle))
+		ug.POST("/series/articles/remove", ginx.WrapBody(h.logger, h.RemoveSeriesArticle))
+		ug.POST("/series/articles/reorder", ginx.WrapBody(h.logger, h.ReorderSeries))
 	}
 
 	pub := engine.Group("/pub")
 	{
 		pub.GET("/:id", ginx.Wrap(h.logger, h.PubDetail))
+		pub.GET("/tags", ginx.Wrap(h.logger, h.Tags))
+		pub.GET("/tags/:tag", ginx.Wrap(h.logger, h.PubListByTag))
 		pub.POST("/like", ginx.WrapBody(h.logger, h.Like))
+		pub.POST("/collect", ginx.WrapBody(h.logger, h.Collect))
+		pub.POST("/uncollect", ginx.WrapBody(h.logger, h.Uncollect))
+		pub.POST("/collect/move", ginx.WrapBody(h.logger, h.MoveCollect))
+	}
+
+	// 收藏夹
+	cg := engine.Group("/collections")
+	{
+		cg.POST("/create", ginx.WrapBody(h.logger, h.CreateCollection))
+		cg.POST("/edit", ginx.WrapBody(h.logger, h.EditCollection))
+		cg.POST("/delete", ginx.WrapBody(h.logger, h.DeleteCollection))
+		cg.POST("/list", ginx.WrapBody(h.logger, h.ListCollections))
+		cg.POST("/items", ginx.WrapBody(h.logger, h.CollectionItems))
 	}
 }
 
@@ -63,6 +119,18 @@ func (h *Handler) Edit(ctx *gin.Context) {
 	//aid := ctx.MustGet("userId").(int64)
 	authorId := ctx.GetInt64("userId")
 	id, err := h.svc.Save(ctx, req.toDomain(authorId))
+	if errors.Is(err, service.ErrArticleVersionConflict) {
+		ctx.JSON(http.StatusOK, h.versionConflict(ctx, req.Id))
+		return
+	}
+	if errors.Is(err, service.ErrInvalidTags) {
+		ctx.JSON(http.StatusOK, invalidTags())
+		return
+	}
+	if errors.Is(err, service.ErrArticlePermissionDenied) {
+		ctx.JSON(http.StatusOK, h.authorErrResult(err))
+		return
+	}
 	if err != nil {
 		ctx.JSON(http.StatusOK, result.Result{
 			Code: 5,
@@ -85,7 +153,28 @@ func (h *Handler) Publish(ctx *gin.Context) {
 	// 检测输入
 	//aid := ctx.MustGet("userId").(int64)
 	authorId := ctx.GetInt64("userId")
-	id, err := h.svc.Publish(ctx, req.toDomain(authorId))
+	id, status, err := h.svc.Publish(ctx, req.toDomain(authorId))
+	var sensitiveErr *service.SensitiveContentError
+	if errors.As(err, &sensitiveErr) {
+		ctx.JSON(http.StatusOK, result.Result{
+			Code: errs.ArticleSensitiveContent,
+			Msg:  "内容包含敏感词",
+			Data: sensitiveErr.Words,
+		})
+		return
+	}
+	if errors.Is(err, service.ErrArticleVersionConflict) {
+		ctx.JSON(http.StatusOK, h.versionConflict(ctx, req.Id))
+		return
+	}
+	if errors.Is(err, service.ErrInvalidTags) {
+		ctx.JSON(http.StatusOK, invalidTags())
+		return
+	}
+	if errors.Is(err, service.ErrArticlePermissionDenied) {
+		ctx.JSON(http.StatusOK, h.authorErrResult(err))
+		return
+	}
 	if err != nil {
 		ctx.JSON(http.StatusOK, result.Result{
 			Code: 5,
@@ -94,9 +183,13 @@ func (h *Handler) Publish(ctx *gin.Context) {
 		h.logger.Error("发表帖子失败", logx.Error(err))
 		return
 	}
-	ctx.JSON(http.StatusOK, result.Result{
-		Msg:  "ok",
-		Data: id,
+	ctx.JSON(http.StatusOK, PublishResult{
+		Result: result.Result{
+			Msg:  "ok",
+			Data: id,
+		},
+		// 进入人工审核的时候线上还是之前的内容，前端要根据状态提示作者
+		Status: status.ToUint8(),
 	})
 }
 
@@ -111,7 +204,7 @@ func (h *Handler) Withdraw(ctx *gin.Context) {
 	authorId := ctx.GetInt64("userId")
 	id, err := h.svc.Withdraw(ctx, req.toDomain(authorId))
 	if err != nil {
-		ctx.JSON(http.StatusOK, ginx.SystemError())
+		ctx.JSON(http.StatusOK, h.authorErrResult(err))
 		h.logger.Error("发表帖子失败", logx.Error(err))
 		return
 	}
@@ -122,17 +215,83 @@ func (h *Handler) Withdraw(ctx *gin.Context) {
 	})
 }
 
-func (h *Handler) List(ctx *gin.Context, req ListReq) (ginx.Result, error) {
+const (
+	defaultPageSize = 10
+	maxPageSize     = 100
+)
+
+func (h *Handler) List(ctx *gin.Context, req CursorListReq) (ginx.Result, error) {
+	cursor, err := domain.ParseArticleCursor(req.Cursor)
+	if err != nil {
+		return ginx.InvalidParam(), nil
+	}
+	limit := req.Limit
+	if limit <= 0 {
+		limit = defaultPageSize
+	}
+	if limit > maxPageSize {
+		limit = maxPageSize
+	}
 	id := ctx.MustGet("userId").(int64)
-	res, err := h.svc.List(ctx, id, req.Offset, req.Limit)
+	res, err := h.svc.List(ctx, id, cursor, limit)
 	if err != nil {
 		return ginx.Result{
 			Code: 5,
 			Msg:  "系统错误",
 		}, nil
 	}
+	var next string
+	// 不满一页说明已经到底了
+	if len(res) == limit {
+		next = res[len(res)-1].Cursor().Encode()
+	}
+	return ginx.Result{
+		Data: CursorListVO{
+			NextCursor: next,
+			List: slice.Map[domain.Article, ArticleVO](res, func(idx int, src domain.Article) ArticleVO {
+				return ArticleVO{
+					Id:        src.Id,
+					Title:     src.Title,
+					CreatedAt: src.CreatedAt.String(),
+					UpdatedAt: src.UpdatedAt.String(),
+					Status:    src.Status.ToUint8(),
+					Abstract:  src.Abstract(),
+					Tags:      src.Tags,
+					Version:   src.Version,
+
+					PublishAt:    toMilli(src.PublishAt),
+					UnpublishAt:  toMilli(src.UnpublishAt),
+					ReviewReason: src.ReviewReason,
+				}
+			}),
+		},
+	}, nil
+}
+
+func (h *Handler) Delete(ctx *gin.Context, req ArticleIdReq) (ginx.Result, error) {
+	uid := ctx.GetInt64("userId")
+	if err := h.svc.Delete(ctx, req.Id, uid); err != nil {
+		return h.authorErrResult(err), err
+	}
+	return ginx.Result{Msg: "ok"}, nil
+}
+
+func (h *Handler) Restore(ctx *gin.Context, req ArticleIdReq) (ginx.Result, error) {
+	uid := ctx.GetInt64("userId")
+	if err := h.svc.Restore(ctx, req.Id, uid); err != nil {
+		return h.authorErrResult(err), err
+	}
+	return ginx.Result{Msg: "ok"}, nil
+}
+
+func (h *Handler) Trash(ctx *gin.Context, req ListReq) (ginx.Result, error) {
+	uid := ctx.GetInt64("userId")
+	arts, err := h.svc.ListTrash(ctx, uid, req.Offset, req.Limit)
+	if err != nil {
+		return ginx.SystemError(), err
+	}
 	return ginx.Result{
-		Data: slice.Map[domain.Article, ArticleVO](res, func(idx int, src domain.Article) ArticleVO {
+		Data: slice.Map[domain.Article, ArticleVO](arts, func(idx int, src domain.Article) ArticleVO {
 			return ArticleVO{
 				Id:        src.Id,
 				Title:     src.Title,
@@ -140,11 +299,58 @@ func (h *Handler) List(ctx *gin.Context, req ListReq) (ginx.Result, error) {
 				UpdatedAt: src.UpdatedAt.String(),
 				Status:    src.Status.ToUint8(),
 				Abstract:  src.Abstract(),
+				Tags:      src.Tags,
+				Version:   src.Version,
+				DeletedAt: toMilli(src.DeletedAt),
 			}
 		}),
 	}, nil
 }
 
+func (h *Handler) SchedulePublish(ctx *gin.Context, req SchedulePublishReq) (ginx.Result, error) {
+	authorId := ctx.GetInt64("userId")
+	id, err := h.svc.SchedulePublish(ctx, req.toDomain(authorId))
+	if errors.Is(err, service.ErrArticleVersionConflict) {
+		return h.versionConflict(ctx, req.Id), nil
+	}
+	if err != nil {
+		return h.scheduleErrResult(err), err
+	}
+	return ginx.Result{
+		Msg:  "ok",
+		Data: id,
+	}, nil
+}
+
+func (h *Handler) ScheduleWithdraw(ctx *gin.Context, req ScheduleWithdrawReq) (ginx.Result, error) {
+	authorId := ctx.GetInt64("userId")
+	err := h.svc.ScheduleWithdraw(ctx, domain.Article{
+		Id:          req.Id,
+		Author:      domain.Author{Id: authorId},
+		UnpublishAt: fromMilli(req.UnpublishAt),
+	})
+	if err != nil {
+		return h.scheduleErrResult(err), err
+	}
+	return ginx.Result{
+		Msg: "ok",
+	}, nil
+}
+
+func (h *Handler) CancelSchedule(ctx *gin.Context, req CancelScheduleReq) (ginx.Result, error) {
+	if !req.Publish && !req.Withdraw {
+		return ginx.InvalidParam(), nil
+	}
+	authorId := ctx.GetInt64("userId")
+	err := h.svc.CancelSchedule(ctx, req.Id, authorId, req.Publish, req.Withdraw)
+	if err != nil {
+		return h.scheduleErrResult(err), err
+	}
+	return ginx.Result{
+		Msg: "ok",
+	}, nil
+}
+
 func (h *Handler) Detail(ctx *gin.Context) (ginx.Result, error) {
 	idStr := ctx.Param("id")
 	id, err := strconv.ParseInt(idStr, 10, 64)
@@ -152,17 +358,11 @@ func (h *Handler) Detail(ctx *gin.Context) (ginx.Result, error) {
 		return ginx.InvalidParam(), err
 	}
 
-	article, err := h.svc.GetById(ctx, id)
-	if err != nil {
-		return ginx.SystemError(), err
-	}
-
+	// 作者和协作者都能看草稿
 	userId := ctx.GetInt64("userId")
-	if article.Author.Id != userId {
-		return ginx.Result{
-			Code: 4,
-			Msg:  "无权限",
-		}, errors.New("无权限")
+	article, err := h.svc.GetDraft(ctx, id, userId)
+	if err != nil {
+		return h.authorErrResult(err), err
 	}
 
 	return ginx.Result{
@@ -173,6 +373,9 @@ func (h *Handler) Detail(ctx *gin.Context) (ginx.Result, error) {
 			UpdatedAt: article.UpdatedAt.String(),
 			Status:    article.Status.ToUint8(),
 			Content:   article.Content,
+			AuthorId:  article.Author.Id,
+			Tags:      article.Tags,
+			Version:   article.Version,
 		},
 	}, nil
 }
@@ -190,6 +393,8 @@ func (h *Handler) PubDetail(ctx *gin.Context) (ginx.Result, error) {
 		id          int64
 		article     domain.Article
 		interactive *interactivev1.GetResponse
+		nav         domain.SeriesNav
+		progress    domain.ReadingProgress
 		eg          errgroup.Group
 		err         error
 	)
@@ -219,12 +424,39 @@ func (h *Handler) PubDetail(ctx *gin.Context) (ginx.Result, error) {
 		return err
 	})
 
+	eg.Go(func() error {
+		// 系列导航查不到不影响看文章
+		var er error
+		nav, er = h.seriesSvc.Nav(ctx, id)
+		if er != nil {
+			h.logger.Error("查询系列导航失败", logx.Int64("aid", id), logx.Error(er))
+		}
+		return nil
+	})
+
+	if uid > 0 {
+		eg.Go(func() error {
+			// 阅读进度查不到就从头开始看
+			var er error
+			progress, er = h.progressSvc.Get(ctx, uid, id)
+			if er != nil {
+				h.logger.Error("查询阅读进度失败", logx.Int64("uid", uid), logx.Int64("aid", id), logx.Error(er))
+			}
+			return nil
+		})
+	}
+
 	err = eg.Wait()
+	if errors.Is(err, service.ErrArticleNotFound) {
+		return ginx.Result{Code: 4, Msg: "文章不存在"}, nil
+	}
 	if err != nil {
 		//查询出错
 		return ginx.SystemError(), err
 	}
 
+	followed := h.authorFollowed(ctx, uid, article.Author.Id)
+
 	// 异步增加阅读计数
 	//go func() {
 	//	// 调用内部服务增加阅读计数
@@ -245,17 +477,42 @@ func (h *Handler) PubDetail(ctx *gin.Context) (ginx.Result, error) {
 			UpdatedAt:  article.UpdatedAt.String(),
 			Status:     article.Status.ToUint8(),
 			Abstract:   article.Abstract(),
+			Content:    article.Content,
+			Tags:       article.Tags,
 			AuthorId:   article.Author.Id,
 			AuthorName: article.Author.Name,
 			LikeCnt:    interactive.Intr.LikeCnt,
 			CollectCnt: interactive.Intr.CollectCnt,
 			ReadCnt:    interactive.Intr.ReadCnt,
+			CommentCnt: interactive.Intr.CommentCnt,
 			Liked:      interactive.Intr.Liked,
 			Collected:  interactive.Intr.Collected,
+
+			Html:        article.Rendered.HTML,
+			Toc:         newTocVOs(article.Rendered.Toc),
+			WordCount:   article.Rendered.WordCount,
+			ReadingTime: article.Rendered.ReadingTime,
+			Series:      newSeriesNavVO(nav),
+
+			AuthorFollowed: followed,
+			Progress:       newReadingProgressVO(progress),
 		},
 	}, nil
 }
 
+// authorFollowed 没登录或者看自己的文章都是 false，查不到不影响看文章
+func (h *Handler) authorFollowed(ctx context.Context, uid int64, authorId int64) bool {
+	if uid <= 0 || uid == authorId {
+		return false
+	}
+	followed, err := h.followSvc.Followed(ctx, uid, []int64{authorId})
+	if err != nil {
+		h.logger.Error("查询是否关注作者失败", logx.Int64("uid", uid), logx.Int64("authorId", authorId), logx.Error(err))
+		return false
+	}
+	return followed[authorId]
+}
+
 func (h *Handler) Like(ctx *gin.Context, req LikeReq) (ginx.Result, error) {
 	Uid := ctx.GetInt64("userId")
 	var err error
@@ -280,10 +537,222 @@ func (h *Handler) Like(ctx *gin.Context, req LikeReq) (ginx.Result, error) {
 	return ginx.Success(), nil
 }
 
+func (h *Handler) Revisions(ctx *gin.Context) (ginx.Result, error) {
+	id, err := strconv.ParseInt(ctx.Param("id"), 10, 64)
+	if err != nil {
+		return ginx.InvalidParam(), err
+	}
+	var req ListReq
+	if err = ctx.ShouldBindQuery(&req); err != nil {
+		return ginx.InvalidParam(), err
+	}
+	if req.Limit <= 0 || req.Limit > maxPageSize {
+		req.Limit = defaultPageSize
+	}
+	uid := ctx.GetInt64("userId")
+	revs, err := h.svc.ListRevisions(ctx, id, uid, req.Offset, req.Limit)
+	if err != nil {
+		return h.authorErrResult(err), err
+	}
+	return ginx.Result{
+		Data: slice.Map[domain.ArticleRevision, RevisionVO](revs, func(idx int, src domain.ArticleRevision) RevisionVO {
+			// 列表不返回正文
+			vo := newRevisionVO(src)
+			vo.Content = ""
+			return vo
+		}),
+	}, nil
+}
+
+func (h *Handler) RevisionDetail(ctx *gin.Context) (ginx.Result, error) {
+	id, err := strconv.ParseInt(ctx.Param("id"), 10, 64)
+	if err != nil {
+		return ginx.InvalidParam(), err
+	}
+	rid, err := strconv.ParseInt(ctx.Param("rid"), 10, 64)
+	if err != nil {
+		return ginx.InvalidParam(), err
+	}
+	uid := ctx.GetInt64("userId")
+	rev, err := h.svc.GetRevision(ctx, id, rid, uid)
+	if err != nil {
+		return h.authorErrResult(err), err
+	}
+	return ginx.Result{
+		Data: newRevisionVO(rev),
+	}, nil
+}
+
+// DiffRevisions 比较两个历史版本 /articles/:id/revisions/diff?from=1&to=2
+func (h *Handler) DiffRevisions(ctx *gin.Context) (ginx.Result, error) {
+	id, err := strconv.ParseInt(ctx.Param("id"), 10, 64)
+	if err != nil {
+		return ginx.InvalidParam(), err
+	}
+	var req DiffReq
+	if err = ctx.ShouldBindQuery(&req); err != nil {
+		return ginx.InvalidParam(), err
+	}
+	uid := ctx.GetInt64("userId")
+	diff, err := h.svc.DiffRevisions(ctx, id, req.From, req.To, uid)
+	if err != nil {
+		return h.authorErrResult(err), err
+	}
+	return ginx.Result{
+		Data: RevisionDiffVO{
+			From:    newRevisionVO(diff.From),
+			To:      newRevisionVO(diff.To),
+			Title:   newDiffLineVOs(diff.Title),
+			Content: newDiffLineVOs(diff.Content),
+		},
+	}, nil
+}
+
+func (h *Handler) RestoreRevision(ctx *gin.Context) (ginx.Result, error) {
+	id, err := strconv.ParseInt(ctx.Param("id"), 10, 64)
+	if err != nil {
+		return ginx.InvalidParam(), err
+	}
+	rid, err := strconv.ParseInt(ctx.Param("rid"), 10, 64)
+	if err != nil {
+		return ginx.InvalidParam(), err
+	}
+	uid := ctx.GetInt64("userId")
+	artId, err := h.svc.RestoreRevision(ctx, id, rid, uid)
+	if err != nil {
+		return h.authorErrResult(err), err
+	}
+	return ginx.Result{
+		Msg:  "ok",
+		Data: artId,
+	}, nil
+}
+
+func (h *Handler) Tags(ctx *gin.Context) (ginx.Result, error) {
+	tags, err := h.svc.TagCounts(ctx, 100)
+	if err != nil {
+		return ginx.SystemError(), err
+	}
+	return ginx.Result{
+		Data: slice.Map[domain.Tag, TagVO](tags, func(idx int, src domain.Tag) TagVO {
+			return TagVO{Name: src.Name, ArticleCnt: src.ArticleCnt}
+		}),
+	}, nil
+}
+
+func (h *Handler) PubListByTag(ctx *gin.Context) (ginx.Result, error) {
+	tag := ctx.Param("tag")
+	var req CursorListReq
+	if err := ctx.ShouldBindQuery(&req); err != nil {
+		return ginx.InvalidParam(), err
+	}
+	cursor, err := domain.ParseArticleCursor(req.Cursor)
+	if err != nil {
+		return ginx.InvalidParam(), nil
+	}
+	limit := req.Limit
+	if limit <= 0 {
+		limit = defaultPageSize
+	}
+	if limit > maxPageSize {
+		limit = maxPageSize
+	}
+	arts, err := h.svc.ListPubByTag(ctx, tag, cursor, limit)
+	if err != nil {
+		return ginx.SystemError(), err
+	}
+	var next string
+	// 不满一页说明已经到底了
+	if len(arts) == limit {
+		next = arts[len(arts)-1].Cursor().Encode()
+	}
+	return ginx.Result{
+		Data: CursorListVO{
+			NextCursor: next,
+			List: slice.Map[domain.Article, ArticleVO](arts, func(idx int, src domain.Article) ArticleVO {
+				return ArticleVO{
+					Id:        src.Id,
+					Title:     src.Title,
+					Abstract:  src.Abstract(),
+					AuthorId:  src.Author.Id,
+					Status:    src.Status.ToUint8(),
+					Tags:      src.Tags,
+					CreatedAt: src.CreatedAt.String(),
+					UpdatedAt: src.UpdatedAt.String(),
+				}
+			}),
+		},
+	}, nil
+}
+
+func invalidTags() ginx.Result {
+	return ginx.Result{
+		Code: 4,
+		Msg:  "标签不合法",
+	}
+}
+
+// versionConflict 版本冲突的时候把服务端当前的版本返回去，让前端自己合并
+func (h *Handler) versionConflict(ctx *gin.Context, id int64) ginx.Result {
+	res := ginx.Result{
+		Code: errs.ArticleVersionConflict,
+		Msg:  "文章已被修改，请合并后重试",
+	}
+	art, err := h.svc.GetById(ctx, id)
+	if err != nil {
+		h.logger.Error("查询文章最新版本失败", logx.Int64("article_id", id), logx.Error(err))
+		return res
+	}
+	res.Data = ArticleVO{
+		Id:        art.Id,
+		Title:     art.Title,
+		Content:   art.Content,
+		AuthorId:  art.Author.Id,
+		Status:    art.Status.ToUint8(),
+		Tags:      art.Tags,
+		Version:   art.Version,
+		CreatedAt: art.CreatedAt.String(),
+		UpdatedAt: art.UpdatedAt.String(),
+	}
+	return res
+}
+
+func (h *Handler) scheduleErrResult(err error) ginx.Result {
+	if errors.Is(err, service.ErrInvalidSchedule) {
+		return ginx.Result{
+			Code: 4,
+			Msg:  "定时时间不合法",
+		}
+	}
+	if errors.Is(err, service.ErrInvalidTags) {
+		return invalidTags()
+	}
+	return h.authorErrResult(err)
+}
+
+func (h *Handler) authorErrResult(err error) ginx.Result {
+	switch {
+	case errors.Is(err, service.ErrArticlePermissionDenied):
+		return ginx.Result{
+			Code: 4,
+			Msg:  "无权限",
+		}
+	case errors.Is(err, service.ErrArticleNotFound):
+		return ginx.Result{
+			Code: 4,
+			Msg:  "文章不存在",
+		}
+	}
+	return ginx.SystemError()
+}
+
 type articleReq struct {
-	Id      int64  `json:"id"`
-	Title   string `json:"title"`
-	Content string `json:"content"`
+	Id      int64    `json:"id"`
+	Title   string   `json:"title"`
+	Content string   `json:"content"`
+	Tags    []string `json:"tags"`
+	// Version 修改已有文章的时候带上读到的版本号
+	Version int64 `json:"version"`
 }
 
 func (a *articleReq) toDomain(authorId int64) domain.Article {
@@ -291,6 +760,8 @@ func (a *articleReq) toDomain(authorId int64) domain.Article {
 		Id:      a.Id,
 		Title:   a.Title,
 		Content: a.Content,
+		Tags:    a.Tags,
+		Version: a.Version,
 		Author: domain.Author{
 			Id: authorId,
 		},
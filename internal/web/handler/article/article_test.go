@@ -5,16 +5,20 @@ import (
 	"encoding/json"
 	"errors"
 	"github.com/Andras5014/gohub/internal/domain"
+	"github.com/Andras5014/gohub/internal/errs"
 	"github.com/Andras5014/gohub/internal/service"
 	svcmocks "github.com/Andras5014/gohub/internal/service/mocks"
 	"github.com/Andras5014/gohub/internal/web/result"
+	"github.com/Andras5014/gohub/pkg/logx"
 	"github.com/gin-gonic/gin"
 	"github.com/stretchr/testify/assert"
 	"github.com/stretchr/testify/require"
 	"go.uber.org/mock/gomock"
+	"go.uber.org/zap"
 	"net/http"
 	"net/http/httptest"
 	"testing"
+	"time"
 )
 
 func TestArticleHandler_Publish(t *testing.T) {
@@ -24,8 +28,9 @@ func TestArticleHandler_Publish(t *testing.T) {
 
 		reqBody string
 
-		wantCode int
-		wantRes  result.Result
+		wantCode   int
+		wantRes    result.Result
+		wantStatus uint8
 	}{
 		{
 			name: "新建并发表",
@@ -37,7 +42,29 @@ func TestArticleHandler_Publish(t *testing.T) {
 					Author: domain.Author{
 						Id: 123,
 					},
-				}).Return(int64(1), nil)
+				}).Return(int64(1), domain.ArticleStatusPublished, nil)
+				return svc
+			},
+			reqBody: `
+{
+	"title": "我的标题",
+	"content": "我的内容"
+}
+`,
+			wantCode: http.StatusOK,
+			wantRes: result.Result{
+				Code: 0,
+				Data: float64(1),
+				Msg:  "ok",
+			},
+			wantStatus: domain.ArticleStatusPublished.ToUint8(),
+		},
+		{
+			name: "命中敏感词进入人工审核",
+			mock: func(ctrl *gomock.Controller) service.ArticleService {
+				svc := svcmocks.NewMockArticleService(ctrl)
+				svc.EXPECT().Publish(gomock.Any(), gomock.Any()).
+					Return(int64(1), domain.ArticleStatusPendingReview, nil)
 				return svc
 			},
 			reqBody: `
@@ -52,6 +79,28 @@ func TestArticleHandler_Publish(t *testing.T) {
 				Data: float64(1),
 				Msg:  "ok",
 			},
+			wantStatus: domain.ArticleStatusPendingReview.ToUint8(),
+		},
+		{
+			name: "命中敏感词拒绝",
+			mock: func(ctrl *gomock.Controller) service.ArticleService {
+				svc := svcmocks.NewMockArticleService(ctrl)
+				svc.EXPECT().Publish(gomock.Any(), gomock.Any()).
+					Return(int64(0), domain.ArticleStatusUnknown, &service.SensitiveContentError{Words: []string{"赌博"}})
+				return svc
+			},
+			reqBody: `
+{
+	"title": "我的标题",
+	"content": "赌博"
+}
+`,
+			wantCode: http.StatusOK,
+			wantRes: result.Result{
+				Code: errs.ArticleSensitiveContent,
+				Msg:  "内容包含敏感词",
+				Data: []any{"赌博"},
+			},
 		},
 		{
 			name: "publish失败",
@@ -63,7 +112,7 @@ func TestArticleHandler_Publish(t *testing.T) {
 					Author: domain.Author{
 						Id: 123,
 					},
-				}).Return(int64(0), errors.New("publish失败"))
+				}).Return(int64(0), domain.ArticleStatusUnknown, errors.New("publish失败"))
 				return svc
 			},
 			reqBody: `
@@ -78,6 +127,65 @@ func TestArticleHandler_Publish(t *testing.T) {
 				Msg:  "系统错误",
 			},
 		},
+		{
+			name: "版本冲突返回服务端版本",
+			mock: func(ctrl *gomock.Controller) service.ArticleService {
+				svc := svcmocks.NewMockArticleService(ctrl)
+				svc.EXPECT().Publish(gomock.Any(), domain.Article{
+					Id:      1,
+					Title:   "我的标题",
+					Content: "我的内容",
+					Version: 2,
+					Author: domain.Author{
+						Id: 123,
+					},
+				}).Return(int64(0), domain.ArticleStatusUnknown, service.ErrArticleVersionConflict)
+				svc.EXPECT().GetById(gomock.Any(), int64(1)).Return(domain.Article{
+					Id:      1,
+					Title:   "别人的标题",
+					Content: "别人的内容",
+					Version: 3,
+					Author: domain.Author{
+						Id: 123,
+					},
+				}, nil)
+				return svc
+			},
+			reqBody: `
+{
+	"id": 1,
+	"title": "我的标题",
+	"content": "我的内容",
+	"version": 2
+}
+`,
+			wantCode: http.StatusOK,
+			wantRes: result.Result{
+				Code: errs.ArticleVersionConflict,
+				Msg:  "文章已被修改，请合并后重试",
+				Data: map[string]any{
+					"id":          float64(1),
+					"title":       "别人的标题",
+					"abstract":    "",
+					"content":     "别人的内容",
+					"authorId":    float64(123),
+					"authorName":  "",
+					"status":      float64(0),
+					"tags":        nil,
+					"version":     float64(3),
+					"readCnt":     float64(0),
+					"likeCnt":     float64(0),
+					"collectCnt":  float64(0),
+					"commentCnt":  float64(0),
+					"liked":       false,
+					"collected":   false,
+					"createdAt":   time.Time{}.String(),
+					"updatedAt":   time.Time{}.String(),
+					"publishAt":   float64(0),
+					"unpublishAt": float64(0),
+				},
+			},
+		},
 	}
 	for _, tc := range testCases {
 		t.Run(tc.name, func(t *testing.T) {
@@ -87,7 +195,7 @@ func TestArticleHandler_Publish(t *testing.T) {
 			server.Use(func(ctx *gin.Context) {
 				ctx.Set("userId", int64(123))
 			})
-			h := NewArticleHandler(tc.mock(ctrl), nil)
+			h := NewArticleHandler(tc.mock(ctrl), nil, nil, nil, nil, logx.NewZapLogger(zap.NewNop()))
 			h.RegisterRoutes(server)
 			req, err := http.NewRequest(http.MethodPost,
 				"/articles/publish", bytes.NewBuffer([]byte(tc.reqBody)))
@@ -98,6 +206,171 @@ func TestArticleHandler_Publish(t *testing.T) {
 
 			server.ServeHTTP(resp, req)
 
+			assert.Equal(t, tc.wantCode, resp.Code)
+			var webRes PublishResult
+			err = json.NewDecoder(resp.Body).Decode(&webRes)
+			assert.NoError(t, err)
+			assert.Equal(t, tc.wantRes, webRes.Result)
+			assert.Equal(t, tc.wantStatus, webRes.Status)
+		})
+	}
+}
+
+func TestArticleHandler_RestoreRevision(t *testing.T) {
+	testCases := []struct {
+		name string
+		mock func(ctrl *gomock.Controller) service.ArticleService
+
+		path string
+
+		wantCode int
+		wantRes  result.Result
+	}{
+		{
+			name: "恢复成功",
+			mock: func(ctrl *gomock.Controller) service.ArticleService {
+				svc := svcmocks.NewMockArticleService(ctrl)
+				svc.EXPECT().RestoreRevision(gomock.Any(), int64(1), int64(2), int64(123)).
+					Return(int64(1), nil)
+				return svc
+			},
+			path:     "/articles/1/revisions/2/restore",
+			wantCode: http.StatusOK,
+			wantRes: result.Result{
+				Msg:  "ok",
+				Data: float64(1),
+			},
+		},
+		{
+			name: "不是作者",
+			mock: func(ctrl *gomock.Controller) service.ArticleService {
+				svc := svcmocks.NewMockArticleService(ctrl)
+				svc.EXPECT().RestoreRevision(gomock.Any(), int64(1), int64(2), int64(123)).
+					Return(int64(0), service.ErrArticlePermissionDenied)
+				return svc
+			},
+			path:     "/articles/1/revisions/2/restore",
+			wantCode: http.StatusOK,
+			wantRes: result.Result{
+				Code: 4,
+				Msg:  "无权限",
+			},
+		},
+		{
+			name: "版本id非法",
+			mock: func(ctrl *gomock.Controller) service.ArticleService {
+				return svcmocks.NewMockArticleService(ctrl)
+			},
+			path:     "/articles/1/revisions/abc/restore",
+			wantCode: http.StatusOK,
+			wantRes: result.Result{
+				Code: 4,
+				Msg:  "参数错误",
+			},
+		},
+	}
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			server := gin.Default()
+			ctrl := gomock.NewController(t)
+			defer ctrl.Finish()
+			server.Use(func(ctx *gin.Context) {
+				ctx.Set("userId", int64(123))
+			})
+			h := NewArticleHandler(tc.mock(ctrl), nil, nil, nil, nil, logx.NewZapLogger(zap.NewNop()))
+			h.RegisterRoutes(server)
+			req, err := http.NewRequest(http.MethodPost, tc.path, nil)
+			require.NoError(t, err)
+
+			resp := httptest.NewRecorder()
+
+			server.ServeHTTP(resp, req)
+
+			assert.Equal(t, tc.wantCode, resp.Code)
+			var webRes result.Result
+			err = json.NewDecoder(resp.Body).Decode(&webRes)
+			assert.NoError(t, err)
+			assert.Equal(t, tc.wantRes, webRes)
+		})
+	}
+}
+
+func TestArticleHandler_SchedulePublish(t *testing.T) {
+	testCases := []struct {
+		name string
+		mock func(ctrl *gomock.Controller) service.ArticleService
+
+		reqBody string
+
+		wantCode int
+		wantRes  result.Result
+	}{
+		{
+			name: "定时发表成功",
+			mock: func(ctrl *gomock.Controller) service.ArticleService {
+				svc := svcmocks.NewMockArticleService(ctrl)
+				svc.EXPECT().SchedulePublish(gomock.Any(), domain.Article{
+					Title:     "我的标题",
+					Content:   "我的内容",
+					Author:    domain.Author{Id: 123},
+					PublishAt: time.UnixMilli(1700000000000),
+				}).Return(int64(1), nil)
+				return svc
+			},
+			reqBody:  `{"title":"我的标题","content":"我的内容","publishAt":1700000000000}`,
+			wantCode: http.StatusOK,
+			wantRes: result.Result{
+				Msg:  "ok",
+				Data: float64(1),
+			},
+		},
+		{
+			name: "定时时间不合法",
+			mock: func(ctrl *gomock.Controller) service.ArticleService {
+				svc := svcmocks.NewMockArticleService(ctrl)
+				svc.EXPECT().SchedulePublish(gomock.Any(), gomock.Any()).
+					Return(int64(0), service.ErrInvalidSchedule)
+				return svc
+			},
+			reqBody:  `{"title":"我的标题","content":"我的内容","publishAt":1}`,
+			wantCode: http.StatusOK,
+			wantRes: result.Result{
+				Code: 4,
+				Msg:  "定时时间不合法",
+			},
+		},
+		{
+			name: "缺少发表时间",
+			mock: func(ctrl *gomock.Controller) service.ArticleService {
+				return svcmocks.NewMockArticleService(ctrl)
+			},
+			reqBody:  `{"title":"我的标题","content":"我的内容"}`,
+			wantCode: http.StatusOK,
+			wantRes: result.Result{
+				Code: 4,
+				Msg:  "参数错误",
+			},
+		},
+	}
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			server := gin.Default()
+			ctrl := gomock.NewController(t)
+			defer ctrl.Finish()
+			server.Use(func(ctx *gin.Context) {
+				ctx.Set("userId", int64(123))
+			})
+			h := NewArticleHandler(tc.mock(ctrl), nil, nil, nil, nil, logx.NewZapLogger(zap.NewNop()))
+			h.RegisterRoutes(server)
+			req, err := http.NewRequest(http.MethodPost,
+				"/articles/publish/schedule", bytes.NewBuffer([]byte(tc.reqBody)))
+			require.NoError(t, err)
+			req.Header.Set("Content-Type", "application/json")
+
+			resp := httptest.NewRecorder()
+
+			server.ServeHTTP(resp, req)
+
 			assert.Equal(t, tc.wantCode, resp.Code)
 			var webRes result.Result
 			err = json.NewDecoder(resp.Body).Decode(&webRes)
@@ -106,3 +379,242 @@ func TestArticleHandler_Publish(t *testing.T) {
 		})
 	}
 }
+
+func TestArticleHandler_Delete(t *testing.T) {
+	testCases := []struct {
+		name string
+		mock func(ctrl *gomock.Controller) service.ArticleService
+
+		reqBody string
+
+		wantCode int
+		wantRes  result.Result
+	}{
+		{
+			name: "删除成功",
+			mock: func(ctrl *gomock.Controller) service.ArticleService {
+				svc := svcmocks.NewMockArticleService(ctrl)
+				svc.EXPECT().Delete(gomock.Any(), int64(1), int64(123)).Return(nil)
+				return svc
+			},
+			reqBody:  `{"id":1}`,
+			wantCode: http.StatusOK,
+			wantRes: result.Result{
+				Msg: "ok",
+			},
+		},
+		{
+			name: "不是作者",
+			mock: func(ctrl *gomock.Controller) service.ArticleService {
+				svc := svcmocks.NewMockArticleService(ctrl)
+				svc.EXPECT().Delete(gomock.Any(), int64(1), int64(123)).
+					Return(service.ErrArticlePermissionDenied)
+				return svc
+			},
+			reqBody:  `{"id":1}`,
+			wantCode: http.StatusOK,
+			wantRes: result.Result{
+				Code: 4,
+				Msg:  "无权限",
+			},
+		},
+		{
+			name: "缺少文章id",
+			mock: func(ctrl *gomock.Controller) service.ArticleService {
+				return svcmocks.NewMockArticleService(ctrl)
+			},
+			reqBody:  `{}`,
+			wantCode: http.StatusOK,
+			wantRes: result.Result{
+				Code: 4,
+				Msg:  "参数错误",
+			},
+		},
+	}
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			server := gin.Default()
+			ctrl := gomock.NewController(t)
+			defer ctrl.Finish()
+			server.Use(func(ctx *gin.Context) {
+				ctx.Set("userId", int64(123))
+			})
+			h := NewArticleHandler(tc.mock(ctrl), nil, nil, nil, nil, logx.NewZapLogger(zap.NewNop()))
+			h.RegisterRoutes(server)
+			req, err := http.NewRequest(http.MethodPost,
+				"/articles/delete", bytes.NewBuffer([]byte(tc.reqBody)))
+			require.NoError(t, err)
+			req.Header.Set("Content-Type", "application/json")
+
+			resp := httptest.NewRecorder()
+
+			server.ServeHTTP(resp, req)
+
+			assert.Equal(t, tc.wantCode, resp.Code)
+			var webRes result.Result
+			err = json.NewDecoder(resp.Body).Decode(&webRes)
+			assert.NoError(t, err)
+			assert.Equal(t, tc.wantRes, webRes)
+		})
+	}
+}
+
+func TestArticleHandler_List(t *testing.T) {
+	now := time.UnixMilli(1700000000000)
+	cursor := domain.ArticleCursor{UpdatedAt: now, Id: 9}
+	testCases := []struct {
+		name string
+		mock func(ctrl *gomock.Controller) service.ArticleService
+
+		reqBody string
+
+		wantCode int
+		wantIds  []int64
+		wantNext string
+	}{
+		{
+			name: "第一页，还有下一页",
+			mock: func(ctrl *gomock.Controller) service.ArticleService {
+				svc := svcmocks.NewMockArticleService(ctrl)
+				svc.EXPECT().List(gomock.Any(), int64(123), domain.ArticleCursor{}, 2).
+					Return([]domain.Article{
+						{Id: 10, UpdatedAt: now},
+						{Id: 9, UpdatedAt: now},
+					}, nil)
+				return svc
+			},
+			reqBody:  `{"limit":2}`,
+			wantIds:  []int64{10, 9},
+			wantNext: cursor.Encode(),
+		},
+		{
+			name: "带上游标，最后一页",
+			mock: func(ctrl *gomock.Controller) service.ArticleService {
+				svc := svcmocks.NewMockArticleService(ctrl)
+				svc.EXPECT().List(gomock.Any(), int64(123), cursor, 2).
+					Return([]domain.Article{
+						{Id: 8, UpdatedAt: now},
+					}, nil)
+				return svc
+			},
+			reqBody: `{"limit":2,"cursor":"` + cursor.Encode() + `"}`,
+			wantIds: []int64{8},
+		},
+		{
+			name: "limit 超过上限",
+			mock: func(ctrl *gomock.Controller) service.ArticleService {
+				svc := svcmocks.NewMockArticleService(ctrl)
+				svc.EXPECT().List(gomock.Any(), int64(123), domain.ArticleCursor{}, maxPageSize).
+					Return(nil, nil)
+				return svc
+			},
+			reqBody: `{"limit":1000}`,
+			wantIds: []int64{},
+		},
+	}
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			server := gin.Default()
+			ctrl := gomock.NewController(t)
+			defer ctrl.Finish()
+			server.Use(func(ctx *gin.Context) {
+				ctx.Set("userId", int64(123))
+			})
+			h := NewArticleHandler(tc.mock(ctrl), nil, nil, nil, nil, logx.NewZapLogger(zap.NewNop()))
+			h.RegisterRoutes(server)
+			req, err := http.NewRequest(http.MethodPost,
+				"/articles/list", bytes.NewBuffer([]byte(tc.reqBody)))
+			require.NoError(t, err)
+			req.Header.Set("Content-Type", "application/json")
+
+			resp := httptest.NewRecorder()
+
+			server.ServeHTTP(resp, req)
+
+			assert.Equal(t, http.StatusOK, resp.Code)
+			var webRes struct {
+				Code int          `json:"code"`
+				Data CursorListVO `json:"data"`
+			}
+			err = json.NewDecoder(resp.Body).Decode(&webRes)
+			require.NoError(t, err)
+			assert.Equal(t, 0, webRes.Code)
+			ids := make([]int64, 0, len(webRes.Data.List))
+			for _, vo := range webRes.Data.List {
+				ids = append(ids, vo.Id)
+			}
+			assert.Equal(t, tc.wantIds, ids)
+			assert.Equal(t, tc.wantNext, webRes.Data.NextCursor)
+		})
+	}
+}
+
+func TestArticleHandler_List_InvalidCursor(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+	server := gin.Default()
+	server.Use(func(ctx *gin.Context) {
+		ctx.Set("userId", int64(123))
+	})
+	h := NewArticleHandler(svcmocks.NewMockArticleService(ctrl), nil, nil, nil, nil, logx.NewZapLogger(zap.NewNop()))
+	h.RegisterRoutes(server)
+	req, err := http.NewRequest(http.MethodPost,
+		"/articles/list", bytes.NewBuffer([]byte(`{"cursor":"!!!"}`)))
+	require.NoError(t, err)
+	req.Header.Set("Content-Type", "application/json")
+	resp := httptest.NewRecorder()
+
+	server.ServeHTTP(resp, req)
+
+	var webRes result.Result
+	require.NoError(t, json.NewDecoder(resp.Body).Decode(&webRes))
+	assert.Equal(t, result.Result{Code: 4, Msg: "参数错误"}, webRes)
+}
+
+func TestArticleHandler_Revisions(t *testing.T) {
+	testCases := []struct {
+		name string
+		mock func(ctrl *gomock.Controller) service.ArticleService
+		url  string
+	}{
+		{
+			name: "没有传 limit",
+			mock: func(ctrl *gomock.Controller) service.ArticleService {
+				svc := svcmocks.NewMockArticleService(ctrl)
+				svc.EXPECT().ListRevisions(gomock.Any(), int64(1), int64(123), 0, defaultPageSize).
+					Return(nil, nil)
+				return svc
+			},
+			url: "/articles/1/revisions",
+		},
+		{
+			name: "limit 超过上限",
+			mock: func(ctrl *gomock.Controller) service.ArticleService {
+				svc := svcmocks.NewMockArticleService(ctrl)
+				svc.EXPECT().ListRevisions(gomock.Any(), int64(1), int64(123), 20, defaultPageSize).
+					Return(nil, nil)
+				return svc
+			},
+			url: "/articles/1/revisions?offset=20&limit=1000",
+		},
+	}
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			server := gin.Default()
+			ctrl := gomock.NewController(t)
+			defer ctrl.Finish()
+			server.Use(func(ctx *gin.Context) {
+				ctx.Set("userId", int64(123))
+			})
+			h := NewArticleHandler(tc.mock(ctrl), nil, nil, nil, nil, logx.NewZapLogger(zap.NewNop()))
+			h.RegisterRoutes(server)
+			req, err := http.NewRequest(http.MethodGet, tc.url, nil)
+			require.NoError(t, err)
+			resp := httptest.NewRecorder()
+
+			server.ServeHTTP(resp, req)
+
+			assert.Equal(t, http.StatusOK, resp.Code)
+		})
+	}
+}
@@ -1,30 +1,403 @@
 package article
 
+import (
+	interactivev1 "github.com/Andras5014/gohub/api/proto/gen/interactive/v1"
+	"github.com/Andras5014/gohub/internal/domain"
+	"github.com/Andras5014/gohub/internal/web/result"
+	"github.com/Andras5014/gohub/pkg/diffx"
+	"github.com/ecodeclub/ekit/slice"
+	"time"
+)
+
 type ArticleVO struct {
-	Id         int64  `json:"id"`
-	Title      string `json:"title"`
-	Abstract   string `json:"abstract"`
-	Content    string `json:"content"`
-	AuthorId   int64  `json:"authorId"`
-	AuthorName string `json:"authorName"`
-	Status     uint8  `json:"status"`
+	Id         int64    `json:"id"`
+	Title      string   `json:"title"`
+	Abstract   string   `json:"abstract"`
+	Content    string   `json:"content"`
+	AuthorId   int64    `json:"authorId"`
+	AuthorName string   `json:"authorName"`
+	Status     uint8    `json:"status"`
+	Tags       []string `json:"tags"`
+	Version    int64    `json:"version"`
 
 	ReadCnt    int64 `json:"readCnt"`
 	LikeCnt    int64 `json:"likeCnt"`
 	CollectCnt int64 `json:"collectCnt"`
+	CommentCnt int64 `json:"commentCnt"`
 	Liked      bool  `json:"liked"`
 	Collected  bool  `json:"collected"`
 
 	CreatedAt string `json:"createdAt"`
 	UpdatedAt string `json:"updatedAt"`
+
+	// PublishAt 定时发表时间，毫秒，0 表示没有
+	PublishAt   int64 `json:"publishAt"`
+	UnpublishAt int64 `json:"unpublishAt"`
+	// DeletedAt 放进回收站的时间，毫秒，只有回收站列表会返回
+	DeletedAt int64 `json:"deletedAt,omitempty"`
+	// ReviewReason 审核没有通过的原因，status 是 5 的时候才有
+	ReviewReason string `json:"reviewReason,omitempty"`
+
+	// 下面是发表时渲染的结果，只有线上库的详情会返回
+	Html        string  `json:"html,omitempty"`
+	Toc         []TocVO `json:"toc,omitempty"`
+	WordCount   int64   `json:"wordCount,omitempty"`
+	ReadingTime int64   `json:"readingTime,omitempty"`
+	// Series 文章在系列里面的时候才有
+	Series *SeriesNavVO `json:"series,omitempty"`
+	// AuthorFollowed 当前用户有没有关注作者，只有线上库的详情会返回
+	AuthorFollowed bool `json:"authorFollowed,omitempty"`
+	// Progress 登录了并且读过的才有，用来恢复上次的位置
+	Progress *ReadingProgressVO `json:"progress,omitempty"`
+}
+
+type TocVO struct {
+	Level  int    `json:"level"`
+	Anchor string `json:"anchor"`
+	Text   string `json:"text"`
+}
+
+func newTocVOs(toc []domain.TocItem) []TocVO {
+	return slice.Map[domain.TocItem, TocVO](toc, func(idx int, src domain.TocItem) TocVO {
+		return TocVO{Level: src.Level, Anchor: src.Anchor, Text: src.Text}
+	})
 }
 
 type ListReq struct {
-	Offset int `json:"offset"`
-	Limit  int `json:"limit"`
+	Offset int `json:"offset" form:"offset"`
+	Limit  int `json:"limit" form:"limit"`
+}
+
+// CursorListReq 游标翻页，第一页不传 cursor，后面每次带上一页返回的 nextCursor
+type CursorListReq struct {
+	Cursor string `json:"cursor" form:"cursor"`
+	Limit  int    `json:"limit" form:"limit"`
+}
+
+type CursorListVO struct {
+	List []ArticleVO `json:"list"`
+	// NextCursor 为空表示没有下一页了
+	NextCursor string `json:"nextCursor"`
+}
+
+// PublishResult 发表的响应，data 还是文章 id，兼容原来的调用方
+// Status 是发表之后的状态，待审核的话线上还是之前的内容
+type PublishResult struct {
+	result.Result
+	Status uint8 `json:"status"`
+}
+
+type ArticleIdReq struct {
+	Id int64 `json:"id" binding:"required"`
+}
+
+type SchedulePublishReq struct {
+	Id      int64    `json:"id"`
+	Title   string   `json:"title"`
+	Content string   `json:"content"`
+	Tags    []string `json:"tags"`
+	Version int64    `json:"version"`
+	// PublishAt 毫秒时间戳
+	PublishAt int64 `json:"publishAt" binding:"required"`
+	// UnpublishAt 可选，毫秒时间戳
+	UnpublishAt int64 `json:"unpublishAt"`
+}
+
+func (r SchedulePublishReq) toDomain(authorId int64) domain.Article {
+	return domain.Article{
+		Id:          r.Id,
+		Title:       r.Title,
+		Content:     r.Content,
+		Tags:        r.Tags,
+		Version:     r.Version,
+		Author:      domain.Author{Id: authorId},
+		PublishAt:   fromMilli(r.PublishAt),
+		UnpublishAt: fromMilli(r.UnpublishAt),
+	}
+}
+
+type ScheduleWithdrawReq struct {
+	Id          int64 `json:"id" binding:"required"`
+	UnpublishAt int64 `json:"unpublishAt" binding:"required"`
+}
+
+type CancelScheduleReq struct {
+	Id       int64 `json:"id" binding:"required"`
+	Publish  bool  `json:"publish"`
+	Withdraw bool  `json:"withdraw"`
+}
+
+func toMilli(t time.Time) int64 {
+	if t.IsZero() {
+		return 0
+	}
+	return t.UnixMilli()
+}
+
+func fromMilli(ms int64) time.Time {
+	if ms <= 0 {
+		return time.Time{}
+	}
+	return time.UnixMilli(ms)
+}
+
+type TagVO struct {
+	Name       string `json:"name"`
+	ArticleCnt int64  `json:"articleCnt"`
 }
 
 type LikeReq struct {
 	Id   int64 `json:"id"`
 	Like bool  `json:"like"`
 }
+
+type DiffReq struct {
+	From int64 `form:"from" binding:"required"`
+	To   int64 `form:"to" binding:"required"`
+}
+
+type RevisionVO struct {
+	Id        int64 `json:"id"`
+	ArticleId int64 `json:"articleId"`
+	// AuthorId 保存这个版本的人
+	AuthorId  int64  `json:"authorId"`
+	Title     string `json:"title"`
+	Content   string `json:"content"`
+	Status    uint8  `json:"status"`
+	CreatedAt string `json:"createdAt"`
+}
+
+func newRevisionVO(rev domain.ArticleRevision) RevisionVO {
+	return RevisionVO{
+		Id:        rev.Id,
+		ArticleId: rev.ArticleId,
+		AuthorId:  rev.AuthorId,
+		Title:     rev.Title,
+		Content:   rev.Content,
+		Status:    rev.Status.ToUint8(),
+		CreatedAt: rev.CreatedAt.String(),
+	}
+}
+
+type DiffLineVO struct {
+	// Op equal/insert/delete
+	Op   string `json:"op"`
+	Text string `json:"text"`
+}
+
+func newDiffLineVOs(lines []diffx.Line) []DiffLineVO {
+	return slice.Map[diffx.Line, DiffLineVO](lines, func(idx int, src diffx.Line) DiffLineVO {
+		return DiffLineVO{
+			Op:   src.Op.String(),
+			Text: src.Text,
+		}
+	})
+}
+
+type RevisionDiffVO struct {
+	From    RevisionVO   `json:"from"`
+	To      RevisionVO   `json:"to"`
+	Title   []DiffLineVO `json:"title"`
+	Content []DiffLineVO `json:"content"`
+}
+
+type InviteReq struct {
+	ArticleId int64 `json:"articleId" binding:"required"`
+	Uid       int64 `json:"uid" binding:"required"`
+	// Role viewer/editor/publisher
+	Role string `json:"role" binding:"required"`
+}
+
+type CollaboratorReq struct {
+	ArticleId int64 `json:"articleId" binding:"required"`
+	Uid       int64 `json:"uid" binding:"required"`
+}
+
+type InvitationReq struct {
+	ArticleId int64 `json:"articleId" binding:"required"`
+}
+
+type CollaborationListReq struct {
+	// Status pending 是收到的邀请，accepted 是参与协作的文章，默认 pending
+	Status string `json:"status"`
+	Offset int    `json:"offset"`
+	Limit  int    `json:"limit"`
+}
+
+type CollaboratorVO struct {
+	ArticleId int64  `json:"articleId"`
+	Uid       int64  `json:"uid"`
+	Role      string `json:"role"`
+	Status    string `json:"status"`
+	InviterId int64  `json:"inviterId"`
+	CreatedAt string `json:"createdAt"`
+	UpdatedAt string `json:"updatedAt"`
+}
+
+func newCollaboratorVOs(collabs []domain.ArticleCollaborator) []CollaboratorVO {
+	return slice.Map[domain.ArticleCollaborator, CollaboratorVO](collabs,
+		func(idx int, src domain.ArticleCollaborator) CollaboratorVO {
+			return CollaboratorVO{
+				ArticleId: src.ArticleId,
+				Uid:       src.Uid,
+				Role:      src.Role.String(),
+				Status:    src.Status.String(),
+				InviterId: src.InviterId,
+				CreatedAt: src.CreatedAt.String(),
+				UpdatedAt: src.UpdatedAt.String(),
+			}
+		})
+}
+
+type SeriesReq struct {
+	Id          int64  `json:"id"`
+	Title       string `json:"title"`
+	Description string `json:"description"`
+}
+
+type SeriesIdReq struct {
+	Id int64 `json:"id" binding:"required"`
+}
+
+type SeriesArticleReq struct {
+	SeriesId  int64 `json:"seriesId" binding:"required"`
+	ArticleId int64 `json:"articleId" binding:"required"`
+}
+
+type SeriesReorderReq struct {
+	SeriesId int64 `json:"seriesId" binding:"required"`
+	// ArticleIds 调整之后的完整顺序
+	ArticleIds []int64 `json:"articleIds"`
+}
+
+type SeriesVO struct {
+	Id          int64  `json:"id"`
+	Title       string `json:"title"`
+	Description string `json:"description"`
+	// Articles 只有详情会返回
+	Articles  []SeriesArticleVO `json:"articles,omitempty"`
+	CreatedAt string            `json:"createdAt"`
+	UpdatedAt string            `json:"updatedAt"`
+}
+
+type SeriesArticleVO struct {
+	Id     int64  `json:"id"`
+	Title  string `json:"title"`
+	Status uint8  `json:"status,omitempty"`
+}
+
+func newSeriesVO(s domain.Series) SeriesVO {
+	return SeriesVO{
+		Id:          s.Id,
+		Title:       s.Title,
+		Description: s.Description,
+		Articles: slice.Map[domain.Article, SeriesArticleVO](s.Articles, func(idx int, src domain.Article) SeriesArticleVO {
+			return SeriesArticleVO{Id: src.Id, Title: src.Title, Status: src.Status.ToUint8()}
+		}),
+		CreatedAt: s.CreatedAt.String(),
+		UpdatedAt: s.UpdatedAt.String(),
+	}
+}
+
+type SeriesNavVO struct {
+	Id    int64            `json:"id"`
+	Title string           `json:"title"`
+	Prev  *SeriesArticleVO `json:"prev,omitempty"`
+	Next  *SeriesArticleVO `json:"next,omitempty"`
+}
+
+type ReadingProgressVO struct {
+	Paragraph int `json:"paragraph"`
+	Percent   int `json:"percent"`
+}
+
+func newReadingProgressVO(p domain.ReadingProgress) *ReadingProgressVO {
+	if p.Utime.IsZero() {
+		return nil
+	}
+	return &ReadingProgressVO{Paragraph: p.Paragraph, Percent: p.Percent}
+}
+
+func newSeriesNavVO(nav domain.SeriesNav) *SeriesNavVO {
+	if nav.Series.Id == 0 {
+		return nil
+	}
+	res := &SeriesNavVO{Id: nav.Series.Id, Title: nav.Series.Title}
+	if nav.Prev.Id > 0 {
+		res.Prev = &SeriesArticleVO{Id: nav.Prev.Id, Title: nav.Prev.Title}
+	}
+	if nav.Next.Id > 0 {
+		res.Next = &SeriesArticleVO{Id: nav.Next.Id, Title: nav.Next.Title}
+	}
+	return res
+}
+
+type CollectReq struct {
+	// Id 文章 id
+	Id int64 `json:"id"`
+	// Cid 收藏夹 id，不传是默认收藏夹
+	Cid int64 `json:"cid"`
+}
+
+type MoveCollectReq struct {
+	Id      int64 `json:"id"`
+	FromCid int64 `json:"fromCid"`
+	ToCid   int64 `json:"toCid"`
+}
+
+type CollectionReq struct {
+	Id          int64  `json:"id"`
+	Name        string `json:"name"`
+	Description string `json:"description"`
+	Public      bool   `json:"public"`
+}
+
+type CollectionIdReq struct {
+	Id int64 `json:"id" binding:"required"`
+}
+
+type CollectionListReq struct {
+	// Uid 不传看自己的，看别人的只有公开的
+	Uid    int64 `json:"uid"`
+	Offset int   `json:"offset"`
+	Limit  int   `json:"limit"`
+}
+
+type CollectionItemsReq struct {
+	// Cid 不传是自己的默认收藏夹
+	Cid    int64 `json:"cid"`
+	Offset int   `json:"offset"`
+	Limit  int   `json:"limit"`
+}
+
+type CollectionVO struct {
+	Id          int64  `json:"id"`
+	Uid         int64  `json:"uid"`
+	Name        string `json:"name"`
+	Description string `json:"description"`
+	Public      bool   `json:"public"`
+	ItemCnt     int64  `json:"itemCnt"`
+	CreatedAt   string `json:"createdAt"`
+	UpdatedAt   string `json:"updatedAt"`
+}
+
+type CollectionItemVO struct {
+	Id          int64  `json:"id"`
+	Title       string `json:"title"`
+	AuthorId    int64  `json:"authorId"`
+	AuthorName  string `json:"authorName"`
+	CollectedAt string `json:"collectedAt"`
+}
+
+func newCollectionVO(c *interactivev1.Collection) CollectionVO {
+	return CollectionVO{
+		Id:          c.GetId(),
+		Uid:         c.GetUid(),
+		Name:        c.GetName(),
+		Description: c.GetDescription(),
+		Public:      c.GetPublic(),
+		ItemCnt:     c.GetItemCnt(),
+		CreatedAt:   time.UnixMilli(c.GetCtime()).String(),
+		UpdatedAt:   time.UnixMilli(c.GetUtime()).String(),
+	}
+}
@@ -0,0 +1,92 @@
+package article
+
+import (
+	"errors"
+	"strconv"
+
+	"github.com/Andras5014/gohub/internal/domain"
+	"github.com/Andras5014/gohub/internal/service"
+	"github.com/Andras5014/gohub/pkg/ginx"
+	"github.com/gin-gonic/gin"
+)
+
+func (h *Handler) InviteCollaborator(ctx *gin.Context, req InviteReq) (ginx.Result, error) {
+	role := domain.ParseArticleRole(req.Role)
+	if !role.Invitable() {
+		return ginx.Result{Code: 4, Msg: "角色不合法"}, nil
+	}
+	uid := ctx.GetInt64("userId")
+	err := h.svc.InviteCollaborator(ctx, req.ArticleId, uid, req.Uid, role)
+	if err != nil {
+		return h.collaboratorErrResult(err), err
+	}
+	return ginx.Result{Msg: "ok"}, nil
+}
+
+func (h *Handler) RemoveCollaborator(ctx *gin.Context, req CollaboratorReq) (ginx.Result, error) {
+	uid := ctx.GetInt64("userId")
+	if err := h.svc.RemoveCollaborator(ctx, req.ArticleId, uid, req.Uid); err != nil {
+		return h.collaboratorErrResult(err), err
+	}
+	return ginx.Result{Msg: "ok"}, nil
+}
+
+func (h *Handler) Collaborators(ctx *gin.Context) (ginx.Result, error) {
+	id, err := strconv.ParseInt(ctx.Param("id"), 10, 64)
+	if err != nil {
+		return ginx.InvalidParam(), err
+	}
+	uid := ctx.GetInt64("userId")
+	collabs, err := h.svc.ListCollaborators(ctx, id, uid)
+	if err != nil {
+		return h.authorErrResult(err), err
+	}
+	return ginx.Result{Data: newCollaboratorVOs(collabs)}, nil
+}
+
+func (h *Handler) AcceptInvitation(ctx *gin.Context, req InvitationReq) (ginx.Result, error) {
+	uid := ctx.GetInt64("userId")
+	if err := h.svc.AcceptInvitation(ctx, req.ArticleId, uid); err != nil {
+		return h.collaboratorErrResult(err), err
+	}
+	return ginx.Result{Msg: "ok"}, nil
+}
+
+func (h *Handler) DeclineInvitation(ctx *gin.Context, req InvitationReq) (ginx.Result, error) {
+	uid := ctx.GetInt64("userId")
+	if err := h.svc.DeclineInvitation(ctx, req.ArticleId, uid); err != nil {
+		return h.collaboratorErrResult(err), err
+	}
+	return ginx.Result{Msg: "ok"}, nil
+}
+
+func (h *Handler) Collaborations(ctx *gin.Context, req CollaborationListReq) (ginx.Result, error) {
+	var status domain.CollaboratorStatus
+	switch req.Status {
+	case "", "pending":
+		status = domain.CollaboratorStatusPending
+	case "accepted":
+		status = domain.CollaboratorStatusAccepted
+	default:
+		return ginx.InvalidParam(), nil
+	}
+	if req.Limit <= 0 || req.Limit > maxPageSize {
+		req.Limit = defaultPageSize
+	}
+	uid := ctx.GetInt64("userId")
+	collabs, err := h.svc.ListCollaborations(ctx, uid, status, req.Offset, req.Limit)
+	if err != nil {
+		return ginx.SystemError(), err
+	}
+	return ginx.Result{Data: newCollaboratorVOs(collabs)}, nil
+}
+
+func (h *Handler) collaboratorErrResult(err error) ginx.Result {
+	switch {
+	case errors.Is(err, service.ErrInvalidInvitation):
+		return ginx.Result{Code: 4, Msg: "邀请不合法"}
+	case errors.Is(err, service.ErrInvitationNotFound):
+		return ginx.Result{Code: 4, Msg: "邀请不存在"}
+	}
+	return h.authorErrResult(err)
+}
@@ -0,0 +1,181 @@
+package article
+
+import (
+	interactivev1 "github.com/Andras5014/gohub/api/proto/gen/interactive/v1"
+	"github.com/Andras5014/gohub/internal/domain"
+	"github.com/Andras5014/gohub/pkg/ginx"
+	"github.com/ecodeclub/ekit/slice"
+	"github.com/gin-gonic/gin"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+	"time"
+)
+
+func (h *Handler) Collect(ctx *gin.Context, req CollectReq) (ginx.Result, error) {
+	_, err := h.intrSvc.Collect(ctx, &interactivev1.CollectRequest{
+		Biz:   h.biz,
+		BizId: req.Id,
+		Cid:   req.Cid,
+		Uid:   ctx.GetInt64("userId"),
+	})
+	if err != nil {
+		return h.collectionErrResult(err), err
+	}
+	return ginx.Success(), nil
+}
+
+func (h *Handler) Uncollect(ctx *gin.Context, req CollectReq) (ginx.Result, error) {
+	_, err := h.intrSvc.Uncollect(ctx, &interactivev1.UncollectRequest{
+		Biz:   h.biz,
+		BizId: req.Id,
+		Cid:   req.Cid,
+		Uid:   ctx.GetInt64("userId"),
+	})
+	if err != nil {
+		return h.collectionErrResult(err), err
+	}
+	return ginx.Success(), nil
+}
+
+func (h *Handler) MoveCollect(ctx *gin.Context, req MoveCollectReq) (ginx.Result, error) {
+	_, err := h.intrSvc.MoveCollectionItem(ctx, &interactivev1.MoveCollectionItemRequest{
+		Biz:     h.biz,
+		BizId:   req.Id,
+		FromCid: req.FromCid,
+		ToCid:   req.ToCid,
+		Uid:     ctx.GetInt64("userId"),
+	})
+	if err != nil {
+		return h.collectionErrResult(err), err
+	}
+	return ginx.Success(), nil
+}
+
+func (h *Handler) CreateCollection(ctx *gin.Context, req CollectionReq) (ginx.Result, error) {
+	resp, err := h.intrSvc.CreateCollection(ctx, &interactivev1.CreateCollectionRequest{
+		Collection: h.toCollectionDTO(ctx, req),
+	})
+	if err != nil {
+		return h.collectionErrResult(err), err
+	}
+	return ginx.Result{Msg: "ok", Data: resp.Id}, nil
+}
+
+func (h *Handler) EditCollection(ctx *gin.Context, req CollectionReq) (ginx.Result, error) {
+	_, err := h.intrSvc.UpdateCollection(ctx, &interactivev1.UpdateCollectionRequest{
+		Collection: h.toCollectionDTO(ctx, req),
+	})
+	if err != nil {
+		return h.collectionErrResult(err), err
+	}
+	return ginx.Result{Msg: "ok"}, nil
+}
+
+func (h *Handler) DeleteCollection(ctx *gin.Context, req CollectionIdReq) (ginx.Result, error) {
+	_, err := h.intrSvc.DeleteCollection(ctx, &interactivev1.DeleteCollectionRequest{
+		Id:  req.Id,
+		Uid: ctx.GetInt64("userId"),
+	})
+	if err != nil {
+		return h.collectionErrResult(err), err
+	}
+	return ginx.Result{Msg: "ok"}, nil
+}
+
+func (h *Handler) ListCollections(ctx *gin.Context, req CollectionListReq) (ginx.Result, error) {
+	if req.Limit <= 0 || req.Limit > maxPageSize {
+		req.Limit = defaultPageSize
+	}
+	viewer := ctx.GetInt64("userId")
+	if req.Uid == 0 {
+		req.Uid = viewer
+	}
+	resp, err := h.intrSvc.ListCollections(ctx, &interactivev1.ListCollectionsRequest{
+		Uid:       req.Uid,
+		ViewerUid: viewer,
+		Offset:    int32(req.Offset),
+		Limit:     int32(req.Limit),
+	})
+	if err != nil {
+		return h.collectionErrResult(err), err
+	}
+	return ginx.Result{
+		Data: slice.Map(resp.Collections, func(idx int, src *interactivev1.Collection) CollectionVO {
+			return newCollectionVO(src)
+		}),
+	}, nil
+}
+
+// CollectionItems 撤回了的文章不返回，所以可能比 limit 少
+func (h *Handler) CollectionItems(ctx *gin.Context, req CollectionItemsReq) (ginx.Result, error) {
+	if req.Limit <= 0 || req.Limit > maxPageSize {
+		req.Limit = defaultPageSize
+	}
+	resp, err := h.intrSvc.ListCollectionItems(ctx, &interactivev1.ListCollectionItemsRequest{
+		Cid:       req.Cid,
+		ViewerUid: ctx.GetInt64("userId"),
+		Offset:    int32(req.Offset),
+		Limit:     int32(req.Limit),
+	})
+	if err != nil {
+		return h.collectionErrResult(err), err
+	}
+	// 收藏夹里面只会有文章，别的业务的先跳过
+	items := slice.FilterMap(resp.Items, func(idx int, src *interactivev1.CollectionItem) (*interactivev1.CollectionItem, bool) {
+		return src, src.GetBiz() == h.biz
+	})
+	if len(items) == 0 {
+		return ginx.Result{Data: []CollectionItemVO{}}, nil
+	}
+	arts, err := h.svc.ListPubByIds(ctx, slice.Map(items, func(idx int, src *interactivev1.CollectionItem) int64 {
+		return src.GetBizId()
+	}))
+	if err != nil {
+		return ginx.SystemError(), err
+	}
+	artMap := make(map[int64]domain.Article, len(arts))
+	for _, art := range arts {
+		if art.Status == domain.ArticleStatusPublished {
+			artMap[art.Id] = art
+		}
+	}
+	res := make([]CollectionItemVO, 0, len(items))
+	for _, item := range items {
+		art, ok := artMap[item.GetBizId()]
+		if !ok {
+			continue
+		}
+		res = append(res, CollectionItemVO{
+			Id:          art.Id,
+			Title:       art.Title,
+			AuthorId:    art.Author.Id,
+			AuthorName:  art.Author.Name,
+			CollectedAt: time.UnixMilli(item.GetCtime()).String(),
+		})
+	}
+	return ginx.Result{Data: res}, nil
+}
+
+func (h *Handler) toCollectionDTO(ctx *gin.Context, req CollectionReq) *interactivev1.Collection {
+	return &interactivev1.Collection{
+		Id:          req.Id,
+		Uid:         ctx.GetInt64("userId"),
+		Name:        req.Name,
+		Description: req.Description,
+		Public:      req.Public,
+	}
+}
+
+func (h *Handler) collectionErrResult(err error) ginx.Result {
+	s, ok := status.FromError(err)
+	if !ok {
+		return ginx.SystemError()
+	}
+	switch s.Code() {
+	case codes.InvalidArgument:
+		return ginx.Result{Code: 4, Msg: "名字不能为空，名字最多 64 个字，简介最多 500 个字"}
+	case codes.NotFound:
+		return ginx.Result{Code: 4, Msg: "收藏夹不存在"}
+	}
+	return ginx.SystemError()
+}
@@ -0,0 +1,109 @@
+package article
+
+import (
+	"errors"
+	"strconv"
+
+	"github.com/Andras5014/gohub/internal/domain"
+	"github.com/Andras5014/gohub/internal/service"
+	"github.com/Andras5014/gohub/pkg/ginx"
+	"github.com/ecodeclub/ekit/slice"
+	"github.com/gin-gonic/gin"
+)
+
+func (h *Handler) CreateSeries(ctx *gin.Context, req SeriesReq) (ginx.Result, error) {
+	id, err := h.seriesSvc.Create(ctx, domain.Series{
+		AuthorId:    ctx.GetInt64("userId"),
+		Title:       req.Title,
+		Description: req.Description,
+	})
+	if err != nil {
+		return h.seriesErrResult(err), err
+	}
+	return ginx.Result{Msg: "ok", Data: id}, nil
+}
+
+func (h *Handler) EditSeries(ctx *gin.Context, req SeriesReq) (ginx.Result, error) {
+	err := h.seriesSvc.Update(ctx, domain.Series{
+		Id:          req.Id,
+		AuthorId:    ctx.GetInt64("userId"),
+		Title:       req.Title,
+		Description: req.Description,
+	})
+	if err != nil {
+		return h.seriesErrResult(err), err
+	}
+	return ginx.Result{Msg: "ok"}, nil
+}
+
+func (h *Handler) DeleteSeries(ctx *gin.Context, req SeriesIdReq) (ginx.Result, error) {
+	if err := h.seriesSvc.Delete(ctx, req.Id, ctx.GetInt64("userId")); err != nil {
+		return h.seriesErrResult(err), err
+	}
+	return ginx.Result{Msg: "ok"}, nil
+}
+
+func (h *Handler) ListSeries(ctx *gin.Context, req ListReq) (ginx.Result, error) {
+	if req.Limit <= 0 || req.Limit > maxPageSize {
+		req.Limit = defaultPageSize
+	}
+	res, err := h.seriesSvc.List(ctx, ctx.GetInt64("userId"), req.Offset, req.Limit)
+	if err != nil {
+		return ginx.SystemError(), err
+	}
+	return ginx.Result{
+		Data: slice.Map[domain.Series, SeriesVO](res, func(idx int, src domain.Series) SeriesVO {
+			return newSeriesVO(src)
+		}),
+	}, nil
+}
+
+func (h *Handler) SeriesDetail(ctx *gin.Context) (ginx.Result, error) {
+	id, err := strconv.ParseInt(ctx.Param("id"), 10, 64)
+	if err != nil {
+		return ginx.InvalidParam(), err
+	}
+	series, err := h.seriesSvc.Get(ctx, id, ctx.GetInt64("userId"))
+	if err != nil {
+		return h.seriesErrResult(err), err
+	}
+	return ginx.Result{Data: newSeriesVO(series)}, nil
+}
+
+func (h *Handler) AddSeriesArticle(ctx *gin.Context, req SeriesArticleReq) (ginx.Result, error) {
+	err := h.seriesSvc.AddArticle(ctx, req.SeriesId, req.ArticleId, ctx.GetInt64("userId"))
+	if err != nil {
+		return h.seriesErrResult(err), err
+	}
+	return ginx.Result{Msg: "ok"}, nil
+}
+
+func (h *Handler) RemoveSeriesArticle(ctx *gin.Context, req SeriesArticleReq) (ginx.Result, error) {
+	err := h.seriesSvc.RemoveArticle(ctx, req.SeriesId, req.ArticleId, ctx.GetInt64("userId"))
+	if err != nil {
+		return h.seriesErrResult(err), err
+	}
+	return ginx.Result{Msg: "ok"}, nil
+}
+
+func (h *Handler) ReorderSeries(ctx *gin.Context, req SeriesReorderReq) (ginx.Result, error) {
+	err := h.seriesSvc.Reorder(ctx, req.SeriesId, ctx.GetInt64("userId"), req.ArticleIds)
+	if err != nil {
+		return h.seriesErrResult(err), err
+	}
+	return ginx.Result{Msg: "ok"}, nil
+}
+
+func (h *Handler) seriesErrResult(err error) ginx.Result {
+	switch {
+	case errors.Is(err, service.ErrInvalidSeries):
+		return ginx.Result{Code: 4, Msg: "标题不能为空，标题最多 100 个字，简介最多 500 个字"}
+	case errors.Is(err, service.ErrSeriesNotFound):
+		return ginx.Result{Code: 4, Msg: "系列或者文章不存在"}
+	case errors.Is(err, service.ErrArticleInSeries):
+		return ginx.Result{Code: 4, Msg: "文章已经在别的系列里面"}
+	case errors.Is(err, service.ErrSeriesOrderMismatch):
+		return ginx.Result{Code: 4, Msg: "排序的文章和系列里面的不一致"}
+	}
+	return h.authorErrResult(err)
+}
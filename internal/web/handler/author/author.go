@@ -0,0 +1,151 @@
+package author
+
+import (
+	"errors"
+	"github.com/Andras5014/gohub/internal/domain"
+	"github.com/Andras5014/gohub/internal/service"
+	"github.com/Andras5014/gohub/internal/web/handler"
+	"github.com/Andras5014/gohub/pkg/ginx"
+	"github.com/Andras5014/gohub/pkg/logx"
+	"github.com/ecodeclub/ekit/slice"
+	"github.com/gin-gonic/gin"
+	"strconv"
+)
+
+var _ handler.Handler = &Handler{}
+
+const (
+	defaultPageSize = 10
+	maxPageSize     = 50
+)
+
+type Handler struct {
+	svc       service.AuthorService
+	followSvc service.FollowService
+	logger    logx.Logger
+}
+
+func NewAuthorHandler(svc service.AuthorService, followSvc service.FollowService, logger logx.Logger) *Handler {
+	return &Handler{
+		svc:       svc,
+		followSvc: followSvc,
+		logger:    logger,
+	}
+}
+
+func (h *Handler) RegisterRoutes(engine *gin.Engine) {
+	engine.GET("/pub/authors/:id", ginx.Wrap(h.logger, h.Home))
+}
+
+// Home 作者主页，作者信息只在第一页返回
+func (h *Handler) Home(ctx *gin.Context) (ginx.Result, error) {
+	id, err := strconv.ParseInt(ctx.Param("id"), 10, 64)
+	if err != nil {
+		return ginx.InvalidParam(), err
+	}
+	var req HomeReq
+	if err = ctx.ShouldBindQuery(&req); err != nil {
+		return ginx.InvalidParam(), err
+	}
+	cursor, err := domain.ParseArticleCursor(req.Cursor)
+	if err != nil {
+		return ginx.InvalidParam(), nil
+	}
+	limit := req.Limit
+	if limit <= 0 {
+		limit = defaultPageSize
+	}
+	if limit > maxPageSize {
+		limit = maxPageSize
+	}
+
+	var res HomeVO
+	if cursor.IsZero() {
+		profile, err := h.svc.Profile(ctx, id)
+		if errors.Is(err, service.ErrAuthorNotFound) {
+			return ginx.Result{Code: 4, Msg: "作者不存在"}, nil
+		}
+		if err != nil {
+			return ginx.SystemError(), err
+		}
+		res.Author = &ProfileVO{
+			Id:          profile.Id,
+			NickName:    profile.NickName,
+			AboutMe:     profile.AboutMe,
+			ArticleCnt:  profile.ArticleCnt,
+			LikeCnt:     profile.LikeCnt,
+			FollowerCnt: profile.FollowerCnt,
+			FolloweeCnt: profile.FolloweeCnt,
+			Followed:    h.followed(ctx, id),
+		}
+	}
+	arts, err := h.svc.ListPub(ctx, id, cursor, limit)
+	if err != nil {
+		return ginx.SystemError(), err
+	}
+	res.List = slice.Map[domain.Article, ArticleVO](arts, func(idx int, src domain.Article) ArticleVO {
+		return ArticleVO{
+			Id:          src.Id,
+			Title:       src.Title,
+			Abstract:    src.Abstract(),
+			Tags:        src.Tags,
+			ReadingTime: src.Rendered.ReadingTime,
+			CreatedAt:   src.CreatedAt.String(),
+			UpdatedAt:   src.UpdatedAt.String(),
+		}
+	})
+	// 不满一页说明已经到底了
+	if len(arts) == limit {
+		res.NextCursor = arts[len(arts)-1].Cursor().Encode()
+	}
+	return ginx.Result{Data: res}, nil
+}
+
+// followed 没登录或者看自己的主页都是 false，查不到不影响看主页
+func (h *Handler) followed(ctx *gin.Context, id int64) bool {
+	uid := ctx.GetInt64("userId")
+	if uid <= 0 || uid == id {
+		return false
+	}
+	followed, err := h.followSvc.Followed(ctx, uid, []int64{id})
+	if err != nil {
+		h.logger.Error("查询是否关注作者失败", logx.Int64("uid", uid), logx.Int64("authorId", id), logx.Error(err))
+		return false
+	}
+	return followed[id]
+}
+
+type HomeReq struct {
+	Cursor string `form:"cursor"`
+	Limit  int    `form:"limit"`
+}
+
+type HomeVO struct {
+	// Author 翻页的时候不再返回
+	Author     *ProfileVO  `json:"author,omitempty"`
+	List       []ArticleVO `json:"list"`
+	NextCursor string      `json:"nextCursor"`
+}
+
+type ProfileVO struct {
+	Id         int64  `json:"id"`
+	NickName   string `json:"nickname"`
+	AboutMe    string `json:"aboutMe"`
+	ArticleCnt int64  `json:"articleCnt"`
+	LikeCnt    int64  `json:"likeCnt"`
+
+	FollowerCnt int64 `json:"followerCnt"`
+	FolloweeCnt int64 `json:"followeeCnt"`
+	// Followed 当前用户有没有关注作者
+	Followed bool `json:"followed"`
+}
+
+type ArticleVO struct {
+	Id          int64    `json:"id"`
+	Title       string   `json:"title"`
+	Abstract    string   `json:"abstract"`
+	Tags        []string `json:"tags,omitempty"`
+	ReadingTime int64    `json:"readingTime,omitempty"`
+	CreatedAt   string   `json:"createdAt"`
+	UpdatedAt   string   `json:"updatedAt"`
+}
@@ -0,0 +1,146 @@
+package author
+
+import (
+	"encoding/json"
+	"errors"
+	"github.com/Andras5014/gohub/internal/domain"
+	"github.com/Andras5014/gohub/internal/service"
+	svcmocks "github.com/Andras5014/gohub/internal/service/mocks"
+	"github.com/Andras5014/gohub/pkg/logx"
+	"github.com/gin-gonic/gin"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"go.uber.org/mock/gomock"
+	"go.uber.org/zap"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+)
+
+func TestHandler_Home(t *testing.T) {
+	utime := time.UnixMilli(1700000000000)
+	cursor := domain.ArticleCursor{UpdatedAt: utime, Id: 2}
+	testCases := []struct {
+		name string
+		mock func(ctrl *gomock.Controller) service.AuthorService
+
+		path string
+
+		wantCode   int
+		wantMsg    string
+		wantAuthor *ProfileVO
+		wantIds    []int64
+		wantNext   string
+	}{
+		{
+			name: "第一页带上作者信息",
+			mock: func(ctrl *gomock.Controller) service.AuthorService {
+				svc := svcmocks.NewMockAuthorService(ctrl)
+				svc.EXPECT().Profile(gomock.Any(), int64(123)).
+					Return(domain.AuthorProfile{
+						Id:          123,
+						NickName:    "andras",
+						AboutMe:     "hello",
+						ArticleCnt:  3,
+						LikeCnt:     10,
+						FollowerCnt: 5,
+						FolloweeCnt: 2,
+					}, nil)
+				svc.EXPECT().ListPub(gomock.Any(), int64(123), domain.ArticleCursor{}, 2).
+					Return([]domain.Article{
+						{Id: 3, UpdatedAt: utime},
+						{Id: 2, UpdatedAt: utime},
+					}, nil)
+				return svc
+			},
+			path: "/pub/authors/123?limit=2",
+			wantAuthor: &ProfileVO{
+				Id:          123,
+				NickName:    "andras",
+				AboutMe:     "hello",
+				ArticleCnt:  3,
+				LikeCnt:     10,
+				FollowerCnt: 5,
+				FolloweeCnt: 2,
+			},
+			wantIds:  []int64{3, 2},
+			wantNext: cursor.Encode(),
+		},
+		{
+			name: "翻页不再查询作者信息",
+			mock: func(ctrl *gomock.Controller) service.AuthorService {
+				svc := svcmocks.NewMockAuthorService(ctrl)
+				svc.EXPECT().ListPub(gomock.Any(), int64(123), cursor, 2).
+					Return([]domain.Article{{Id: 1, UpdatedAt: utime}}, nil)
+				return svc
+			},
+			path:    "/pub/authors/123?limit=2&cursor=" + cursor.Encode(),
+			wantIds: []int64{1},
+		},
+		{
+			name: "作者不存在",
+			mock: func(ctrl *gomock.Controller) service.AuthorService {
+				svc := svcmocks.NewMockAuthorService(ctrl)
+				svc.EXPECT().Profile(gomock.Any(), int64(123)).
+					Return(domain.AuthorProfile{}, service.ErrAuthorNotFound)
+				return svc
+			},
+			path:     "/pub/authors/123",
+			wantCode: 4,
+			wantMsg:  "作者不存在",
+		},
+		{
+			name: "游标不合法",
+			mock: func(ctrl *gomock.Controller) service.AuthorService {
+				return svcmocks.NewMockAuthorService(ctrl)
+			},
+			path:     "/pub/authors/123?cursor=!!!",
+			wantCode: 4,
+			wantMsg:  "参数错误",
+		},
+		{
+			name: "查询文章失败",
+			mock: func(ctrl *gomock.Controller) service.AuthorService {
+				svc := svcmocks.NewMockAuthorService(ctrl)
+				svc.EXPECT().ListPub(gomock.Any(), int64(123), cursor, defaultPageSize).
+					Return(nil, errors.New("mock db error"))
+				return svc
+			},
+			path:     "/pub/authors/123?cursor=" + cursor.Encode(),
+			wantCode: 5,
+			wantMsg:  "系统错误",
+		},
+	}
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			ctrl := gomock.NewController(t)
+			defer ctrl.Finish()
+			server := gin.Default()
+			h := NewAuthorHandler(tc.mock(ctrl), nil, logx.NewZapLogger(zap.NewNop()))
+			h.RegisterRoutes(server)
+			req, err := http.NewRequest(http.MethodGet, tc.path, nil)
+			require.NoError(t, err)
+			resp := httptest.NewRecorder()
+
+			server.ServeHTTP(resp, req)
+
+			assert.Equal(t, http.StatusOK, resp.Code)
+			var webRes struct {
+				Code int    `json:"code"`
+				Msg  string `json:"msg"`
+				Data HomeVO `json:"data"`
+			}
+			require.NoError(t, json.NewDecoder(resp.Body).Decode(&webRes))
+			assert.Equal(t, tc.wantCode, webRes.Code)
+			assert.Equal(t, tc.wantMsg, webRes.Msg)
+			assert.Equal(t, tc.wantAuthor, webRes.Data.Author)
+			var ids []int64
+			for _, vo := range webRes.Data.List {
+				ids = append(ids, vo.Id)
+			}
+			assert.Equal(t, tc.wantIds, ids)
+			assert.Equal(t, tc.wantNext, webRes.Data.NextCursor)
+		})
+	}
+}
@@ -0,0 +1,199 @@
+package comment
+
+import (
+	"context"
+	"errors"
+	commentv1 "github.com/Andras5014/gohub/api/proto/gen/comment/v1"
+	"github.com/Andras5014/gohub/internal/domain"
+	"github.com/Andras5014/gohub/internal/service"
+	"github.com/Andras5014/gohub/internal/web/handler"
+	"github.com/Andras5014/gohub/pkg/ginx"
+	"github.com/Andras5014/gohub/pkg/logx"
+	"github.com/gin-gonic/gin"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+)
+
+var _ handler.Handler = &Handler{}
+
+var (
+	errUnsupportedBiz = errors.New("不支持评论的业务")
+	errBizNotFound    = errors.New("评论的对象不存在")
+)
+
+const (
+	defaultPageSize = 20
+	maxPageSize     = 100
+
+	bizArticle = "article"
+)
+
+type Handler struct {
+	svc    commentv1.CommentServiceClient
+	artSvc service.ArticleService
+	logger logx.Logger
+}
+
+func NewCommentHandler(svc commentv1.CommentServiceClient, artSvc service.ArticleService, logger logx.Logger) *Handler {
+	return &Handler{
+		svc:    svc,
+		artSvc: artSvc,
+		logger: logger,
+	}
+}
+
+func (h *Handler) RegisterRoutes(engine *gin.Engine) {
+	g := engine.Group("/comments")
+	g.POST("/create", ginx.WrapBody(h.logger, h.Create))
+	g.POST("/delete", ginx.WrapBody(h.logger, h.Delete))
+	g.POST("/pin", ginx.WrapBody(h.logger, h.Pin))
+	g.POST("/like", ginx.WrapBody(h.logger, h.Like))
+	g.POST("/list", ginx.WrapBody(h.logger, h.List))
+	g.POST("/replies", ginx.WrapBody(h.logger, h.Replies))
+}
+
+func (h *Handler) Create(ctx *gin.Context, req CreateReq) (ginx.Result, error) {
+	if _, err := h.ownerOf(ctx, req.Biz, req.BizId); err != nil {
+		return h.errResult(err), err
+	}
+	resp, err := h.svc.CreateComment(ctx, &commentv1.CreateCommentRequest{
+		Comment: &commentv1.Comment{
+			Biz:      req.Biz,
+			BizId:    req.BizId,
+			Uid:      ctx.GetInt64("userId"),
+			ParentId: req.ParentId,
+			Content:  req.Content,
+		},
+	})
+	if err != nil {
+		return h.errResult(err), err
+	}
+	return ginx.Result{Msg: "ok", Data: resp.Id}, nil
+}
+
+func (h *Handler) Delete(ctx *gin.Context, req DeleteReq) (ginx.Result, error) {
+	_, err := h.svc.DeleteComment(ctx, &commentv1.DeleteCommentRequest{
+		Id:  req.Id,
+		Uid: ctx.GetInt64("userId"),
+	})
+	if err != nil {
+		return h.errResult(err), err
+	}
+	return ginx.Success(), nil
+}
+
+// Pin 只有资源的作者可以置顶
+func (h *Handler) Pin(ctx *gin.Context, req PinReq) (ginx.Result, error) {
+	owner, err := h.ownerOf(ctx, req.Biz, req.BizId)
+	if err != nil {
+		return h.errResult(err), err
+	}
+	if owner != ctx.GetInt64("userId") {
+		return ginx.Result{Code: 4, Msg: "只有作者可以置顶评论"}, nil
+	}
+	_, err = h.svc.PinComment(ctx, &commentv1.PinCommentRequest{
+		Biz:    req.Biz,
+		BizId:  req.BizId,
+		Id:     req.Id,
+		Pinned: req.Pinned,
+	})
+	if err != nil {
+		return h.errResult(err), err
+	}
+	return ginx.Success(), nil
+}
+
+func (h *Handler) Like(ctx *gin.Context, req LikeReq) (ginx.Result, error) {
+	uid := ctx.GetInt64("userId")
+	var err error
+	if req.Like {
+		_, err = h.svc.LikeComment(ctx, &commentv1.LikeCommentRequest{Id: req.Id, Uid: uid})
+	} else {
+		_, err = h.svc.CancelLikeComment(ctx, &commentv1.CancelLikeCommentRequest{Id: req.Id, Uid: uid})
+	}
+	if err != nil {
+		return h.errResult(err), err
+	}
+	return ginx.Success(), nil
+}
+
+func (h *Handler) List(ctx *gin.Context, req ListReq) (ginx.Result, error) {
+	sortBy := commentv1.SortBy_SORT_BY_TIME
+	switch req.SortBy {
+	case "", "time":
+	case "like":
+		sortBy = commentv1.SortBy_SORT_BY_LIKE
+	default:
+		return ginx.InvalidParam(), nil
+	}
+	if req.Limit <= 0 || req.Limit > maxPageSize {
+		req.Limit = defaultPageSize
+	}
+	resp, err := h.svc.GetCommentList(ctx, &commentv1.GetCommentListRequest{
+		Biz:    req.Biz,
+		BizId:  req.BizId,
+		SortBy: sortBy,
+		Offset: int32(req.Offset),
+		Limit:  int32(req.Limit),
+		Uid:    ctx.GetInt64("userId"),
+	})
+	if err != nil {
+		return h.errResult(err), err
+	}
+	return ginx.Result{Data: newCommentVOs(resp.Comments)}, nil
+}
+
+func (h *Handler) Replies(ctx *gin.Context, req RepliesReq) (ginx.Result, error) {
+	if req.Limit <= 0 || req.Limit > maxPageSize {
+		req.Limit = defaultPageSize
+	}
+	resp, err := h.svc.GetReplyList(ctx, &commentv1.GetReplyListRequest{
+		RootId: req.RootId,
+		Offset: int32(req.Offset),
+		Limit:  int32(req.Limit),
+		Uid:    ctx.GetInt64("userId"),
+	})
+	if err != nil {
+		return h.errResult(err), err
+	}
+	return ginx.Result{Data: newCommentVOs(resp.Replies)}, nil
+}
+
+// ownerOf 目前只有文章可以评论，只能评论已经发表的文章
+func (h *Handler) ownerOf(ctx context.Context, biz string, bizId int64) (int64, error) {
+	if biz != bizArticle {
+		return 0, errUnsupportedBiz
+	}
+	// 不用 GetPubById，它会记一次阅读
+	arts, err := h.artSvc.ListPubByIds(ctx, []int64{bizId})
+	if err != nil {
+		return 0, err
+	}
+	if len(arts) == 0 || arts[0].Status != domain.ArticleStatusPublished {
+		return 0, errBizNotFound
+	}
+	return arts[0].Author.Id, nil
+}
+
+// errResult 评论服务用 grpc 的错误码区分业务错误
+func (h *Handler) errResult(err error) ginx.Result {
+	switch {
+	case errors.Is(err, errUnsupportedBiz):
+		return ginx.Result{Code: 4, Msg: "不支持评论的业务"}
+	case errors.Is(err, errBizNotFound):
+		return ginx.Result{Code: 4, Msg: "文章不存在"}
+	}
+	s, ok := status.FromError(err)
+	if !ok {
+		return ginx.SystemError()
+	}
+	switch s.Code() {
+	case codes.InvalidArgument:
+		return ginx.Result{Code: 4, Msg: "评论内容不能为空，最多 1000 个字"}
+	case codes.NotFound:
+		return ginx.Result{Code: 4, Msg: "评论不存在"}
+	case codes.PermissionDenied:
+		return ginx.Result{Code: 4, Msg: "只能删除自己的评论"}
+	}
+	return ginx.SystemError()
+}
@@ -0,0 +1,79 @@
+package comment
+
+import (
+	commentv1 "github.com/Andras5014/gohub/api/proto/gen/comment/v1"
+	"github.com/ecodeclub/ekit/slice"
+	"time"
+)
+
+type CreateReq struct {
+	Biz   string `json:"biz"`
+	BizId int64  `json:"bizId"`
+	// ParentId 回复的评论，为 0 表示一级评论
+	ParentId int64  `json:"parentId"`
+	Content  string `json:"content"`
+}
+
+type DeleteReq struct {
+	Id int64 `json:"id"`
+}
+
+type PinReq struct {
+	Biz   string `json:"biz"`
+	BizId int64  `json:"bizId"`
+	Id    int64  `json:"id"`
+	// Pinned 为 false 表示取消置顶
+	Pinned bool `json:"pinned"`
+}
+
+type LikeReq struct {
+	Id   int64 `json:"id"`
+	Like bool  `json:"like"`
+}
+
+type ListReq struct {
+	Biz   string `json:"biz"`
+	BizId int64  `json:"bizId"`
+	// SortBy time 或者 like，默认按时间
+	SortBy string `json:"sortBy"`
+	Offset int    `json:"offset"`
+	Limit  int    `json:"limit"`
+}
+
+type RepliesReq struct {
+	RootId int64 `json:"rootId"`
+	Offset int   `json:"offset"`
+	Limit  int   `json:"limit"`
+}
+
+type CommentVO struct {
+	Id         int64  `json:"id"`
+	Uid        int64  `json:"uid"`
+	RootId     int64  `json:"rootId"`
+	ParentId   int64  `json:"parentId"`
+	ReplyToUid int64  `json:"replyToUid"`
+	Content    string `json:"content"`
+	LikeCnt    int64  `json:"likeCnt"`
+	ReplyCnt   int64  `json:"replyCnt"`
+	Pinned     bool   `json:"pinned"`
+	Liked      bool   `json:"liked"`
+	CreatedAt  string `json:"createdAt"`
+}
+
+func newCommentVOs(comments []*commentv1.Comment) []CommentVO {
+	return slice.Map(comments, func(idx int, src *commentv1.Comment) CommentVO {
+		return CommentVO{
+			Id:         src.Id,
+			Uid:        src.Uid,
+			RootId:     src.RootId,
+			ParentId:   src.ParentId,
+			ReplyToUid: src.ReplyToUid,
+			Content:    src.Content,
+			LikeCnt:    src.LikeCnt,
+			ReplyCnt:   src.ReplyCnt,
+			Pinned:     src.Pinned,
+			Liked:      src.Liked,
+			CreatedAt:  time.UnixMilli(src.Ctime).String(),
+		}
+	})
+}
@@ -0,0 +1,89 @@
+package feed
+
+import (
+	"github.com/Andras5014/gohub/internal/domain"
+	"github.com/Andras5014/gohub/internal/service"
+	"github.com/Andras5014/gohub/internal/web/handler"
+	"github.com/Andras5014/gohub/pkg/ginx"
+	"github.com/Andras5014/gohub/pkg/logx"
+	"github.com/ecodeclub/ekit/slice"
+	"github.com/gin-gonic/gin"
+)
+
+var _ handler.Handler = &Handler{}
+
+const (
+	defaultPageSize = 10
+	maxPageSize     = 50
+)
+
+type Handler struct {
+	svc    service.FeedService
+	logger logx.Logger
+}
+
+func NewFeedHandler(svc service.FeedService, logger logx.Logger) *Handler {
+	return &Handler{
+		svc:    svc,
+		logger: logger,
+	}
+}
+
+func (h *Handler) RegisterRoutes(engine *gin.Engine) {
+	engine.POST("/feed", ginx.WrapBody(h.logger, h.Feed))
+}
+
+// Feed 关注的作者发表的文章，按发表时间倒序翻页
+func (h *Handler) Feed(ctx *gin.Context, req FeedReq) (ginx.Result, error) {
+	cursor, err := domain.ParseArticleCursor(req.Cursor)
+	if err != nil {
+		return ginx.InvalidParam(), nil
+	}
+	if req.Limit <= 0 || req.Limit > maxPageSize {
+		req.Limit = defaultPageSize
+	}
+	arts, err := h.svc.Feed(ctx, ctx.GetInt64("userId"), cursor, req.Limit)
+	if err != nil {
+		return ginx.SystemError(), err
+	}
+	res := FeedVO{
+		List: slice.Map(arts, func(idx int, src domain.Article) ArticleVO {
+			return ArticleVO{
+				Id:          src.Id,
+				Title:       src.Title,
+				Abstract:    src.Abstract(),
+				AuthorId:    src.Author.Id,
+				AuthorName:  src.Author.Name,
+				Tags:        src.Tags,
+				ReadingTime: src.Rendered.ReadingTime,
+				UpdatedAt:   src.UpdatedAt.String(),
+			}
+		}),
+	}
+	// 不满一页说明已经到底了
+	if len(arts) == req.Limit {
+		res.NextCursor = arts[len(arts)-1].Cursor().Encode()
+	}
+	return ginx.Result{Data: res}, nil
+}
+
+type FeedReq struct {
+	Cursor string `json:"cursor"`
+	Limit  int    `json:"limit"`
+}
+
+type FeedVO struct {
+	List       []ArticleVO `json:"list"`
+	NextCursor string      `json:"nextCursor"`
+}
+
+type ArticleVO struct {
+	Id          int64    `json:"id"`
+	Title       string   `json:"title"`
+	Abstract    string   `json:"abstract"`
+	AuthorId    int64    `json:"authorId"`
+	AuthorName  string   `json:"authorName"`
+	Tags        []string `json:"tags"`
+	ReadingTime int64    `json:"readingTime"`
+	UpdatedAt   string   `json:"updatedAt"`
+}
@@ -0,0 +1,134 @@
+package follow
+
+import (
+	"errors"
+	"github.com/Andras5014/gohub/internal/domain"
+	"github.com/Andras5014/gohub/internal/service"
+	"github.com/Andras5014/gohub/internal/web/handler"
+	"github.com/Andras5014/gohub/pkg/ginx"
+	"github.com/Andras5014/gohub/pkg/logx"
+	"github.com/ecodeclub/ekit/slice"
+	"github.com/gin-gonic/gin"
+)
+
+var _ handler.Handler = &Handler{}
+
+const (
+	defaultPageSize = 20
+	maxPageSize     = 100
+	// maxCheckSize 一次最多判断多少个用户
+	maxCheckSize = 100
+)
+
+type Handler struct {
+	svc    service.FollowService
+	logger logx.Logger
+}
+
+func NewFollowHandler(svc service.FollowService, logger logx.Logger) *Handler {
+	return &Handler{
+		svc:    svc,
+		logger: logger,
+	}
+}
+
+func (h *Handler) RegisterRoutes(engine *gin.Engine) {
+	g := engine.Group("/follow")
+	g.POST("/follow", ginx.WrapBody(h.logger, h.Follow))
+	g.POST("/cancel", ginx.WrapBody(h.logger, h.Cancel))
+	g.POST("/followers", ginx.WrapBody(h.logger, h.Followers))
+	g.POST("/followees", ginx.WrapBody(h.logger, h.Followees))
+	g.POST("/statics", ginx.WrapBody(h.logger, h.Statics))
+	g.POST("/check", ginx.WrapBody(h.logger, h.Check))
+}
+
+func (h *Handler) Follow(ctx *gin.Context, req FollowReq) (ginx.Result, error) {
+	err := h.svc.Follow(ctx, ctx.GetInt64("userId"), req.Followee)
+	switch {
+	case errors.Is(err, service.ErrFollowSelf):
+		return ginx.Result{Code: 4, Msg: "不能关注自己"}, nil
+	case errors.Is(err, service.ErrFolloweeNotFound):
+		return ginx.Result{Code: 4, Msg: "用户不存在"}, nil
+	case err != nil:
+		return ginx.SystemError(), err
+	}
+	return ginx.Success(), nil
+}
+
+func (h *Handler) Cancel(ctx *gin.Context, req FollowReq) (ginx.Result, error) {
+	err := h.svc.Unfollow(ctx, ctx.GetInt64("userId"), req.Followee)
+	if err != nil {
+		return ginx.SystemError(), err
+	}
+	return ginx.Success(), nil
+}
+
+// Followers uid 为 0 的时候查自己的粉丝
+func (h *Handler) Followers(ctx *gin.Context, req ListReq) (ginx.Result, error) {
+	h.normalize(ctx, &req)
+	res, err := h.svc.FollowerList(ctx, req.Uid, req.Offset, req.Limit)
+	if err != nil {
+		return ginx.SystemError(), err
+	}
+	return ginx.Result{
+		Data: slice.Map(res, func(idx int, src domain.FollowRelation) FollowVO {
+			return FollowVO{Uid: src.Follower, FollowedAt: src.Ctime.String()}
+		}),
+	}, nil
+}
+
+// Followees uid 为 0 的时候查自己关注的人
+func (h *Handler) Followees(ctx *gin.Context, req ListReq) (ginx.Result, error) {
+	h.normalize(ctx, &req)
+	res, err := h.svc.FolloweeList(ctx, req.Uid, req.Offset, req.Limit)
+	if err != nil {
+		return ginx.SystemError(), err
+	}
+	return ginx.Result{
+		Data: slice.Map(res, func(idx int, src domain.FollowRelation) FollowVO {
+			return FollowVO{Uid: src.Followee, FollowedAt: src.Ctime.String()}
+		}),
+	}, nil
+}
+
+func (h *Handler) Statics(ctx *gin.Context, req StaticsReq) (ginx.Result, error) {
+	if req.Uid <= 0 {
+		req.Uid = ctx.GetInt64("userId")
+	}
+	res, err := h.svc.Statics(ctx, req.Uid)
+	if err != nil {
+		return ginx.SystemError(), err
+	}
+	return ginx.Result{Data: StaticsVO{
+		Uid:       res.Uid,
+		Followers: res.Followers,
+		Followees: res.Followees,
+	}}, nil
+}
+
+// Check 返回 uids 里面已经关注了的
+func (h *Handler) Check(ctx *gin.Context, req CheckReq) (ginx.Result, error) {
+	if len(req.Uids) == 0 || len(req.Uids) > maxCheckSize {
+		return ginx.InvalidParam(), nil
+	}
+	followed, err := h.svc.Followed(ctx, ctx.GetInt64("userId"), req.Uids)
+	if err != nil {
+		return ginx.SystemError(), err
+	}
+	res := make([]int64, 0, len(followed))
+	for _, uid := range req.Uids {
+		if followed[uid] {
+			res = append(res, uid)
+		}
+	}
+	return ginx.Result{Data: res}, nil
+}
+
+func (h *Handler) normalize(ctx *gin.Context, req *ListReq) {
+	if req.Uid <= 0 {
+		req.Uid = ctx.GetInt64("userId")
+	}
+	if req.Limit <= 0 || req.Limit > maxPageSize {
+		req.Limit = defaultPageSize
+	}
+}
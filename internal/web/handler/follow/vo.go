@@ -0,0 +1,32 @@
+package follow
+
+type FollowReq struct {
+	Followee int64 `json:"followee"`
+}
+
+type ListReq struct {
+	Uid    int64 `json:"uid"`
+	Offset int   `json:"offset"`
+	Limit  int   `json:"limit"`
+}
+
+type StaticsReq struct {
+	Uid int64 `json:"uid"`
+}
+
+type CheckReq struct {
+	Uids []int64 `json:"uids"`
+}
+
+type FollowVO struct {
+	Uid        int64  `json:"uid"`
+	FollowedAt string `json:"followedAt"`
+}
+
+type StaticsVO struct {
+	Uid int64 `json:"uid"`
+	// Followers 粉丝数
+	Followers int64 `json:"followers"`
+	// Followees 关注数
+	Followees int64 `json:"followees"`
+}
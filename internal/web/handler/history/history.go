@@ -0,0 +1,108 @@
+package history
+
+import (
+	"github.com/Andras5014/gohub/internal/domain"
+	"github.com/Andras5014/gohub/internal/service"
+	"github.com/Andras5014/gohub/internal/web/handler"
+	"github.com/Andras5014/gohub/pkg/ginx"
+	"github.com/Andras5014/gohub/pkg/logx"
+	"github.com/ecodeclub/ekit/slice"
+	"github.com/gin-gonic/gin"
+)
+
+var _ handler.Handler = &Handler{}
+
+const (
+	defaultPageSize = 20
+	maxPageSize     = 100
+
+	bizArticle = "article"
+)
+
+type Handler struct {
+	svc    service.HistoryService
+	artSvc service.ArticleService
+	logger logx.Logger
+}
+
+func NewHistoryHandler(svc service.HistoryService, artSvc service.ArticleService, logger logx.Logger) *Handler {
+	return &Handler{
+		svc:    svc,
+		artSvc: artSvc,
+		logger: logger,
+	}
+}
+
+func (h *Handler) RegisterRoutes(engine *gin.Engine) {
+	g := engine.Group("/users/history")
+	g.POST("/list", ginx.WrapBody(h.logger, h.List))
+	g.POST("/delete", ginx.WrapBody(h.logger, h.Delete))
+	g.POST("/clear", ginx.Wrap(h.logger, h.Clear))
+}
+
+func (h *Handler) List(ctx *gin.Context, req ListReq) (ginx.Result, error) {
+	if req.Limit <= 0 || req.Limit > maxPageSize {
+		req.Limit = defaultPageSize
+	}
+	records, err := h.svc.List(ctx, ctx.GetInt64("userId"), req.Offset, req.Limit)
+	if err != nil {
+		return ginx.SystemError(), err
+	}
+	res := slice.Map(records, func(idx int, src domain.HistoryRecord) HistoryVO {
+		return HistoryVO{
+			Biz:    src.Biz,
+			BizId:  src.BizId,
+			ReadAt: src.Ctime.String(),
+		}
+	})
+	h.fillTitles(ctx, res)
+	return ginx.Result{Data: res}, nil
+}
+
+// fillTitles 目前只有文章，撤回了的文章没有标题，查不到不影响看历史
+func (h *Handler) fillTitles(ctx *gin.Context, records []HistoryVO) {
+	var ids []int64
+	for _, r := range records {
+		if r.Biz == bizArticle {
+			ids = append(ids, r.BizId)
+		}
+	}
+	if len(ids) == 0 {
+		return
+	}
+	arts, err := h.artSvc.ListPubByIds(ctx, ids)
+	if err != nil {
+		h.logger.Error("查询阅读历史的文章失败", logx.Error(err))
+		return
+	}
+	titles := make(map[int64]string, len(arts))
+	for _, art := range arts {
+		if art.Status == domain.ArticleStatusPublished {
+			titles[art.Id] = art.Title
+		}
+	}
+	for i := range records {
+		if records[i].Biz == bizArticle {
+			records[i].Title = titles[records[i].BizId]
+		}
+	}
+}
+
+func (h *Handler) Delete(ctx *gin.Context, req DeleteReq) (ginx.Result, error) {
+	if req.Biz == "" || req.BizId <= 0 {
+		return ginx.InvalidParam(), nil
+	}
+	err := h.svc.Delete(ctx, ctx.GetInt64("userId"), req.Biz, req.BizId)
+	if err != nil {
+		return ginx.SystemError(), err
+	}
+	return ginx.Success(), nil
+}
+
+func (h *Handler) Clear(ctx *gin.Context) (ginx.Result, error) {
+	err := h.svc.Clear(ctx, ctx.GetInt64("userId"))
+	if err != nil {
+		return ginx.SystemError(), err
+	}
+	return ginx.Success(), nil
+}
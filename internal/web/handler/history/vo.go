@@ -0,0 +1,19 @@
+package history
+
+type ListReq struct {
+	Offset int `json:"offset"`
+	Limit  int `json:"limit"`
+}
+
+type DeleteReq struct {
+	Biz   string `json:"biz"`
+	BizId int64  `json:"bizId"`
+}
+
+type HistoryVO struct {
+	Biz   string `json:"biz"`
+	BizId int64  `json:"bizId"`
+	// Title 撤回了的文章为空
+	Title  string `json:"title"`
+	ReadAt string `json:"readAt"`
+}
@@ -0,0 +1,122 @@
+package notification
+
+import (
+	"github.com/Andras5014/gohub/internal/domain"
+	"github.com/Andras5014/gohub/internal/service"
+	"github.com/Andras5014/gohub/internal/web/handler"
+	"github.com/Andras5014/gohub/pkg/ginx"
+	"github.com/Andras5014/gohub/pkg/logx"
+	"github.com/ecodeclub/ekit/slice"
+	"github.com/gin-gonic/gin"
+)
+
+var _ handler.Handler = &Handler{}
+
+const (
+	defaultPageSize = 20
+	maxPageSize     = 100
+)
+
+// Handler 站内通知，通知是 kafka 消费的时候写进去的，这里只有读和标记
+type Handler struct {
+	svc    service.NotificationService
+	logger logx.Logger
+}
+
+func NewNotificationHandler(svc service.NotificationService, logger logx.Logger) *Handler {
+	return &Handler{
+		svc:    svc,
+		logger: logger,
+	}
+}
+
+func (h *Handler) RegisterRoutes(engine *gin.Engine) {
+	g := engine.Group("/notifications")
+	g.POST("/list", ginx.WrapBody(h.logger, h.List))
+	g.GET("/unread", ginx.Wrap(h.logger, h.Unread))
+	g.POST("/read", ginx.WrapBody(h.logger, h.Read))
+	g.POST("/read_all", ginx.Wrap(h.logger, h.ReadAll))
+	g.POST("/mute", ginx.WrapBody(h.logger, h.Mute))
+	g.GET("/mutes", ginx.Wrap(h.logger, h.Mutes))
+}
+
+func (h *Handler) List(ctx *gin.Context, req ListReq) (ginx.Result, error) {
+	if req.Limit <= 0 || req.Limit > maxPageSize {
+		req.Limit = defaultPageSize
+	}
+	res, err := h.svc.List(ctx, ctx.GetInt64("userId"), req.Offset, req.Limit)
+	if err != nil {
+		return ginx.SystemError(), err
+	}
+	return ginx.Result{
+		Data: slice.Map(res, func(idx int, src domain.Notification) NotificationVO {
+			return NotificationVO{
+				Id:        src.Id,
+				Type:      src.Type.String(),
+				Biz:       src.Biz,
+				BizId:     src.BizId,
+				ActorId:   src.ActorId,
+				ActorCnt:  src.ActorCnt,
+				RefId:     src.RefId,
+				Read:      src.Read,
+				UpdatedAt: src.Utime.String(),
+			}
+		}),
+	}, nil
+}
+
+func (h *Handler) Unread(ctx *gin.Context) (ginx.Result, error) {
+	cnts, err := h.svc.UnreadCnt(ctx, ctx.GetInt64("userId"))
+	if err != nil {
+		return ginx.SystemError(), err
+	}
+	res := UnreadVO{Types: make(map[string]int64, len(cnts))}
+	for typ, cnt := range cnts {
+		res.Total += cnt
+		res.Types[typ.String()] = cnt
+	}
+	return ginx.Result{Data: res}, nil
+}
+
+func (h *Handler) Read(ctx *gin.Context, req ReadReq) (ginx.Result, error) {
+	if len(req.Ids) == 0 {
+		return ginx.InvalidParam(), nil
+	}
+	err := h.svc.MarkRead(ctx, ctx.GetInt64("userId"), req.Ids)
+	if err != nil {
+		return ginx.SystemError(), err
+	}
+	return ginx.Success(), nil
+}
+
+func (h *Handler) ReadAll(ctx *gin.Context) (ginx.Result, error) {
+	err := h.svc.MarkAllRead(ctx, ctx.GetInt64("userId"))
+	if err != nil {
+		return ginx.SystemError(), err
+	}
+	return ginx.Success(), nil
+}
+
+func (h *Handler) Mute(ctx *gin.Context, req MuteReq) (ginx.Result, error) {
+	typ := domain.ParseNotificationType(req.Type)
+	if !typ.Valid() {
+		return ginx.InvalidParam(), nil
+	}
+	err := h.svc.Mute(ctx, ctx.GetInt64("userId"), typ, req.Mute)
+	if err != nil {
+		return ginx.SystemError(), err
+	}
+	return ginx.Success(), nil
+}
+
+func (h *Handler) Mutes(ctx *gin.Context) (ginx.Result, error) {
+	types, err := h.svc.MutedTypes(ctx, ctx.GetInt64("userId"))
+	if err != nil {
+		return ginx.SystemError(), err
+	}
+	return ginx.Result{
+		Data: slice.Map(types, func(idx int, src domain.NotificationType) string {
+			return src.String()
+		}),
+	}, nil
+}
@@ -0,0 +1,37 @@
+package notification
+
+type ListReq struct {
+	Offset int `json:"offset"`
+	Limit  int `json:"limit"`
+}
+
+type ReadReq struct {
+	Ids []int64 `json:"ids"`
+}
+
+type MuteReq struct {
+	// Type like、collect、comment、reply
+	Type string `json:"type"`
+	// Mute 为 false 表示取消屏蔽
+	Mute bool `json:"mute"`
+}
+
+type NotificationVO struct {
+	Id   int64  `json:"id"`
+	Type string `json:"type"`
+	Biz  string `json:"biz"`
+	// BizId 评论和回复也是文章的 id，评论的 id 在 RefId
+	BizId int64 `json:"bizId"`
+	// ActorId 最近一个触发通知的人，ActorCnt 一共多少人，前端拼成 "xx 等 12 人赞了你的文章"
+	ActorId   int64  `json:"actorId"`
+	ActorCnt  int64  `json:"actorCnt"`
+	RefId     int64  `json:"refId"`
+	Read      bool   `json:"read"`
+	UpdatedAt string `json:"updatedAt"`
+}
+
+type UnreadVO struct {
+	Total int64 `json:"total"`
+	// Types 按通知类型分开的未读数
+	Types map[string]int64 `json:"types"`
+}
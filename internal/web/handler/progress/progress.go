@@ -0,0 +1,102 @@
+package progress
+
+import (
+	"errors"
+	"github.com/Andras5014/gohub/internal/domain"
+	"github.com/Andras5014/gohub/internal/service"
+	"github.com/Andras5014/gohub/internal/web/handler"
+	"github.com/Andras5014/gohub/pkg/ginx"
+	"github.com/Andras5014/gohub/pkg/logx"
+	"github.com/ecodeclub/ekit/slice"
+	"github.com/gin-gonic/gin"
+)
+
+var _ handler.Handler = &Handler{}
+
+const (
+	defaultPageSize = 10
+	maxPageSize     = 50
+)
+
+type Handler struct {
+	svc    service.ReadingProgressService
+	artSvc service.ArticleService
+	logger logx.Logger
+}
+
+func NewReadingProgressHandler(svc service.ReadingProgressService, artSvc service.ArticleService, logger logx.Logger) *Handler {
+	return &Handler{
+		svc:    svc,
+		artSvc: artSvc,
+		logger: logger,
+	}
+}
+
+func (h *Handler) RegisterRoutes(engine *gin.Engine) {
+	g := engine.Group("/reading")
+	// 客户端滚动的时候上报，服务端只写缓存
+	g.POST("/progress", ginx.WrapBody(h.logger, h.Report))
+	// 继续阅读
+	g.POST("/continue", ginx.WrapBody(h.logger, h.Continue))
+}
+
+func (h *Handler) Report(ctx *gin.Context, req ReportReq) (ginx.Result, error) {
+	err := h.svc.Report(ctx, domain.ReadingProgress{
+		Uid:       ctx.GetInt64("userId"),
+		ArticleId: req.Id,
+		Paragraph: req.Paragraph,
+		Percent:   req.Percent,
+	})
+	switch {
+	case errors.Is(err, service.ErrInvalidReadingProgress):
+		return ginx.InvalidParam(), nil
+	case errors.Is(err, service.ErrArticleNotFound):
+		return ginx.Result{Code: 4, Msg: "文章不存在"}, nil
+	case err != nil:
+		return ginx.SystemError(), err
+	}
+	return ginx.Success(), nil
+}
+
+// Continue 撤回了的文章不返回，所以可能比 limit 少
+func (h *Handler) Continue(ctx *gin.Context, req ContinueReq) (ginx.Result, error) {
+	if req.Limit <= 0 || req.Limit > maxPageSize {
+		req.Limit = defaultPageSize
+	}
+	ps, err := h.svc.ContinueReading(ctx, ctx.GetInt64("userId"), req.Limit)
+	if err != nil {
+		return ginx.SystemError(), err
+	}
+	if len(ps) == 0 {
+		return ginx.Result{Data: []ContinueVO{}}, nil
+	}
+	arts, err := h.artSvc.ListPubByIds(ctx, slice.Map(ps, func(idx int, src domain.ReadingProgress) int64 {
+		return src.ArticleId
+	}))
+	if err != nil {
+		return ginx.SystemError(), err
+	}
+	artMap := make(map[int64]domain.Article, len(arts))
+	for _, art := range arts {
+		if art.Status == domain.ArticleStatusPublished {
+			artMap[art.Id] = art
+		}
+	}
+	res := make([]ContinueVO, 0, len(ps))
+	for _, p := range ps {
+		art, ok := artMap[p.ArticleId]
+		if !ok {
+			continue
+		}
+		res = append(res, ContinueVO{
+			Id:         art.Id,
+			Title:      art.Title,
+			AuthorId:   art.Author.Id,
+			AuthorName: art.Author.Name,
+			Paragraph:  p.Paragraph,
+			Percent:    p.Percent,
+			ReadAt:     p.Utime.String(),
+		})
+	}
+	return ginx.Result{Data: res}, nil
+}
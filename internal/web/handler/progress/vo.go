@@ -0,0 +1,22 @@
+package progress
+
+type ReportReq struct {
+	// Id 文章 id
+	Id        int64 `json:"id"`
+	Paragraph int   `json:"paragraph"`
+	Percent   int   `json:"percent"`
+}
+
+type ContinueReq struct {
+	Limit int `json:"limit"`
+}
+
+type ContinueVO struct {
+	Id         int64  `json:"id"`
+	Title      string `json:"title"`
+	AuthorId   int64  `json:"authorId"`
+	AuthorName string `json:"authorName"`
+	Paragraph  int    `json:"paragraph"`
+	Percent    int    `json:"percent"`
+	ReadAt     string `json:"readAt"`
+}
@@ -0,0 +1,64 @@
+package ranking
+
+import (
+	"errors"
+	"github.com/Andras5014/gohub/internal/domain"
+	"github.com/Andras5014/gohub/internal/service"
+	"github.com/Andras5014/gohub/internal/web/handler"
+	"github.com/Andras5014/gohub/pkg/ginx"
+	"github.com/Andras5014/gohub/pkg/logx"
+	"github.com/ecodeclub/ekit/slice"
+	"github.com/gin-gonic/gin"
+)
+
+var _ handler.Handler = &Handler{}
+
+type Handler struct {
+	svc    service.RankingService
+	logger logx.Logger
+}
+
+func NewRankingHandler(svc service.RankingService, logger logx.Logger) *Handler {
+	return &Handler{
+		svc:    svc,
+		logger: logger,
+	}
+}
+
+func (h *Handler) RegisterRoutes(engine *gin.Engine) {
+	engine.GET("/pub/ranking", ginx.Wrap(h.logger, h.TopN))
+	engine.GET("/pub/ranking/:name", ginx.Wrap(h.logger, h.TopN))
+}
+
+func (h *Handler) TopN(ctx *gin.Context) (ginx.Result, error) {
+	// 不带名字的是默认热榜
+	arts, err := h.svc.GetTopN(ctx, ctx.Param("name"))
+	if errors.Is(err, service.ErrRankingNotFound) {
+		return ginx.Result{Code: 4, Msg: "热榜不存在"}, nil
+	}
+	if err != nil {
+		return ginx.SystemError(), err
+	}
+	return ginx.Result{
+		Data: slice.Map[domain.Article, ArticleVO](arts, func(idx int, src domain.Article) ArticleVO {
+			return ArticleVO{
+				Rank:      idx + 1,
+				Id:        src.Id,
+				Title:     src.Title,
+				Abstract:  src.Abstract(),
+				AuthorId:  src.Author.Id,
+				UpdatedAt: src.UpdatedAt.String(),
+			}
+		}),
+	}, nil
+}
+
+type ArticleVO struct {
+	// Rank 从 1 开始
+	Rank      int    `json:"rank"`
+	Id        int64  `json:"id"`
+	Title     string `json:"title"`
+	Abstract  string `json:"abstract"`
+	AuthorId  int64  `json:"authorId"`
+	UpdatedAt string `json:"updatedAt"`
+}
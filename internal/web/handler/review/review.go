@@ -0,0 +1,120 @@
+package review
+
+import (
+	"errors"
+	"github.com/Andras5014/gohub/internal/domain"
+	"github.com/Andras5014/gohub/internal/service"
+	"github.com/Andras5014/gohub/internal/web/handler"
+	"github.com/Andras5014/gohub/pkg/ginx"
+	"github.com/Andras5014/gohub/pkg/logx"
+	"github.com/ecodeclub/ekit/slice"
+	"github.com/gin-gonic/gin"
+	"strings"
+	"unicode/utf8"
+)
+
+var _ handler.Handler = &Handler{}
+
+const (
+	defaultPageSize = 20
+	maxPageSize     = 100
+	maxReasonLength = 200
+)
+
+// Handler 管理员处理文章的人工审核
+type Handler struct {
+	svc    service.ArticleService
+	admin  gin.HandlerFunc
+	logger logx.Logger
+}
+
+// NewReviewHandler admin 负责校验是不是管理员
+func NewReviewHandler(svc service.ArticleService, admin gin.HandlerFunc, logger logx.Logger) *Handler {
+	return &Handler{
+		svc:    svc,
+		admin:  admin,
+		logger: logger,
+	}
+}
+
+func (h *Handler) RegisterRoutes(engine *gin.Engine) {
+	g := engine.Group("/admin/reviews", h.admin)
+	g.POST("/list", ginx.WrapBody(h.logger, h.List))
+	g.POST("/approve", ginx.WrapBody(h.logger, h.Approve))
+	g.POST("/reject", ginx.WrapBody(h.logger, h.Reject))
+}
+
+func (h *Handler) List(ctx *gin.Context, req ListReq) (ginx.Result, error) {
+	if req.Limit <= 0 || req.Limit > maxPageSize {
+		req.Limit = defaultPageSize
+	}
+	arts, err := h.svc.ListPendingReview(ctx, req.Offset, req.Limit)
+	if err != nil {
+		return ginx.SystemError(), err
+	}
+	return ginx.Result{
+		Data: slice.Map[domain.Article, ArticleVO](arts, func(idx int, src domain.Article) ArticleVO {
+			return ArticleVO{
+				Id:        src.Id,
+				Title:     src.Title,
+				Content:   src.Content,
+				AuthorId:  src.Author.Id,
+				Tags:      src.Tags,
+				UpdatedAt: src.UpdatedAt.String(),
+			}
+		}),
+	}, nil
+}
+
+func (h *Handler) Approve(ctx *gin.Context, req ApproveReq) (ginx.Result, error) {
+	err := h.svc.ApproveReview(ctx, req.Id, ctx.GetInt64("userId"))
+	if err != nil {
+		return h.errResult(err), err
+	}
+	return ginx.Result{Msg: "ok"}, nil
+}
+
+func (h *Handler) Reject(ctx *gin.Context, req RejectReq) (ginx.Result, error) {
+	reason := strings.TrimSpace(req.Reason)
+	if reason == "" || utf8.RuneCountInString(reason) > maxReasonLength {
+		return ginx.Result{Code: 4, Msg: "原因不能为空，也不能超过 200 个字"}, nil
+	}
+	err := h.svc.RejectReview(ctx, req.Id, ctx.GetInt64("userId"), reason)
+	if err != nil {
+		return h.errResult(err), err
+	}
+	return ginx.Result{Msg: "ok"}, nil
+}
+
+func (h *Handler) errResult(err error) ginx.Result {
+	switch {
+	case errors.Is(err, service.ErrArticleNotPendingReview):
+		return ginx.Result{Code: 4, Msg: "文章不在待审核状态"}
+	case errors.Is(err, service.ErrArticleNotFound):
+		return ginx.Result{Code: 4, Msg: "文章不存在"}
+	}
+	return ginx.SystemError()
+}
+
+type ListReq struct {
+	Offset int `json:"offset"`
+	Limit  int `json:"limit"`
+}
+
+type ApproveReq struct {
+	Id int64 `json:"id" binding:"required"`
+}
+
+type RejectReq struct {
+	Id     int64  `json:"id" binding:"required"`
+	Reason string `json:"reason" binding:"required"`
+}
+
+type ArticleVO struct {
+	Id        int64    `json:"id"`
+	Title     string   `json:"title"`
+	Content   string   `json:"content"`
+	AuthorId  int64    `json:"authorId"`
+	Tags      []string `json:"tags"`
+	UpdatedAt string   `json:"updatedAt"`
+}
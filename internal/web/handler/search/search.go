@@ -0,0 +1,82 @@
+package search
+
+import (
+	"github.com/Andras5014/gohub/internal/domain"
+	"github.com/Andras5014/gohub/internal/service"
+	"github.com/Andras5014/gohub/internal/web/handler"
+	"github.com/Andras5014/gohub/pkg/ginx"
+	"github.com/Andras5014/gohub/pkg/logx"
+	"github.com/ecodeclub/ekit/slice"
+	"github.com/gin-gonic/gin"
+	"strings"
+)
+
+var _ handler.Handler = &Handler{}
+
+type Handler struct {
+	svc    service.SearchService
+	logger logx.Logger
+}
+
+func NewSearchHandler(svc service.SearchService, logger logx.Logger) *Handler {
+	return &Handler{
+		svc:    svc,
+		logger: logger,
+	}
+}
+
+func (h *Handler) RegisterRoutes(engine *gin.Engine) {
+	engine.GET("/pub/search", ginx.Wrap(h.logger, h.Search))
+}
+
+func (h *Handler) Search(ctx *gin.Context) (ginx.Result, error) {
+	var req SearchReq
+	if err := ctx.ShouldBindQuery(&req); err != nil {
+		return ginx.InvalidParam(), err
+	}
+	req.Q = strings.TrimSpace(req.Q)
+	if req.Q == "" {
+		return ginx.InvalidParam(), nil
+	}
+	if req.Limit <= 0 || req.Limit > 50 {
+		req.Limit = 10
+	}
+	res, err := h.svc.SearchArticle(ctx, req.Q, req.Offset, req.Limit)
+	if err != nil {
+		return ginx.SystemError(), err
+	}
+	return ginx.Result{
+		Data: SearchVO{
+			Total: res.Total,
+			List: slice.Map[domain.ArticleSearchHit, ArticleHitVO](res.Hits, func(idx int, src domain.ArticleSearchHit) ArticleHitVO {
+				return ArticleHitVO{
+					Id:        src.Article.Id,
+					Title:     src.Title,
+					Content:   src.Content,
+					AuthorId:  src.Article.Author.Id,
+					UpdatedAt: src.Article.UpdatedAt.String(),
+				}
+			}),
+		},
+	}, nil
+}
+
+type SearchReq struct {
+	Q      string `form:"q"`
+	Offset int    `form:"offset"`
+	Limit  int    `form:"limit"`
+}
+
+type SearchVO struct {
+	Total int            `json:"total"`
+	List  []ArticleHitVO `json:"list"`
+}
+
+type ArticleHitVO struct {
+	Id int64 `json:"id"`
+	// Title Content 都是高亮之后的片段
+	Title     string `json:"title"`
+	Content   string `json:"content"`
+	AuthorId  int64  `json:"authorId"`
+	UpdatedAt string `json:"updatedAt"`
+}
@@ -0,0 +1,100 @@
+package search
+
+import (
+	"encoding/json"
+	"github.com/Andras5014/gohub/internal/domain"
+	"github.com/Andras5014/gohub/internal/service"
+	svcmocks "github.com/Andras5014/gohub/internal/service/mocks"
+	"github.com/Andras5014/gohub/internal/web/result"
+	"github.com/Andras5014/gohub/pkg/logx"
+	"github.com/gin-gonic/gin"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"go.uber.org/mock/gomock"
+	"go.uber.org/zap"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+)
+
+func TestHandler_Search(t *testing.T) {
+	utime := time.UnixMilli(1700000000000)
+	testCases := []struct {
+		name string
+		mock func(ctrl *gomock.Controller) service.SearchService
+
+		path string
+
+		wantRes result.Result
+	}{
+		{
+			name: "搜索成功",
+			mock: func(ctrl *gomock.Controller) service.SearchService {
+				svc := svcmocks.NewMockSearchService(ctrl)
+				svc.EXPECT().SearchArticle(gomock.Any(), "搜索", 0, 10).
+					Return(domain.ArticleSearchResult{
+						Total: 1,
+						Hits: []domain.ArticleSearchHit{
+							{
+								Article: domain.Article{
+									Id:        1,
+									Author:    domain.Author{Id: 123},
+									UpdatedAt: utime,
+								},
+								Title:   "<em>搜索</em>入门",
+								Content: "内容",
+							},
+						},
+					}, nil)
+				return svc
+			},
+			path: "/pub/search?q=%E6%90%9C%E7%B4%A2",
+			wantRes: result.Result{
+				Data: map[string]any{
+					"total": float64(1),
+					"list": []any{
+						map[string]any{
+							"id":        float64(1),
+							"title":     "<em>搜索</em>入门",
+							"content":   "内容",
+							"authorId":  float64(123),
+							"updatedAt": utime.String(),
+						},
+					},
+				},
+			},
+		},
+		{
+			name: "关键字为空",
+			mock: func(ctrl *gomock.Controller) service.SearchService {
+				return svcmocks.NewMockSearchService(ctrl)
+			},
+			path: "/pub/search?q=%20",
+			wantRes: result.Result{
+				Code: 4,
+				Msg:  "参数错误",
+			},
+		},
+	}
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			ctrl := gomock.NewController(t)
+			defer ctrl.Finish()
+			server := gin.Default()
+			h := NewSearchHandler(tc.mock(ctrl), logx.NewZapLogger(zap.NewNop()))
+			h.RegisterRoutes(server)
+			req, err := http.NewRequest(http.MethodGet, tc.path, nil)
+			require.NoError(t, err)
+			resp := httptest.NewRecorder()
+
+			server.ServeHTTP(resp, req)
+
+			assert.Equal(t, http.StatusOK, resp.Code)
+			var webRes result.Result
+			err = json.NewDecoder(resp.Body).Decode(&webRes)
+			assert.NoError(t, err)
+			assert.Equal(t, tc.wantRes, webRes)
+		})
+	}
+}
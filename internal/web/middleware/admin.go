@@ -0,0 +1,28 @@
+package middleware
+
+import (
+	"github.com/gin-gonic/gin"
+	"net/http"
+)
+
+// AdminMiddlewareBuilder 只放行配置里面的管理员，要放在登录校验后面
+type AdminMiddlewareBuilder struct {
+	admins map[int64]struct{}
+}
+
+func NewAdminMiddlewareBuilder(uids []int64) *AdminMiddlewareBuilder {
+	admins := make(map[int64]struct{}, len(uids))
+	for _, uid := range uids {
+		admins[uid] = struct{}{}
+	}
+	return &AdminMiddlewareBuilder{admins: admins}
+}
+
+func (a *AdminMiddlewareBuilder) Build() gin.HandlerFunc {
+	return func(ctx *gin.Context) {
+		if _, ok := a.admins[ctx.GetInt64("userId")]; !ok {
+			ctx.AbortWithStatus(http.StatusForbidden)
+			return
+		}
+	}
+}
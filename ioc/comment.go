@@ -0,0 +1,23 @@
+package ioc
+
+import (
+	commentv1 "github.com/Andras5014/gohub/api/proto/gen/comment/v1"
+	"github.com/Andras5014/gohub/config"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/credentials/insecure"
+)
+
+// InitCommentGrpcClient 评论一开始就是独立的服务，不需要灰度
+func InitCommentGrpcClient(cfg *config.Config) commentv1.CommentServiceClient {
+	var opts []grpc.DialOption
+	if cfg.Grpc.Client.Comment.Secure {
+		// 加载证书
+	} else {
+		opts = append(opts, grpc.WithTransportCredentials(insecure.NewCredentials()))
+	}
+	cc, err := grpc.NewClient(cfg.Grpc.Client.Comment.Addr, opts...)
+	if err != nil {
+		panic(err)
+	}
+	return commentv1.NewCommentServiceClient(cc)
+}
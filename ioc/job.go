@@ -1,6 +1,7 @@
 package ioc
 
 import (
+	"github.com/Andras5014/gohub/config"
 	"github.com/Andras5014/gohub/internal/job"
 	"github.com/Andras5014/gohub/internal/service"
 	"github.com/Andras5014/gohub/pkg/logx"
@@ -15,7 +16,30 @@ func InitRankingJob(svc service.RankingService, lock *redsync.Redsync, l logx.Lo
 	return job.NewRankingJob(svc, time.Second*30, mu, l)
 }
 
-func InitJobs(rankingJob *job.RankingJob, l logx.Logger) *cron.Cron {
+func InitRankingStreamJob(svc service.RankingService, l logx.Logger) *job.RankingStreamJob {
+	return job.NewRankingStreamJob(svc, time.Second*10, l)
+}
+
+// InitTrashPurgeJob 默认保留 30 天
+func InitTrashPurgeJob(cfg *config.Config, svc service.ArticleService, l logx.Logger) *job.TrashPurgeJob {
+	retention := cfg.Article.Trash.Retention
+	if retention <= 0 {
+		retention = time.Hour * 24 * 30
+	}
+	return job.NewTrashPurgeJob(svc, retention, time.Minute*10, l)
+}
+
+func InitReadingProgressFlushJob(svc service.ReadingProgressService, l logx.Logger) *job.ReadingProgressFlushJob {
+	return job.NewReadingProgressFlushJob(svc, time.Second*20, l)
+}
+
+func InitHistoryTrimJob(svc service.HistoryService, l logx.Logger) *job.HistoryTrimJob {
+	return job.NewHistoryTrimJob(svc, time.Minute*10, l)
+}
+
+func InitJobs(cfg *config.Config, rankingJob *job.RankingJob, streamJob *job.RankingStreamJob,
+	trashJob *job.TrashPurgeJob, progressJob *job.ReadingProgressFlushJob,
+	historyJob *job.HistoryTrimJob, l logx.Logger) *cron.Cron {
 	builder := job.NewCronJobBuilder(prometheus.SummaryOpts{
 		Namespace: "echohub",
 		Subsystem: "job",
@@ -34,5 +58,27 @@ func InitJobs(rankingJob *job.RankingJob, l logx.Logger) *cron.Cron {
 	if err != nil {
 		panic(err)
 	}
+	_, err = expr.AddJob("@every 5s", builder.Build(streamJob))
+	if err != nil {
+		panic(err)
+	}
+	// 默认每天凌晨三点清理回收站
+	trashCron := cfg.Article.Trash.Cron
+	if trashCron == "" {
+		trashCron = "0 0 3 * * *"
+	}
+	_, err = expr.AddJob(trashCron, builder.Build(trashJob))
+	if err != nil {
+		panic(err)
+	}
+	_, err = expr.AddJob("@every 30s", builder.Build(progressJob))
+	if err != nil {
+		panic(err)
+	}
+	// 超过上限的记录晚一点删不影响，要扫全表，一个小时跑一次
+	_, err = expr.AddJob("@every 1h", builder.Build(historyJob))
+	if err != nil {
+		panic(err)
+	}
 	return expr
 }
@@ -4,6 +4,10 @@ import (
 	"github.com/Andras5014/gohub/config"
 	events2 "github.com/Andras5014/gohub/interactive/events"
 	"github.com/Andras5014/gohub/internal/events"
+	"github.com/Andras5014/gohub/internal/events/article"
+	"github.com/Andras5014/gohub/internal/events/feed"
+	"github.com/Andras5014/gohub/internal/events/notification"
+	"github.com/Andras5014/gohub/internal/events/ranking"
 	"github.com/IBM/sarama"
 )
 
@@ -25,6 +29,8 @@ func InitSyncProducer(client sarama.Client) sarama.SyncProducer {
 	return producer
 }
 
-func InitConsumers(c *events2.InteractiveReadEventBatchConsumer) []events.Consumer {
-	return []events.Consumer{c}
+func InitConsumers(c *events2.InteractiveReadEventBatchConsumer, search *article.SearchIndexConsumer,
+	rankingScore *ranking.RankingScoreConsumer, notify *notification.NotificationConsumer,
+	feedConsumer *feed.FeedConsumer, history *article.HistoryRecordConsumer) []events.Consumer {
+	return []events.Consumer{c, search, rankingScore, notify, feedConsumer, history}
 }
@@ -0,0 +1,76 @@
+package ioc
+
+import (
+	"github.com/Andras5014/gohub/config"
+	"github.com/Andras5014/gohub/internal/service"
+	"github.com/Andras5014/gohub/internal/web/handler/review"
+	"github.com/Andras5014/gohub/internal/web/middleware"
+	"github.com/Andras5014/gohub/pkg/logx"
+	"github.com/Andras5014/gohub/pkg/sensitivex"
+	"github.com/fsnotify/fsnotify"
+	"path/filepath"
+)
+
+func InitModerator(cfg *config.Config, l logx.Logger) service.Moderator {
+	mode := service.ModerationMode(cfg.Moderation.Mode)
+	switch mode {
+	case "":
+		mode = service.ModerationReject
+	case service.ModerationReject, service.ModerationMask, service.ModerationReview:
+	default:
+		panic("不支持的审核模式 " + cfg.Moderation.Mode)
+	}
+	filter := sensitivex.NewFilter(nil)
+	if path := cfg.Moderation.Dict; path != "" {
+		words, err := sensitivex.LoadFile(path)
+		if err != nil {
+			panic(err)
+		}
+		filter.Reload(words)
+		watchDict(path, filter, l)
+	}
+	return service.NewWordModerator(filter, mode, cfg.Moderation.ReviewAll)
+}
+
+// watchDict 监听的是目录，很多编辑器保存的时候是先写临时文件再改名
+func watchDict(path string, filter *sensitivex.Filter, l logx.Logger) {
+	watcher, err := fsnotify.NewWatcher()
+	if err != nil {
+		panic(err)
+	}
+	if err = watcher.Add(filepath.Dir(path)); err != nil {
+		panic(err)
+	}
+	target := filepath.Clean(path)
+	go func() {
+		for {
+			select {
+			case evt, ok := <-watcher.Events:
+				if !ok {
+					return
+				}
+				if filepath.Clean(evt.Name) != target || evt.Op&(fsnotify.Write|fsnotify.Create) == 0 {
+					continue
+				}
+				words, er := sensitivex.LoadFile(path)
+				if er != nil {
+					// 保留旧的词库
+					l.Error("重新加载敏感词失败", logx.String("path", path), logx.Error(er))
+					continue
+				}
+				filter.Reload(words)
+				l.Info("重新加载敏感词", logx.String("path", path), logx.Any("cnt", filter.Len()))
+			case er, ok := <-watcher.Errors:
+				if !ok {
+					return
+				}
+				l.Error("监听敏感词文件失败", logx.Error(er))
+			}
+		}
+	}()
+}
+
+func InitReviewHandler(cfg *config.Config, svc service.ArticleService, l logx.Logger) *review.Handler {
+	admin := middleware.NewAdminMiddlewareBuilder(cfg.Moderation.Reviewers).Build()
+	return review.NewReviewHandler(svc, admin, l)
+}
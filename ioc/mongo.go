@@ -0,0 +1,33 @@
+package ioc
+
+import (
+	"context"
+	"github.com/Andras5014/gohub/config"
+	articleDao "github.com/Andras5014/gohub/internal/repository/dao/article"
+	"github.com/bwmarrin/snowflake"
+	"go.mongodb.org/mongo-driver/mongo"
+	"go.mongodb.org/mongo-driver/mongo/options"
+	"time"
+)
+
+// initMongoArticleDAO 只有文章存到 mongo 的时候才连接 mongo
+func initMongoArticleDAO(cfg config.MongoConfig) articleDao.ArticleDAO {
+	ctx, cancel := context.WithTimeout(context.Background(), time.Second*10)
+	defer cancel()
+	client, err := mongo.Connect(ctx, options.Client().ApplyURI(cfg.URI))
+	if err != nil {
+		panic(err)
+	}
+	if err = client.Ping(ctx, nil); err != nil {
+		panic(err)
+	}
+	db := client.Database(cfg.Database)
+	if err = articleDao.InitCollections(db); err != nil {
+		panic(err)
+	}
+	node, err := snowflake.NewNode(cfg.NodeId)
+	if err != nil {
+		panic(err)
+	}
+	return articleDao.NewMongoDBDAO(db, node)
+}
@@ -9,19 +9,38 @@ import (
 	"time"
 )
 
+const articleScheduleJob = "article_schedule"
+
 func InitScheduler(local *job.LocalFuncExecutor, svc service.JobService, l logx.Logger) *job.Scheduler {
 	res := job.NewScheduler(svc, l)
 	res.RegisterExecutor(local)
+
+	ctx, cancel := context.WithTimeout(context.Background(), time.Second*3)
+	defer cancel()
+	// 定时发表/撤回，每 10 秒扫一次到期的文章
+	err := svc.AddJob(ctx, domain.Job{
+		Name:           articleScheduleJob,
+		Executor:       local.Name(),
+		CronExpression: "*/10 * * * * *",
+	})
+	if err != nil {
+		panic(err)
+	}
 	return res
 }
 
-func InitLocalFuncExecutor(svc service.RankingService) *job.LocalFuncExecutor {
+func InitLocalFuncExecutor(svc service.RankingService, artSvc service.ArticleService) *job.LocalFuncExecutor {
 	res := job.NewLocalFuncExecutor()
 	res.RegisterFunc("ranking", func(ctx context.Context, j domain.Job) error {
 		ctx, cancel := context.WithTimeout(ctx, time.Minute)
 		defer cancel()
-		return svc.TopN(ctx, 100)
+		return svc.TopN(ctx)
 
 	})
+	res.RegisterFunc(articleScheduleJob, func(ctx context.Context, j domain.Job) error {
+		ctx, cancel := context.WithTimeout(ctx, time.Minute)
+		defer cancel()
+		return artSvc.RunSchedules(ctx, time.Now())
+	})
 	return res
 }
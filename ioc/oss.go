@@ -0,0 +1,55 @@
+package ioc
+
+import (
+	"context"
+	"github.com/Andras5014/gohub/config"
+	articleDao "github.com/Andras5014/gohub/internal/repository/dao/article"
+	"github.com/Andras5014/gohub/pkg/ossx"
+	"github.com/minio/minio-go/v7"
+	"github.com/minio/minio-go/v7/pkg/credentials"
+	"gorm.io/gorm"
+	"time"
+)
+
+func InitOSS(cfg *config.Config) ossx.Store {
+	switch cfg.OSS.Type {
+	case "minio":
+		return initMinioStore(cfg.OSS)
+	default:
+		return ossx.NewLocalStore(cfg.OSS.Root)
+	}
+}
+
+// InitArticleDAO 文章存到 mongo，或者存到 MySQL，配置了对象存储就把线上库的正文放到对象存储
+func InitArticleDAO(cfg *config.Config, db *gorm.DB, oss ossx.Store) articleDao.ArticleDAO {
+	if cfg.Article.Storage == "mongo" {
+		return initMongoArticleDAO(cfg.Mongo)
+	}
+	if cfg.OSS.Type == "" {
+		return articleDao.NewArticleDAO(db)
+	}
+	return articleDao.NewArticleS3DAO(db, oss)
+}
+
+func initMinioStore(cfg config.OSSConfig) ossx.Store {
+	client, err := minio.New(cfg.Endpoint, &minio.Options{
+		Creds:  credentials.NewStaticV4(cfg.AccessKey, cfg.SecretKey, ""),
+		Secure: cfg.UseSSL,
+	})
+	if err != nil {
+		panic(err)
+	}
+	ctx, cancel := context.WithTimeout(context.Background(), time.Second*5)
+	defer cancel()
+	ok, err := client.BucketExists(ctx, cfg.Bucket)
+	if err != nil {
+		panic(err)
+	}
+	if !ok {
+		err = client.MakeBucket(ctx, cfg.Bucket, minio.MakeBucketOptions{})
+		if err != nil {
+			panic(err)
+		}
+	}
+	return ossx.NewMinioStore(client, cfg.Bucket)
+}
@@ -0,0 +1,65 @@
+package ioc
+
+import (
+	"github.com/Andras5014/gohub/config"
+	"github.com/Andras5014/gohub/internal/service"
+	"time"
+)
+
+// defaultRankingBoards 没有配置的时候用的热榜，第一个是默认的周榜
+var defaultRankingBoards = []config.RankingBoardConfig{
+	{Name: "weekly", Window: time.Hour * 24 * 7, Size: 100, Scorer: service.ScorerHackerNews,
+		ReadWeight: 0.1, LikeWeight: 1, CollectWeight: 2, Gravity: 1.5},
+	{Name: "daily", Window: time.Hour * 24, Size: 50, Scorer: service.ScorerHackerNews,
+		ReadWeight: 0.1, LikeWeight: 1, CollectWeight: 2, Gravity: 1.8},
+	{Name: "all_time", Size: 100, Scorer: service.ScorerWeighted,
+		ReadWeight: 0.1, LikeWeight: 1, CollectWeight: 2},
+	{Name: "realtime", Size: 50, Scorer: service.ScorerDecay,
+		ReadWeight: 0.1, LikeWeight: 1, CollectWeight: 2, HalfLife: time.Hour * 6, Streaming: true},
+}
+
+func InitRankingBoards(cfg *config.Config) []service.RankingBoard {
+	boardCfgs := cfg.Ranking.Boards
+	if len(boardCfgs) == 0 {
+		boardCfgs = defaultRankingBoards
+	}
+	boards := make([]service.RankingBoard, 0, len(boardCfgs))
+	seen := make(map[string]struct{}, len(boardCfgs))
+	for _, c := range boardCfgs {
+		if c.Name == "" {
+			panic("热榜必须有名字")
+		}
+		if _, ok := seen[c.Name]; ok {
+			panic("热榜重名 " + c.Name)
+		}
+		seen[c.Name] = struct{}{}
+		scorer, err := service.NewScorer(c.Scorer, service.RankingWeights{
+			Read:    c.ReadWeight,
+			Like:    c.LikeWeight,
+			Collect: c.CollectWeight,
+		}, c.Gravity, c.HalfLife)
+		if err != nil {
+			panic(err)
+		}
+		if c.Streaming {
+			if _, ok := scorer.(service.StreamScorer); !ok {
+				panic("增量热榜不支持打分算法 " + c.Scorer)
+			}
+			if c.Window > 0 {
+				panic("增量热榜靠半衰期淘汰旧文章，不能配置时间窗口 " + c.Name)
+			}
+		}
+		size := c.Size
+		if size <= 0 {
+			size = 100
+		}
+		boards = append(boards, service.RankingBoard{
+			Name:      c.Name,
+			Window:    c.Window,
+			Size:      size,
+			Scorer:    scorer,
+			Streaming: c.Streaming,
+		})
+	}
+	return boards
+}
@@ -0,0 +1,38 @@
+package ioc
+
+import (
+	"context"
+	"github.com/Andras5014/gohub/config"
+	articleEvent "github.com/Andras5014/gohub/internal/events/article"
+	"github.com/Andras5014/gohub/internal/repository"
+	"github.com/Andras5014/gohub/internal/repository/article"
+	"github.com/Andras5014/gohub/internal/service"
+	"github.com/Andras5014/gohub/pkg/logx"
+	"github.com/Andras5014/gohub/pkg/searchx"
+	"github.com/IBM/sarama"
+)
+
+func InitSearchIndex() searchx.Index {
+	return searchx.NewMemoryIndex(searchx.NewBigramTokenizer(), map[string]float64{
+		"title":   3,
+		"content": 1,
+	})
+}
+
+// InitSearchService 内置索引在内存里面，启动的时候后台全量重建
+func InitSearchService(repo repository.SearchRepository, artRepo article.Repository, l logx.Logger) service.SearchService {
+	svc := service.NewSearchService(repo, artRepo, l)
+	go func() {
+		if err := svc.RebuildArticleIndex(context.Background()); err != nil {
+			l.Error("重建文章索引失败", logx.Error(err))
+		}
+	}()
+	return svc
+}
+
+func InitSearchIndexConsumer(cfg *config.Config, client sarama.Client, repo repository.SearchRepository, l logx.Logger) *articleEvent.SearchIndexConsumer {
+	if cfg.Search.InstanceId == "" {
+		panic("没有配置 search.instance_id")
+	}
+	return articleEvent.NewSearchIndexConsumer(client, repo, l, cfg.Search.InstanceId)
+}
@@ -4,7 +4,17 @@ import (
 	"context"
 	"fmt"
 	"github.com/Andras5014/gohub/internal/web/handler/article"
+	"github.com/Andras5014/gohub/internal/web/handler/author"
+	"github.com/Andras5014/gohub/internal/web/handler/comment"
+	"github.com/Andras5014/gohub/internal/web/handler/feed"
+	"github.com/Andras5014/gohub/internal/web/handler/follow"
+	"github.com/Andras5014/gohub/internal/web/handler/history"
+	"github.com/Andras5014/gohub/internal/web/handler/notification"
 	"github.com/Andras5014/gohub/internal/web/handler/oauth2"
+	"github.com/Andras5014/gohub/internal/web/handler/progress"
+	"github.com/Andras5014/gohub/internal/web/handler/ranking"
+	"github.com/Andras5014/gohub/internal/web/handler/review"
+	"github.com/Andras5014/gohub/internal/web/handler/search"
 	"github.com/Andras5014/gohub/internal/web/handler/user"
 	ijwt "github.com/Andras5014/gohub/internal/web/jwt"
 	"github.com/Andras5014/gohub/internal/web/middleware"
@@ -20,12 +30,26 @@ import (
 	"time"
 )
 
-func InitWebServer(mdls []gin.HandlerFunc, userHdl *user.Handler, oauth2Hdl *oauth2.WeChatHandler, articleHdl *article.Handler) *gin.Engine {
+func InitWebServer(mdls []gin.HandlerFunc, userHdl *user.Handler, oauth2Hdl *oauth2.WeChatHandler,
+	articleHdl *article.Handler, searchHdl *search.Handler, authorHdl *author.Handler,
+	rankingHdl *ranking.Handler, reviewHdl *review.Handler, commentHdl *comment.Handler,
+	notificationHdl *notification.Handler, followHdl *follow.Handler, feedHdl *feed.Handler,
+	historyHdl *history.Handler, progressHdl *progress.Handler) *gin.Engine {
 	server := gin.Default()
 	server.Use(mdls...)
 	userHdl.RegisterRoutes(server)
 	oauth2Hdl.RegisterRoutes(server)
 	articleHdl.RegisterRoutes(server)
+	searchHdl.RegisterRoutes(server)
+	authorHdl.RegisterRoutes(server)
+	rankingHdl.RegisterRoutes(server)
+	reviewHdl.RegisterRoutes(server)
+	commentHdl.RegisterRoutes(server)
+	notificationHdl.RegisterRoutes(server)
+	followHdl.RegisterRoutes(server)
+	feedHdl.RegisterRoutes(server)
+	historyHdl.RegisterRoutes(server)
+	progressHdl.RegisterRoutes(server)
 	return server
 
 }
@@ -35,6 +35,12 @@ func main() {
 		<-app.Cron.Stop().Done()
 	}()
 
+	schedCtx, schedCancel := context.WithCancel(context.Background())
+	defer schedCancel()
+	go func() {
+		_ = app.Scheduler.Schedule(schedCtx)
+	}()
+
 	server := app.Server
 	server.Run(":8080")
 	ctx, cancel := context.WithTimeout(context.Background(), time.Minute)
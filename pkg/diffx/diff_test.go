@@ -0,0 +1,68 @@
+package diffx
+
+import (
+	"github.com/stretchr/testify/assert"
+	"testing"
+)
+
+func TestDiff(t *testing.T) {
+	testCases := []struct {
+		name string
+		a    []string
+		b    []string
+		want []Line
+	}{
+		{
+			name: "完全相同",
+			a:    []string{"a", "b"},
+			b:    []string{"a", "b"},
+			want: []Line{{OpEqual, "a"}, {OpEqual, "b"}},
+		},
+		{
+			name: "新增",
+			a:    []string{},
+			b:    []string{"a"},
+			want: []Line{{OpInsert, "a"}},
+		},
+		{
+			name: "删除",
+			a:    []string{"a"},
+			b:    []string{},
+			want: []Line{{OpDelete, "a"}},
+		},
+		{
+			name: "修改中间一行",
+			a:    []string{"a", "b", "c"},
+			b:    []string{"a", "x", "c"},
+			want: []Line{{OpEqual, "a"}, {OpDelete, "b"}, {OpInsert, "x"}, {OpEqual, "c"}},
+		},
+		{
+			name: "经典例子",
+			a:    []string{"A", "B", "C", "A", "B", "B", "A"},
+			b:    []string{"C", "B", "A", "B", "A", "C"},
+			want: []Line{
+				{OpDelete, "A"}, {OpDelete, "B"}, {OpEqual, "C"}, {OpInsert, "B"},
+				{OpEqual, "A"}, {OpEqual, "B"}, {OpDelete, "B"}, {OpEqual, "A"}, {OpInsert, "C"},
+			},
+		},
+	}
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			res := Diff(tc.a, tc.b)
+			assert.Equal(t, tc.want, res)
+			assert.Equal(t, tc.a, apply(res, OpInsert))
+			assert.Equal(t, tc.b, apply(res, OpDelete))
+		})
+	}
+}
+
+// apply 过滤掉某一类操作，用来还原原文或者新文
+func apply(lines []Line, skip Op) []string {
+	res := make([]string, 0, len(lines))
+	for _, l := range lines {
+		if l.Op != skip {
+			res = append(res, l.Text)
+		}
+	}
+	return res
+}
@@ -0,0 +1,131 @@
+package markdownx
+
+import (
+	"github.com/stretchr/testify/assert"
+	"testing"
+)
+
+func TestRender(t *testing.T) {
+	testCases := []struct {
+		name     string
+		src      string
+		wantHTML string
+		wantText string
+	}{
+		{
+			name:     "段落和强调",
+			src:      "hello **bold** and *em* ~~del~~ `a<b`",
+			wantHTML: "<p>hello <strong>bold</strong> and <em>em</em> <del>del</del> <code>a&lt;b</code></p>\n",
+			wantText: "hello bold and em del a<b",
+		},
+		{
+			name:     "标题",
+			src:      "# Hello World #\n\nSub\n---",
+			wantHTML: "<h1 id=\"hello-world\">Hello World</h1>\n<h2 id=\"sub\">Sub</h2>\n",
+			wantText: "Hello World\nSub",
+		},
+		{
+			name:     "代码块不计入纯文本",
+			src:      "```go\nfmt.Println(\"<x>\")\n```\n\ntext",
+			wantHTML: "<pre><code class=\"language-go\">fmt.Println(&#34;&lt;x&gt;&#34;)\n</code></pre>\n<p>text</p>\n",
+			wantText: "text",
+		},
+		{
+			name:     "列表",
+			src:      "- a\n- b\n  - c",
+			wantHTML: "<ul>\n<li>a</li>\n<li>b\n<ul>\n<li>c</li>\n</ul>\n</li>\n</ul>\n",
+			wantText: "a\nb\nc",
+		},
+		{
+			name:     "引用",
+			src:      "> quote\n> more",
+			wantHTML: "<blockquote>\n<p>quote\nmore</p>\n</blockquote>\n",
+			wantText: "quote more",
+		},
+		{
+			name:     "硬换行",
+			src:      "a  \nb",
+			wantHTML: "<p>a<br>\nb</p>\n",
+			wantText: "a\nb",
+		},
+		{
+			name:     "表格",
+			src:      "| a | b |\n|---|---|\n| 1 | 2 |",
+			wantHTML: "<table>\n<thead>\n<tr>\n<th>a</th>\n<th>b</th>\n</tr>\n</thead>\n<tbody>\n<tr>\n<td>1</td>\n<td>2</td>\n</tr>\n</tbody>\n</table>\n",
+			wantText: "a\nb\n1\n2",
+		},
+		{
+			name:     "链接和图片",
+			src:      "[site](https://example.com \"t\") [rel](/a/b) ![pic](https://example.com/a.png)",
+			wantHTML: "<p><a href=\"https://example.com\" title=\"t\" rel=\"nofollow noopener\" target=\"_blank\">site</a> <a href=\"/a/b\" rel=\"nofollow\">rel</a> <img src=\"https://example.com/a.png\" alt=\"pic\"></p>\n",
+			wantText: "site rel pic",
+		},
+		{
+			name:     "自动链接",
+			src:      "<https://example.com> <a@b.com>",
+			wantHTML: "<p><a href=\"https://example.com\" rel=\"nofollow noopener\" target=\"_blank\">https://example.com</a> <a href=\"mailto:a@b.com\" rel=\"nofollow\">a@b.com</a></p>\n",
+			wantText: "https://example.com a@b.com",
+		},
+		{
+			name:     "原始 HTML 不输出",
+			src:      "<script>alert(1)</script>\n\n<img src=x onerror=alert(1)>\n\ninline <b onclick=x>b</b>",
+			wantHTML: "\n\n<p>inline b</p>\n",
+			wantText: "inline b",
+		},
+		{
+			name:     "危险的链接只保留文字",
+			src:      "[x](javascript:alert(1)) [y]( JaVaScRiPt:alert(1)) ![z](data:image/png;base64,xx) [v](vbscript:x)",
+			wantHTML: "<p>x y <img alt=\"z\"> v</p>\n",
+			wantText: "x y z v",
+		},
+		{
+			name:     "属性里面的引号被转义",
+			src:      "[x](https://a.com/\"onmouseover=\"alert(1))",
+			wantHTML: "<p><a href=\"https://a.com/%22onmouseover=%22alert(1)\" rel=\"nofollow noopener\" target=\"_blank\">x</a></p>\n",
+			wantText: "x",
+		},
+		{
+			name:     "语言里面的特殊字符",
+			src:      "```\"><script>\nx\n```",
+			wantHTML: "<pre><code>x\n</code></pre>\n",
+			wantText: "",
+		},
+		{
+			name:     "转义字符",
+			src:      "\\*not em\\* &amp; `\\*`",
+			wantHTML: "<p>*not em* &amp; <code>\\*</code></p>\n",
+			wantText: "*not em* & \\*",
+		},
+	}
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			res := Render(tc.src)
+			assert.Equal(t, tc.wantHTML, res.HTML)
+			assert.Equal(t, tc.wantText, res.Text)
+		})
+	}
+}
+
+func TestRender_Toc(t *testing.T) {
+	res := Render("# 介绍\n\n## Go 语言\n\n## Go 语言\n\n### `code` *x*\n\n# !!!")
+	assert.Equal(t, []Heading{
+		{Level: 1, Id: "介绍", Text: "介绍"},
+		{Level: 2, Id: "go-语言", Text: "Go 语言"},
+		{Level: 2, Id: "go-语言-1", Text: "Go 语言"},
+		{Level: 3, Id: "code-x", Text: "code x"},
+		{Level: 1, Id: "section", Text: "!!!"},
+	}, res.Toc)
+	// 中文的 id 不能被过滤掉，不然目录跳不过去
+	assert.Contains(t, res.HTML, "<h1 id=\"介绍\">介绍</h1>")
+}
+
+func TestCountWords(t *testing.T) {
+	assert.Equal(t, 0, CountWords(""))
+	assert.Equal(t, 3, CountWords("hello, world 2024"))
+	assert.Equal(t, 6, CountWords("你好世界 go1 v2"))
+}
+
+func TestSummary(t *testing.T) {
+	assert.Equal(t, "a b c", Summary(" a\n\nb \t c ", 10))
+	assert.Equal(t, "你好", Summary("你好世界", 2))
+}
@@ -0,0 +1,167 @@
+package markdownx
+
+import (
+	"bytes"
+	"github.com/microcosm-cc/bluemonday"
+	"github.com/yuin/goldmark"
+	"github.com/yuin/goldmark/ast"
+	"github.com/yuin/goldmark/extension"
+	east "github.com/yuin/goldmark/extension/ast"
+	"github.com/yuin/goldmark/parser"
+	"github.com/yuin/goldmark/text"
+	"github.com/yuin/goldmark/util"
+	"regexp"
+	"strconv"
+	"strings"
+	"unicode"
+)
+
+var (
+	md = goldmark.New(
+		goldmark.WithExtensions(extension.Strikethrough, extension.Table, extension.Linkify),
+		// 没有打开 html.WithUnsafe，原始的 HTML 不会输出，危险的链接也会被去掉
+		goldmark.WithParserOptions(parser.WithAutoHeadingID()),
+	)
+	policy = newPolicy()
+)
+
+// newPolicy goldmark 的输出再用 bluemonday 过滤一遍，不依赖 goldmark 自己的转义
+func newPolicy() *bluemonday.Policy {
+	p := bluemonday.UGCPolicy()
+	// 标题的 id 是目录的锚点，可能是中文
+	p.AllowAttrs("id").Matching(regexp.MustCompile(`^[\p{L}\p{N}_-]+$`)).
+		OnElements("h1", "h2", "h3", "h4", "h5", "h6")
+	p.AllowAttrs("class").Matching(regexp.MustCompile(`^language-[\w+#.-]+$`)).OnElements("code")
+	p.RequireNoFollowOnLinks(true)
+	p.AddTargetBlankToFullyQualifiedLinks(true)
+	return p
+}
+
+// Render 把 Markdown 渲染成 HTML
+// 解析用 goldmark，输出再经过 bluemonday 过滤，可以直接放到页面上
+func Render(src string) Result {
+	source := []byte(src)
+	ctx := parser.NewContext(parser.WithIDs(newHeadingIDs()))
+	doc := md.Parser().Parse(text.NewReader(source), parser.WithContext(ctx))
+	var buf bytes.Buffer
+	// 写到 bytes.Buffer 不会出错
+	_ = md.Renderer().Render(&buf, source, doc)
+
+	var (
+		toc []Heading
+		sb  strings.Builder
+	)
+	_ = ast.Walk(doc, func(n ast.Node, entering bool) (ast.WalkStatus, error) {
+		if !entering {
+			return ast.WalkContinue, nil
+		}
+		switch node := n.(type) {
+		case *ast.FencedCodeBlock, *ast.CodeBlock, *ast.HTMLBlock:
+			// 代码块不算正文
+			return ast.WalkSkipChildren, nil
+		case *ast.Heading:
+			var hs strings.Builder
+			inlineText(&hs, node, source)
+			id, _ := node.AttributeString("id")
+			idBytes, _ := id.([]byte)
+			toc = append(toc, Heading{Level: node.Level, Id: string(idBytes), Text: hs.String()})
+			writeBlock(&sb, hs.String())
+			return ast.WalkSkipChildren, nil
+		case *ast.Paragraph, *ast.TextBlock, *east.TableCell:
+			var ps strings.Builder
+			inlineText(&ps, node, source)
+			writeBlock(&sb, ps.String())
+			return ast.WalkSkipChildren, nil
+		}
+		return ast.WalkContinue, nil
+	})
+	return Result{
+		HTML: policy.Sanitize(buf.String()),
+		Toc:  toc,
+		Text: strings.TrimSpace(sb.String()),
+	}
+}
+
+func writeBlock(sb *strings.Builder, s string) {
+	if s == "" {
+		return
+	}
+	if sb.Len() > 0 {
+		sb.WriteByte('\n')
+	}
+	sb.WriteString(s)
+}
+
+// inlineText 行内元素的纯文本，原始的 HTML 标签不输出
+func inlineText(sb *strings.Builder, n ast.Node, source []byte) {
+	for c := n.FirstChild(); c != nil; c = c.NextSibling() {
+		switch node := c.(type) {
+		case *ast.CodeSpan:
+			// 代码里面的反斜杠和实体都是原样的
+			for t := node.FirstChild(); t != nil; t = t.NextSibling() {
+				if seg, ok := t.(*ast.Text); ok {
+					sb.Write(seg.Segment.Value(source))
+				}
+			}
+		case *ast.Text:
+			value := util.UnescapePunctuations(node.Segment.Value(source))
+			sb.Write(util.ResolveEntityNames(util.ResolveNumericReferences(value)))
+			switch {
+			case node.HardLineBreak():
+				sb.WriteByte('\n')
+			case node.SoftLineBreak():
+				sb.WriteByte(' ')
+			}
+		case *ast.String:
+			sb.Write(node.Value)
+		case *ast.AutoLink:
+			sb.Write(node.Label(source))
+		case *ast.RawHTML:
+		default:
+			inlineText(sb, c, source)
+		}
+	}
+}
+
+// headingIDs 标题 id 保留中文，重复的加上序号
+type headingIDs struct {
+	used map[string]struct{}
+}
+
+func newHeadingIDs() parser.IDs {
+	return &headingIDs{used: make(map[string]struct{})}
+}
+
+func (h *headingIDs) Generate(value []byte, kind ast.NodeKind) []byte {
+	var sb strings.Builder
+	dash := false
+	for _, c := range strings.ToLower(string(value)) {
+		switch {
+		case unicode.IsLetter(c) || unicode.IsDigit(c) || c == '_':
+			if dash && sb.Len() > 0 {
+				sb.WriteByte('-')
+			}
+			dash = false
+			sb.WriteRune(c)
+		case unicode.IsSpace(c) || c == '-':
+			dash = true
+		}
+	}
+	base := sb.String()
+	if base == "" {
+		base = "section"
+	}
+	id := base
+	for i := 1; ; i++ {
+		if _, ok := h.used[id]; !ok {
+			break
+		}
+		id = base + "-" + strconv.Itoa(i)
+	}
+	h.used[id] = struct{}{}
+	return []byte(id)
+}
+
+func (h *headingIDs) Put(value []byte) {
+	h.used[string(value)] = struct{}{}
+}
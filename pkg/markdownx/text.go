@@ -0,0 +1,43 @@
+package markdownx
+
+import (
+	"strings"
+	"unicode"
+)
+
+// Summary 把连续的空白折叠成一个空格，截取前 n 个字符
+func Summary(text string, n int) string {
+	cs := []rune(strings.Join(strings.Fields(text), " "))
+	if len(cs) <= n {
+		return string(cs)
+	}
+	return strings.TrimSpace(string(cs[:n]))
+}
+
+// CountWords 中日韩文字一个字算一个词，其余连续的字母数字算一个词
+func CountWords(text string) int {
+	cnt := 0
+	inWord := false
+	for _, c := range text {
+		switch {
+		case isCJK(c):
+			cnt++
+			inWord = false
+		case unicode.IsLetter(c) || unicode.IsDigit(c):
+			if !inWord {
+				cnt++
+			}
+			inWord = true
+		default:
+			inWord = false
+		}
+	}
+	return cnt
+}
+
+func isCJK(r rune) bool {
+	return unicode.Is(unicode.Han, r) ||
+		unicode.Is(unicode.Hiragana, r) ||
+		unicode.Is(unicode.Katakana, r) ||
+		unicode.Is(unicode.Hangul, r)
+}
@@ -0,0 +1,18 @@
+package markdownx
+
+// Heading 目录里面的一项
+type Heading struct {
+	Level int
+	// Id 渲染出来的 h 标签上的 id，可以直接作为锚点
+	Id   string
+	Text string
+}
+
+type Result struct {
+	// HTML 已经做过转义，可以直接输出到页面
+	HTML string
+	// Toc 按出现顺序排列的标题
+	Toc []Heading
+	// Text 去掉了 Markdown 语法的纯文本，不包含代码块
+	Text string
+}
@@ -0,0 +1,61 @@
+package ossx
+
+import (
+	"context"
+	"errors"
+	"os"
+	"path/filepath"
+)
+
+// LocalStore 用本地目录模拟对象存储，key 就是相对路径
+type LocalStore struct {
+	root string
+}
+
+func NewLocalStore(root string) Store {
+	return &LocalStore{
+		root: root,
+	}
+}
+
+func (l *LocalStore) Put(ctx context.Context, key string, data []byte) error {
+	path := l.path(key)
+	if err := os.MkdirAll(filepath.Dir(path), 0o755); err != nil {
+		return err
+	}
+	// 先写临时文件再改名，避免读到写了一半的内容
+	tmp, err := os.CreateTemp(filepath.Dir(path), ".tmp-*")
+	if err != nil {
+		return err
+	}
+	defer os.Remove(tmp.Name())
+	if _, err = tmp.Write(data); err != nil {
+		tmp.Close()
+		return err
+	}
+	if err = tmp.Close(); err != nil {
+		return err
+	}
+	return os.Rename(tmp.Name(), path)
+}
+
+func (l *LocalStore) Get(ctx context.Context, key string) ([]byte, error) {
+	data, err := os.ReadFile(l.path(key))
+	if errors.Is(err, os.ErrNotExist) {
+		return nil, ErrObjectNotFound
+	}
+	return data, err
+}
+
+func (l *LocalStore) Delete(ctx context.Context, key string) error {
+	err := os.Remove(l.path(key))
+	if errors.Is(err, os.ErrNotExist) {
+		return nil
+	}
+	return err
+}
+
+// path 把 key 限制在 root 目录下面，防止 ../ 跳出去
+func (l *LocalStore) path(key string) string {
+	return filepath.Join(l.root, filepath.Clean("/"+key))
+}
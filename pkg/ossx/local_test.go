@@ -0,0 +1,42 @@
+package ossx
+
+import (
+	"context"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"os"
+	"path/filepath"
+	"testing"
+)
+
+func TestLocalStore(t *testing.T) {
+	root := t.TempDir()
+	store := NewLocalStore(root)
+	ctx := context.Background()
+
+	err := store.Put(ctx, "article/1", []byte("第一版"))
+	require.NoError(t, err)
+	data, err := store.Get(ctx, "article/1")
+	require.NoError(t, err)
+	assert.Equal(t, "第一版", string(data))
+
+	// 覆盖写
+	err = store.Put(ctx, "article/1", []byte("第二版"))
+	require.NoError(t, err)
+	data, err = store.Get(ctx, "article/1")
+	require.NoError(t, err)
+	assert.Equal(t, "第二版", string(data))
+
+	// 不能跳出 root
+	err = store.Put(ctx, "../../escape", []byte("x"))
+	require.NoError(t, err)
+	_, err = os.Stat(filepath.Join(root, "escape"))
+	assert.NoError(t, err)
+
+	err = store.Delete(ctx, "article/1")
+	require.NoError(t, err)
+	_, err = store.Get(ctx, "article/1")
+	assert.Equal(t, ErrObjectNotFound, err)
+	// 重复删除不报错
+	assert.NoError(t, store.Delete(ctx, "article/1"))
+}
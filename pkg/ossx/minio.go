@@ -0,0 +1,43 @@
+package ossx
+
+import (
+	"bytes"
+	"context"
+	"github.com/minio/minio-go/v7"
+	"io"
+)
+
+type MinioStore struct {
+	client *minio.Client
+	bucket string
+}
+
+func NewMinioStore(client *minio.Client, bucket string) Store {
+	return &MinioStore{
+		client: client,
+		bucket: bucket,
+	}
+}
+
+func (m *MinioStore) Put(ctx context.Context, key string, data []byte) error {
+	_, err := m.client.PutObject(ctx, m.bucket, key, bytes.NewReader(data), int64(len(data)),
+		minio.PutObjectOptions{ContentType: "text/plain; charset=utf-8"})
+	return err
+}
+
+func (m *MinioStore) Get(ctx context.Context, key string) ([]byte, error) {
+	obj, err := m.client.GetObject(ctx, m.bucket, key, minio.GetObjectOptions{})
+	if err != nil {
+		return nil, err
+	}
+	defer obj.Close()
+	data, err := io.ReadAll(obj)
+	if minio.ToErrorResponse(err).Code == "NoSuchKey" {
+		return nil, ErrObjectNotFound
+	}
+	return data, err
+}
+
+func (m *MinioStore) Delete(ctx context.Context, key string) error {
+	return m.client.RemoveObject(ctx, m.bucket, key, minio.RemoveObjectOptions{})
+}
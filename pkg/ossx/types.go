@@ -0,0 +1,15 @@
+package ossx
+
+import (
+	"context"
+	"errors"
+)
+
+var ErrObjectNotFound = errors.New("对象不存在")
+
+// Store 对象存储的抽象，生产环境用 S3 兼容的实现（比如 MinIO），开发和测试可以用本地文件
+type Store interface {
+	Put(ctx context.Context, key string, data []byte) error
+	Get(ctx context.Context, key string) ([]byte, error)
+	Delete(ctx context.Context, key string) error
+}
@@ -0,0 +1,90 @@
+package searchx
+
+import (
+	"html"
+	"strings"
+	"unicode"
+)
+
+const (
+	highlightPre  = "<em>"
+	highlightPost = "</em>"
+	// snippetSize 片段的长度，按字符算
+	snippetSize = 120
+	// snippetLead 第一个命中位置前面保留多少字符
+	snippetLead = 20
+)
+
+// Highlight 在 text 里面把 keywords 标出来，并截取第一个命中附近的片段
+// 匹配不区分大小写，没有命中的时候返回开头的片段
+func Highlight(text string, keywords []string) string {
+	src := []rune(text)
+	lower := make([]rune, len(src))
+	for i, r := range src {
+		lower[i] = unicode.ToLower(r)
+	}
+	marked := make([]bool, len(src))
+	first := -1
+	for _, kw := range keywords {
+		k := []rune(strings.ToLower(kw))
+		if len(k) == 0 {
+			continue
+		}
+		for i := 0; i+len(k) <= len(lower); i++ {
+			if !runesEqual(lower[i:i+len(k)], k) {
+				continue
+			}
+			for j := i; j < i+len(k); j++ {
+				marked[j] = true
+			}
+			if first < 0 || i < first {
+				first = i
+			}
+		}
+	}
+
+	start, end := 0, len(src)
+	if len(src) > snippetSize {
+		if first > snippetLead {
+			start = first - snippetLead
+		}
+		end = start + snippetSize
+		if end > len(src) {
+			end = len(src)
+			start = end - snippetSize
+		}
+	}
+
+	var sb strings.Builder
+	if start > 0 {
+		sb.WriteString("...")
+	}
+	for i := start; i < end; {
+		j := i
+		for j < end && marked[j] == marked[i] {
+			j++
+		}
+		seg := html.EscapeString(string(src[i:j]))
+		if marked[i] {
+			sb.WriteString(highlightPre)
+			sb.WriteString(seg)
+			sb.WriteString(highlightPost)
+		} else {
+			sb.WriteString(seg)
+		}
+		i = j
+	}
+	if end < len(src) {
+		sb.WriteString("...")
+	}
+	return sb.String()
+}
+
+func runesEqual(a, b []rune) bool {
+	for i := range a {
+		if a[i] != b[i] {
+			return false
+		}
+	}
+	return true
+}
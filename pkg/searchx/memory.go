@@ -0,0 +1,161 @@
+package searchx
+
+import (
+	"context"
+	"math"
+	"sort"
+	"strings"
+	"sync"
+)
+
+// MemoryIndex 进程内的倒排索引，不依赖外部服务
+// 数据只在内存里面，重启之后要自己重建；多个实例各自维护一份
+type MemoryIndex struct {
+	mu        sync.RWMutex
+	tokenizer Tokenizer
+	// weights 参与检索的字段和权重
+	weights map[string]float64
+
+	docs map[int64]Document
+	// postings 词 -> 文档 -> 字段 -> 词频
+	postings map[string]map[int64]map[string]int
+	// terms 文档包含的词，删除的时候用
+	terms map[int64][]string
+}
+
+func NewMemoryIndex(tokenizer Tokenizer, weights map[string]float64) *MemoryIndex {
+	return &MemoryIndex{
+		tokenizer: tokenizer,
+		weights:   weights,
+		docs:      make(map[int64]Document),
+		postings:  make(map[string]map[int64]map[string]int),
+		terms:     make(map[int64][]string),
+	}
+}
+
+func (m *MemoryIndex) Upsert(ctx context.Context, doc Document) error {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	m.remove(doc.Id)
+
+	var terms []string
+	for field := range m.weights {
+		for _, token := range m.tokenizer.Tokenize(doc.Fields[field]) {
+			docs, ok := m.postings[token]
+			if !ok {
+				docs = make(map[int64]map[string]int)
+				m.postings[token] = docs
+			}
+			tf, ok := docs[doc.Id]
+			if !ok {
+				tf = make(map[string]int)
+				docs[doc.Id] = tf
+				terms = append(terms, token)
+			}
+			tf[field]++
+		}
+	}
+	m.docs[doc.Id] = doc
+	m.terms[doc.Id] = terms
+	return nil
+}
+
+func (m *MemoryIndex) Delete(ctx context.Context, id int64) error {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	m.remove(id)
+	return nil
+}
+
+func (m *MemoryIndex) remove(id int64) {
+	for _, token := range m.terms[id] {
+		docs := m.postings[token]
+		delete(docs, id)
+		if len(docs) == 0 {
+			delete(m.postings, token)
+		}
+	}
+	delete(m.terms, id)
+	delete(m.docs, id)
+}
+
+func (m *MemoryIndex) Search(ctx context.Context, q Query) (Result, error) {
+	tokens := unique(m.tokenizer.QueryTokenize(q.Text))
+	if len(tokens) == 0 {
+		return Result{}, nil
+	}
+
+	m.mu.RLock()
+	defer m.mu.RUnlock()
+
+	// 所有的词都要命中
+	var candidates map[int64]float64
+	total := float64(len(m.docs))
+	for _, token := range tokens {
+		docs := m.postings[token]
+		if len(docs) == 0 {
+			return Result{}, nil
+		}
+		idf := math.Log(1 + total/float64(len(docs)))
+		next := make(map[int64]float64, len(docs))
+		for id, tf := range docs {
+			score, ok := candidates[id]
+			if candidates != nil && !ok {
+				continue
+			}
+			for field, cnt := range tf {
+				score += m.weights[field] * (1 + math.Log(float64(cnt))) * idf
+			}
+			next[id] = score
+		}
+		candidates = next
+	}
+
+	hits := make([]Hit, 0, len(candidates))
+	for id, score := range candidates {
+		hits = append(hits, Hit{Id: id, Score: score})
+	}
+	sort.Slice(hits, func(i, j int) bool {
+		if hits[i].Score != hits[j].Score {
+			return hits[i].Score > hits[j].Score
+		}
+		// 分数一样新的在前面
+		return hits[i].Id > hits[j].Id
+	})
+
+	res := Result{Total: len(hits)}
+	if q.Offset >= len(hits) {
+		return res, nil
+	}
+	end := len(hits)
+	if q.Limit > 0 && q.Offset+q.Limit < end {
+		end = q.Offset + q.Limit
+	}
+	keywords := append(strings.Fields(q.Text), tokens...)
+	res.Hits = hits[q.Offset:end]
+	for i := range res.Hits {
+		doc := m.docs[res.Hits[i].Id]
+		res.Hits[i].Fields = doc.Fields
+		if len(q.Highlight) == 0 {
+			continue
+		}
+		res.Hits[i].Highlights = make(map[string]string, len(q.Highlight))
+		for _, field := range q.Highlight {
+			res.Hits[i].Highlights[field] = Highlight(doc.Fields[field], keywords)
+		}
+	}
+	return res, nil
+}
+
+func unique(tokens []string) []string {
+	seen := make(map[string]struct{}, len(tokens))
+	res := tokens[:0]
+	for _, t := range tokens {
+		if _, ok := seen[t]; ok {
+			continue
+		}
+		seen[t] = struct{}{}
+		res = append(res, t)
+	}
+	return res
+}
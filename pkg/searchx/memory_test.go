@@ -0,0 +1,104 @@
+package searchx
+
+import (
+	"context"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"testing"
+)
+
+func TestMemoryIndex_Search(t *testing.T) {
+	ctx := context.Background()
+	idx := NewMemoryIndex(NewBigramTokenizer(), map[string]float64{
+		"title":   3,
+		"content": 1,
+	})
+	docs := []Document{
+		{Id: 1, Fields: map[string]string{"title": "搜索引擎入门", "content": "倒排索引是搜索引擎的基础"}},
+		{Id: 2, Fields: map[string]string{"title": "Go 并发", "content": "顺便提一下搜索引擎"}},
+		{Id: 3, Fields: map[string]string{"title": "数据库", "content": "索引和事务"}},
+	}
+	for _, doc := range docs {
+		require.NoError(t, idx.Upsert(ctx, doc))
+	}
+
+	testCases := []struct {
+		name      string
+		before    func(t *testing.T)
+		q         Query
+		wantTotal int
+		wantIds   []int64
+	}{
+		{
+			name:      "标题命中的排在前面",
+			q:         Query{Text: "搜索引擎", Limit: 10},
+			wantTotal: 2,
+			wantIds:   []int64{1, 2},
+		},
+		{
+			name:      "单字",
+			q:         Query{Text: "库", Limit: 10},
+			wantTotal: 1,
+			wantIds:   []int64{3},
+		},
+		{
+			name: "分页",
+			// 2 和 3 分数一样，id 大的在前面
+			q:         Query{Text: "索引", Offset: 1, Limit: 1},
+			wantTotal: 3,
+			wantIds:   []int64{3},
+		},
+		{
+			name:      "所有词都要命中",
+			q:         Query{Text: "搜索 事务", Limit: 10},
+			wantTotal: 0,
+		},
+		{
+			name: "覆盖之后旧的内容搜不到",
+			before: func(t *testing.T) {
+				require.NoError(t, idx.Upsert(ctx, Document{Id: 3, Fields: map[string]string{"title": "缓存"}}))
+			},
+			q:         Query{Text: "事务", Limit: 10},
+			wantTotal: 0,
+		},
+		{
+			name: "删除",
+			before: func(t *testing.T) {
+				require.NoError(t, idx.Delete(ctx, 1))
+			},
+			q:         Query{Text: "搜索引擎", Limit: 10},
+			wantTotal: 1,
+			wantIds:   []int64{2},
+		},
+	}
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			if tc.before != nil {
+				tc.before(t)
+			}
+			res, err := idx.Search(ctx, tc.q)
+			require.NoError(t, err)
+			assert.Equal(t, tc.wantTotal, res.Total)
+			var ids []int64
+			for _, hit := range res.Hits {
+				ids = append(ids, hit.Id)
+			}
+			assert.Equal(t, tc.wantIds, ids)
+		})
+	}
+}
+
+func TestMemoryIndex_Highlight(t *testing.T) {
+	ctx := context.Background()
+	idx := NewMemoryIndex(NewBigramTokenizer(), map[string]float64{"title": 1})
+	require.NoError(t, idx.Upsert(ctx, Document{Id: 1, Fields: map[string]string{
+		"title":     "学习 Go 语言",
+		"author_id": "123",
+	}}))
+	res, err := idx.Search(ctx, Query{Text: "go", Limit: 10, Highlight: []string{"title"}})
+	require.NoError(t, err)
+	require.Len(t, res.Hits, 1)
+	assert.Equal(t, "学习 <em>Go</em> 语言", res.Hits[0].Highlights["title"])
+	// 没有权重的字段只存储
+	assert.Equal(t, "123", res.Hits[0].Fields["author_id"])
+}
@@ -0,0 +1,83 @@
+package searchx
+
+import (
+	"strings"
+	"unicode"
+)
+
+type Tokenizer interface {
+	// Tokenize 建索引的时候用
+	Tokenize(text string) []string
+	// QueryTokenize 查询的时候用，粒度可以和建索引不一样
+	QueryTokenize(text string) []string
+}
+
+// BigramTokenizer 不依赖词典的中文切分
+// 连续的中日韩字符按二元切分，建索引的时候额外保留单字，保证单字查询也能命中；
+// 其余的字母数字按单词切分并转成小写
+type BigramTokenizer struct{}
+
+func NewBigramTokenizer() Tokenizer {
+	return BigramTokenizer{}
+}
+
+func (b BigramTokenizer) Tokenize(text string) []string {
+	return b.split(text, true)
+}
+
+func (b BigramTokenizer) QueryTokenize(text string) []string {
+	return b.split(text, false)
+}
+
+func (b BigramTokenizer) split(text string, withUnigram bool) []string {
+	var (
+		res  []string
+		word []rune
+		cjk  []rune
+	)
+	flushWord := func() {
+		if len(word) > 0 {
+			res = append(res, strings.ToLower(string(word)))
+			word = word[:0]
+		}
+	}
+	flushCJK := func() {
+		switch {
+		case len(cjk) == 1:
+			res = append(res, string(cjk))
+		case len(cjk) > 1:
+			for i := 0; i < len(cjk)-1; i++ {
+				res = append(res, string(cjk[i:i+2]))
+			}
+			if withUnigram {
+				for _, r := range cjk {
+					res = append(res, string(r))
+				}
+			}
+		}
+		cjk = cjk[:0]
+	}
+	for _, r := range text {
+		switch {
+		case isCJK(r):
+			flushWord()
+			cjk = append(cjk, r)
+		case unicode.IsLetter(r) || unicode.IsDigit(r):
+			flushCJK()
+			word = append(word, r)
+		default:
+			flushWord()
+			flushCJK()
+		}
+	}
+	flushWord()
+	flushCJK()
+	return res
+}
+
+func isCJK(r rune) bool {
+	return unicode.Is(unicode.Han, r) ||
+		unicode.Is(unicode.Hiragana, r) ||
+		unicode.Is(unicode.Katakana, r) ||
+		unicode.Is(unicode.Hangul, r)
+}
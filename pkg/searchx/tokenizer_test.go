@@ -0,0 +1,72 @@
+package searchx
+
+import (
+	"github.com/stretchr/testify/assert"
+	"testing"
+)
+
+func TestBigramTokenizer(t *testing.T) {
+	testCases := []struct {
+		name      string
+		text      string
+		wantIndex []string
+		wantQuery []string
+	}{
+		{
+			name:      "中文二元切分",
+			text:      "搜索引擎",
+			wantIndex: []string{"搜索", "索引", "引擎", "搜", "索", "引", "擎"},
+			wantQuery: []string{"搜索", "索引", "引擎"},
+		},
+		{
+			name:      "中英混合",
+			text:      "用Go写搜索, Hello!",
+			wantIndex: []string{"用", "go", "写搜", "搜索", "写", "搜", "索", "hello"},
+			wantQuery: []string{"用", "go", "写搜", "搜索", "hello"},
+		},
+		{
+			name: "只有标点",
+			text: "，。!?",
+		},
+	}
+	tk := NewBigramTokenizer()
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			assert.Equal(t, tc.wantIndex, tk.Tokenize(tc.text))
+			assert.Equal(t, tc.wantQuery, tk.QueryTokenize(tc.text))
+		})
+	}
+}
+
+func TestHighlight(t *testing.T) {
+	testCases := []struct {
+		name     string
+		text     string
+		keywords []string
+		want     string
+	}{
+		{
+			name:     "不区分大小写",
+			text:     "Go 语言的 go 程",
+			keywords: []string{"GO"},
+			want:     "<em>Go</em> 语言的 <em>go</em> 程",
+		},
+		{
+			name:     "转义",
+			text:     "<b>搜索</b>",
+			keywords: []string{"搜索"},
+			want:     "&lt;b&gt;<em>搜索</em>&lt;/b&gt;",
+		},
+		{
+			name:     "没有命中",
+			text:     "随便写点",
+			keywords: []string{"搜索"},
+			want:     "随便写点",
+		},
+	}
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			assert.Equal(t, tc.want, Highlight(tc.text, tc.keywords))
+		})
+	}
+}
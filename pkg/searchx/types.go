@@ -0,0 +1,40 @@
+package searchx
+
+import "context"
+
+// Index 全文检索的抽象，内置的是进程内的 MemoryIndex，
+// 后面换成 ES 之类的独立搜索引擎只需要实现这个接口
+type Index interface {
+	// Upsert 同一个 Id 会整个覆盖
+	Upsert(ctx context.Context, doc Document) error
+	Delete(ctx context.Context, id int64) error
+	Search(ctx context.Context, q Query) (Result, error)
+}
+
+type Document struct {
+	Id int64
+	// Fields 字段名到内容，只有配置了权重的字段参与检索，其余原样存储
+	Fields map[string]string
+}
+
+type Query struct {
+	Text   string
+	Offset int
+	Limit  int
+	// Highlight 需要高亮的字段
+	Highlight []string
+}
+
+type Hit struct {
+	Id     int64
+	Score  float64
+	Fields map[string]string
+	// Highlights 高亮之后的片段，命中的词用 <em></em> 包起来，其余部分做了 HTML 转义
+	Highlights map[string]string
+}
+
+type Result struct {
+	// Total 命中的总数，不受分页影响
+	Total int
+	Hits  []Hit
+}
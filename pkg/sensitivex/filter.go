@@ -0,0 +1,59 @@
+package sensitivex
+
+import (
+	"bufio"
+	"io"
+	"os"
+	"strings"
+	"sync/atomic"
+)
+
+// Filter 词库可以在运行时整个替换，正在进行的匹配用的还是旧的词库
+type Filter struct {
+	m atomic.Pointer[Matcher]
+}
+
+func NewFilter(words []string) *Filter {
+	f := &Filter{}
+	f.Reload(words)
+	return f
+}
+
+func (f *Filter) Reload(words []string) {
+	f.m.Store(NewMatcher(words))
+}
+
+func (f *Filter) FindAll(text string) []Hit {
+	return f.m.Load().FindAll(text)
+}
+
+func (f *Filter) Mask(text string, mask rune) (string, []Hit) {
+	return f.m.Load().Mask(text, mask)
+}
+
+func (f *Filter) Len() int {
+	return f.m.Load().Len()
+}
+
+// LoadWords 一行一个词，跳过空行和 # 开头的注释
+func LoadWords(r io.Reader) ([]string, error) {
+	var words []string
+	scanner := bufio.NewScanner(r)
+	for scanner.Scan() {
+		line := strings.TrimSpace(scanner.Text())
+		if line == "" || strings.HasPrefix(line, "#") {
+			continue
+		}
+		words = append(words, line)
+	}
+	return words, scanner.Err()
+}
+
+func LoadFile(path string) ([]string, error) {
+	file, err := os.Open(path)
+	if err != nil {
+		return nil, err
+	}
+	defer file.Close()
+	return LoadWords(file)
+}
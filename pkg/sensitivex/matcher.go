@@ -0,0 +1,151 @@
+package sensitivex
+
+import (
+	"unicode"
+)
+
+// Hit 命中的词，Start 和 End 是 rune 下标，左闭右开
+type Hit struct {
+	Word  string
+	Start int
+	End   int
+}
+
+type node struct {
+	next map[rune]int32
+	fail int32
+	// out 在这个节点结束的词，包括沿着 fail 链能走到的
+	out []int32
+}
+
+// Matcher Aho-Corasick 自动机，构建之后只读，可以并发使用
+// 匹配不区分大小写
+type Matcher struct {
+	nodes []node
+	words []string
+	lens  []int
+}
+
+func NewMatcher(words []string) *Matcher {
+	m := &Matcher{nodes: []node{{}}}
+	seen := make(map[string]struct{}, len(words))
+	for _, w := range words {
+		runes := normalize([]rune(w))
+		if len(runes) == 0 {
+			continue
+		}
+		key := string(runes)
+		if _, ok := seen[key]; ok {
+			continue
+		}
+		seen[key] = struct{}{}
+		m.insert(runes, int32(len(m.words)))
+		m.words = append(m.words, w)
+		m.lens = append(m.lens, len(runes))
+	}
+	m.build()
+	return m
+}
+
+func (m *Matcher) insert(runes []rune, idx int32) {
+	cur := int32(0)
+	for _, r := range runes {
+		nxt, ok := m.nodes[cur].next[r]
+		if !ok {
+			if m.nodes[cur].next == nil {
+				m.nodes[cur].next = make(map[rune]int32)
+			}
+			nxt = int32(len(m.nodes))
+			m.nodes = append(m.nodes, node{})
+			m.nodes[cur].next[r] = nxt
+		}
+		cur = nxt
+	}
+	m.nodes[cur].out = append(m.nodes[cur].out, idx)
+}
+
+// build 按层次遍历计算 fail 指针
+func (m *Matcher) build() {
+	queue := make([]int32, 0, len(m.nodes))
+	for _, child := range m.nodes[0].next {
+		queue = append(queue, child)
+	}
+	for len(queue) > 0 {
+		cur := queue[0]
+		queue = queue[1:]
+		for r, child := range m.nodes[cur].next {
+			f := m.nodes[cur].fail
+			for f > 0 {
+				if _, ok := m.nodes[f].next[r]; ok {
+					break
+				}
+				f = m.nodes[f].fail
+			}
+			if nxt, ok := m.nodes[f].next[r]; ok && nxt != child {
+				m.nodes[child].fail = nxt
+			}
+			fail := m.nodes[child].fail
+			m.nodes[child].out = append(m.nodes[child].out, m.nodes[fail].out...)
+			queue = append(queue, child)
+		}
+	}
+}
+
+func (m *Matcher) step(cur int32, r rune) int32 {
+	for {
+		if nxt, ok := m.nodes[cur].next[r]; ok {
+			return nxt
+		}
+		if cur == 0 {
+			return 0
+		}
+		cur = m.nodes[cur].fail
+	}
+}
+
+// FindAll 返回所有命中，按结束位置排序，重叠的也会返回
+func (m *Matcher) FindAll(text string) []Hit {
+	if len(m.words) == 0 {
+		return nil
+	}
+	var hits []Hit
+	cur := int32(0)
+	for i, r := range normalize([]rune(text)) {
+		cur = m.step(cur, r)
+		for _, idx := range m.nodes[cur].out {
+			hits = append(hits, Hit{
+				Word:  m.words[idx],
+				Start: i + 1 - m.lens[idx],
+				End:   i + 1,
+			})
+		}
+	}
+	return hits
+}
+
+// Mask 把命中的部分替换成 mask，返回替换之后的文本和命中的词
+func (m *Matcher) Mask(text string, mask rune) (string, []Hit) {
+	hits := m.FindAll(text)
+	if len(hits) == 0 {
+		return text, nil
+	}
+	runes := []rune(text)
+	for _, h := range hits {
+		for i := h.Start; i < h.End; i++ {
+			runes[i] = mask
+		}
+	}
+	return string(runes), hits
+}
+
+// Len 词库里面词的数量
+func (m *Matcher) Len() int {
+	return len(m.words)
+}
+
+func normalize(runes []rune) []rune {
+	for i, r := range runes {
+		runes[i] = unicode.ToLower(r)
+	}
+	return runes
+}
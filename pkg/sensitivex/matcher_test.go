@@ -0,0 +1,92 @@
+package sensitivex
+
+import (
+	"github.com/stretchr/testify/assert"
+	"strings"
+	"testing"
+)
+
+func TestMatcher_FindAll(t *testing.T) {
+	testCases := []struct {
+		name  string
+		words []string
+		text  string
+		want  []Hit
+	}{
+		{
+			name:  "没有词库",
+			words: nil,
+			text:  "随便什么内容",
+		},
+		{
+			name:  "没有命中",
+			words: []string{"赌博", "诈骗"},
+			text:  "今天天气不错",
+		},
+		{
+			name:  "中文命中",
+			words: []string{"赌博", "诈骗"},
+			text:  "网络赌博和电信诈骗",
+			want: []Hit{
+				{Word: "赌博", Start: 2, End: 4},
+				{Word: "诈骗", Start: 7, End: 9},
+			},
+		},
+		{
+			name:  "重叠和包含",
+			words: []string{"he", "she", "his", "hers"},
+			text:  "ushers",
+			want: []Hit{
+				{Word: "she", Start: 1, End: 4},
+				{Word: "he", Start: 2, End: 4},
+				{Word: "hers", Start: 2, End: 6},
+			},
+		},
+		{
+			name:  "不区分大小写",
+			words: []string{"Spam"},
+			text:  "no SPAM here",
+			want: []Hit{
+				{Word: "Spam", Start: 3, End: 7},
+			},
+		},
+		{
+			name:  "重复的词只算一次",
+			words: []string{"abc", "ABC", " "},
+			text:  "xabcx",
+			want: []Hit{
+				{Word: "abc", Start: 1, End: 4},
+			},
+		},
+	}
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			m := NewMatcher(tc.words)
+			assert.Equal(t, tc.want, m.FindAll(tc.text))
+		})
+	}
+}
+
+func TestMatcher_Mask(t *testing.T) {
+	m := NewMatcher([]string{"赌博", "博彩"})
+	res, hits := m.Mask("不要赌博彩票", '*')
+	assert.Equal(t, "不要***票", res)
+	assert.Len(t, hits, 2)
+
+	res, hits = m.Mask("干干净净", '*')
+	assert.Equal(t, "干干净净", res)
+	assert.Nil(t, hits)
+}
+
+func TestLoadWords(t *testing.T) {
+	words, err := LoadWords(strings.NewReader("# 注释\n赌博\n\n  诈骗  \n"))
+	assert.NoError(t, err)
+	assert.Equal(t, []string{"赌博", "诈骗"}, words)
+}
+
+func TestFilter_Reload(t *testing.T) {
+	f := NewFilter([]string{"旧词"})
+	assert.Len(t, f.FindAll("旧词新词"), 1)
+	f.Reload([]string{"新词"})
+	assert.Equal(t, []Hit{{Word: "新词", Start: 2, End: 4}}, f.FindAll("旧词新词"))
+}
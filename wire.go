@@ -9,6 +9,10 @@ import (
 	dao2 "github.com/Andras5014/gohub/interactive/repository/dao"
 	service2 "github.com/Andras5014/gohub/interactive/service"
 	articleEvent "github.com/Andras5014/gohub/internal/events/article"
+	feedEvent "github.com/Andras5014/gohub/internal/events/feed"
+	followEvent "github.com/Andras5014/gohub/internal/events/follow"
+	notificationEvent "github.com/Andras5014/gohub/internal/events/notification"
+	rankingEvent "github.com/Andras5014/gohub/internal/events/ranking"
 	"github.com/Andras5014/gohub/internal/repository"
 	articleRepo "github.com/Andras5014/gohub/internal/repository/article"
 	"github.com/Andras5014/gohub/internal/repository/cache"
@@ -16,7 +20,16 @@ import (
 	articleDao "github.com/Andras5014/gohub/internal/repository/dao/article"
 	"github.com/Andras5014/gohub/internal/service"
 	"github.com/Andras5014/gohub/internal/web/handler/article"
+	"github.com/Andras5014/gohub/internal/web/handler/author"
+	"github.com/Andras5014/gohub/internal/web/handler/comment"
+	"github.com/Andras5014/gohub/internal/web/handler/feed"
+	"github.com/Andras5014/gohub/internal/web/handler/follow"
+	"github.com/Andras5014/gohub/internal/web/handler/history"
+	"github.com/Andras5014/gohub/internal/web/handler/notification"
 	"github.com/Andras5014/gohub/internal/web/handler/oauth2"
+	"github.com/Andras5014/gohub/internal/web/handler/progress"
+	"github.com/Andras5014/gohub/internal/web/handler/ranking"
+	"github.com/Andras5014/gohub/internal/web/handler/search"
 	"github.com/Andras5014/gohub/internal/web/handler/user"
 	ijwt "github.com/Andras5014/gohub/internal/web/jwt"
 	"github.com/Andras5014/gohub/ioc"
@@ -25,14 +38,20 @@ import (
 
 var rankingSvcSet = wire.NewSet(
 	cache.NewRedisRankingCache,
+	cache.NewRedisRankingScoreCache,
+	cache.NewRankingLocalCache,
 	repository.NewRankingRepository,
 	service.NewRankingService,
+	ioc.InitRankingBoards,
 )
 var interactiveSvcSet = wire.NewSet(
 	service2.NewInteractiveService,
+	events.NewSaramaSyncProducer,
 	repository2.NewInteractiveRepository,
+	repository2.NewCollectionRepository,
 	cache2.NewInteractiveCache,
 	dao2.NewInteractiveDAO,
+	dao2.NewCollectionDAO,
 )
 
 var userSvcSet = wire.NewSet(
@@ -45,9 +64,59 @@ var userSvcSet = wire.NewSet(
 var articleSvcSet = wire.NewSet(
 	service.NewArticleService,
 	articleRepo.NewArticleRepository,
-	articleDao.NewArticleDAO,
+	articleRepo.NewCollaboratorRepository,
+	ioc.InitArticleDAO,
+	articleDao.NewCollaboratorDAO,
+	service.NewSeriesService,
+	articleRepo.NewSeriesRepository,
+	articleDao.NewSeriesDAO,
+	ioc.InitModerator,
 	cache.NewRedisArticleCache,
 )
+var jobSvcSet = wire.NewSet(
+	service.NewCronJobService,
+	repository.NewJobRepository,
+	dao.NewJobDAO,
+)
+
+var searchSvcSet = wire.NewSet(
+	ioc.InitSearchIndex,
+	repository.NewSearchRepository,
+	ioc.InitSearchService,
+)
+
+var notificationSvcSet = wire.NewSet(
+	service.NewNotificationService,
+	repository.NewNotificationRepository,
+	dao.NewNotificationDAO,
+)
+
+var followSvcSet = wire.NewSet(
+	service.NewFollowService,
+	repository.NewFollowRepository,
+	dao.NewFollowDAO,
+	followEvent.NewSaramaSyncProducer,
+)
+
+var feedSvcSet = wire.NewSet(
+	service.NewFeedService,
+	repository.NewFeedRepository,
+	dao.NewFeedDAO,
+)
+
+var historySvcSet = wire.NewSet(
+	service.NewHistoryService,
+	repository.NewHistoryRecordRepository,
+	dao.NewHistoryRecordDAO,
+)
+
+var progressSvcSet = wire.NewSet(
+	service.NewReadingProgressService,
+	repository.NewReadingProgressRepository,
+	cache.NewRedisReadingProgressCache,
+	dao.NewReadingProgressDAO,
+)
+
 var codeSvcProvider = wire.NewSet(
 	cache.NewCodeCache,
 	repository.NewCodeRepository,
@@ -66,6 +135,7 @@ var thirdPartySet = wire.NewSet(
 	ioc.InitKafka,
 	ioc.InitSyncProducer,
 	ioc.InitConsumers,
+	ioc.InitOSS,
 )
 
 func InitApp() *App {
@@ -73,6 +143,11 @@ func InitApp() *App {
 		//event
 		articleEvent.NewSaramaSyncProducer,
 		events.NewInteractiveReadEventBatchConsumer,
+		ioc.InitSearchIndexConsumer,
+		rankingEvent.NewRankingScoreConsumer,
+		notificationEvent.NewNotificationConsumer,
+		feedEvent.NewFeedConsumer,
+		articleEvent.NewHistoryRecordConsumer,
 
 		user.NewUserHandler,
 		userSvcSet,
@@ -81,6 +156,10 @@ func InitApp() *App {
 
 		article.NewArticleHandler,
 		articleSvcSet,
+		search.NewSearchHandler,
+		searchSvcSet,
+		author.NewAuthorHandler,
+		service.NewAuthorService,
 		interactiveSvcSet,
 		ioc.InitInteractiveGrpcClient,
 		thirdPartySet,
@@ -91,8 +170,29 @@ func InitApp() *App {
 
 		// job
 		rankingSvcSet,
+		ranking.NewRankingHandler,
+		ioc.InitReviewHandler,
+		ioc.InitCommentGrpcClient,
+		comment.NewCommentHandler,
+		notification.NewNotificationHandler,
+		notificationSvcSet,
+		follow.NewFollowHandler,
+		followSvcSet,
+		feed.NewFeedHandler,
+		feedSvcSet,
+		history.NewHistoryHandler,
+		historySvcSet,
+		progress.NewReadingProgressHandler,
+		progressSvcSet,
 		ioc.InitRankingJob,
+		ioc.InitRankingStreamJob,
+		ioc.InitTrashPurgeJob,
+		ioc.InitReadingProgressFlushJob,
+		ioc.InitHistoryTrimJob,
 		ioc.InitJobs,
+		jobSvcSet,
+		ioc.InitLocalFuncExecutor,
+		ioc.InitScheduler,
 
 		oauth2.NewOAuth2WeChatHandler,
 		ioc.InitOAuth2WeChatService,
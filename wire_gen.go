@@ -13,14 +13,27 @@ import (
 	dao2 "github.com/Andras5014/gohub/interactive/repository/dao"
 	service2 "github.com/Andras5014/gohub/interactive/service"
 	article3 "github.com/Andras5014/gohub/internal/events/article"
+	feed2 "github.com/Andras5014/gohub/internal/events/feed"
+	follow2 "github.com/Andras5014/gohub/internal/events/follow"
+	notification2 "github.com/Andras5014/gohub/internal/events/notification"
+	ranking2 "github.com/Andras5014/gohub/internal/events/ranking"
 	"github.com/Andras5014/gohub/internal/repository"
 	article2 "github.com/Andras5014/gohub/internal/repository/article"
 	"github.com/Andras5014/gohub/internal/repository/cache"
 	"github.com/Andras5014/gohub/internal/repository/dao"
-	"github.com/Andras5014/gohub/internal/repository/dao/article"
+	article5 "github.com/Andras5014/gohub/internal/repository/dao/article"
 	"github.com/Andras5014/gohub/internal/service"
 	article4 "github.com/Andras5014/gohub/internal/web/handler/article"
+	"github.com/Andras5014/gohub/internal/web/handler/author"
+	"github.com/Andras5014/gohub/internal/web/handler/comment"
+	"github.com/Andras5014/gohub/internal/web/handler/feed"
+	"github.com/Andras5014/gohub/internal/web/handler/follow"
+	"github.com/Andras5014/gohub/internal/web/handler/history"
+	"github.com/Andras5014/gohub/internal/web/handler/notification"
 	"github.com/Andras5014/gohub/internal/web/handler/oauth2"
+	"github.com/Andras5014/gohub/internal/web/handler/progress"
+	"github.com/Andras5014/gohub/internal/web/handler/ranking"
+	"github.com/Andras5014/gohub/internal/web/handler/search"
 	"github.com/Andras5014/gohub/internal/web/handler/user"
 	"github.com/Andras5014/gohub/internal/web/jwt"
 	"github.com/Andras5014/gohub/ioc"
@@ -53,45 +66,121 @@ func InitApp() *App {
 	userHandler := user.NewUserHandler(userService, codeService, handler, logger)
 	oauth2Service := ioc.InitOAuth2WeChatService(logger)
 	weChatHandler := oauth2.NewOAuth2WeChatHandler(oauth2Service, userService, handler)
-	articleDAO := article.NewArticleDAO(db)
+	store := ioc.InitOSS(config)
+	articleDAO := ioc.InitArticleDAO(config, db, store)
 	articleCache := cache.NewRedisArticleCache(cmdable)
-	articleRepository := article2.NewArticleRepository(articleDAO, articleCache, logger)
+	articleRepository := article2.NewArticleRepository(articleDAO, articleCache, userRepository, logger)
 	client := ioc.InitKafka(config)
 	syncProducer := ioc.InitSyncProducer(client)
 	producer := article3.NewSaramaSyncProducer(syncProducer)
-	articleService := service.NewArticleService(articleRepository, producer, logger)
+	collaboratorDAO := article5.NewCollaboratorDAO(db)
+	collaboratorRepository := article2.NewCollaboratorRepository(collaboratorDAO)
+	moderator := ioc.InitModerator(config, logger)
+	articleService := service.NewArticleService(articleRepository, collaboratorRepository, moderator, producer, logger)
 	interactiveDAO := dao2.NewInteractiveDAO(db)
 	interactiveCache := cache2.NewInteractiveCache(cmdable)
 	interactiveRepository := repository2.NewInteractiveRepository(interactiveDAO, interactiveCache, logger)
-	interactiveService := service2.NewInteractiveService(interactiveRepository)
+	collectionDAO := dao2.NewCollectionDAO(db)
+	collectionRepository := repository2.NewCollectionRepository(collectionDAO, interactiveCache, logger)
+	eventsProducer := events.NewSaramaSyncProducer(syncProducer)
+	interactiveService := service2.NewInteractiveService(interactiveRepository, collectionRepository, eventsProducer, logger)
 	interactiveServiceClient := ioc.InitInteractiveGrpcClient(interactiveService, config)
-	articleHandler := article4.NewArticleHandler(articleService, interactiveServiceClient, logger)
-	engine := ioc.InitWebServer(v, userHandler, weChatHandler, articleHandler)
+	seriesDAO := article5.NewSeriesDAO(db)
+	seriesRepository := article2.NewSeriesRepository(seriesDAO)
+	seriesService := service.NewSeriesService(seriesRepository, articleRepository)
+	followDAO := dao.NewFollowDAO(db)
+	followRepository := repository.NewFollowRepository(followDAO)
+	followProducer := follow2.NewSaramaSyncProducer(syncProducer)
+	followService := service.NewFollowService(followRepository, userRepository, followProducer, logger)
+	readingProgressDAO := dao.NewReadingProgressDAO(db)
+	readingProgressCache := cache.NewRedisReadingProgressCache(cmdable)
+	readingProgressRepository := repository.NewReadingProgressRepository(readingProgressDAO, readingProgressCache, logger)
+	readingProgressService := service.NewReadingProgressService(readingProgressRepository, articleRepository)
+	articleHandler := article4.NewArticleHandler(articleService, seriesService, followService, readingProgressService, interactiveServiceClient, logger)
+	index := ioc.InitSearchIndex()
+	searchRepository := repository.NewSearchRepository(index)
+	searchService := ioc.InitSearchService(searchRepository, articleRepository, logger)
+	searchHandler := search.NewSearchHandler(searchService, logger)
+	authorService := service.NewAuthorService(articleRepository, userRepository, followRepository, interactiveServiceClient)
+	authorHandler := author.NewAuthorHandler(authorService, followService, logger)
+	rankingCache := cache.NewRedisRankingCache(cmdable)
+	rankingScoreCache := cache.NewRedisRankingScoreCache(cmdable)
+	rankingLocalCache := cache.NewRankingLocalCache()
+	rankingRepository := repository.NewRankingRepository(rankingCache, rankingScoreCache, rankingLocalCache, logger)
+	v3 := ioc.InitRankingBoards(config)
+	rankingService := service.NewRankingService(articleService, interactiveServiceClient, rankingRepository, v3)
+	rankingHandler := ranking.NewRankingHandler(rankingService, logger)
+	reviewHandler := ioc.InitReviewHandler(config, articleService, logger)
+	commentServiceClient := ioc.InitCommentGrpcClient(config)
+	commentHandler := comment.NewCommentHandler(commentServiceClient, articleService, logger)
+	notificationDAO := dao.NewNotificationDAO(db)
+	notificationRepository := repository.NewNotificationRepository(notificationDAO)
+	notificationService := service.NewNotificationService(notificationRepository, articleRepository)
+	notificationHandler := notification.NewNotificationHandler(notificationService, logger)
+	followHandler := follow.NewFollowHandler(followService, logger)
+	feedDAO := dao.NewFeedDAO(db)
+	feedRepository := repository.NewFeedRepository(feedDAO)
+	feedService := service.NewFeedService(feedRepository, followRepository, articleRepository)
+	feedHandler := feed.NewFeedHandler(feedService, logger)
+	historyRecordDAO := dao.NewHistoryRecordDAO(db)
+	historyRecordRepository := repository.NewHistoryRecordRepository(historyRecordDAO)
+	historyService := service.NewHistoryService(historyRecordRepository)
+	historyHandler := history.NewHistoryHandler(historyService, articleService, logger)
+	progressHandler := progress.NewReadingProgressHandler(readingProgressService, articleService, logger)
+	engine := ioc.InitWebServer(v, userHandler, weChatHandler, articleHandler, searchHandler, authorHandler, rankingHandler, reviewHandler, commentHandler, notificationHandler, followHandler, feedHandler, historyHandler, progressHandler)
 	interactiveReadEventBatchConsumer := events.NewInteractiveReadEventBatchConsumer(client, interactiveRepository, logger)
-	v2 := ioc.InitConsumers(interactiveReadEventBatchConsumer)
-	rankingService := service.NewRankingService(articleService, interactiveServiceClient)
+	searchIndexConsumer := ioc.InitSearchIndexConsumer(config, client, searchRepository, logger)
+	rankingScoreConsumer := ranking2.NewRankingScoreConsumer(client, rankingService, logger)
+	notificationConsumer := notification2.NewNotificationConsumer(client, notificationService, logger)
+	feedConsumer := feed2.NewFeedConsumer(client, feedService, logger)
+	historyRecordConsumer := article3.NewHistoryRecordConsumer(client, historyRecordRepository, logger)
+	v2 := ioc.InitConsumers(interactiveReadEventBatchConsumer, searchIndexConsumer, rankingScoreConsumer, notificationConsumer, feedConsumer, historyRecordConsumer)
 	universalClient := ioc.InitRedisUniversalClient(config)
 	redsync := ioc.InitRedSync(universalClient)
 	rankingJob := ioc.InitRankingJob(rankingService, redsync, logger)
-	cron := ioc.InitJobs(rankingJob, logger)
+	rankingStreamJob := ioc.InitRankingStreamJob(rankingService, logger)
+	trashPurgeJob := ioc.InitTrashPurgeJob(config, articleService, logger)
+	readingProgressFlushJob := ioc.InitReadingProgressFlushJob(readingProgressService, logger)
+	historyTrimJob := ioc.InitHistoryTrimJob(historyService, logger)
+	cron := ioc.InitJobs(config, rankingJob, rankingStreamJob, trashPurgeJob, readingProgressFlushJob, historyTrimJob, logger)
+	localFuncExecutor := ioc.InitLocalFuncExecutor(rankingService, articleService)
+	jobDAO := dao.NewJobDAO(db)
+	jobRepository := repository.NewJobRepository(jobDAO)
+	jobService := service.NewCronJobService(jobRepository, logger)
+	scheduler := ioc.InitScheduler(localFuncExecutor, jobService, logger)
 	app := &App{
 		Server:    engine,
 		Consumers: v2,
 		Cron:      cron,
+		Scheduler: scheduler,
 	}
 	return app
 }
 
 // wire.go:
 
-var rankingSvcSet = wire.NewSet(cache.NewRedisRankingCache, repository.NewRankingRepository, service.NewRankingService)
+var rankingSvcSet = wire.NewSet(cache.NewRedisRankingCache, cache.NewRedisRankingScoreCache, cache.NewRankingLocalCache, repository.NewRankingRepository, service.NewRankingService, ioc.InitRankingBoards)
 
-var interactiveSvcSet = wire.NewSet(service2.NewInteractiveService, repository2.NewInteractiveRepository, cache2.NewInteractiveCache, dao2.NewInteractiveDAO)
+var interactiveSvcSet = wire.NewSet(service2.NewInteractiveService, events.NewSaramaSyncProducer, repository2.NewInteractiveRepository, repository2.NewCollectionRepository, cache2.NewInteractiveCache, dao2.NewInteractiveDAO, dao2.NewCollectionDAO)
 
 var userSvcSet = wire.NewSet(service.NewUserService, repository.NewUserRepository, cache.NewUserCache, dao.NewUserDAO)
 
-var articleSvcSet = wire.NewSet(service.NewArticleService, article2.NewArticleRepository, article.NewArticleDAO, cache.NewRedisArticleCache)
+var articleSvcSet = wire.NewSet(service.NewArticleService, article2.NewArticleRepository, article2.NewCollaboratorRepository, ioc.InitArticleDAO, article5.NewCollaboratorDAO, service.NewSeriesService, article2.NewSeriesRepository, article5.NewSeriesDAO, ioc.InitModerator, cache.NewRedisArticleCache)
+
+var jobSvcSet = wire.NewSet(service.NewCronJobService, repository.NewJobRepository, dao.NewJobDAO)
+
+var searchSvcSet = wire.NewSet(ioc.InitSearchIndex, repository.NewSearchRepository, ioc.InitSearchService)
+
+var notificationSvcSet = wire.NewSet(service.NewNotificationService, repository.NewNotificationRepository, dao.NewNotificationDAO)
+
+var followSvcSet = wire.NewSet(service.NewFollowService, repository.NewFollowRepository, dao.NewFollowDAO, follow2.NewSaramaSyncProducer)
+
+var feedSvcSet = wire.NewSet(service.NewFeedService, repository.NewFeedRepository, dao.NewFeedDAO)
+
+var historySvcSet = wire.NewSet(service.NewHistoryService, repository.NewHistoryRecordRepository, dao.NewHistoryRecordDAO)
+
+var progressSvcSet = wire.NewSet(service.NewReadingProgressService, repository.NewReadingProgressRepository, cache.NewRedisReadingProgressCache, dao.NewReadingProgressDAO)
 
 var codeSvcProvider = wire.NewSet(cache.NewCodeCache, repository.NewCodeRepository, service.NewCodeService)
 
-var thirdPartySet = wire.NewSet(ioc.InitConfig, ioc.InitLogger, ioc.InitDB, ioc.InitRedis, ioc.InitRedisUniversalClient, ioc.InitRedSync, ioc.InitSmsService, ioc.InitKafka, ioc.InitSyncProducer, ioc.InitConsumers)
+var thirdPartySet = wire.NewSet(ioc.InitConfig, ioc.InitLogger, ioc.InitDB, ioc.InitRedis, ioc.InitRedisUniversalClient, ioc.InitRedSync, ioc.InitSmsService, ioc.InitKafka, ioc.InitSyncProducer, ioc.InitConsumers, ioc.InitOSS)